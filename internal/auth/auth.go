@@ -2,8 +2,10 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/golang-jwt/jwt"
 	"github.com/zalando/go-keyring"
 	"golang.org/x/oauth2"
@@ -11,6 +13,7 @@ import (
 
 type IAuthenticator interface {
 	Authenticate() error
+	AuthenticateDevice() error
 	Logout() error
 	Token() (*oauth2.Token, error)
 }
@@ -25,6 +28,8 @@ type IOAuthConfig interface {
 	AuthCodeURL(string, ...oauth2.AuthCodeOption) string
 	Exchange(context.Context, string, ...oauth2.AuthCodeOption) (*oauth2.Token, error)
 	TokenSource(context.Context, *oauth2.Token) oauth2.TokenSource
+	DeviceAuth(context.Context, ...oauth2.AuthCodeOption) (*oauth2.DeviceAuthResponse, error)
+	DeviceAccessToken(context.Context, *oauth2.DeviceAuthResponse, ...oauth2.AuthCodeOption) (*oauth2.Token, error)
 } // Wrapping interface for config to simplify mocking
 
 type Authenticator struct {
@@ -58,10 +63,11 @@ func NewDebrickedAuthenticator(host string) Authenticator {
 			ClientID:     "01919462-7d6e-78e8-aa24-ba779213c90f",
 			ClientSecret: "",
 			Endpoint: oauth2.Endpoint{
-				AuthURL:  host + "/app/oauth/authorize",
-				TokenURL: host + "/app/oauth/token",
+				AuthURL:       host + "/app/oauth/authorize",
+				TokenURL:      host + "/app/oauth/token",
+				DeviceAuthURL: host + "/app/oauth/device_authorization",
 			},
-			RedirectURL: "http://localhost:9096/callback",
+			RedirectURL: fmt.Sprintf("http://localhost:%d/callback", callbackPort),
 			Scopes:      []string{"select", "profile", "basicRepo", "fullApi"},
 		},
 		AuthWebHelper: NewAuthWebHelper(),
@@ -140,23 +146,35 @@ func (a Authenticator) refresh(refreshToken string) (*oauth2.Token, error) {
 }
 
 func (a Authenticator) Authenticate() error {
+	redirectURL, err := a.AuthWebHelper.Listen()
+	if err != nil {
+		return err
+	}
+	defer a.AuthWebHelper.Close()
+	redirectOption := oauth2.SetAuthURLParam("redirect_uri", redirectURL)
+
 	state := oauth2.GenerateVerifier()
 	codeVerifier := oauth2.GenerateVerifier()
 	authURL := a.OAuthConfig.AuthCodeURL(
 		state,
 		oauth2.S256ChallengeOption(codeVerifier),
+		redirectOption,
 	)
 
-	err := a.AuthWebHelper.OpenURL(authURL)
+	err = a.AuthWebHelper.OpenURL(authURL)
 	if err != nil {
 		return err
 	}
 
-	authCode := a.AuthWebHelper.Callback(state)
+	authCode, err := a.AuthWebHelper.Callback(state)
+	if err != nil {
+		return err
+	}
 	token, err := a.OAuthConfig.Exchange(
 		context.Background(),
 		authCode,
 		oauth2.VerifierOption(codeVerifier),
+		redirectOption,
 	)
 	if err != nil {
 		return err
@@ -164,3 +182,31 @@ func (a Authenticator) Authenticate() error {
 
 	return a.save(token)
 }
+
+// AuthenticateDevice authenticates using the OAuth device authorization flow, for machines without a browser. The
+// verification URL and user code are printed, to be entered on another device, and the token endpoint is polled
+// until the user has approved or the code has expired
+func (a Authenticator) AuthenticateDevice() error {
+	ctx := context.Background()
+	deviceAuth, err := a.OAuthConfig.DeviceAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"To authenticate, open %s and enter the code %s\n",
+		color.BlueString(deviceAuth.VerificationURI),
+		color.BlueString(deviceAuth.UserCode),
+	)
+	if len(deviceAuth.VerificationURIComplete) > 0 {
+		fmt.Printf("Or open %s\n", color.BlueString(deviceAuth.VerificationURIComplete))
+	}
+	fmt.Println("Waiting for authentication...")
+
+	token, err := a.OAuthConfig.DeviceAccessToken(ctx, deviceAuth)
+	if err != nil {
+		return err
+	}
+
+	return a.save(token)
+}
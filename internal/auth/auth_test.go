@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"runtime"
 	"testing"
 
@@ -259,3 +261,147 @@ func TestMockedAuthenticateOpenURLError(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestMockedAuthenticateListenError(t *testing.T) {
+	authenticator := Authenticator{
+		SecretClient:  testdata.MockSecretClient{},
+		OAuthConfig:   testdata.MockOAuthConfig{},
+		AuthWebHelper: testdata.MockListenErrorAuthWebHelper{},
+	}
+	err := authenticator.Authenticate()
+
+	assert.ErrorContains(t, err, "address already in use")
+}
+
+func TestMockedAuthenticateCallbackError(t *testing.T) {
+	authenticator := Authenticator{
+		SecretClient:  testdata.MockSecretClient{},
+		OAuthConfig:   testdata.MockOAuthConfig{},
+		AuthWebHelper: testdata.MockCallbackErrorAuthWebHelper{},
+	}
+	err := authenticator.Authenticate()
+
+	assert.ErrorContains(t, err, "HTTP server error")
+}
+
+func TestMockedAuthenticateDevice(t *testing.T) {
+	authenticator := Authenticator{
+		SecretClient: testdata.MockSecretClient{},
+		OAuthConfig:  testdata.MockOAuthConfig{},
+	}
+	err := authenticator.AuthenticateDevice()
+
+	assert.NoError(t, err)
+}
+
+func TestMockedAuthenticateDeviceAuthError(t *testing.T) {
+	authenticator := Authenticator{
+		SecretClient: testdata.MockSecretClient{},
+		OAuthConfig:  testdata.MockOAuthConfigDeviceAuthError{},
+	}
+	err := authenticator.AuthenticateDevice()
+
+	assert.ErrorContains(t, err, "HTTP Error")
+}
+
+func TestMockedAuthenticateDeviceAccessTokenError(t *testing.T) {
+	authenticator := Authenticator{
+		SecretClient: testdata.MockSecretClient{},
+		OAuthConfig:  testdata.MockOAuthConfigExchangeError{},
+	}
+	err := authenticator.AuthenticateDevice()
+
+	assert.ErrorContains(t, err, "expired_token")
+}
+
+func TestMockedAuthenticateDeviceSaveError(t *testing.T) {
+	authenticator := Authenticator{
+		SecretClient: testdata.MockErrorSecretClient{ErrorPattern: "DebrickedRefreshToken", Message: "keyring error"},
+		OAuthConfig:  testdata.MockOAuthConfig{},
+	}
+	err := authenticator.AuthenticateDevice()
+
+	assert.ErrorContains(t, err, "keyring error")
+}
+
+func TestAuthenticateDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/app/oauth/device_authorization":
+			assert.Equal(t, "01919462-7d6e-78e8-aa24-ba779213c90f", r.Form.Get("client_id"))
+			_, _ = w.Write([]byte(`{"device_code": "device-code", "user_code": "ABCD-EFGH", "verification_uri": "https://debricked.com/app/oauth/device", "expires_in": 60, "interval": 1}`))
+		case "/app/oauth/token":
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.Form.Get("grant_type"))
+			assert.Equal(t, "device-code", r.Form.Get("device_code"))
+			_, _ = w.Write([]byte(`{"access_token": "access-token", "refresh_token": "refresh-token", "token_type": "Bearer"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	secretClient := &recordingSecretClient{secrets: map[string]string{}}
+	authenticator := NewDebrickedAuthenticator(server.URL)
+	authenticator.SecretClient = secretClient
+
+	err := authenticator.AuthenticateDevice()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token", secretClient.secrets["DebrickedAccessToken"])
+	assert.Equal(t, "refresh-token", secretClient.secrets["DebrickedRefreshToken"])
+}
+
+type recordingSecretClient struct {
+	secrets map[string]string
+}
+
+func (rsc *recordingSecretClient) Set(service, secret string) error {
+	rsc.secrets[service] = secret
+
+	return nil
+}
+
+func (rsc *recordingSecretClient) Get(service string) (string, error) {
+	return rsc.secrets[service], nil
+}
+
+func (rsc *recordingSecretClient) Delete(service string) error {
+	delete(rsc.secrets, service)
+
+	return nil
+}
+
+type redirectAuthWebHelper struct {
+	testdata.MockAuthWebHelper
+	authURL *string
+}
+
+func (rawh redirectAuthWebHelper) Listen() (string, error) {
+	return "http://localhost:12345/callback", nil
+}
+
+func (rawh redirectAuthWebHelper) OpenURL(authURL string) error {
+	*rawh.authURL = authURL
+
+	return nil
+}
+
+func TestAuthenticateRedirectURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "http://localhost:12345/callback", r.Form.Get("redirect_uri"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "access-token", "refresh_token": "refresh-token", "token_type": "Bearer"}`))
+	}))
+	defer server.Close()
+	var authURL string
+	authenticator := NewDebrickedAuthenticator(server.URL)
+	authenticator.SecretClient = &recordingSecretClient{secrets: map[string]string{}}
+	authenticator.AuthWebHelper = redirectAuthWebHelper{authURL: &authURL}
+
+	err := authenticator.Authenticate()
+
+	assert.NoError(t, err)
+	assert.Contains(t, authURL, "redirect_uri=http%3A%2F%2Flocalhost%3A12345%2Fcallback")
+}
@@ -2,35 +2,61 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/pkg/browser"
 )
 
+// callbackPort is the port of the redirect URL registered for the CLI. A free port is chosen if it is taken
+const callbackPort = 9096
+
 type IAuthWebHelper interface {
-	Callback(string) string
+	Listen() (string, error)
+	Callback(string) (string, error)
 	OpenURL(string) error
+	Close() error
 }
 
 type AuthWebHelper struct {
 	ServeMux *http.ServeMux
+	listener net.Listener
 }
 
-func NewAuthWebHelper() AuthWebHelper {
+func NewAuthWebHelper() *AuthWebHelper {
 	mux := http.NewServeMux()
 
-	return AuthWebHelper{
+	return &AuthWebHelper{
 		ServeMux: mux,
 	}
 }
 
-func (awh AuthWebHelper) Callback(state string) string {
-	code := make(chan string)
-	defer close(code)
+// Listen starts listening on the loopback interface for the authorization callback, and returns the redirect URL.
+// Port 9096 is used if it is free, and otherwise a port chosen by the system
+func (awh *AuthWebHelper) Listen() (string, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", callbackPort))
+	if err != nil {
+		listener, err = net.Listen("tcp", "localhost:0")
+		if err != nil {
+			return "", err
+		}
+	}
+	awh.listener = listener
+
+	return fmt.Sprintf("http://localhost:%d/callback", listener.Addr().(*net.TCPAddr).Port), nil
+}
 
+// Callback serves the redirect URL until it is called with state, and returns the authorization code
+func (awh *AuthWebHelper) Callback(state string) (string, error) {
+	if awh.listener == nil {
+		if _, err := awh.Listen(); err != nil {
+			return "", err
+		}
+	}
+	code := make(chan string, 1)
 	awh.ServeMux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("state") != state {
 			http.Error(w, "Invalid state", http.StatusBadRequest)
@@ -38,30 +64,46 @@ func (awh AuthWebHelper) Callback(state string) string {
 			return
 		}
 
-		code <- r.URL.Query().Get("code")
+		select {
+		case code <- r.URL.Query().Get("code"):
+		default:
+		}
 		fmt.Fprintf(w, "Authentication successful! You can close this window now.")
 	})
 
 	server := &http.Server{
-		Addr:              ":9096",
 		ReadHeaderTimeout: time.Minute,
 		Handler:           awh.ServeMux,
 	}
+	serverErr := make(chan error, 1)
 	go func() {
-		if err := server.ListenAndServe(); err != http.ErrServerClosed {
-			log.Fatalf("HTTP server error: %v", err)
-		}
+		serverErr <- server.Serve(awh.listener)
 	}()
 	defer func() {
-		if err := server.Shutdown(context.Background()); err != nil {
-			log.Fatalf("HTTP server shutdown error: %v", err)
-		}
+		_ = server.Shutdown(context.Background())
 	}()
-	authCode := <-code // Wait for the authorization code
 
-	return authCode
+	select {
+	case authCode := <-code: // Wait for the authorization code
+		return authCode, nil
+	case err := <-serverErr:
+		return "", fmt.Errorf("HTTP server error: %w", err)
+	}
 }
 
-func (awh AuthWebHelper) OpenURL(authURL string) error {
+func (awh *AuthWebHelper) OpenURL(authURL string) error {
 	return browser.OpenURL(authURL)
 }
+
+// Close stops listening for the authorization callback, if Callback has not already done so
+func (awh *AuthWebHelper) Close() error {
+	if awh.listener == nil {
+		return nil
+	}
+	err := awh.listener.Close()
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
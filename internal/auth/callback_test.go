@@ -1,9 +1,10 @@
 package auth
 
 import (
-	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,17 +15,21 @@ const testState = "test_state"
 
 func TestCallback(t *testing.T) {
 	awh := NewAuthWebHelper()
+	redirectURL, err := awh.Listen()
+	assert.NoError(t, err)
+	defer awh.Close()
 
 	resultChan := make(chan string)
 	go func() {
-		result := awh.Callback(testState)
+		result, err := awh.Callback(testState)
+		assert.NoError(t, err)
 		resultChan <- result
 	}()
 
 	time.Sleep(100 * time.Millisecond)
 
 	testCode := "test_code"
-	resp, err := http.Get(fmt.Sprintf("http://localhost:9096/callback?state=%s&code=%s", testState, testCode))
+	resp, err := http.Get(fmt.Sprintf("%s?state=%s&code=%s", redirectURL, testState, testCode))
 	if err != nil {
 		t.Fatalf("Failed to make callback request: %v", err)
 	}
@@ -48,14 +53,17 @@ func TestCallback(t *testing.T) {
 
 func TestCallbackInvalidState(t *testing.T) {
 	awh := NewAuthWebHelper()
+	redirectURL, err := awh.Listen()
+	assert.NoError(t, err)
+	defer awh.Close()
 
 	go func() {
-		awh.Callback(testState)
+		_, _ = awh.Callback(testState)
 	}()
 
 	time.Sleep(100 * time.Millisecond)
 
-	resp, err := http.Get("http://localhost:9096/callback?state=invalid_state&code=test_code")
+	resp, err := http.Get(redirectURL + "?state=invalid_state&code=test_code")
 	if err != nil {
 		t.Fatalf("Failed to make callback request: %v", err)
 	}
@@ -66,35 +74,48 @@ func TestCallbackInvalidState(t *testing.T) {
 	}
 }
 
-func TestCallbackServerError(t *testing.T) {
-	server := &http.Server{Addr: ":9096", ReadHeaderTimeout: time.Second}
-	go func() {
-		err := server.ListenAndServe()
-		assert.Error(t, err) // Two servers trying to run on localhost:9096
-	}()
-	defer func() {
-		err := server.Shutdown(context.Background())
-		assert.NoError(t, err)
-	}()
+func TestListenPortTaken(t *testing.T) {
+	occupied, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", callbackPort))
+	if err == nil {
+		defer occupied.Close()
+	}
+
+	awh := NewAuthWebHelper()
+	redirectURL, err := awh.Listen()
+	defer awh.Close()
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(redirectURL, "http://localhost:"), redirectURL)
+	assert.True(t, strings.HasSuffix(redirectURL, "/callback"), redirectURL)
+	assert.NotContains(t, redirectURL, fmt.Sprintf(":%d/", callbackPort))
+}
 
-	awh := AuthWebHelper{}
+func TestCallbackServerError(t *testing.T) {
+	awh := NewAuthWebHelper()
+	_, err := awh.Listen()
+	assert.NoError(t, err)
+	assert.NoError(t, awh.Close())
 
 	resultChan := make(chan error)
 	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				resultChan <- fmt.Errorf("panic occurred: %v", r)
-			} else {
-				resultChan <- nil
-			}
-		}()
-		awh.Callback(testState)
+		_, err := awh.Callback(testState)
+		resultChan <- err
 	}()
 
 	select {
 	case err := <-resultChan:
-		assert.Error(t, err)
+		assert.ErrorContains(t, err, "HTTP server error")
 	case <-time.After(2 * time.Second):
 		t.Fatal("Test timed out")
 	}
 }
+
+func TestClose(t *testing.T) {
+	awh := NewAuthWebHelper()
+	assert.NoError(t, awh.Close())
+
+	_, err := awh.Listen()
+	assert.NoError(t, err)
+	assert.NoError(t, awh.Close())
+	assert.NoError(t, awh.Close(), "failed to assert that closing twice was allowed")
+}
@@ -123,14 +123,30 @@ type MockOAuthConfig struct {
 
 type MockOAuthConfigExchangeError struct{}
 
+type MockOAuthConfigDeviceAuthError struct {
+	MockOAuthConfig
+}
+
 type MockAuthWebHelper struct{}
 
 type MockErrorAuthWebHelper struct{}
 
+type MockListenErrorAuthWebHelper struct {
+	MockAuthWebHelper
+}
+
+type MockCallbackErrorAuthWebHelper struct {
+	MockAuthWebHelper
+}
+
 func (ma MockAuthenticator) Authenticate() error {
 	return nil
 }
 
+func (ma MockAuthenticator) AuthenticateDevice() error {
+	return nil
+}
+
 func (ma MockAuthenticator) Logout() error {
 	return nil
 }
@@ -147,6 +163,10 @@ func (ma ErrorMockAuthenticator) Authenticate() error {
 	return MockError{""}
 }
 
+func (ma ErrorMockAuthenticator) AuthenticateDevice() error {
+	return MockError{""}
+}
+
 func (ma ErrorMockAuthenticator) Logout() error {
 	return MockError{""}
 }
@@ -155,20 +175,44 @@ func (ma ErrorMockAuthenticator) Token() (*oauth2.Token, error) {
 	return nil, MockError{""}
 }
 
+func (mawh MockAuthWebHelper) Listen() (string, error) {
+	return "http://localhost:9096/callback", nil
+}
+
 func (mawh MockAuthWebHelper) OpenURL(string) error {
 	return nil
 }
 
-func (mawh MockAuthWebHelper) Callback(string) string {
-	return "callback"
+func (mawh MockAuthWebHelper) Callback(string) (string, error) {
+	return "callback", nil
+}
+
+func (mawh MockAuthWebHelper) Close() error {
+	return nil
+}
+
+func (mawh MockErrorAuthWebHelper) Listen() (string, error) {
+	return "http://localhost:9096/callback", nil
 }
 
 func (mawh MockErrorAuthWebHelper) OpenURL(string) error {
 	return MockError{}
 }
 
-func (mawh MockErrorAuthWebHelper) Callback(string) string {
-	return "callback"
+func (mawh MockErrorAuthWebHelper) Callback(string) (string, error) {
+	return "callback", nil
+}
+
+func (mawh MockErrorAuthWebHelper) Close() error {
+	return nil
+}
+
+func (mawh MockListenErrorAuthWebHelper) Listen() (string, error) {
+	return "", MockError{Message: "address already in use"}
+}
+
+func (mawh MockCallbackErrorAuthWebHelper) Callback(string) (string, error) {
+	return "", MockError{Message: "HTTP server error"}
 }
 
 func (moc MockOAuthConfig) Exchange(context.Context, string, ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
@@ -182,6 +226,26 @@ func (moc MockOAuthConfig) AuthCodeURL(string, ...oauth2.AuthCodeOption) string
 	return "localhost"
 }
 
+func (moc MockOAuthConfig) DeviceAuth(context.Context, ...oauth2.AuthCodeOption) (*oauth2.DeviceAuthResponse, error) {
+	return &oauth2.DeviceAuthResponse{
+		DeviceCode:              "deviceCode",
+		UserCode:                "ABCD-EFGH",
+		VerificationURI:         "https://debricked.com/app/oauth/device",
+		VerificationURIComplete: "https://debricked.com/app/oauth/device?user_code=ABCD-EFGH",
+	}, nil
+}
+
+func (moc MockOAuthConfig) DeviceAccessToken(context.Context, *oauth2.DeviceAuthResponse, ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken:  "accessToken",
+		RefreshToken: "accessToken",
+	}, nil
+}
+
+func (moc MockOAuthConfigDeviceAuthError) DeviceAuth(context.Context, ...oauth2.AuthCodeOption) (*oauth2.DeviceAuthResponse, error) {
+	return nil, MockError{Message: "HTTP Error"}
+}
+
 func (moc MockOAuthConfigExchangeError) Exchange(context.Context, string, ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
 	return nil, MockError{Message: "HTTP Error"}
 }
@@ -194,6 +258,14 @@ func (moc MockOAuthConfigExchangeError) TokenSource(context.Context, *oauth2.Tok
 	return nil
 }
 
+func (moc MockOAuthConfigExchangeError) DeviceAuth(context.Context, ...oauth2.AuthCodeOption) (*oauth2.DeviceAuthResponse, error) {
+	return &oauth2.DeviceAuthResponse{DeviceCode: "deviceCode", UserCode: "ABCD-EFGH"}, nil
+}
+
+func (moc MockOAuthConfigExchangeError) DeviceAccessToken(context.Context, *oauth2.DeviceAuthResponse, ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return nil, MockError{Message: "expired_token"}
+}
+
 type MockTokenSource struct {
 	StaticToken *oauth2.Token
 	Error       error
@@ -0,0 +1,231 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/upload"
+)
+
+const (
+	DefaultOutputFileName = "debricked-bundle.tar.gz"
+	ManifestFileName      = "debricked-bundle.json"
+	FormatVersion         = 1
+	filesDir              = "files"
+)
+
+var (
+	MissingManifestErr = errors.New("bundle does not contain " + ManifestFileName)
+	UnsafePathErr      = errors.New("bundle path is not local to the scanned directory")
+)
+
+type Group struct {
+	ManifestFile string   `json:"manifestFile"`
+	LockFiles    []string `json:"lockFiles"`
+}
+
+// Manifest describes the content of a bundle and everything needed to replay it through the uploader
+type Manifest struct {
+	FormatVersion          int                     `json:"formatVersion"`
+	FileGroups             []Group                 `json:"fileGroups"`
+	GitMetaObject          git.MetaObject          `json:"gitMetaObject"`
+	DebrickedConfig        *upload.DebrickedConfig `json:"debrickedConfig"`
+	IntegrationName        string                  `json:"integrationName"`
+	CallGraphUploadTimeout int                     `json:"callGraphUploadTimeout"`
+	VersionHint            bool                    `json:"versionHint"`
+	TagCommitAsRelease     bool                    `json:"tagCommitAsRelease"`
+	Experimental           bool                    `json:"experimental"`
+}
+
+// NewManifest creates a Manifest from the upload options that would have been used for an online scan
+func NewManifest(options upload.DebrickedOptions) Manifest {
+	var groups []Group
+	for _, g := range options.FileGroups.ToSlice() {
+		groups = append(groups, Group{ManifestFile: g.ManifestFile, LockFiles: g.LockFiles})
+	}
+
+	return Manifest{
+		FormatVersion:          FormatVersion,
+		FileGroups:             groups,
+		GitMetaObject:          options.GitMetaObject,
+		DebrickedConfig:        options.DebrickedConfig,
+		IntegrationName:        options.IntegrationsName,
+		CallGraphUploadTimeout: options.CallGraphUploadTimeout,
+		VersionHint:            options.VersionHint,
+		TagCommitAsRelease:     options.TagCommitAsRelease,
+		Experimental:           options.Experimental,
+	}
+}
+
+// UploadOptions returns the upload options needed to replay the bundle. File paths are relative to the bundle root
+func (m Manifest) UploadOptions() upload.DebrickedOptions {
+	var groups file.Groups
+	for _, g := range m.FileGroups {
+		groups.Add(*file.NewGroup(g.ManifestFile, nil, g.LockFiles))
+	}
+
+	return upload.DebrickedOptions{
+		FileGroups:             groups,
+		GitMetaObject:          m.GitMetaObject,
+		IntegrationsName:       m.IntegrationName,
+		CallGraphUploadTimeout: m.CallGraphUploadTimeout,
+		VersionHint:            m.VersionHint,
+		DebrickedConfig:        m.DebrickedConfig,
+		TagCommitAsRelease:     m.TagCommitAsRelease,
+		Experimental:           m.Experimental,
+	}
+}
+
+func (m Manifest) files() []string {
+	var files []string
+	for _, g := range m.FileGroups {
+		if g.ManifestFile != "" {
+			files = append(files, g.ManifestFile)
+		}
+		files = append(files, g.LockFiles...)
+	}
+
+	return files
+}
+
+// Write writes manifest and all files referenced by it to a gzipped tarball at outputPath.
+// File paths in the manifest must be relative to the current working directory
+func Write(outputPath string, manifest Manifest) error {
+	out, err := os.Create(filepath.Clean(outputPath))
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gzipWriter := gzip.NewWriter(out)
+	defer gzipWriter.Close()
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer tarWriter.Close()
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name: ManifestFileName,
+		Mode: 0600,
+		Size: int64(len(manifestBytes)),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = tarWriter.Write(manifestBytes); err != nil {
+		return err
+	}
+
+	for _, f := range manifest.files() {
+		if err = writeFile(tarWriter, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(tarWriter *tar.Writer, filePath string) error {
+	if !filepath.IsLocal(filePath) {
+		return fmt.Errorf("%w: %s", UnsafePathErr, filePath)
+	}
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name: path.Join(filesDir, filepath.ToSlash(filePath)),
+		Mode: 0600,
+		Size: info.Size(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, f)
+
+	return err
+}
+
+// Read extracts the bundle at bundlePath into dir and returns its Manifest
+func Read(bundlePath string, dir string) (*Manifest, error) {
+	in, err := os.Open(filepath.Clean(bundlePath))
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	gzipReader, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	var manifest *Manifest
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Name == ManifestFileName {
+			manifest = &Manifest{}
+			if err = json.NewDecoder(tarReader).Decode(manifest); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+		if err = extractFile(tarReader, header, dir); err != nil {
+			return nil, err
+		}
+	}
+	if manifest == nil {
+		return nil, MissingManifestErr
+	}
+
+	return manifest, nil
+}
+
+func extractFile(tarReader *tar.Reader, header *tar.Header, dir string) error {
+	relPath, isFile := strings.CutPrefix(header.Name, filesDir+"/")
+	if !isFile || header.Typeflag != tar.TypeReg {
+		return nil
+	}
+	relPath = filepath.FromSlash(relPath)
+	if !filepath.IsLocal(relPath) {
+		return fmt.Errorf("%w: %s", UnsafePathErr, header.Name)
+	}
+	target := filepath.Join(dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, tarReader) // #nosec
+
+	return err
+}
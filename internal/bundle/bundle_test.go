@@ -0,0 +1,183 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+var testdataProject = filepath.Join("testdata", "project")
+
+// writeTestBundle writes manifest to bundlePath from within the testdata project
+func writeTestBundle(t *testing.T, bundlePath string, manifest Manifest) {
+	t.Helper()
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(testdataProject))
+	defer func() {
+		assert.NoError(t, os.Chdir(cwd))
+	}()
+
+	assert.NoError(t, Write(bundlePath, manifest))
+}
+
+func newTestManifest() Manifest {
+	var groups file.Groups
+	groups.Add(*file.NewGroup("package.json", nil, []string{"yarn.lock"}))
+	groups.Add(*file.NewGroup("", nil, []string{filepath.Join("sub", "requirements.txt")}))
+
+	return NewManifest(upload.DebrickedOptions{
+		FileGroups: groups,
+		GitMetaObject: git.MetaObject{
+			RepositoryName: "repository",
+			CommitName:     "commit",
+			BranchName:     "main",
+		},
+		IntegrationsName:       "CLI",
+		CallGraphUploadTimeout: 60,
+		VersionHint:            true,
+	})
+}
+
+func TestNewManifest(t *testing.T) {
+	manifest := newTestManifest()
+
+	assert.Equal(t, FormatVersion, manifest.FormatVersion)
+	assert.Len(t, manifest.FileGroups, 2)
+	assert.Equal(t, "package.json", manifest.FileGroups[0].ManifestFile)
+	assert.Equal(t, []string{"yarn.lock"}, manifest.FileGroups[0].LockFiles)
+	assert.Equal(t, "repository", manifest.GitMetaObject.RepositoryName)
+	assert.Equal(t, "CLI", manifest.IntegrationName)
+	assert.Equal(t, 60, manifest.CallGraphUploadTimeout)
+	assert.True(t, manifest.VersionHint)
+}
+
+func TestUploadOptions(t *testing.T) {
+	manifest := newTestManifest()
+
+	options := manifest.UploadOptions()
+
+	assert.Equal(t, 2, options.FileGroups.Size())
+	assert.Equal(
+		t,
+		[]string{"package.json", "yarn.lock", filepath.Join("sub", "requirements.txt")},
+		options.FileGroups.GetFiles(),
+	)
+	assert.Equal(t, manifest.GitMetaObject, options.GitMetaObject)
+	assert.Equal(t, "CLI", options.IntegrationsName)
+	assert.Equal(t, 60, options.CallGraphUploadTimeout)
+	assert.True(t, options.VersionHint)
+}
+
+func TestWriteAndRead(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), DefaultOutputFileName)
+	extractDir := t.TempDir()
+	manifest := newTestManifest()
+
+	writeTestBundle(t, bundlePath, manifest)
+
+	readManifest, err := Read(bundlePath, extractDir)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, *readManifest)
+
+	for _, f := range manifest.files() {
+		expected, err := os.ReadFile(filepath.Join(testdataProject, f))
+		assert.NoError(t, err)
+		actual, err := os.ReadFile(filepath.Join(extractDir, f))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestWriteMissingFile(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), DefaultOutputFileName)
+	var groups file.Groups
+	groups.Add(*file.NewGroup("missing.json", nil, nil))
+
+	err := Write(bundlePath, NewManifest(upload.DebrickedOptions{FileGroups: groups}))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWriteUnsafePath(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), DefaultOutputFileName)
+	var groups file.Groups
+	groups.Add(*file.NewGroup(filepath.Join("..", "package.json"), nil, nil))
+
+	err := Write(bundlePath, NewManifest(upload.DebrickedOptions{FileGroups: groups}))
+
+	assert.ErrorIs(t, err, UnsafePathErr)
+}
+
+func TestWriteBadOutputPath(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "missing", DefaultOutputFileName)
+
+	err := Write(bundlePath, Manifest{})
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadMissingBundle(t *testing.T) {
+	_, err := Read(filepath.Join(t.TempDir(), DefaultOutputFileName), t.TempDir())
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadNotGzipped(t *testing.T) {
+	_, err := Read(filepath.Join(testdataProject, "package.json"), t.TempDir())
+
+	assert.Error(t, err)
+}
+
+func TestReadMissingManifest(t *testing.T) {
+	bundlePath := writeTestTarball(t, map[string]string{"files/package.json": "{}"})
+
+	_, err := Read(bundlePath, t.TempDir())
+
+	assert.ErrorIs(t, err, MissingManifestErr)
+}
+
+func TestReadUnsafePath(t *testing.T) {
+	bundlePath := writeTestTarball(t, map[string]string{
+		ManifestFileName:       "{}",
+		"files/../escape.json": "{}",
+	})
+
+	_, err := Read(bundlePath, t.TempDir())
+
+	assert.ErrorIs(t, err, UnsafePathErr)
+}
+
+func TestReadBadManifest(t *testing.T) {
+	bundlePath := writeTestTarball(t, map[string]string{ManifestFileName: "not json"})
+
+	_, err := Read(bundlePath, t.TempDir())
+
+	assert.Error(t, err)
+}
+
+func writeTestTarball(t *testing.T, entries map[string]string) string {
+	t.Helper()
+	bundlePath := filepath.Join(t.TempDir(), DefaultOutputFileName)
+	out, err := os.Create(bundlePath)
+	assert.NoError(t, err)
+	defer out.Close()
+	gzipWriter := gzip.NewWriter(out)
+	defer gzipWriter.Close()
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer tarWriter.Close()
+	for name, content := range entries {
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+		_, err = tarWriter.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+
+	return bundlePath
+}
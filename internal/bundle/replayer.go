@@ -0,0 +1,58 @@
+package bundle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/upload"
+)
+
+type IReplayer interface {
+	Replay(bundlePath string) (*upload.UploadResult, error)
+}
+
+// Replayer uploads a bundle created by an offline scan
+type Replayer struct {
+	uploader upload.IUploader
+}
+
+func NewReplayer(uploader upload.IUploader) (*Replayer, error) {
+	if uploader == nil {
+		return nil, errors.New("uploader is nil")
+	}
+
+	return &Replayer{uploader}, nil
+}
+
+// Replay extracts the bundle to a temporary directory and uploads its content from there,
+// so that relative file paths are identical to the ones of the offline scan
+func (replayer *Replayer) Replay(bundlePath string) (*upload.UploadResult, error) {
+	absBundlePath, err := filepath.Abs(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	tempDir, err := os.MkdirTemp("", "debricked-bundle-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	manifest, err := Read(absBundlePath, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chdir(tempDir); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.Chdir(cwd)
+	}()
+
+	return replayer.uploader.Upload(manifest.UploadOptions())
+}
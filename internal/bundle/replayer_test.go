@@ -0,0 +1,63 @@
+package bundle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/upload"
+	"github.com/debricked/cli/internal/upload/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReplayer(t *testing.T) {
+	replayer, err := NewReplayer(nil)
+	assert.ErrorContains(t, err, "uploader is nil")
+	assert.Nil(t, replayer)
+
+	replayer, err = NewReplayer(testdata.NewUploaderMock())
+	assert.NoError(t, err)
+	assert.NotNil(t, replayer)
+}
+
+func TestReplay(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), DefaultOutputFileName)
+	manifest := newTestManifest()
+	writeTestBundle(t, bundlePath, manifest)
+	uploaderMock := testdata.NewUploaderMock()
+	uploaderMock.Result = &upload.UploadResult{DetailsUrl: "https://debricked.com/details"}
+	replayer, _ := NewReplayer(uploaderMock)
+	cwd, _ := os.Getwd()
+
+	result, err := replayer.Replay(bundlePath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uploaderMock.Result, result)
+	assert.Equal(t, manifest.UploadOptions(), uploaderMock.Options)
+	assert.Len(t, uploaderMock.Files, 3)
+	expected, _ := os.ReadFile(filepath.Join(testdataProject, "yarn.lock"))
+	assert.Equal(t, expected, uploaderMock.Files["yarn.lock"])
+	wd, _ := os.Getwd()
+	assert.Equal(t, cwd, wd, "failed to assert that the working directory was restored")
+}
+
+func TestReplayUploadError(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), DefaultOutputFileName)
+	writeTestBundle(t, bundlePath, newTestManifest())
+	uploaderMock := testdata.NewUploaderMock()
+	uploaderMock.Error = errors.New("upload failed")
+	replayer, _ := NewReplayer(uploaderMock)
+
+	_, err := replayer.Replay(bundlePath)
+
+	assert.ErrorContains(t, err, "upload failed")
+}
+
+func TestReplayMissingBundle(t *testing.T) {
+	replayer, _ := NewReplayer(testdata.NewUploaderMock())
+
+	_, err := replayer.Replay(filepath.Join(t.TempDir(), DefaultOutputFileName))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -10,12 +10,39 @@ import (
 
 type JavaFinder struct{}
 
+// FindRoots returns the root pom.xml files of Maven projects, the root settings or build files of Gradle builds and
+// the root build.sbt files of sbt builds. Directories with both Maven and Gradle files are built with Maven, while
+// pom.xml files next to build.sbt files are the POMs converted by the sbt resolver, so those are built with sbt
 func (f JavaFinder) FindRoots(files []string) ([]string, error) {
+	ss := SbtService{}
+	sbtRoots := ss.GetRootSbtFiles(finder.FilterFiles(files, `^build\.sbt$`))
+	sbtDirs := make(map[string]bool)
+	for _, rootFile := range sbtRoots {
+		sbtDirs[filepath.Dir(rootFile)] = true
+	}
+
 	pomFiles := finder.FilterFiles(files, "pom.xml")
 	ps := PomService{}
-	rootFiles := ps.GetRootPomFiles(pomFiles)
+	var rootFiles []string
+	for _, rootFile := range ps.GetRootPomFiles(pomFiles) {
+		if !sbtDirs[filepath.Dir(rootFile)] {
+			rootFiles = append(rootFiles, rootFile)
+		}
+	}
+
+	mavenDirs := make(map[string]bool)
+	for _, rootFile := range rootFiles {
+		mavenDirs[filepath.Dir(rootFile)] = true
+	}
+	gradleFiles := finder.FilterFiles(files, `^(settings|build)\.gradle(\.kts)?$`)
+	gs := GradleService{}
+	for _, rootFile := range gs.GetRootGradleFiles(gradleFiles) {
+		if !mavenDirs[filepath.Dir(rootFile)] && !sbtDirs[filepath.Dir(rootFile)] {
+			rootFiles = append(rootFiles, rootFile)
+		}
+	}
 
-	return rootFiles, nil
+	return append(rootFiles, sbtRoots...), nil
 }
 
 func (f JavaFinder) FindDependencyDirs(files []string, findJars bool) ([]string, error) {
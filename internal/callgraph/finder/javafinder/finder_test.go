@@ -17,6 +17,39 @@ func TestFindMavenRoots(t *testing.T) {
 	assert.Len(t, roots, 0)
 }
 
+func TestFindGradleRoots(t *testing.T) {
+	settings := filepath.Join("gradle", "settings.gradle")
+	subprojectBuild := filepath.Join("gradle", "app", "build.gradle")
+	singleBuild := filepath.Join("single", "build.gradle.kts")
+	f := JavaFinder{}
+	roots, err := f.FindRoots([]string{settings, subprojectBuild, singleBuild, "gradle.properties"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{settings, singleBuild}, roots)
+}
+
+func TestFindRootsMavenBeforeGradle(t *testing.T) {
+	pom := filepath.Join("testdata", "pom.xml")
+	build := filepath.Join("testdata", "build.gradle")
+	f := JavaFinder{}
+	roots, err := f.FindRoots([]string{pom, build})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{pom}, roots)
+}
+
+func TestFindSbtRoots(t *testing.T) {
+	sbt := filepath.Join("testdata", "build.sbt")
+	// The pom.xml next to the build.sbt file is the POM converted by the sbt resolver
+	pom := filepath.Join("testdata", "pom.xml")
+	gradle := filepath.Join("testdata", "build.gradle")
+	f := JavaFinder{}
+	roots, err := f.FindRoots([]string{pom, gradle, sbt})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{sbt}, roots)
+}
+
 func TestFindDependencyDirs(t *testing.T) {
 	files := []string{"test/asd/pom.xml", "test2/basd/qwe/asd.class", "test2/test/asd", "test3/tes.jar"}
 	f := JavaFinder{}
@@ -0,0 +1,69 @@
+package javafinder
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// GradleSettingsFiles define the projects of multi-project Gradle builds
+var GradleSettingsFiles = []string{"settings.gradle", "settings.gradle.kts"}
+
+// GradleBuildFiles define Gradle projects
+var GradleBuildFiles = []string{"build.gradle", "build.gradle.kts"}
+
+type IGradleService interface {
+	GetRootGradleFiles(files []string) []string
+}
+
+type GradleService struct{}
+
+// GetRootGradleFiles returns the settings files of Gradle builds, and the build files of single-project builds
+// without a settings file. Build files of subprojects are part of the build of the closest settings file above them
+func (g GradleService) GetRootGradleFiles(files []string) []string {
+	settingsDirs := map[string]bool{}
+	var roots []string
+	for _, file := range files {
+		if isOneOf(filepath.Base(file), GradleSettingsFiles) {
+			settingsDirs[filepath.Dir(file)] = true
+			roots = append(roots, file)
+		}
+	}
+
+	for _, file := range files {
+		if !isOneOf(filepath.Base(file), GradleBuildFiles) || isWithinAny(filepath.Dir(file), settingsDirs) {
+			continue
+		}
+		roots = append(roots, file)
+	}
+
+	return roots
+}
+
+// IsGradleFile returns true if file is a Gradle settings or build file
+func IsGradleFile(file string) bool {
+	base := filepath.Base(file)
+
+	return isOneOf(base, GradleSettingsFiles) || isOneOf(base, GradleBuildFiles)
+}
+
+// isWithinAny returns true if dir is one of dirs, or is within one of them
+func isWithinAny(dir string, dirs map[string]bool) bool {
+	for other := range dirs {
+		rel, err := filepath.Rel(other, dir)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isOneOf(name string, names []string) bool {
+	for _, candidate := range names {
+		if name == candidate {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,37 @@
+package javafinder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRootGradleFiles(t *testing.T) {
+	settings := filepath.Join("project", "settings.gradle")
+	rootBuild := filepath.Join("project", "build.gradle")
+	subprojectBuild := filepath.Join("project", "app", "build.gradle.kts")
+	singleBuild := filepath.Join("single", "build.gradle.kts")
+	g := GradleService{}
+
+	roots := g.GetRootGradleFiles([]string{rootBuild, subprojectBuild, settings, singleBuild})
+
+	assert.Equal(t, []string{settings, singleBuild}, roots)
+}
+
+func TestGetRootGradleFilesSiblingSettings(t *testing.T) {
+	settings := filepath.Join("project", "settings.gradle.kts")
+	siblingBuild := filepath.Join("project-other", "build.gradle")
+	g := GradleService{}
+
+	roots := g.GetRootGradleFiles([]string{settings, siblingBuild})
+
+	assert.Equal(t, []string{settings, siblingBuild}, roots)
+}
+
+func TestIsGradleFile(t *testing.T) {
+	assert.True(t, IsGradleFile(filepath.Join("project", "settings.gradle")))
+	assert.True(t, IsGradleFile(filepath.Join("project", "build.gradle.kts")))
+	assert.False(t, IsGradleFile(filepath.Join("project", "pom.xml")))
+	assert.False(t, IsGradleFile(filepath.Join("project", "gradle.properties")))
+}
@@ -0,0 +1,44 @@
+package javafinder
+
+import (
+	"path/filepath"
+)
+
+// SbtBuildFile defines sbt projects
+const SbtBuildFile = "build.sbt"
+
+type ISbtService interface {
+	GetRootSbtFiles(files []string) []string
+}
+
+type SbtService struct{}
+
+// GetRootSbtFiles returns the build files of sbt builds. Build files of subprojects are part of the build of the
+// closest build file above them
+func (s SbtService) GetRootSbtFiles(files []string) []string {
+	buildDirs := map[string]bool{}
+	for _, file := range files {
+		if filepath.Base(file) == SbtBuildFile {
+			buildDirs[filepath.Dir(file)] = true
+		}
+	}
+
+	var roots []string
+	for _, file := range files {
+		if filepath.Base(file) != SbtBuildFile {
+			continue
+		}
+		dir := filepath.Dir(file)
+		parent := filepath.Dir(dir)
+		if parent == dir || !isWithinAny(parent, buildDirs) {
+			roots = append(roots, file)
+		}
+	}
+
+	return roots
+}
+
+// IsSbtFile returns true if file is an sbt build file
+func IsSbtFile(file string) bool {
+	return filepath.Base(file) == SbtBuildFile
+}
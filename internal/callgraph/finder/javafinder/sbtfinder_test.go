@@ -0,0 +1,32 @@
+package javafinder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRootSbtFiles(t *testing.T) {
+	root := filepath.Join("project", "build.sbt")
+	subproject := filepath.Join("project", "core", "build.sbt")
+	sibling := filepath.Join("project-other", "build.sbt")
+	s := SbtService{}
+
+	roots := s.GetRootSbtFiles([]string{subproject, root, sibling})
+
+	assert.Equal(t, []string{root, sibling}, roots)
+}
+
+func TestGetRootSbtFilesCurrentDirectory(t *testing.T) {
+	s := SbtService{}
+
+	roots := s.GetRootSbtFiles([]string{"build.sbt", filepath.Join("core", "build.sbt")})
+
+	assert.Equal(t, []string{"build.sbt"}, roots)
+}
+
+func TestIsSbtFile(t *testing.T) {
+	assert.True(t, IsSbtFile(filepath.Join("project", "build.sbt")))
+	assert.False(t, IsSbtFile(filepath.Join("project", "plugins.sbt")))
+}
@@ -0,0 +1,225 @@
+package javascriptfinder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+)
+
+const packageJson = "package.json"
+
+// SourceExtensions are the extensions of JavaScript and TypeScript sources, in resolution order
+var SourceExtensions = []string{".js", ".mjs", ".cjs", ".jsx", ".ts", ".mts", ".cts", ".tsx"}
+
+// buildDirs are output directories of compiled TypeScript, whose sources are commonly found in src
+var buildDirs = []string{"dist", "lib", "build", "out"}
+
+type JavascriptFinder struct{}
+
+type PackageJson struct {
+	Name    string      `json:"name"`
+	Main    string      `json:"main"`
+	Module  string      `json:"module"`
+	Bin     interface{} `json:"bin"`
+	Exports interface{} `json:"exports"`
+}
+
+// ReadPackageJson reads the package.json at path
+func ReadPackageJson(path string) (PackageJson, error) {
+	var pkg PackageJson
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return pkg, err
+	}
+	err = json.Unmarshal(content, &pkg)
+
+	return pkg, err
+}
+
+// FindRoots returns the entry points declared by the main, bin and exports fields of package.json files.
+// Packages without declared entry points default to index.js, like Node.js does
+func (f JavascriptFinder) FindRoots(files []string) ([]string, error) {
+	roots := map[string]bool{}
+	for _, file := range files {
+		if filepath.Base(file) != packageJson {
+			continue
+		}
+		pkg, err := ReadPackageJson(file)
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Dir(file)
+		entryPoints := pkg.EntryPoints()
+		if len(entryPoints) == 0 {
+			entryPoints = []string{"index"}
+		}
+		for _, entryPoint := range entryPoints {
+			if root := ResolveSourceFile(filepath.Join(dir, filepath.FromSlash(entryPoint))); len(root) > 0 {
+				roots[root] = true
+			}
+		}
+	}
+
+	rootList := make([]string, 0, len(roots))
+	for root := range roots {
+		rootList = append(rootList, root)
+	}
+	sort.Strings(rootList)
+
+	return rootList, nil
+}
+
+// EntryPoints returns the relative paths of the main, bin and exports entry points of the package
+func (pkg PackageJson) EntryPoints() []string {
+	var entryPoints []string
+	for _, entryPoint := range []string{pkg.Main, pkg.Module} {
+		if len(entryPoint) > 0 {
+			entryPoints = append(entryPoints, entryPoint)
+		}
+	}
+	switch bin := pkg.Bin.(type) {
+	case string:
+		entryPoints = append(entryPoints, bin)
+	case map[string]interface{}:
+		for _, command := range bin {
+			if path, ok := command.(string); ok {
+				entryPoints = append(entryPoints, path)
+			}
+		}
+	}
+
+	return append(entryPoints, exportPaths(pkg.Exports)...)
+}
+
+// exportPaths returns the relative paths of the exports field, which is a path, an array of paths or
+// a map of subpaths and conditions to exports
+func exportPaths(exports interface{}) []string {
+	var paths []string
+	switch value := exports.(type) {
+	case string:
+		// Subpath patterns such as ./features/*.js can't be resolved to files
+		if strings.HasPrefix(value, "./") && !strings.Contains(value, "*") {
+			paths = append(paths, value)
+		}
+	case []interface{}:
+		for _, export := range value {
+			paths = append(paths, exportPaths(export)...)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// Type declarations are not code
+			if key != "types" {
+				paths = append(paths, exportPaths(value[key])...)
+			}
+		}
+	}
+
+	return paths
+}
+
+// ResolveSourceFile returns the source file of path, which may omit its extension or be a directory with an index.
+// Compiled files which don't exist are looked up among the TypeScript sources in src. An empty string is
+// returned if there is no such file
+func ResolveSourceFile(path string) string {
+	candidates := []string{path}
+	if ext := filepath.Ext(path); ext == ".js" || ext == ".mjs" || ext == ".cjs" || ext == ".jsx" {
+		// TypeScript sources import each other with the extensions of their compiled files
+		candidates = append(candidates, strings.TrimSuffix(path, ext))
+	}
+	for _, candidate := range candidates {
+		if source := resolveFile(candidate); len(source) > 0 {
+			return source
+		}
+	}
+	parts := strings.Split(path, string(filepath.Separator))
+	for i, part := range parts {
+		if !isBuildDir(part) {
+			continue
+		}
+		sourceParts := append(append(append([]string{}, parts[:i]...), "src"), parts[i+1:]...)
+		if source := ResolveSourceFile(strings.Join(sourceParts, string(filepath.Separator))); len(source) > 0 {
+			return source
+		}
+	}
+
+	return ""
+}
+
+func isBuildDir(name string) bool {
+	for _, buildDir := range buildDirs {
+		if name == buildDir {
+			return true
+		}
+	}
+
+	return false
+}
+
+func resolveFile(path string) string {
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return path
+	}
+	for _, ext := range SourceExtensions {
+		if info, err := os.Stat(path + ext); err == nil && !info.IsDir() {
+			return path + ext
+		}
+	}
+	for _, ext := range SourceExtensions {
+		index := filepath.Join(path, "index"+ext)
+		if info, err := os.Stat(index); err == nil && !info.IsDir() {
+			return index
+		}
+	}
+
+	return ""
+}
+
+// Not needed for javascript, dependencies are resolved from node_modules
+func (f JavascriptFinder) FindDependencyDirs(files []string, findJars bool) ([]string, error) {
+	return []string{}, nil
+}
+
+// FindFiles returns the package.json files in paths
+func (f JavascriptFinder) FindFiles(paths []string, exclusions []string, inclusions []string) ([]string, error) {
+	files := make(map[string]bool)
+
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			excluded := file.Excluded(exclusions, inclusions, path)
+
+			if info.IsDir() && (excluded || info.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+
+			if !info.IsDir() && !excluded && info.Name() == packageJson {
+				files[path] = true
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileList := make([]string, 0, len(files))
+	for k := range files {
+		fileList = append(fileList, k)
+	}
+
+	return fileList, nil
+}
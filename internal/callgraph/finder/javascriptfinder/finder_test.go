@@ -0,0 +1,95 @@
+package javascriptfinder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFiles(t *testing.T) {
+	f := JavascriptFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, nil, nil)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join("testdata", "app", "package.json"),
+		filepath.Join("testdata", "compiled", "package.json"),
+		filepath.Join("testdata", "library", "package.json"),
+		filepath.Join("testdata", "noentry", "package.json"),
+	}, files)
+}
+
+func TestFindFilesExclusions(t *testing.T) {
+	f := JavascriptFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, []string{"testdata"}, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFindFilesError(t *testing.T) {
+	f := JavascriptFinder{}
+	_, err := f.FindFiles([]string{"nonexistent"}, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestFindRoots(t *testing.T) {
+	f := JavascriptFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, nil, nil)
+	assert.Nil(t, err)
+
+	roots, err := f.FindRoots(files)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("testdata", "app", "bin", "admin.js"),
+		filepath.Join("testdata", "app", "bin", "app.js"),
+		filepath.Join("testdata", "app", "src", "index.js"),
+		filepath.Join("testdata", "compiled", "src", "main.ts"),
+		filepath.Join("testdata", "library", "lib", "index.cjs"),
+		filepath.Join("testdata", "library", "lib", "index.mjs"),
+		filepath.Join("testdata", "library", "lib", "utils.js"),
+	}, roots)
+}
+
+func TestFindRootsFileError(t *testing.T) {
+	f := JavascriptFinder{}
+	_, err := f.FindRoots([]string{filepath.Join("nonexistent", "package.json")})
+	assert.Error(t, err)
+
+	invalid := filepath.Join(t.TempDir(), "package.json")
+	assert.NoError(t, os.WriteFile(invalid, []byte("{"), 0600))
+	_, err = f.FindRoots([]string{invalid})
+	assert.Error(t, err)
+}
+
+func TestFindRootsDefaultsToIndex(t *testing.T) {
+	project := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(project, "package.json"), []byte(`{"name": "project"}`), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(project, "index.ts"), []byte(""), 0600))
+
+	f := JavascriptFinder{}
+	roots, err := f.FindRoots([]string{filepath.Join(project, "package.json")})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(project, "index.ts")}, roots)
+}
+
+func TestEntryPoints(t *testing.T) {
+	pkg := PackageJson{Main: "main.js", Module: "module.mjs", Bin: "cli.js", Exports: []interface{}{"./a.js", "./b/*.js", "c.js"}}
+	assert.Equal(t, []string{"main.js", "module.mjs", "cli.js", "./a.js"}, pkg.EntryPoints())
+}
+
+func TestResolveSourceFile(t *testing.T) {
+	app := filepath.Join("testdata", "app")
+	assert.Equal(t, filepath.Join(app, "src", "index.js"), ResolveSourceFile(filepath.Join(app, "src", "index.js")))
+	assert.Equal(t, filepath.Join(app, "src", "index.js"), ResolveSourceFile(filepath.Join(app, "src", "index")))
+	assert.Equal(t, filepath.Join(app, "src", "index.js"), ResolveSourceFile(filepath.Join(app, "src")))
+	assert.Equal(t, filepath.Join("testdata", "compiled", "src", "main.ts"), ResolveSourceFile(filepath.Join("testdata", "compiled", "dist", "main.js")))
+	assert.Empty(t, ResolveSourceFile(filepath.Join(app, "missing")))
+}
+
+func TestFindDependencyDirs(t *testing.T) {
+	f := JavascriptFinder{}
+	dirs, err := f.FindDependencyDirs(nil, false)
+	assert.Nil(t, err)
+	assert.Empty(t, dirs)
+}
@@ -0,0 +1 @@
+require('../src')
@@ -0,0 +1,238 @@
+package pythonfinder
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	setupCfg      = "setup.cfg"
+	pyprojectToml = "pyproject.toml"
+	mainModule    = "__main__.py"
+	venvConfig    = "pyvenv.cfg"
+)
+
+var mainGuardRegex = regexp.MustCompile(`(?m)^if\s+(__name__\s*==\s*['"]__main__['"]|['"]__main__['"]\s*==\s*__name__)\s*:`)
+
+type PythonFinder struct{}
+
+// FindRoots returns the entry points of the Python files, which are __main__ modules, scripts guarded by
+// if __name__ == "__main__" and the modules of console scripts declared in setup.cfg or pyproject.toml
+func (f PythonFinder) FindRoots(files []string) ([]string, error) {
+	roots := map[string]bool{}
+	for _, file := range files {
+		switch {
+		case filepath.Base(file) == setupCfg:
+			modules, err := f.setupCfgScripts(file)
+			if err != nil {
+				return nil, err
+			}
+			f.addScriptRoots(roots, filepath.Dir(file), modules)
+		case filepath.Base(file) == pyprojectToml:
+			modules, err := f.pyprojectScripts(file)
+			if err != nil {
+				return nil, err
+			}
+			f.addScriptRoots(roots, filepath.Dir(file), modules)
+		case filepath.Base(file) == mainModule:
+			roots[file] = true
+		case filepath.Ext(file) == ".py":
+			isMain, err := f.isMainScript(file)
+			if err != nil {
+				return nil, err
+			}
+			if isMain {
+				roots[file] = true
+			}
+		}
+	}
+
+	rootList := make([]string, 0, len(roots))
+	for root := range roots {
+		rootList = append(rootList, root)
+	}
+	sort.Strings(rootList)
+
+	return rootList, nil
+}
+
+func (f PythonFinder) isMainScript(file string) (bool, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+
+	return mainGuardRegex.Match(content), nil
+}
+
+// addScriptRoots adds the module files of console script references, such as pkg.cli:main, to roots.
+// Modules are looked up in dir and in a src layout within dir
+func (f PythonFinder) addScriptRoots(roots map[string]bool, dir string, references []string) {
+	for _, reference := range references {
+		module, _, _ := strings.Cut(reference, ":")
+		module = strings.TrimSpace(module)
+		if len(module) == 0 {
+			continue
+		}
+		modulePath := filepath.Join(strings.Split(module, ".")...)
+		candidates := []string{
+			filepath.Join(dir, modulePath+".py"),
+			filepath.Join(dir, modulePath, "__init__.py"),
+			filepath.Join(dir, "src", modulePath+".py"),
+			filepath.Join(dir, "src", modulePath, "__init__.py"),
+		}
+		for _, candidate := range candidates {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				roots[candidate] = true
+
+				break
+			}
+		}
+	}
+}
+
+// setupCfgScripts returns the references of the console_scripts in the [options.entry_points] section of setup.cfg
+func (f PythonFinder) setupCfgScripts(file string) ([]string, error) {
+	cfg, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer cfg.Close()
+
+	var references []string
+	section := ""
+	inConsoleScripts := false
+	scanner := bufio.NewScanner(cfg)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			inConsoleScripts = false
+
+			continue
+		}
+		if section != "options.entry_points" {
+			continue
+		}
+		isContinuation := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if !isContinuation {
+			key, value, _ := strings.Cut(trimmed, "=")
+			inConsoleScripts = strings.TrimSpace(key) == "console_scripts"
+			trimmed = strings.TrimSpace(value)
+			if len(trimmed) == 0 {
+				continue
+			}
+		}
+		if inConsoleScripts {
+			if _, reference, found := strings.Cut(trimmed, "="); found {
+				references = append(references, strings.TrimSpace(reference))
+			}
+		}
+	}
+
+	return references, scanner.Err()
+}
+
+type pyproject struct {
+	Project struct {
+		Scripts map[string]string `toml:"scripts"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Scripts map[string]interface{} `toml:"scripts"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+// pyprojectScripts returns the references of [project.scripts] and [tool.poetry.scripts] in pyproject.toml
+func (f PythonFinder) pyprojectScripts(file string) ([]string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var project pyproject
+	if err = toml.Unmarshal(content, &project); err != nil {
+		return nil, err
+	}
+
+	var references []string
+	for _, reference := range project.Project.Scripts {
+		references = append(references, reference)
+	}
+	for _, script := range project.Tool.Poetry.Scripts {
+		switch reference := script.(type) {
+		case string:
+			references = append(references, reference)
+		case map[string]interface{}:
+			// Poetry also supports tables such as { reference = "pkg.cli:main", type = "console" }
+			if value, ok := reference["reference"].(string); ok {
+				references = append(references, value)
+			}
+		}
+	}
+
+	return references, nil
+}
+
+// Not needed for python, dependencies are resolved from the environment of the project
+func (f PythonFinder) FindDependencyDirs(files []string, findJars bool) ([]string, error) {
+	return []string{}, nil
+}
+
+// FindFiles returns the Python files and project configurations in paths. Virtual environments are skipped
+func (f PythonFinder) FindFiles(paths []string, exclusions []string, inclusions []string) ([]string, error) {
+	files := make(map[string]bool)
+
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			excluded := file.Excluded(exclusions, inclusions, path)
+
+			if info.IsDir() {
+				if excluded || info.Name() == "__pycache__" || isVenv(path) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			base := filepath.Base(path)
+			if !excluded && (filepath.Ext(path) == ".py" || base == setupCfg || base == pyprojectToml) {
+				files[path] = true
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileList := make([]string, 0, len(files))
+	for k := range files {
+		fileList = append(fileList, k)
+	}
+
+	return fileList, nil
+}
+
+func isVenv(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, venvConfig))
+
+	return err == nil
+}
@@ -0,0 +1,120 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFiles(t *testing.T) {
+	f := PythonFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, nil, nil)
+	assert.Nil(t, err)
+	assert.Contains(t, files, filepath.Join("testdata", "project", "setup.cfg"))
+	assert.Contains(t, files, filepath.Join("testdata", "project", "src", "tool", "cli.py"))
+	assert.Contains(t, files, filepath.Join("testdata", "poetry", "pyproject.toml"))
+	assert.Contains(t, files, filepath.Join("testdata", "scripts", "script.py"))
+	assert.NotContains(t, files, filepath.Join("testdata", ".venv", "lib", "installed.py"))
+}
+
+func TestFindFilesExclusions(t *testing.T) {
+	f := PythonFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, []string{"testdata"}, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFindFilesError(t *testing.T) {
+	f := PythonFinder{}
+	_, err := f.FindFiles([]string{"nonexistent"}, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestFindRoots(t *testing.T) {
+	f := PythonFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, nil, nil)
+	assert.Nil(t, err)
+
+	roots, err := f.FindRoots(files)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("testdata", "poetry", "app", "admin.py"),
+		filepath.Join("testdata", "poetry", "app", "run.py"),
+		filepath.Join("testdata", "project", "src", "tool", "__main__.py"),
+		filepath.Join("testdata", "project", "src", "tool", "cli.py"),
+		filepath.Join("testdata", "scripts", "script.py"),
+	}, roots)
+}
+
+func TestFindRootsNoEntryPoint(t *testing.T) {
+	f := PythonFinder{}
+	roots, err := f.FindRoots([]string{filepath.Join("testdata", "scripts", "library.py")})
+	assert.Nil(t, err)
+	assert.Empty(t, roots)
+}
+
+func TestFindRootsFileError(t *testing.T) {
+	f := PythonFinder{}
+	for _, file := range []string{"nonexistent.py", "setup.cfg", "pyproject.toml"} {
+		t.Run(file, func(t *testing.T) {
+			_, err := f.FindRoots([]string{filepath.Join("nonexistent", file)})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestFindRootsInvalidPyproject(t *testing.T) {
+	pyproject := filepath.Join(t.TempDir(), "pyproject.toml")
+	assert.NoError(t, os.WriteFile(pyproject, []byte("[project"), 0600))
+
+	f := PythonFinder{}
+	_, err := f.FindRoots([]string{pyproject})
+	assert.NotNil(t, err)
+}
+
+func TestSetupCfgScripts(t *testing.T) {
+	setupCfg := filepath.Join(t.TempDir(), "setup.cfg")
+	content := `[options.entry_points]
+gui_scripts =
+    gui = tool.gui:main
+console_scripts = single = tool.single:main
+[options]
+packages = find:
+`
+	assert.NoError(t, os.WriteFile(setupCfg, []byte(content), 0600))
+
+	f := PythonFinder{}
+	references, err := f.setupCfgScripts(setupCfg)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tool.single:main"}, references)
+}
+
+func TestIsMainScript(t *testing.T) {
+	cases := map[string]bool{
+		"if __name__ == \"__main__\":\n    main()\n": true,
+		"if __name__ == '__main__':\n    main()\n":   true,
+		"if '__main__' == __name__:\n    main()\n":   true,
+		"def main():\n    pass\n":                    false,
+		"    if __name__ == '__main__':\n":           false,
+	}
+	dir := t.TempDir()
+	f := PythonFinder{}
+	for content, isMain := range cases {
+		t.Run(content, func(t *testing.T) {
+			script := filepath.Join(dir, "script.py")
+			assert.NoError(t, os.WriteFile(script, []byte(content), 0600))
+			result, err := f.isMainScript(script)
+			assert.Nil(t, err)
+			assert.Equal(t, isMain, result)
+		})
+	}
+}
+
+func TestFindDependencyDirs(t *testing.T) {
+	f := PythonFinder{}
+	dirs, err := f.FindDependencyDirs([]string{filepath.Join("testdata", "scripts", "script.py")}, false)
+	assert.Nil(t, err)
+	assert.Empty(t, dirs)
+}
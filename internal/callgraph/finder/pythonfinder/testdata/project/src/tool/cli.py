@@ -0,0 +1,5 @@
+from tool.util import greet
+
+
+def main():
+    greet("world")
@@ -275,6 +275,7 @@ func (cg *CallgraphBuilder) RunCallGraph() (string, error) {
 		return "", err
 	}
 
+	cg.cgModel.RelativizeFilenames(cg.workingDirectory)
 	cgOutputBytes, err := cg.cgModel.ToBytes()
 	if err != nil {
 		return "", err
@@ -2,16 +2,19 @@ package golang
 
 const Name = "golang"
 const StandardVersion = "1"
+const StandardPackageManager = "go"
 
 type Language struct {
-	name    string
-	version string
+	name           string
+	version        string
+	packageManager string
 }
 
 func NewLanguage() Language {
 	return Language{
-		name:    Name,
-		version: StandardVersion,
+		name:           Name,
+		version:        StandardVersion,
+		packageManager: StandardPackageManager,
 	}
 }
 
@@ -22,3 +25,7 @@ func (language Language) Name() string {
 func (language Language) Version() string {
 	return language.version
 }
+
+func (language Language) PackageManager() string {
+	return language.packageManager
+}
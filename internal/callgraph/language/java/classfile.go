@@ -0,0 +1,301 @@
+package java
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const classFileMagic = 0xCAFEBABE
+
+var errInvalidClassFile = errors.New("invalid class file")
+
+type lineRange struct {
+	start int
+	end   int
+}
+
+// classFile holds what the call graph needs of a compiled class. Methods maps Soot sub-signatures, such as
+// void main(java.lang.String[]), to the source lines of their code
+type classFile struct {
+	name       string
+	sourceFile string
+	methods    map[string]lineRange
+}
+
+type classReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *classReader) read(n int) []byte {
+	if r.err != nil || r.offset+n > len(r.data) {
+		r.err = errInvalidClassFile
+
+		return make([]byte, n)
+	}
+	bytes := r.data[r.offset : r.offset+n]
+	r.offset += n
+
+	return bytes
+}
+
+func (r *classReader) u1() int {
+	return int(r.read(1)[0])
+}
+
+func (r *classReader) u2() int {
+	return int(binary.BigEndian.Uint16(r.read(2)))
+}
+
+func (r *classReader) u4() int {
+	return int(binary.BigEndian.Uint32(r.read(4)))
+}
+
+// parseClassFile reads the name, source file and method line ranges of a class file. Line numbers of Kotlin
+// functions inlined from other files are left out, as they refer to lines beyond the end of the source file
+func parseClassFile(data []byte) (*classFile, error) {
+	r := &classReader{data: data}
+	if r.u4() != classFileMagic {
+		return nil, errInvalidClassFile
+	}
+	r.read(4) // minor and major version
+
+	utf8s, classes := r.constantPool()
+	r.read(2) // access flags
+	class := &classFile{name: strings.ReplaceAll(utf8s[classes[r.u2()]], "/", "."), methods: map[string]lineRange{}}
+	r.read(2) // super class
+	r.read(2 * r.u2())
+
+	fieldCount := r.u2()
+	for i := 0; i < fieldCount && r.err == nil; i++ {
+		r.read(6) // access flags, name and descriptor
+		r.skipAttributes()
+	}
+
+	type method struct {
+		subSignature string
+		lines        []int
+	}
+	var methods []method
+	methodCount := r.u2()
+	for i := 0; i < methodCount && r.err == nil; i++ {
+		r.read(2) // access flags
+		name, descriptor := utf8s[r.u2()], utf8s[r.u2()]
+		m := method{subSignature: sootSubSignature(name, descriptor)}
+		attributeCount := r.u2()
+		for j := 0; j < attributeCount && r.err == nil; j++ {
+			attributeName, length := utf8s[r.u2()], r.u4()
+			attribute := r.read(length)
+			if attributeName == "Code" {
+				m.lines = codeLines(attribute, utf8s)
+			}
+		}
+		methods = append(methods, m)
+	}
+
+	lastLine := 0
+	attributeCount := r.u2()
+	for i := 0; i < attributeCount && r.err == nil; i++ {
+		attributeName, length := utf8s[r.u2()], r.u4()
+		attribute := r.read(length)
+		switch attributeName {
+		case "SourceFile":
+			if len(attribute) == 2 {
+				class.sourceFile = utf8s[int(binary.BigEndian.Uint16(attribute))]
+			}
+		case "SourceDebugExtension":
+			lastLine = smapLastLine(string(attribute))
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	for _, m := range methods {
+		lines := lineRange{}
+		for _, line := range m.lines {
+			if lastLine > 0 && line > lastLine {
+				continue
+			}
+			if lines.start == 0 || line < lines.start {
+				lines.start = line
+			}
+			if line > lines.end {
+				lines.end = line
+			}
+		}
+		if lines.start > 0 {
+			class.methods[m.subSignature] = lines
+		}
+	}
+
+	return class, nil
+}
+
+// constantPool reads the constant pool, and returns its UTF-8 entries and the name indices of its class entries
+func (r *classReader) constantPool() (map[int]string, map[int]int) {
+	utf8s := map[int]string{}
+	classes := map[int]int{}
+	count := r.u2()
+	for i := 1; i < count && r.err == nil; i++ {
+		switch tag := r.u1(); tag {
+		case 1: // Utf8
+			utf8s[i] = string(r.read(r.u2()))
+		case 7: // Class
+			classes[i] = r.u2()
+		case 8, 16, 19, 20: // String, MethodType, Module and Package
+			r.read(2)
+		case 15: // MethodHandle
+			r.read(3)
+		case 3, 4, 9, 10, 11, 12, 17, 18: // Integer, Float, references, NameAndType and dynamic constants
+			r.read(4)
+		case 5, 6: // Long and Double take two entries
+			r.read(8)
+			i++
+		default:
+			r.err = errInvalidClassFile
+		}
+	}
+
+	return utf8s, classes
+}
+
+func (r *classReader) skipAttributes() {
+	count := r.u2()
+	for i := 0; i < count && r.err == nil; i++ {
+		r.read(2)
+		r.read(r.u4())
+	}
+}
+
+// codeLines returns the line numbers of the LineNumberTable of a Code attribute
+func codeLines(code []byte, utf8s map[int]string) []int {
+	r := &classReader{data: code}
+	r.read(4) // max stack and max locals
+	r.read(r.u4())
+	r.read(8 * r.u2()) // exception table
+	var lines []int
+	attributeCount := r.u2()
+	for i := 0; i < attributeCount && r.err == nil; i++ {
+		attributeName, length := utf8s[r.u2()], r.u4()
+		attribute := &classReader{data: r.read(length)}
+		if attributeName != "LineNumberTable" {
+			continue
+		}
+		entries := attribute.u2()
+		for j := 0; j < entries && attribute.err == nil; j++ {
+			attribute.read(2) // start pc
+			lines = append(lines, attribute.u2())
+		}
+	}
+
+	return lines
+}
+
+// smapLastLine returns the last line of the source file of a class from its source map (JSR-45), which Kotlin writes
+// for classes with inlined functions. The lines of the source file are the ranges of file 1 in the first stratum
+func smapLastLine(smap string) int {
+	lastLine := 0
+	inLines := false
+	fileId := "1"
+	strata := 0
+	for _, line := range strings.Split(smap, "\n") {
+		line = strings.TrimSpace(line)
+		// Only the first stratum maps the lines of the class, while KotlinDebug maps inlined calls to call sites
+		if strings.HasPrefix(line, "*S") {
+			strata++
+		}
+		if strata > 1 {
+			break
+		}
+		if strings.HasPrefix(line, "*") {
+			inLines = line == "*L"
+
+			continue
+		}
+		// Line info has the form InputStartLine#LineFileID,RepeatCount:OutputStartLine,OutputLineIncrement, where
+		// an omitted file ID is the one of the previous line info
+		input, output, found := strings.Cut(line, ":")
+		if !inLines || !found {
+			continue
+		}
+		input, repeatCount, _ := strings.Cut(input, ",")
+		if _, id, hasFileId := strings.Cut(input, "#"); hasFileId {
+			fileId = id
+		}
+		outputStart, outputIncrement, _ := strings.Cut(output, ",")
+		start, err := strconv.Atoi(outputStart)
+		if err != nil || fileId != "1" {
+			continue
+		}
+		repeat, increment := atoiOr(repeatCount, 1), atoiOr(outputIncrement, 1)
+		if end := start + repeat*increment - 1; end > lastLine {
+			lastLine = end
+		}
+	}
+
+	return lastLine
+}
+
+func atoiOr(value string, defaultValue int) int {
+	if number, err := strconv.Atoi(value); err == nil {
+		return number
+	}
+
+	return defaultValue
+}
+
+// sootSubSignature formats a method the way Soot does in method signatures, such as int add(int,java.lang.String[])
+func sootSubSignature(name string, descriptor string) string {
+	params, returnType, _ := strings.Cut(strings.TrimPrefix(descriptor, "("), ")")
+	var paramTypes []string
+	for len(params) > 0 {
+		var paramType string
+		paramType, params = descriptorType(params)
+		paramTypes = append(paramTypes, paramType)
+	}
+	returnTypeName, _ := descriptorType(returnType)
+
+	return returnTypeName + " " + name + "(" + strings.Join(paramTypes, ",") + ")"
+}
+
+// descriptorType returns the Java name of the first type of a field descriptor, and the rest of the descriptor
+func descriptorType(descriptor string) (string, string) {
+	dimensions := 0
+	for len(descriptor) > 0 && descriptor[0] == '[' {
+		dimensions++
+		descriptor = descriptor[1:]
+	}
+	if len(descriptor) == 0 {
+		return "", ""
+	}
+	name, rest := "", descriptor[1:]
+	switch descriptor[0] {
+	case 'B':
+		name = "byte"
+	case 'C':
+		name = "char"
+	case 'D':
+		name = "double"
+	case 'F':
+		name = "float"
+	case 'I':
+		name = "int"
+	case 'J':
+		name = "long"
+	case 'S':
+		name = "short"
+	case 'Z':
+		name = "boolean"
+	case 'V':
+		name = "void"
+	case 'L':
+		className, after, _ := strings.Cut(descriptor[1:], ";")
+		name, rest = strings.ReplaceAll(className, "/", "."), after
+	}
+
+	return name + strings.Repeat("[]", dimensions), rest
+}
@@ -0,0 +1,217 @@
+package java
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// classFileBuilder assembles minimal class files with the parts read by parseClassFile
+type classFileBuilder struct {
+	pool      bytes.Buffer
+	poolCount int
+	fields    int
+	methods   bytes.Buffer
+	count     int
+	attrs     bytes.Buffer
+	attrCount int
+}
+
+func u2(value int) []byte {
+	return binary.BigEndian.AppendUint16(nil, uint16(value))
+}
+
+func u4(value int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(value))
+}
+
+func (b *classFileBuilder) utf8(value string) int {
+	b.pool.WriteByte(1)
+	b.pool.Write(u2(len(value)))
+	b.pool.WriteString(value)
+	b.poolCount++
+
+	return b.poolCount
+}
+
+func (b *classFileBuilder) class(name string) int {
+	nameIndex := b.utf8(name)
+	b.pool.WriteByte(7)
+	b.pool.Write(u2(nameIndex))
+	b.poolCount++
+
+	return b.poolCount
+}
+
+func (b *classFileBuilder) long() {
+	b.pool.WriteByte(5)
+	b.pool.Write(make([]byte, 8))
+	b.poolCount += 2
+}
+
+func (b *classFileBuilder) method(name string, descriptor string, lines ...int) {
+	b.methods.Write(u2(1))
+	b.methods.Write(u2(b.utf8(name)))
+	b.methods.Write(u2(b.utf8(descriptor)))
+	b.methods.Write(u2(1))
+
+	var lineTable bytes.Buffer
+	lineTable.Write(u2(len(lines)))
+	for pc, line := range lines {
+		lineTable.Write(u2(pc))
+		lineTable.Write(u2(line))
+	}
+	var code bytes.Buffer
+	code.Write(u2(1))
+	code.Write(u2(1))
+	code.Write(u4(1))
+	code.WriteByte(0xb1) // return
+	code.Write(u2(0))
+	code.Write(u2(1))
+	code.Write(u2(b.utf8("LineNumberTable")))
+	code.Write(u4(lineTable.Len()))
+	code.Write(lineTable.Bytes())
+
+	b.methods.Write(u2(b.utf8("Code")))
+	b.methods.Write(u4(code.Len()))
+	b.methods.Write(code.Bytes())
+	b.count++
+}
+
+func (b *classFileBuilder) attribute(name string, value []byte) {
+	b.attrs.Write(u2(b.utf8(name)))
+	b.attrs.Write(u4(len(value)))
+	b.attrs.Write(value)
+	b.attrCount++
+}
+
+func (b *classFileBuilder) sourceFile(name string) {
+	b.attribute("SourceFile", u2(b.utf8(name)))
+}
+
+func (b *classFileBuilder) field() {
+	b.fields++
+}
+
+func (b *classFileBuilder) build(name string) []byte {
+	thisClass := b.class(name)
+	superClass := b.class("java/lang/Object")
+	var data bytes.Buffer
+	data.Write(u4(classFileMagic))
+	data.Write(u2(0))
+	data.Write(u2(52))
+	data.Write(u2(b.poolCount + 1))
+	data.Write(b.pool.Bytes())
+	data.Write(u2(0x21))
+	data.Write(u2(thisClass))
+	data.Write(u2(superClass))
+	data.Write(u2(0))
+	data.Write(u2(b.fields))
+	for i := 0; i < b.fields; i++ {
+		data.Write(u2(0x2))
+		data.Write(u4(0))
+		data.Write(u2(0))
+	}
+	data.Write(u2(b.count))
+	data.Write(b.methods.Bytes())
+	data.Write(u2(b.attrCount))
+	data.Write(b.attrs.Bytes())
+
+	return data.Bytes()
+}
+
+func TestParseClassFile(t *testing.T) {
+	b := &classFileBuilder{}
+	b.long()
+	b.field()
+	b.method("<init>", "()V", 3)
+	b.method("greet", "(Ljava/lang/String;[I)Ljava/lang/String;", 5, 7, 6)
+	b.method("abstract", "()V")
+	b.sourceFile("Greeter.kt")
+
+	class, err := parseClassFile(b.build("com/example/Greeter"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "com.example.Greeter", class.name)
+	assert.Equal(t, "Greeter.kt", class.sourceFile)
+	assert.Equal(t, map[string]lineRange{
+		"void <init>()": {3, 3},
+		"java.lang.String greet(java.lang.String,int[])": {5, 7},
+	}, class.methods)
+}
+
+func TestParseClassFileInlinedLines(t *testing.T) {
+	b := &classFileBuilder{}
+	b.method("main", "([Ljava/lang/String;)V", 4, 5, 21, 22)
+	b.sourceFile("Main.kt")
+	smap := "SMAP\nMain.kt\nKotlin\n*S Kotlin\n*F\n+ 1 Main.kt\nMainKt\n+ 2 Util.kt\nUtilKt\n*L\n1#1,10:1\n3#2,2:21\n*E\n"
+	b.attribute("SourceDebugExtension", []byte(smap))
+
+	class, err := parseClassFile(b.build("MainKt"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "MainKt", class.name)
+	assert.Equal(t, lineRange{4, 5}, class.methods["void main(java.lang.String[])"])
+}
+
+func TestParseClassFileInvalid(t *testing.T) {
+	b := &classFileBuilder{}
+	b.method("main", "()V", 1)
+	data := b.build("Main")
+
+	for name, invalid := range map[string][]byte{
+		"empty":       {},
+		"magic":       append([]byte{0, 0, 0, 0}, data[4:]...),
+		"truncated":   data[:len(data)-1],
+		"pool entry":  append(append([]byte{}, data[:10]...), 42),
+		"only header": data[:8],
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseClassFile(invalid)
+			assert.ErrorIs(t, err, errInvalidClassFile)
+		})
+	}
+}
+
+func TestSmapLastLine(t *testing.T) {
+	cases := map[string]struct {
+		smap     string
+		lastLine int
+	}{
+		"single file": {
+			smap:     "SMAP\nA.kt\nKotlin\n*S Kotlin\n*F\n+ 1 A.kt\nA\n*L\n1#1,30:1\n*E\n",
+			lastLine: 30,
+		},
+		"omitted file id": {
+			smap:     "SMAP\nA.kt\nKotlin\n*S Kotlin\n*F\n+ 1 A.kt\nA\n+ 2 B.kt\nB\n*L\n1#1,10:1\n20:40\n5#2,3:50,2\n*E\n",
+			lastLine: 40,
+		},
+		"kotlin debug stratum": {
+			smap:     "SMAP\nA.kt\nKotlin\n*S Kotlin\n*F\n+ 1 A.kt\nA\n*L\n1#1,12:1\n*S KotlinDebug\n*F\n+ 1 A.kt\nA\n*L\n4#1:90\n*E\n",
+			lastLine: 12,
+		},
+		"invalid": {
+			smap:     "SMAP\n*L\nnot:line\n",
+			lastLine: 0,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.lastLine, smapLastLine(c.smap))
+		})
+	}
+}
+
+func TestSootSubSignature(t *testing.T) {
+	cases := map[string]string{
+		"()V":                                    "void run()",
+		"(BCDFIJSZ)Z":                            "boolean run(byte,char,double,float,int,long,short,boolean)",
+		"([[Ljava/lang/String;)[J":               "long[] run(java.lang.String[][])",
+		"(Lscala/Function1;I)Ljava/lang/Object;": "java.lang.Object run(scala.Function1,int)",
+	}
+	for descriptor, subSignature := range cases {
+		assert.Equal(t, subSignature, sootSubSignature("run", descriptor))
+	}
+}
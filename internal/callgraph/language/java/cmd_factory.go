@@ -11,6 +11,11 @@ type ICmdFactory interface {
 	MakeCallGraphGenerationCmd(callgraphJarPath string, workingDirectory string, targetClasses []string, dependencyClasses string, outputName string, ctx cgexec.IContext) (*exec.Cmd, error)
 	MakeBuildMavenCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error)
 	MakeJavaVersionCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeBuildGradleCmd(workingDirectory string, gradlew string, initScript string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeGradleCopyDependenciesCmd(workingDirectory string, gradlew string, initScript string, targetDir string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeBuildSbtCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeSbtPomCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeMvnCopyPomDependenciesCmd(workingDirectory string, pomFile string, targetDir string, ctx cgexec.IContext) (*exec.Cmd, error)
 }
 
 type CmdFactory struct{}
@@ -82,3 +87,86 @@ func (_ CmdFactory) MakeJavaVersionCmd(workingDirectory string, ctx cgexec.ICont
 
 	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
 }
+
+// MakeBuildGradleCmd compiles the main classes of all projects of a Gradle build, and records their class directories
+// with the debrickedClassDirs task of initScript
+func (_ CmdFactory) MakeBuildGradleCmd(workingDirectory string, gradlew string, initScript string, ctx cgexec.IContext) (*exec.Cmd, error) {
+	path, err := exec.LookPath(gradlew)
+	args := []string{
+		gradlew,
+		"--init-script",
+		initScript,
+		"-q",
+		"classes",
+		"debrickedClassDirs",
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+// MakeGradleCopyDependenciesCmd copies the external jars of the runtime classpaths of all projects of a Gradle build
+// to targetDir, with the debrickedCopyDependencies task of initScript
+func (_ CmdFactory) MakeGradleCopyDependenciesCmd(
+	workingDirectory string,
+	gradlew string,
+	initScript string,
+	targetDir string,
+	ctx cgexec.IContext,
+) (*exec.Cmd, error) {
+	path, err := exec.LookPath(gradlew)
+	args := []string{
+		gradlew,
+		"--init-script",
+		initScript,
+		"-q",
+		"debrickedCopyDependencies",
+		"-PdebrickedDependencyDir=" + targetDir,
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+func (_ CmdFactory) MakeBuildSbtCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error) {
+	path, err := exec.LookPath("sbt")
+	args := []string{
+		"sbt",
+		"-batch",
+		"compile",
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+// MakeSbtPomCmd generates the POM files of all projects of an sbt build in their target/scala-* directories
+func (_ CmdFactory) MakeSbtPomCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error) {
+	path, err := exec.LookPath("sbt")
+	args := []string{
+		"sbt",
+		"-batch",
+		"makePom",
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+// MakeMvnCopyPomDependenciesCmd copies the dependencies of pomFile, which may be a POM generated by sbt, to targetDir
+func (_ CmdFactory) MakeMvnCopyPomDependenciesCmd(
+	workingDirectory string,
+	pomFile string,
+	targetDir string,
+	ctx cgexec.IContext,
+) (*exec.Cmd, error) {
+	path, err := exec.LookPath("mvn")
+	args := []string{
+		"mvn",
+		"-q",
+		"-B",
+		"-f",
+		pomFile,
+		"dependency:copy-dependencies",
+		"-DoutputDirectory=" + targetDir,
+		"-DincludeScope=runtime",
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
@@ -63,3 +63,61 @@ func TestMakeJavaVersionCmd(t *testing.T) {
 	assert.Contains(t, args, "java")
 	assert.Contains(t, args, "--version")
 }
+
+func TestMakeBuildGradleCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, err := CmdFactory{}.MakeBuildGradleCmd(dir, "gradle", "init.groovy", ctx)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "gradle")
+	assert.Contains(t, args, "--init-script")
+	assert.Contains(t, args, "init.groovy")
+	assert.Contains(t, args, "classes")
+	assert.Contains(t, args, "debrickedClassDirs")
+}
+
+func TestMakeGradleCopyDependenciesCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, err := CmdFactory{}.MakeGradleCopyDependenciesCmd(dir, "gradle", "init.groovy", "target", ctx)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "gradle")
+	assert.Contains(t, args, "--init-script")
+	assert.Contains(t, args, "init.groovy")
+	assert.Contains(t, args, "debrickedCopyDependencies")
+	assert.Contains(t, args, "-PdebrickedDependencyDir=target")
+}
+
+func TestMakeBuildSbtCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, _ := CmdFactory{}.MakeBuildSbtCmd(dir, ctx)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "sbt")
+	assert.Contains(t, args, "-batch")
+	assert.Contains(t, args, "compile")
+}
+
+func TestMakeSbtPomCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, _ := CmdFactory{}.MakeSbtPomCmd(dir, ctx)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "sbt")
+	assert.Contains(t, args, "makePom")
+}
+
+func TestMakeMvnCopyPomDependenciesCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, _ := CmdFactory{}.MakeMvnCopyPomDependenciesCmd(dir, "app.pom", "target", ctx)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "mvn")
+	assert.Contains(t, args, "app.pom")
+	assert.Contains(t, args, "dependency:copy-dependencies")
+	assert.Contains(t, args, "-DoutputDirectory=target")
+}
@@ -1,8 +1,28 @@
+import org.gradle.api.artifacts.component.ModuleComponentIdentifier
 
-
-allprojects{
+allprojects {
     task debrickedCopyDependencies(type: Copy) {
-        into ".debrickedTmpDir"
-        from configurations.default
+        // Project dependencies are analysed from their classes, so only external modules are copied
+        from {
+            def runtimeClasspath = project.configurations.findByName('runtimeClasspath')
+            runtimeClasspath == null ? [] : runtimeClasspath.incoming.artifactView {
+                componentFilter { it instanceof ModuleComponentIdentifier }
+            }.files
+        }
+        into project.rootProject.findProperty('debrickedDependencyDir') ?: project.rootProject.file('.debrickedTmpFolder')
+    }
+
+    task debrickedClassDirs {
+        mustRunAfter project.tasks.matching { it.name == 'classes' }
+        doLast {
+            def sourceSets = project.extensions.findByName('sourceSets')
+            def main = sourceSets == null ? null : sourceSets.findByName('main')
+            if (main != null) {
+                def output = project.rootProject.file('.debricked.classdirs.txt')
+                synchronized (project.rootProject) {
+                    main.output.classesDirs.files.each { output << it.absolutePath + System.getProperty('line.separator') }
+                }
+            }
+        }
     }
-}
\ No newline at end of file
+}
@@ -0,0 +1,85 @@
+package java
+
+import (
+	"embed"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	ioFs "github.com/debricked/cli/internal/io"
+	internalOs "github.com/debricked/cli/internal/runtime/os"
+)
+
+const (
+	gradleInitScriptName = ".gradle-init-script.debricked-callgraph.groovy"
+	gradleClassDirsFile  = ".debricked.classdirs.txt"
+)
+
+//go:embed embedded/gradle-script.groovy
+var gradleInitScript embed.FS
+
+// writeGradleInitScript writes the init script defining the call graph tasks of Gradle builds to dir,
+// and returns its absolute path
+func writeGradleInitScript(fs ioFs.IFileSystem, dir string) (string, error) {
+	script, err := fs.FsOpenEmbed(gradleInitScript, "embedded/gradle-script.groovy")
+	if err != nil {
+		return "", err
+	}
+	defer fs.FsCloseFile(script)
+
+	scriptBytes, err := fs.FsReadAll(script)
+	if err != nil {
+		return "", err
+	}
+
+	scriptPath, err := filepath.Abs(filepath.Join(dir, gradleInitScriptName))
+	if err != nil {
+		return "", err
+	}
+
+	return scriptPath, fs.FsWriteFile(scriptPath, scriptBytes, 0600)
+}
+
+// gradleCommand returns the Gradle wrapper of the build in dir, or gradle if the build has no wrapper
+func gradleCommand(fs ioFs.IFileSystem, dir string) string {
+	gradlew := "gradlew"
+	if runtime.GOOS == internalOs.Windows {
+		gradlew = "gradlew.bat"
+	}
+	wrapper, err := filepath.Abs(filepath.Join(dir, gradlew))
+	if err != nil {
+		return gradle
+	}
+	if _, err = fs.Stat(wrapper); err != nil {
+		return gradle
+	}
+
+	return wrapper
+}
+
+// readGradleClassDirs returns the existing class output directories of the main source sets of the build in dir,
+// which are recorded by the debrickedClassDirs task. The record is removed
+func readGradleClassDirs(fs ioFs.IFileSystem, dir string) []string {
+	record := filepath.Join(dir, gradleClassDirsFile)
+	content, err := fs.ReadFile(record)
+	if err != nil {
+		return nil
+	}
+	defer fs.Remove(record)
+
+	var classDirs []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		classDir := strings.TrimSpace(line)
+		if len(classDir) == 0 || seen[classDir] {
+			continue
+		}
+		seen[classDir] = true
+		// Source sets without sources, such as Kotlin output of Java projects, have no class directory
+		if _, err := fs.Stat(classDir); err == nil {
+			classDirs = append(classDirs, classDir)
+		}
+	}
+
+	return classDirs
+}
@@ -0,0 +1,70 @@
+package java
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteGradleInitScript(t *testing.T) {
+	dir := t.TempDir()
+
+	scriptPath, err := writeGradleInitScript(io.FileSystem{}, dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, gradleInitScriptName), scriptPath)
+	script, err := os.ReadFile(scriptPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(script), "debrickedCopyDependencies")
+	assert.Contains(t, string(script), "debrickedClassDirs")
+}
+
+func TestWriteGradleInitScriptErrors(t *testing.T) {
+	err := errors.New("error")
+	for name, fsMock := range map[string]ioTestData.FileSystemMock{
+		"open":  {FsOpenEmbedError: err},
+		"read":  {FsReadAllError: err},
+		"write": {FsWriteFileError: err},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, scriptErr := writeGradleInitScript(fsMock, "dir")
+			assert.ErrorIs(t, scriptErr, err)
+		})
+	}
+}
+
+func TestGradleCommand(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, gradle, gradleCommand(io.FileSystem{}, dir))
+
+	wrapper := filepath.Join(dir, "gradlew")
+	if filepath.Separator == '\\' {
+		wrapper = filepath.Join(dir, "gradlew.bat")
+	}
+	assert.NoError(t, os.WriteFile(wrapper, nil, 0700))
+	assert.Equal(t, wrapper, gradleCommand(io.FileSystem{}, dir))
+}
+
+func TestReadGradleClassDirs(t *testing.T) {
+	dir := t.TempDir()
+	mainDir := filepath.Join(dir, "build", "classes", "java", "main")
+	kotlinDir := filepath.Join(dir, "build", "classes", "kotlin", "main")
+	assert.NoError(t, os.MkdirAll(mainDir, 0700))
+	record := filepath.Join(dir, gradleClassDirsFile)
+	content := mainDir + "\n" + kotlinDir + "\n\n" + mainDir + "\n"
+	assert.NoError(t, os.WriteFile(record, []byte(content), 0600))
+
+	classDirs := readGradleClassDirs(io.FileSystem{}, dir)
+
+	assert.Equal(t, []string{mainDir}, classDirs)
+	assert.NoFileExists(t, record)
+}
+
+func TestReadGradleClassDirsNoRecord(t *testing.T) {
+	assert.Empty(t, readGradleClassDirs(io.FileSystem{}, t.TempDir()))
+}
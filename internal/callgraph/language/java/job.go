@@ -1,14 +1,17 @@
 package java
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"syscall"
 
 	"github.com/debricked/cli/internal/callgraph/cgexec"
 	conf "github.com/debricked/cli/internal/callgraph/config"
 	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/callgraph/model"
 	"github.com/debricked/cli/internal/io"
 	ioFs "github.com/debricked/cli/internal/io"
 )
@@ -16,6 +19,7 @@ import (
 const (
 	maven         = "maven"
 	gradle        = "gradle"
+	sbt           = "sbt"
 	dependencyDir = ".debrickedTmpFolder"
 	outputName    = "debricked-call-graph.java"
 )
@@ -63,9 +67,21 @@ func (j *Job) Run() {
 
 	// If folder doesn't exist, copy dependencies
 	if _, err := j.fs.Stat(targetDir); j.fs.IsNotExist(err) {
-		var osCmd *exec.Cmd
+		var osCmds []*exec.Cmd
 		if pmConfig == maven {
+			var osCmd *exec.Cmd
 			osCmd, err = j.cmdFactory.MakeMvnCopyDependenciesCmd(workingDirectory, targetDir, j.ctx)
+			osCmds = append(osCmds, osCmd)
+			j.SendStatus("copying external dep jars to target folder" + targetDir)
+		} else if pmConfig == gradle {
+			var osCmd *exec.Cmd
+			osCmd, err = j.makeGradleCopyDependenciesCmd(workingDirectory, targetDir)
+			osCmds = append(osCmds, osCmd)
+			defer j.fs.Remove(path.Join(workingDirectory, gradleInitScriptName))
+			j.SendStatus("copying external dep jars to target folder" + targetDir)
+		} else if pmConfig == sbt {
+			j.SendStatus("generating POM files with sbt")
+			osCmds, err = j.makeSbtCopyDependenciesCmds(workingDirectory, targetDir)
 			j.SendStatus("copying external dep jars to target folder" + targetDir)
 		}
 		if err != nil {
@@ -74,13 +90,15 @@ func (j *Job) Run() {
 			return
 		}
 
-		j.runCopyDependencies(osCmd)
-		if j.Errors().HasError() {
-			// If error during copy to .debricked_call_graph, remove the folder
+		for _, osCmd := range osCmds {
+			j.runCopyDependencies(osCmd)
+			if j.Errors().HasError() {
+				// If error during copy to .debricked_call_graph, remove the folder
 
-			j.fs.RemoveAll(targetDir)
+				j.fs.RemoveAll(targetDir)
 
-			return
+				return
+			}
 		}
 	}
 	callgraph := NewCallgraph(
@@ -101,9 +119,67 @@ func (j *Job) Run() {
 		return
 	}
 
+	j.SendStatus("mapping symbols to source files")
+	j.runSourceMapping(targetClasses)
+	if j.Errors().HasError() {
+
+		return
+	}
+
 	j.runPostProcess()
 }
 
+// makeGradleCopyDependenciesCmd writes the init script that adds the copy task to the Gradle build in
+// workingDirectory. The target directory is absolute, as Gradle resolves relative paths per project
+func (j *Job) makeGradleCopyDependenciesCmd(workingDirectory string, targetDir string) (*exec.Cmd, error) {
+	initScript, err := writeGradleInitScript(j.fs, workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+	absTargetDir, err := filepath.Abs(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	gradlew := gradleCommand(j.fs, workingDirectory)
+
+	return j.cmdFactory.MakeGradleCopyDependenciesCmd(workingDirectory, gradlew, initScript, absTargetDir, j.ctx)
+}
+
+// makeSbtCopyDependenciesCmds copies the dependencies of all projects of the sbt build in workingDirectory with Maven,
+// using the POM files generated by sbt makePom. The POM files generated by the sbt resolver are reused
+func (j *Job) makeSbtCopyDependenciesCmds(workingDirectory string, targetDir string) ([]*exec.Cmd, error) {
+	pomFiles := findSbtPomFiles(workingDirectory)
+	if len(pomFiles) == 0 {
+		osCmd, err := j.cmdFactory.MakeSbtPomCmd(workingDirectory, j.ctx)
+		if err != nil {
+			return nil, err
+		}
+		err = cgexec.RunCommand(*cgexec.NewCommand(osCmd), j.ctx)
+		if err != nil {
+			return nil, err
+		}
+		pomFiles = findSbtPomFiles(workingDirectory)
+	}
+	if len(pomFiles) == 0 {
+		return nil, errors.New("no POM files were generated by sbt makePom in " + workingDirectory)
+	}
+
+	absTargetDir, err := filepath.Abs(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	var osCmds []*exec.Cmd
+	for _, pomFile := range pomFiles {
+		osCmd, err := j.cmdFactory.MakeMvnCopyPomDependenciesCmd(workingDirectory, pomFile, absTargetDir, j.ctx)
+		if err != nil {
+			return nil, err
+		}
+		osCmds = append(osCmds, osCmd)
+	}
+
+	return osCmds, nil
+}
+
 func (j *Job) runCopyDependencies(osCmd *exec.Cmd) {
 	cmd := cgexec.NewCommand(osCmd)
 	err := cgexec.RunCommand(*cmd, j.ctx)
@@ -121,6 +197,31 @@ func (j *Job) runCallGraph(callgraph ICallgraph) {
 	}
 }
 
+// runSourceMapping maps the nodes of application classes to their source files, which is needed for classes generated
+// by the Kotlin and Scala compilers, and makes filenames within the project, such as those of the copied dependencies,
+// relative to it. Call graphs that cannot be parsed are left as they are
+func (j *Job) runSourceMapping(classDirs []string) {
+	outputFullPath := path.Join(j.GetDir(), outputName)
+	output, err := j.fs.ReadFile(outputFullPath)
+	if err != nil {
+		return
+	}
+	callgraph, err := model.ParseCallGraph(output)
+	if err != nil {
+		return
+	}
+
+	newSourceIndex(j.GetDir(), classDirs).remap(callgraph)
+	callgraph.RelativizeFilenames(j.GetDir())
+	output, err = callgraph.ToBytes()
+	if err == nil {
+		err = j.fs.FsWriteFile(outputFullPath, output, 0600)
+	}
+	if err != nil {
+		j.Errors().Critical(err)
+	}
+}
+
 func (j *Job) runPostProcess() {
 	workingDirectory := j.GetDir()
 	outputFullPath := path.Join(workingDirectory, outputName)
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	conf "github.com/debricked/cli/internal/callgraph/config"
 	jobTestdata "github.com/debricked/cli/internal/callgraph/job/testdata"
 	"github.com/debricked/cli/internal/callgraph/language/java/testdata"
+	"github.com/debricked/cli/internal/callgraph/model"
 	io "github.com/debricked/cli/internal/io"
 	ioTestData "github.com/debricked/cli/internal/io/testdata"
 	"github.com/stretchr/testify/assert"
@@ -285,3 +287,154 @@ func TestRunWithErrorsIsNotExistTrue(t *testing.T) {
 
 	assert.True(t, j.Errors().HasError())
 }
+
+func TestRunGradle(t *testing.T) {
+	fileWriterMock := &ioTestData.FileWriterMock{}
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	config := conf.NewConfig("java", nil, nil, true, gradle, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{IsNotExistBool: true}
+	zip := ioTestData.ZipMock{}
+	archiveMock := io.NewArchiveWithStructs("dir", fsMock, zip)
+	shMock := testdata.MockSootHandler{}
+
+	j := NewJob(dir, files, cmdFactoryMock, fileWriterMock, archiveMock, config, ctx, fsMock, shMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunGradleCopyDependenciesErr(t *testing.T) {
+	cmdErr := errors.New("cmd-error")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.GradleCopyErr = cmdErr
+	config := conf.NewConfig("java", nil, nil, true, gradle, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{IsNotExistBool: true}
+	archiveMock := io.NewArchiveWithStructs("dir", fsMock, ioTestData.ZipMock{})
+	shMock := testdata.MockSootHandler{}
+
+	j := NewJob(dir, files, cmdFactoryMock, &ioTestData.FileWriterMock{}, archiveMock, config, ctx, fsMock, shMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), cmdErr)
+}
+
+func TestRunGradleInitScriptErr(t *testing.T) {
+	writeErr := errors.New("write-error")
+	config := conf.NewConfig("java", nil, nil, true, gradle, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{IsNotExistBool: true, FsWriteFileError: writeErr}
+	archiveMock := io.NewArchiveWithStructs("dir", fsMock, ioTestData.ZipMock{})
+	shMock := testdata.MockSootHandler{}
+
+	j := NewJob(dir, files, testdata.NewEchoCmdFactory(), &ioTestData.FileWriterMock{}, archiveMock, config, ctx, fsMock, shMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Contains(t, j.Errors().GetAll(), writeErr)
+}
+
+func TestRunSbt(t *testing.T) {
+	rootDir := t.TempDir()
+	writeTestFile(t, filepath.Join(rootDir, "target", "scala-2.13", "app_2.13-1.0.pom"), nil)
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	// The POM files are already generated
+	cmdFactoryMock.SbtPomErr = errors.New("sbt-error")
+	config := conf.NewConfig("java", nil, nil, true, sbt, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{IsNotExistBool: true}
+	archiveMock := io.NewArchiveWithStructs(rootDir, fsMock, ioTestData.ZipMock{})
+	shMock := testdata.MockSootHandler{}
+
+	j := NewJob(rootDir, files, cmdFactoryMock, &ioTestData.FileWriterMock{}, archiveMock, config, ctx, fsMock, shMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunSbtNoPomFiles(t *testing.T) {
+	config := conf.NewConfig("java", nil, nil, true, sbt, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{IsNotExistBool: true}
+	archiveMock := io.NewArchiveWithStructs("dir", fsMock, ioTestData.ZipMock{})
+	shMock := testdata.MockSootHandler{}
+
+	j := NewJob(t.TempDir(), files, testdata.NewEchoCmdFactory(), &ioTestData.FileWriterMock{}, archiveMock, config, ctx, fsMock, shMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.ErrorContains(t, j.Errors().GetAll()[0], "no POM files were generated by sbt makePom")
+}
+
+func TestRunSbtPomCmdErr(t *testing.T) {
+	cmdErr := errors.New("cmd-error")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.SbtPomErr = cmdErr
+	config := conf.NewConfig("java", nil, nil, true, sbt, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{IsNotExistBool: true}
+	archiveMock := io.NewArchiveWithStructs("dir", fsMock, ioTestData.ZipMock{})
+	shMock := testdata.MockSootHandler{}
+
+	j := NewJob(t.TempDir(), files, cmdFactoryMock, &ioTestData.FileWriterMock{}, archiveMock, config, ctx, fsMock, shMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Contains(t, j.Errors().GetAll(), cmdErr)
+}
+
+func TestRunSourceMapping(t *testing.T) {
+	rootDir := t.TempDir()
+	classDir := filepath.Join(rootDir, "target", "classes")
+	writeTestFile(t, filepath.Join(rootDir, "src", "main", "kotlin", "App.kt"), nil)
+	class := &classFileBuilder{}
+	class.method("main", "()V", 3, 4)
+	class.sourceFile("App.kt")
+	writeTestFile(t, filepath.Join(classDir, "AppKt.class"), class.build("AppKt"))
+	cg := model.NewCallGraph()
+	cg.AddNode("", "main", "<AppKt: void main()>", false, false, 0, 0)
+	output, err := cg.ToBytes()
+	assert.NoError(t, err)
+	writeTestFile(t, filepath.Join(rootDir, outputName), output)
+
+	j := NewJob(rootDir, []string{classDir}, testdata.NewEchoCmdFactory(), &ioTestData.FileWriterMock{}, nil, conf.Config{}, nil, io.FileSystem{}, testdata.MockSootHandler{})
+	j.runSourceMapping([]string{classDir})
+
+	assert.False(t, j.Errors().HasError())
+	output, err = os.ReadFile(filepath.Join(rootDir, outputName))
+	assert.NoError(t, err)
+	mapped, err := model.ParseCallGraph(output)
+	assert.NoError(t, err)
+	node := mapped.GetNode("<AppKt: void main()>")
+	assert.True(t, node.IsApplicationNode)
+	assert.Equal(t, filepath.Join("src", "main", "kotlin", "App.kt"), node.Filename)
+	assert.Equal(t, []int{3, 4}, []int{node.LineStart, node.LineEnd})
+}
+
+func TestRunSourceMappingUnparsedOutput(t *testing.T) {
+	writeErr := errors.New("write-error")
+	fsMock := ioTestData.FileSystemMock{FsWriteFileError: writeErr}
+
+	j := NewJob(dir, files, testdata.NewEchoCmdFactory(), &ioTestData.FileWriterMock{}, nil, conf.Config{}, nil, fsMock, testdata.MockSootHandler{})
+	j.runSourceMapping(files)
+
+	assert.False(t, j.Errors().HasError())
+}
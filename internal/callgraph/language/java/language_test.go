@@ -21,3 +21,8 @@ func TestVersion(t *testing.T) {
 	pm := NewLanguage()
 	assert.Equal(t, StandardVersion, pm.Version())
 }
+
+func TestPackageManager(t *testing.T) {
+	pm := NewLanguage()
+	assert.Equal(t, StandardPackageManager, pm.PackageManager())
+}
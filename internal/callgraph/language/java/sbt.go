@@ -0,0 +1,41 @@
+package java
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// findSbtPomFiles returns the POM files generated by sbt makePom for the projects of the sbt build in dir, which are
+// written to target/scala-<version> of each project. The project directory of the build holds the build definition,
+// so it is skipped
+func findSbtPomFiles(dir string) []string {
+	var pomFiles []string
+	_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "project" && filepath.Dir(path) == dir) {
+				return filepath.SkipDir
+			}
+			// Compiled classes never hold POM files
+			if name == "classes" || name == "test-classes" || name == "streams" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		versionDir := filepath.Dir(path)
+		if filepath.Ext(name) == ".pom" && strings.HasPrefix(filepath.Base(versionDir), "scala-") && filepath.Base(filepath.Dir(versionDir)) == "target" {
+			pomFiles = append(pomFiles, path)
+		}
+
+		return nil
+	})
+	sort.Strings(pomFiles)
+
+	return pomFiles
+}
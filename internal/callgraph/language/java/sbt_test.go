@@ -0,0 +1,30 @@
+package java
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSbtPomFiles(t *testing.T) {
+	dir := t.TempDir()
+	rootPom := filepath.Join(dir, "target", "scala-2.13", "app_2.13-1.0.pom")
+	corePom := filepath.Join(dir, "core", "target", "scala-3.3.1", "core_3-1.0.pom")
+	for _, pomFile := range []string{
+		rootPom,
+		corePom,
+		filepath.Join(dir, "project", "target", "scala-2.12", "build.pom"),
+		filepath.Join(dir, "target", "scala-2.13", "classes", "nested.pom"),
+		filepath.Join(dir, ".bsp", "target", "scala-2.13", "hidden.pom"),
+		filepath.Join(dir, "target", "other.pom"),
+	} {
+		writeTestFile(t, pomFile, nil)
+	}
+
+	assert.Equal(t, []string{corePom, rootPom}, findSbtPomFiles(dir))
+}
+
+func TestFindSbtPomFilesNoPom(t *testing.T) {
+	assert.Empty(t, findSbtPomFiles(t.TempDir()))
+}
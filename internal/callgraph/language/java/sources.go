@@ -0,0 +1,126 @@
+package java
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+)
+
+var sourceExtensions = map[string]bool{".java": true, ".kt": true, ".scala": true}
+
+// skippedSourceDirs hold build output or tooling rather than sources
+var skippedSourceDirs = map[string]bool{"target": true, "build": true, "out": true, "node_modules": true}
+
+// sourceIndex maps the application classes of a project, including classes generated by the Kotlin and Scala
+// compilers such as FooKt, Foo$Companion and Foo$$anonfun$1, to the source files they were compiled from
+type sourceIndex struct {
+	rootDir string
+	classes map[string]*classFile
+	// sources maps source file names to their paths relative to rootDir
+	sources map[string][]string
+}
+
+func newSourceIndex(rootDir string, classDirs []string) *sourceIndex {
+	index := &sourceIndex{rootDir: rootDir, classes: map[string]*classFile{}, sources: map[string][]string{}}
+	for _, classDir := range classDirs {
+		_ = filepath.WalkDir(classDir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || filepath.Ext(path) != ".class" {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			if class, err := parseClassFile(data); err == nil && len(class.sourceFile) > 0 {
+				index.classes[class.name] = class
+			}
+
+			return nil
+		})
+	}
+
+	_ = filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := entry.Name()
+		rel, err := filepath.Rel(rootDir, path)
+		if err != nil || rel == "." {
+			return nil
+		}
+		if entry.IsDir() {
+			// Packages within source sets, such as src/main/java/com/example/build, may have the names of build output
+			isSourceSet := strings.HasPrefix(filepath.ToSlash(rel), "src/") || strings.Contains(filepath.ToSlash(rel), "/src/")
+			if strings.HasPrefix(name, ".") || skippedSourceDirs[name] && !isSourceSet {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if sourceExtensions[filepath.Ext(name)] {
+			index.sources[name] = append(index.sources[name], rel)
+		}
+
+		return nil
+	})
+	for _, paths := range index.sources {
+		sort.Strings(paths)
+	}
+
+	return index
+}
+
+// sourceFile returns the path of the source file of class. Java sources are placed by package, while Kotlin and
+// Scala sources may be placed anywhere, so a single source file with the name of the class source file is used too
+func (index *sourceIndex) sourceFile(class *classFile) string {
+	packageDir := ""
+	if i := strings.LastIndex(class.name, "."); i >= 0 {
+		packageDir = strings.ReplaceAll(class.name[:i], ".", "/")
+	}
+	candidates := index.sources[class.sourceFile]
+	for _, candidate := range candidates {
+		dir := filepath.ToSlash(filepath.Dir(candidate))
+		if dir == packageDir || strings.HasSuffix(dir, "/"+packageDir) {
+			return candidate
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	return filepath.Join(filepath.FromSlash(packageDir), class.sourceFile)
+}
+
+// remap sets the source files and line ranges of the nodes of application classes, and marks them as application
+// nodes. Nodes of other classes are left as they are
+func (index *sourceIndex) remap(cg *model.CallGraph) {
+	for _, node := range cg.Nodes {
+		className, subSignature, ok := parseSootSignature(node.Symbol)
+		if !ok {
+			continue
+		}
+		class, ok := index.classes[className]
+		if !ok {
+			continue
+		}
+		node.IsApplicationNode = true
+		node.Filename = index.sourceFile(class)
+		if lines, ok := class.methods[subSignature]; ok {
+			node.LineStart, node.LineEnd = lines.start, lines.end
+		}
+	}
+}
+
+// parseSootSignature splits a Soot method signature, such as <com.example.Foo: void bar(int)>, into its class name
+// and sub-signature
+func parseSootSignature(signature string) (string, string, bool) {
+	if !strings.HasPrefix(signature, "<") || !strings.HasSuffix(signature, ">") {
+		return "", "", false
+	}
+
+	return strings.Cut(signature[1:len(signature)-1], ": ")
+}
@@ -0,0 +1,81 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	assert.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func TestSourceIndexRemap(t *testing.T) {
+	rootDir := t.TempDir()
+	classDir := filepath.Join(rootDir, "target", "classes")
+	writeTestFile(t, filepath.Join(rootDir, "src", "main", "kotlin", "com", "example", "Greeter.kt"), nil)
+	writeTestFile(t, filepath.Join(rootDir, "src", "main", "scala", "App.scala"), nil)
+	// Build output is not indexed as sources
+	writeTestFile(t, filepath.Join(rootDir, "target", "Greeter.kt"), nil)
+
+	greeterKt := &classFileBuilder{}
+	greeterKt.method("greet", "(Ljava/lang/String;)Ljava/lang/String;", 8, 9)
+	greeterKt.sourceFile("Greeter.kt")
+	writeTestFile(t, filepath.Join(classDir, "com", "example", "GreeterKt.class"), greeterKt.build("com/example/GreeterKt"))
+	companion := &classFileBuilder{}
+	companion.method("create", "()V", 15)
+	companion.sourceFile("Greeter.kt")
+	writeTestFile(t, filepath.Join(classDir, "com", "example", "Greeter$Companion.class"), companion.build("com/example/Greeter$Companion"))
+	anonfun := &classFileBuilder{}
+	anonfun.method("apply", "(I)I", 3)
+	anonfun.sourceFile("App.scala")
+	writeTestFile(t, filepath.Join(classDir, "com", "example", "App$$anonfun$1.class"), anonfun.build("com/example/App$$anonfun$1"))
+	writeTestFile(t, filepath.Join(classDir, "Broken.class"), []byte("broken"))
+
+	cg := model.NewCallGraph()
+	greet := cg.AddNode("", "greet", "<com.example.GreeterKt: java.lang.String greet(java.lang.String)>", false, false, 0, 0)
+	create := cg.AddNode("", "create", "<com.example.Greeter$Companion: void create()>", false, false, 0, 0)
+	apply := cg.AddNode("", "apply", "<com.example.App$$anonfun$1: int apply(int)>", false, false, 0, 0)
+	unknownMethod := cg.AddNode("", "unknown", "<com.example.GreeterKt: void unknown()>", false, false, 0, 0)
+	library := cg.AddNode("String.java", "length", "<java.lang.String: int length()>", false, true, 1, 2)
+
+	newSourceIndex(rootDir, []string{classDir}).remap(cg)
+
+	kotlinSource := filepath.Join("src", "main", "kotlin", "com", "example", "Greeter.kt")
+	assert.True(t, greet.IsApplicationNode)
+	assert.Equal(t, kotlinSource, greet.Filename)
+	assert.Equal(t, []int{8, 9}, []int{greet.LineStart, greet.LineEnd})
+	assert.Equal(t, kotlinSource, create.Filename)
+	assert.Equal(t, []int{15, 15}, []int{create.LineStart, create.LineEnd})
+	assert.Equal(t, filepath.Join("src", "main", "scala", "App.scala"), apply.Filename)
+	assert.True(t, unknownMethod.IsApplicationNode)
+	assert.Equal(t, kotlinSource, unknownMethod.Filename)
+	assert.Equal(t, 0, unknownMethod.LineStart)
+	assert.False(t, library.IsApplicationNode)
+	assert.Equal(t, "String.java", library.Filename)
+}
+
+func TestSourceFileWithoutSource(t *testing.T) {
+	index := newSourceIndex(t.TempDir(), nil)
+
+	sourceFile := index.sourceFile(&classFile{name: "com.example.Foo", sourceFile: "Foo.java"})
+
+	assert.Equal(t, filepath.Join("com", "example", "Foo.java"), sourceFile)
+}
+
+func TestParseSootSignature(t *testing.T) {
+	className, subSignature, ok := parseSootSignature("<com.example.Foo: void bar(int,java.lang.String)>")
+	assert.True(t, ok)
+	assert.Equal(t, "com.example.Foo", className)
+	assert.Equal(t, "void bar(int,java.lang.String)", subSignature)
+
+	for _, signature := range []string{"com.example.Foo.bar", "<com.example.Foo>", ""} {
+		_, _, ok = parseSootSignature(signature)
+		assert.False(t, ok, signature)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/debricked/cli/internal/callgraph/cgexec"
 	conf "github.com/debricked/cli/internal/callgraph/config"
 	"github.com/debricked/cli/internal/callgraph/finder"
+	"github.com/debricked/cli/internal/callgraph/finder/javafinder"
 	"github.com/debricked/cli/internal/callgraph/job"
 	"github.com/debricked/cli/internal/io"
 	"github.com/debricked/cli/internal/tui"
@@ -60,8 +61,9 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 		return jobs, err
 	}
 
+	gradleClassDirs := map[string][]string{}
 	if s.config.Build() {
-		err = buildProjects(s, roots)
+		gradleClassDirs, err = buildProjects(s, roots)
 		if err != nil {
 
 			return jobs, err
@@ -75,6 +77,22 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	absRoots, _ := finder.ConvertPathsToAbsPaths(roots)
 	absClassDirs, _ := finder.ConvertPathsToAbsPaths(javaClassDirs)
 	rootClassMapping := finder.MapFilesToDir(absRoots, absClassDirs)
+	// Built Gradle projects record the class directories of their main source sets, which excludes test classes
+	for root, classDirs := range gradleClassDirs {
+		if absRoot, err := filepath.Abs(root); err == nil && len(classDirs) > 0 {
+			rootClassMapping[absRoot] = classDirs
+		}
+	}
+	for root, classDirs := range rootClassMapping {
+		if !javafinder.IsSbtFile(root) {
+			continue
+		}
+		if classDirs = withoutSbtBuildDefinition(filepath.Dir(root), classDirs); len(classDirs) > 0 {
+			rootClassMapping[root] = classDirs
+		} else {
+			delete(rootClassMapping, root)
+		}
+	}
 
 	foundRootsWoClasses := 0
 	for _, root := range absRoots {
@@ -89,13 +107,17 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 		// For each class paths dir within the root, find GCDPath as entrypoint
 		// classDir := finder.GCDPath(classDirs)
 		rootDir := filepath.Dir(rootFile)
+		config := s.config
+		if pm := rootPackageManager(rootFile); pm != maven {
+			config = conf.NewConfig(config.Language(), config.Args(), config.Kwargs(), config.Build(), pm, config.Version())
+		}
 		jobs = append(jobs, NewJob(
 			rootDir,
 			classDirs,
 			s.cmdFactory,
 			io.FileWriter{},
 			io.NewArchive(rootDir),
-			s.config,
+			config,
 			s.ctx,
 			io.FileSystem{},
 			SootHandler{s.config.Version()},
@@ -125,27 +147,27 @@ func strategyWarning(errMsg string) {
 	log.SetOutput(defaultOutputWriter)
 }
 
-func buildProjects(s Strategy, roots []string) error {
-	spinnerType := "building maven project"
+// buildProjects builds the Maven, Gradle and sbt projects of roots, and returns the class directories recorded by
+// the Gradle builds
+func buildProjects(s Strategy, roots []string) (map[string][]string, error) {
+	spinnerType := "building project"
 	spinnerManager := tui.NewSpinnerManager("Callgraph Build Project", spinnerType)
 	spinnerManager.Start()
 	success := false || len(roots) == 0
 	errors := []string{}
+	gradleClassDirs := map[string][]string{}
 	for _, rootFile := range roots {
 		rootDir := filepath.Dir(rootFile)
 		spinner := spinnerManager.AddSpinner(rootDir)
-		osCmd, err := s.cmdFactory.MakeBuildMavenCmd(rootDir, s.ctx)
-		if err != nil {
-			err := "Error while building roots (Make command): " + err.Error() + "\nRoot: " + rootDir
-			errors = append(errors, err)
-			spinner.Error()
-			spinnerManager.SetSpinnerMessage(spinner, rootDir, "fail")
-
-			continue
+		var err error
+		switch rootPackageManager(rootFile) {
+		case gradle:
+			gradleClassDirs[rootFile], err = buildGradleProject(s, rootDir)
+		case sbt:
+			err = buildSbtProject(s, rootDir)
+		default:
+			err = buildMavenProject(s, rootDir)
 		}
-		cmd := cgexec.NewCommand(osCmd)
-		err = cgexec.RunCommand(*cmd, s.ctx)
-
 		if err != nil {
 			err := "Error while building roots (Make command): " + err.Error() + "\nRoot: " + rootDir
 			errors = append(errors, err)
@@ -161,13 +183,13 @@ func buildProjects(s Strategy, roots []string) error {
 	spinnerManager.Stop()
 
 	if success {
-		return nil
+		return gradleClassDirs, nil
 	} else {
 		for _, err := range errors {
 			strategyWarning(err)
 		}
 
-		return fmt.Errorf(strings.Join([]string{
+		return gradleClassDirs, fmt.Errorf(strings.Join([]string{
 			"Build failed for all projects, if already built disable the build flag.",
 			"Or you can refer to the documentation for a detailed guide on manually building your Java project:",
 			"https://github.com/debricked/cli/blob/main/internal/callgraph/language/java11/README.md",
@@ -175,3 +197,69 @@ func buildProjects(s Strategy, roots []string) error {
 	}
 
 }
+
+func buildMavenProject(s Strategy, rootDir string) error {
+	osCmd, err := s.cmdFactory.MakeBuildMavenCmd(rootDir, s.ctx)
+	if err != nil {
+		return err
+	}
+
+	return cgexec.RunCommand(*cgexec.NewCommand(osCmd), s.ctx)
+}
+
+func buildSbtProject(s Strategy, rootDir string) error {
+	osCmd, err := s.cmdFactory.MakeBuildSbtCmd(rootDir, s.ctx)
+	if err != nil {
+		return err
+	}
+
+	return cgexec.RunCommand(*cgexec.NewCommand(osCmd), s.ctx)
+}
+
+// buildGradleProject compiles the classes of the Gradle build in rootDir, and returns the class directories of
+// its projects
+func buildGradleProject(s Strategy, rootDir string) ([]string, error) {
+	fs := io.FileSystem{}
+	initScript, err := writeGradleInitScript(fs, rootDir)
+	defer fs.Remove(initScript)
+	if err != nil {
+		return nil, err
+	}
+
+	osCmd, err := s.cmdFactory.MakeBuildGradleCmd(rootDir, gradleCommand(fs, rootDir), initScript, s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = cgexec.RunCommand(*cgexec.NewCommand(osCmd), s.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return readGradleClassDirs(fs, rootDir), nil
+}
+
+// rootPackageManager returns the build tool of the project of rootFile
+func rootPackageManager(rootFile string) string {
+	switch {
+	case javafinder.IsGradleFile(rootFile):
+		return gradle
+	case javafinder.IsSbtFile(rootFile):
+		return sbt
+	default:
+		return maven
+	}
+}
+
+// withoutSbtBuildDefinition leaves out the classes of the build definition of the sbt build in rootDir, which are
+// compiled to the project directory
+func withoutSbtBuildDefinition(rootDir string, classDirs []string) []string {
+	buildDefinition := filepath.Join(rootDir, "project") + string(filepath.Separator)
+	var filtered []string
+	for _, classDir := range classDirs {
+		if !strings.HasPrefix(classDir+string(filepath.Separator), buildDefinition) {
+			filtered = append(filtered, classDir)
+		}
+	}
+
+	return filtered
+}
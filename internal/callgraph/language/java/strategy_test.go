@@ -2,6 +2,7 @@ package java
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -93,7 +94,78 @@ func TestBuildProjectsError(t *testing.T) {
 	factoryMock := javaTestdata.NewEchoCmdFactory()
 	factoryMock.BuildMavenErr = fmt.Errorf("build-error")
 	s.cmdFactory = factoryMock
-	err := buildProjects(s, []string{"file-3/pom.xml"})
+	_, err := buildProjects(s, []string{"file-3/pom.xml"})
 
 	assert.NotNil(t, err)
 }
+
+func TestBuildProjectsGradle(t *testing.T) {
+	rootDir := t.TempDir()
+	classDir := filepath.Join(rootDir, "app", "build", "classes", "java", "main")
+	assert.NoError(t, os.MkdirAll(classDir, 0700))
+	conf := config.NewConfig("java", nil, nil, true, "maven", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, nil, nil, nil, testdata.NewEmptyFinderMock(), ctx)
+	s.cmdFactory = javaTestdata.NewEchoCmdFactory()
+	// The record of the class directories is written by the build
+	record := []byte(classDir + "\n" + classDir + "\n" + filepath.Join(rootDir, "missing") + "\n")
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, gradleClassDirsFile), record, 0600))
+	rootFile := filepath.Join(rootDir, "settings.gradle")
+
+	classDirs, err := buildProjects(s, []string{rootFile})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{classDir}, classDirs[rootFile])
+	assert.NoFileExists(t, filepath.Join(rootDir, gradleInitScriptName))
+	assert.NoFileExists(t, filepath.Join(rootDir, gradleClassDirsFile))
+}
+
+func TestBuildProjectsGradleError(t *testing.T) {
+	conf := config.NewConfig("java", nil, nil, true, "maven", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, nil, nil, nil, testdata.NewEmptyFinderMock(), ctx)
+	factoryMock := javaTestdata.NewEchoCmdFactory()
+	factoryMock.BuildGradleErr = fmt.Errorf("build-error")
+	s.cmdFactory = factoryMock
+
+	_, err := buildProjects(s, []string{filepath.Join(t.TempDir(), "build.gradle")})
+
+	assert.NotNil(t, err)
+}
+
+func TestBuildProjectsSbt(t *testing.T) {
+	conf := config.NewConfig("java", nil, nil, true, "maven", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, nil, nil, nil, testdata.NewEmptyFinderMock(), ctx)
+	factoryMock := javaTestdata.NewEchoCmdFactory()
+	s.cmdFactory = factoryMock
+
+	_, err := buildProjects(s, []string{filepath.Join("app", "build.sbt")})
+	assert.NoError(t, err)
+
+	factoryMock.BuildSbtErr = fmt.Errorf("build-error")
+	s.cmdFactory = factoryMock
+	_, err = buildProjects(s, []string{filepath.Join("app", "build.sbt")})
+	assert.NotNil(t, err)
+}
+
+func TestRootPackageManager(t *testing.T) {
+	assert.Equal(t, maven, rootPackageManager(filepath.Join("app", "pom.xml")))
+	assert.Equal(t, gradle, rootPackageManager(filepath.Join("app", "settings.gradle.kts")))
+	assert.Equal(t, gradle, rootPackageManager(filepath.Join("app", "build.gradle")))
+	assert.Equal(t, sbt, rootPackageManager(filepath.Join("app", "build.sbt")))
+}
+
+func TestWithoutSbtBuildDefinition(t *testing.T) {
+	rootDir := "app"
+	classDirs := []string{
+		filepath.Join(rootDir, "target", "scala-2.13", "classes"),
+		filepath.Join(rootDir, "project", "target", "scala-2.12", "sbt-1.0", "classes"),
+		filepath.Join(rootDir, "core", "project", "target", "classes"),
+		filepath.Join(rootDir, "projects", "target", "classes"),
+	}
+
+	filtered := withoutSbtBuildDefinition(rootDir, classDirs)
+
+	assert.Equal(t, []string{classDirs[0], classDirs[2], classDirs[3]}, filtered)
+}
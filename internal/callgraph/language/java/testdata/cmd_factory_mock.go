@@ -16,6 +16,16 @@ type CmdFactoryMock struct {
 	BuildMavenErr    error
 	JavaVersionName  string
 	JavaVersionErr   error
+	BuildGradleName  string
+	BuildGradleErr   error
+	GradleCopyName   string
+	GradleCopyErr    error
+	BuildSbtName     string
+	BuildSbtErr      error
+	SbtPomName       string
+	SbtPomErr        error
+	MvnCopyPomName   string
+	MvnCopyPomErr    error
 }
 
 func NewEchoCmdFactory() CmdFactoryMock {
@@ -24,6 +34,11 @@ func NewEchoCmdFactory() CmdFactoryMock {
 		CallGraphGenName: "echo",
 		BuildMavenName:   "echo",
 		JavaVersionName:  "echo",
+		BuildGradleName:  "echo",
+		GradleCopyName:   "echo",
+		BuildSbtName:     "echo",
+		SbtPomName:       "echo",
+		MvnCopyPomName:   "echo",
 	}
 }
 
@@ -46,6 +61,26 @@ func (f CmdFactoryMock) MakeJavaVersionCmd(workingDirectory string, ctx cgexec.I
 	), f.JavaVersionErr
 }
 
+func (f CmdFactoryMock) MakeBuildGradleCmd(_ string, _ string, _ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.BuildGradleName, "BuildGradle"), f.BuildGradleErr
+}
+
+func (f CmdFactoryMock) MakeGradleCopyDependenciesCmd(_ string, _ string, _ string, _ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.GradleCopyName, "GradleCopy"), f.GradleCopyErr
+}
+
+func (f CmdFactoryMock) MakeBuildSbtCmd(_ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.BuildSbtName, "BuildSbt"), f.BuildSbtErr
+}
+
+func (f CmdFactoryMock) MakeSbtPomCmd(_ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.SbtPomName, "SbtPom"), f.SbtPomErr
+}
+
+func (f CmdFactoryMock) MakeMvnCopyPomDependenciesCmd(_ string, _ string, _ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.MvnCopyPomName, "MvnCopyPom"), f.MvnCopyPomErr
+}
+
 type MockSootHandler struct {
 	GetSootWrapperError error
 }
@@ -0,0 +1,302 @@
+package javascript
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+const (
+	// maxSourceSize skips large sources, such as bundled and minified files
+	maxSourceSize = 2 << 20
+	// maxResolutionDepth bounds the chains of imports and exports followed to resolve a call
+	maxResolutionDepth = 16
+	globalScope        = "globalThis"
+	symbolSeparator    = "#"
+)
+
+// globals are the global objects and functions of Node.js, whose calls are standard library calls
+var globals = map[string]bool{
+	"console": true, "JSON": true, "Math": true, "Object": true, "Array": true, "Promise": true, "Reflect": true,
+	"Number": true, "String": true, "Boolean": true, "Date": true, "RegExp": true, "Error": true, "TypeError": true,
+	"Map": true, "Set": true, "WeakMap": true, "WeakSet": true, "Symbol": true, "BigInt": true, "Buffer": true,
+	"process": true, "setTimeout": true, "setInterval": true, "setImmediate": true, "clearTimeout": true,
+	"clearInterval": true, "clearImmediate": true, "queueMicrotask": true, "structuredClone": true, "fetch": true,
+	"parseInt": true, "parseFloat": true, "isNaN": true, "encodeURIComponent": true, "decodeURIComponent": true,
+	"URL": true, "URLSearchParams": true, "TextEncoder": true, "TextDecoder": true, "AbortController": true,
+}
+
+// target is a function of a module, or a builtin function identified by its symbol
+type target struct {
+	module   *module
+	qualname string
+	builtin  string
+}
+
+// analyzer builds the call graph of the functions reachable from entry points
+type analyzer struct {
+	projectDir string
+	filesystem ioFs.IFileSystem
+	resolver   *resolver
+	modules    map[string]*module
+	graph      *model.CallGraph
+	queue      []target
+}
+
+func newAnalyzer(projectDir string, filesystem ioFs.IFileSystem) *analyzer {
+	return &analyzer{
+		projectDir: projectDir,
+		filesystem: filesystem,
+		resolver:   newResolver(),
+		modules:    map[string]*module{},
+		graph:      model.NewCallGraph(),
+	}
+}
+
+// build adds the module bodies of entryPoints, which are relative to the project directory, and all functions
+// reachable from them to the call graph
+func (a *analyzer) build(entryPoints []string) *model.CallGraph {
+	for _, entryPoint := range entryPoints {
+		if m := a.load(filepath.Join(a.projectDir, entryPoint)); m != nil {
+			a.node(target{module: m, qualname: moduleScope})
+		}
+	}
+	for len(a.queue) > 0 {
+		current := a.queue[0]
+		a.queue = a.queue[1:]
+		a.visit(current)
+	}
+
+	return a.graph
+}
+
+func (a *analyzer) visit(current target) {
+	m := current.module
+	fn := m.functions[current.qualname]
+	caller := a.graph.GetNode(a.symbol(current))
+	if current.qualname == moduleScope {
+		// Imported modules are loaded, which runs their bodies
+		for _, dependency := range m.dependencies {
+			resolved := a.resolver.resolve(m.path, dependency.specifier)
+			if imported := a.load(resolved.path); imported != nil {
+				a.graph.AddEdge(caller, a.node(target{module: imported, qualname: moduleScope}), dependency.line)
+			}
+		}
+	}
+	for _, c := range fn.calls {
+		if callee, ok := a.resolveChain(m, fn, c.chain, 0); ok {
+			a.graph.AddEdge(caller, a.node(callee), c.line)
+		}
+	}
+}
+
+// load parses the source file at path once. Nil is returned for files which can't be read or are too large
+func (a *analyzer) load(path string) *module {
+	if len(path) == 0 {
+		return nil
+	}
+	if m, ok := a.modules[path]; ok {
+		return m
+	}
+	var m *module
+	content, err := a.filesystem.ReadFile(path)
+	if err == nil && len(content) <= maxSourceSize {
+		m = parseModule(path, string(content))
+	}
+	a.modules[path] = m
+
+	return m
+}
+
+// node returns the node of t, adding it to the call graph and queueing it for a visit if it is new
+func (a *analyzer) node(t target) *model.Node {
+	symbol := a.symbol(t)
+	if node := a.graph.GetNode(symbol); node != nil {
+		return node
+	}
+	if len(t.builtin) > 0 {
+		return a.graph.AddNode("", lastName(t.builtin), symbol, false, true, -1, -1)
+	}
+	a.queue = append(a.queue, t)
+	fn := t.module.functions[t.qualname]
+	filename, isApplication := a.filename(t.module.path)
+
+	return a.graph.AddNode(filename, lastName(t.qualname), symbol, isApplication, false, fn.lineStart, fn.lineEnd)
+}
+
+func (a *analyzer) symbol(t target) string {
+	if len(t.builtin) > 0 {
+		return t.builtin
+	}
+
+	return a.moduleID(t.module.path) + symbolSeparator + t.qualname
+}
+
+// filename returns the path of a source file relative to the project directory, and whether it is
+// application code rather than a dependency
+func (a *analyzer) filename(path string) (string, bool) {
+	rel, err := filepath.Rel(a.projectDir, path)
+	if err != nil {
+		return filepath.ToSlash(path), false
+	}
+	rel = filepath.ToSlash(rel)
+	isDependency := strings.HasPrefix(rel, "../") || strings.Contains("/"+rel, "/"+nodeModules+"/")
+
+	return rel, !isDependency
+}
+
+// moduleID identifies the module of a source file by its path relative to the project directory, or
+// for dependencies by its path within node_modules, such as lodash/template.js
+func (a *analyzer) moduleID(path string) string {
+	filename, _ := a.filename(path)
+	if i := strings.LastIndex(filename, nodeModules+"/"); i >= 0 {
+		return filename[i+len(nodeModules)+1:]
+	}
+
+	return filename
+}
+
+func builtinTarget(module string, chain []string) target {
+	return target{builtin: module + symbolSeparator + joinChain(chain)}
+}
+
+func lastName(qualname string) string {
+	name := qualname[strings.LastIndex(qualname, symbolSeparator)+1:]
+
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// resolveChain resolves a call of chain, such as a.b.c, within fn. Names are looked up in the enclosing
+// functions, the module and its imports
+func (a *analyzer) resolveChain(m *module, fn *function, chain []string, depth int) (target, bool) {
+	if depth > maxResolutionDepth {
+		return target{}, false
+	}
+	name, rest := chain[0], chain[1:]
+	if name == "this" {
+		// Methods of this are looked up in the classes and objects fn is a member of
+		for owner := parentQualname(fn.qualname); len(owner) > 0 && len(rest) > 0; owner = parentQualname(owner) {
+			if t, ok := a.member(m, owner, rest); ok {
+				return t, true
+			}
+		}
+
+		return target{}, false
+	}
+	for enclosing := fn; enclosing != nil; enclosing = enclosing.parent {
+		if qualname, ok := enclosing.nested[name]; ok {
+			return a.member(m, qualname, rest)
+		}
+		if class, ok := enclosing.instances[name]; ok {
+			return a.resolveChain(m, enclosing, append(append([]string{}, class...), rest...), depth+1)
+		}
+	}
+	if t, ok := a.member(m, name, rest); ok {
+		return t, true
+	}
+	if imported, ok := m.imports[name]; ok {
+		return a.resolveImport(m, imported, rest, depth+1)
+	}
+	if globals[name] && len(chain) <= 2 {
+		return builtinTarget(globalScope, chain), true
+	}
+
+	return target{}, false
+}
+
+// member resolves the function qualname.members of m. Calls of classes resolve to their constructors
+func (a *analyzer) member(m *module, qualname string, members []string) (target, bool) {
+	qualname = joinChain(append([]string{qualname}, members...))
+	if m.classes[qualname] {
+		qualname += ".constructor"
+	}
+	if _, ok := m.functions[qualname]; ok && qualname != moduleScope {
+		return target{module: m, qualname: qualname}, true
+	}
+
+	return target{}, false
+}
+
+// resolveImport resolves members of the binding imported by m
+func (a *analyzer) resolveImport(m *module, imported binding, members []string, depth int) (target, bool) {
+	resolved := a.resolver.resolve(m.path, imported.specifier)
+	if len(resolved.builtin) > 0 {
+		chain := members
+		if len(imported.name) > 0 && imported.name != defaultExport {
+			chain = append([]string{imported.name}, members...)
+		}
+		if len(chain) == 0 {
+			return target{}, false
+		}
+
+		return builtinTarget(builtinPrefix+resolved.builtin, chain), true
+	}
+	exporter := a.load(resolved.path)
+	if exporter == nil {
+		return target{}, false
+	}
+	name := imported.name
+	if len(name) == 0 {
+		// Calls of namespaces, such as the module of const run = require('run'), call its default export
+		name = defaultExport
+		if len(members) > 0 {
+			name, members = members[0], members[1:]
+		}
+	}
+
+	return a.resolveExport(exporter, name, members, depth+1)
+}
+
+// resolveExport resolves members of the name exported by m. CommonJS modules also export the members of
+// module.exports, and their default export is module.exports itself
+func (a *analyzer) resolveExport(m *module, name string, members []string, depth int) (target, bool) {
+	if t, ok := a.lookupExport(m, name, members, depth); ok {
+		return t, true
+	}
+	if name != defaultExport {
+		return a.lookupExport(m, defaultExport, append([]string{name}, members...), depth)
+	}
+	if len(members) > 0 {
+		return a.lookupExport(m, members[0], members[1:], depth)
+	}
+
+	return target{}, false
+}
+
+func (a *analyzer) lookupExport(m *module, name string, members []string, depth int) (target, bool) {
+	if depth > maxResolutionDepth {
+		return target{}, false
+	}
+	if local, ok := m.exports[name]; ok {
+		if t, ok := a.resolveChain(m, m.functions[moduleScope], append([]string{local}, members...), depth+1); ok {
+			return t, true
+		}
+	}
+	if reexported, ok := m.reexports[name]; ok {
+		return a.resolveImport(m, reexported, members, depth+1)
+	}
+	if name == defaultExport {
+		return target{}, false
+	}
+	for _, specifier := range m.starExports {
+		resolved := a.resolver.resolve(m.path, specifier)
+		if exporter := a.load(resolved.path); exporter != nil {
+			if t, ok := a.lookupExport(exporter, name, members, depth+1); ok {
+				return t, true
+			}
+		}
+	}
+
+	return target{}, false
+}
+
+// parentQualname returns the qualified name of the class, object or function qualname is a member of
+func parentQualname(qualname string) string {
+	if i := strings.LastIndex(qualname, "."); i >= 0 {
+		return qualname[:i]
+	}
+
+	return ""
+}
@@ -0,0 +1,55 @@
+package javascript
+
+import (
+	"path"
+	"path/filepath"
+
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+type ICallgraphBuilder interface {
+	RunCallGraph() (string, error)
+}
+
+// CallgraphBuilder builds call graphs by static analysis of the sources of the project and its node_modules
+type CallgraphBuilder struct {
+	filesystem       ioFs.IFileSystem
+	workingDirectory string
+	entryPoints      []string
+	outputName       string
+}
+
+func NewCallgraphBuilder(
+	workingDirectory string,
+	entryPoints []string,
+	outputName string,
+	filesystem ioFs.IFileSystem,
+) CallgraphBuilder {
+	return CallgraphBuilder{
+		filesystem:       filesystem,
+		workingDirectory: workingDirectory,
+		entryPoints:      entryPoints,
+		outputName:       outputName,
+	}
+}
+
+func (cg *CallgraphBuilder) RunCallGraph() (string, error) {
+	projectDir, err := filepath.Abs(cg.workingDirectory)
+	if err != nil {
+		return "", err
+	}
+
+	callGraph := newAnalyzer(projectDir, cg.filesystem).build(cg.entryPoints)
+	cgOutputBytes, err := callGraph.ToBytes()
+	if err != nil {
+		return "", err
+	}
+
+	outputFullPath := path.Join(cg.workingDirectory, cg.outputName)
+	err = cg.filesystem.FsWriteFile(outputFullPath, cgOutputBytes, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return outputFullPath, nil
+}
@@ -0,0 +1,101 @@
+package javascript
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCallGraph(t *testing.T) {
+	fixture := filepath.Join("testdata", "fixture")
+	outputName := "debricked-call-graph.javascript-test"
+	defer os.Remove(filepath.Join(fixture, outputName))
+
+	cg := NewCallgraphBuilder(fixture, []string{filepath.Join("src", "index.ts"), filepath.Join("bin", "cli.js")}, outputName, io.FileSystem{})
+	outputPath, err := cg.RunCallGraph()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(fixture, outputName), outputPath)
+
+	content, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	output := string(content)
+	assert.Contains(t, output, `["src/index.ts#main", true, false, "main", "src/index.ts", 5, 9, [["bin/cli.js#<module>", 4, "bin/cli.js"], ["src/index.ts#<module>", 15, "src/index.ts"]]]`)
+	assert.Contains(t, output, `["src/store.ts#Store.constructor", true, false, "constructor", "src/store.ts", 4, 6, [["src/index.ts#main", 6, "src/index.ts"]]]`)
+	assert.Contains(t, output, `["src/store.ts#Store.log", true, false, "log", "src/store.ts", 13, 15, [["src/store.ts#Store.constructor", 5, "src/store.ts"], ["src/store.ts#Store.save", 10, "src/store.ts"]]]`)
+	assert.Contains(t, output, `["greeter/lib/greeter.js#greet", false, false, "greet", "node_modules/greeter/lib/greeter.js", 3, 5, [["src/index.ts#main", 7, "src/index.ts"]]]`)
+	assert.Contains(t, output, `["greeter/lib/format.js#module.exports.exclaim", false, false, "exclaim", "node_modules/greeter/lib/format.js", 2, 4, [["greeter/lib/greeter.js#greet", 4, "node_modules/greeter/lib/greeter.js"]]]`)
+	assert.Contains(t, output, `["node:fs#writeFileSync", false, true, "writeFileSync", "", -1, -1, [["src/index.ts#main", 8, "src/index.ts"]]]`)
+	assert.Contains(t, output, `["globalThis#console.log", false, true, "log", "", -1, -1, [["src/store.ts#Store.log", 14, "src/store.ts"]]]`)
+	assert.NotContains(t, output, "unused")
+}
+
+func TestRunCallGraphErrors(t *testing.T) {
+	fsMock := ioTestData.FileSystemMock{FsWriteFileError: assert.AnError}
+	cg := NewCallgraphBuilder("dir", []string{"index.js"}, outputName, fsMock)
+	_, err := cg.RunCallGraph()
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	project := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(project, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	return project
+}
+
+func TestAnalyzerResolvesExports(t *testing.T) {
+	project := writeProject(t, map[string]string{
+		"index.mjs": `
+import lib, { run as go } from './lib/index.mjs'
+import * as ns from './lib/index.mjs'
+import Client from 'client'
+go()
+ns.all.star()
+lib.method()
+new Client().connect()
+Client.create()
+require('./cli.js')()
+`,
+		"lib/index.mjs": `
+export { run } from './run.mjs'
+export * as all from './star.mjs'
+export default { method() {} }
+`,
+		"lib/run.mjs":                      "export function run() {}\n",
+		"lib/star.mjs":                     "export * from './deep.mjs'\n",
+		"lib/deep.mjs":                     "export const star = () => {}\n",
+		"cli.js":                           "module.exports = () => {}\n",
+		"node_modules/client/package.json": `{"main": "client.js"}`,
+		"node_modules/client/client.js": `
+class Client {
+  constructor() {}
+  static create() { return new Client() }
+}
+module.exports = Client
+`,
+	})
+
+	graph := newAnalyzer(project, io.FileSystem{}).build([]string{"index.mjs"})
+	for _, symbol := range []string{
+		"lib/run.mjs#run",
+		"lib/deep.mjs#star",
+		"lib/index.mjs#default.method",
+		"client/client.js#Client.constructor",
+		"client/client.js#Client.create",
+		"cli.js#module.exports",
+		"cli.js#<module>",
+	} {
+		assert.NotNilf(t, graph.GetNode(symbol), "failed to assert that %s is in the call graph", symbol)
+	}
+	assert.True(t, graph.GetNode("lib/run.mjs#run").IsApplicationNode)
+	assert.False(t, graph.GetNode("client/client.js#Client.create").IsApplicationNode)
+}
@@ -0,0 +1,32 @@
+package javascript
+
+import (
+	"os/exec"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+)
+
+type ICmdFactory interface {
+	MakeInstallCmd(workingDirectory string, clean bool, ctx cgexec.IContext) (*exec.Cmd, error)
+}
+
+type CmdFactory struct{}
+
+// MakeInstallCmd installs the dependencies of the project in node_modules. Clean installs use npm ci, which installs
+// the versions of package-lock.json
+func (_ CmdFactory) MakeInstallCmd(workingDirectory string, clean bool, ctx cgexec.IContext) (*exec.Cmd, error) {
+	path, err := exec.LookPath("npm")
+	args := []string{"npm", "install", "--no-save", "--package-lock=false"}
+	if clean {
+		args = []string{"npm", "ci"}
+	}
+	args = append(
+		args,
+		"--ignore-scripts", // Avoid risky scripts
+		"--audit=false",
+		"--fund=false",
+		"--bin-links=false",
+	)
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
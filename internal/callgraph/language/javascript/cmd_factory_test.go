@@ -0,0 +1,21 @@
+package javascript
+
+import (
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeInstallCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, _ := CmdFactory{}.MakeInstallCmd(dir, false, ctx)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"npm", "install", "--no-save", "--package-lock=false"}, cmd.Args[:4])
+	assert.Contains(t, cmd.Args, "--ignore-scripts")
+	assert.Equal(t, dir, cmd.Dir)
+
+	cmd, _ = CmdFactory{}.MakeInstallCmd(dir, true, ctx)
+	assert.Equal(t, []string{"npm", "ci"}, cmd.Args[:2])
+	assert.Contains(t, cmd.Args, "--ignore-scripts")
+}
@@ -0,0 +1,216 @@
+package javascript
+
+// importDeclaration parses the import declaration at i and returns the index after it
+func (p *parser) importDeclaration(i int, end int) int {
+	line := p.tokens[i].line
+	j := i + 1
+	switch {
+	case p.at(j).kind == tokenString:
+		// Side effect imports, such as import './polyfills'
+		p.addDependency(p.at(j).value, line)
+
+		return j + 1
+	case p.at(j).is("type") && !p.at(j+1).is(",", "from"):
+		// Type imports are erased
+		return p.specifierEnd(j, end)
+	case p.at(j).kind == tokenIdentifier && p.at(j+1).is("=") && p.at(j+2).is("require"):
+		// TypeScript import x = require('m')
+		specifier := p.at(j + 4).value
+		p.addDependency(specifier, line)
+		p.module.imports[p.at(j).value] = binding{specifier: specifier}
+
+		return j + 6
+	}
+
+	specifierEnd := p.specifierEnd(j, end)
+	if p.at(specifierEnd-1).kind != tokenString || !p.at(specifierEnd-2).is("from") {
+		return specifierEnd
+	}
+	specifier := p.at(specifierEnd - 1).value
+	p.addDependency(specifier, line)
+	for k := j; k < specifierEnd-2; k++ {
+		t := p.tokens[k]
+		switch {
+		case t.is("*") && p.at(k+1).is("as"):
+			p.module.imports[p.at(k+2).value] = binding{specifier: specifier}
+			k += 2
+		case t.is("{"):
+			closing := p.match(k, end)
+			for local, imported := range p.namedSpecifiers(k+1, closing) {
+				p.module.imports[local] = binding{specifier: specifier, name: imported}
+			}
+			k = closing
+		case t.kind == tokenIdentifier && !t.is("from", "type"):
+			p.module.imports[t.value] = binding{specifier: specifier, name: defaultExport}
+		}
+	}
+
+	return specifierEnd
+}
+
+// specifierEnd returns the index after the module specifier of the import or export declaration at i
+func (p *parser) specifierEnd(i int, end int) int {
+	for j := i; j < end; j++ {
+		t := p.tokens[j]
+		switch {
+		case t.is("{"):
+			j = p.match(j, end)
+		case t.is("from") && p.at(j+1).kind == tokenString:
+			return j + 2
+		case t.is(";"):
+			return j + 1
+		}
+	}
+
+	return end
+}
+
+// namedSpecifiers maps the local names of the specifiers in braces, such as { a, b as c, type d }, to the
+// names in the other module
+func (p *parser) namedSpecifiers(start int, end int) map[string]string {
+	specifiers := map[string]string{}
+	for k := start; k < end; k++ {
+		t := p.tokens[k]
+		if t.kind != tokenIdentifier && t.kind != tokenString || !(k == start || p.at(k-1).is(",")) {
+			continue
+		}
+		if t.is("type") && p.at(k+1).kind == tokenIdentifier && !p.at(k+1).is("as") {
+			// Type only specifiers are erased
+			continue
+		}
+		local := t.value
+		if p.at(k+1).is("as") && k+2 < end {
+			local = p.at(k + 2).value
+		}
+		specifiers[local] = t.value
+	}
+
+	return specifiers
+}
+
+// exportDeclaration parses the export declaration at i and returns the index to continue parsing at
+func (p *parser) exportDeclaration(i int, end int, sc scope) int {
+	j := i + 1
+	t := p.at(j)
+	switch {
+	case t.is("default"):
+		return p.exportDefault(j+1, end, sc)
+	case t.is("="):
+		// TypeScript export = x
+		return p.moduleExports(j+1, end, sc)
+	case t.is("type") && p.at(j+1).is("{", "*"):
+		return p.specifierEnd(j, end)
+	case t.is("*"):
+		specifierEnd := p.specifierEnd(j, end)
+		specifier := p.at(specifierEnd - 1)
+		if specifier.kind != tokenString {
+			return specifierEnd
+		}
+		p.addDependency(specifier.value, p.tokens[i].line)
+		if p.at(j + 1).is("as") {
+			p.module.reexports[p.at(j+2).value] = binding{specifier: specifier.value}
+		} else {
+			p.module.starExports = append(p.module.starExports, specifier.value)
+		}
+
+		return specifierEnd
+	case t.is("{"):
+		closing := p.match(j, end)
+		specifiers := p.namedSpecifiers(j+1, closing)
+		specifierEnd := p.specifierEnd(j, end)
+		if specifier := p.at(specifierEnd - 1); p.at(specifierEnd-2).is("from") && specifier.kind == tokenString {
+			p.addDependency(specifier.value, p.tokens[i].line)
+			for exported, local := range specifiers {
+				p.module.reexports[exported] = binding{specifier: specifier.value, name: local}
+			}
+
+			return specifierEnd
+		}
+		for exported, local := range specifiers {
+			p.module.exports[exported] = local
+		}
+
+		return closing + 1
+	}
+
+	// Exported declarations, such as export async function run() {}, are parsed as declarations
+	for k := j; k < end && k < j+4; k++ {
+		switch {
+		case p.tokens[k].is("function", "class", "enum", "namespace"):
+			name := p.at(k + 1)
+			if name.is("*") {
+				name = p.at(k + 2)
+			}
+			if name.kind == tokenIdentifier {
+				p.module.exports[name.value] = name.value
+			}
+
+			return j
+		case p.tokens[k].is("const", "let", "var"):
+			p.exportVariables(k+1, end)
+
+			return j
+		}
+	}
+
+	return j
+}
+
+// exportVariables exports the names declared by the declarators starting at i
+func (p *parser) exportVariables(i int, end int) {
+	for k := i; k < end; k++ {
+		t := p.tokens[k]
+		if t.kind == tokenIdentifier && (k == i || p.at(k-1).is(",")) {
+			p.module.exports[t.value] = t.value
+		}
+		k = p.expressionEnd(k, end)
+		if !p.at(k).is(",") {
+			return
+		}
+	}
+}
+
+// exportDefault parses the default export at i
+func (p *parser) exportDefault(i int, end int, sc scope) int {
+	t := p.at(i)
+	if t.is("async") && p.at(i+1).is("function") {
+		i++
+		t = p.at(i)
+	}
+	switch {
+	case t.is("function"):
+		name := p.at(i + 1)
+		if name.is("*") {
+			name = p.at(i + 2)
+		}
+		qualname := defaultExport
+		if name.kind == tokenIdentifier && !name.is("(") {
+			qualname = name.value
+		}
+		p.module.exports[defaultExport] = qualname
+
+		return p.functionKeyword(i, end, sc, qualname)
+	case t.is("class"):
+		qualname := defaultExport
+		if name := p.at(i + 1); name.kind == tokenIdentifier && !name.is("extends", "implements") {
+			qualname = name.value
+		}
+		p.module.exports[defaultExport] = qualname
+
+		return p.classKeyword(i, end, sc, qualname)
+	case t.is("{"):
+		p.module.exports[defaultExport] = defaultExport
+
+		return p.braces(i, end, sc, defaultExport)
+	case p.isFunctionValue(i, end):
+		p.module.exports[defaultExport] = defaultExport
+
+		return p.functionValue(i, end, sc, defaultExport, sc.this)
+	case t.kind == tokenIdentifier && (p.at(i+1).is(";") || p.at(i+1).newline || i+1 >= end):
+		p.module.exports[defaultExport] = t.value
+
+		return i + 1
+	}
+
+	return i
+}
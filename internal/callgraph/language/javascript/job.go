@@ -0,0 +1,154 @@
+package javascript
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/io"
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+const (
+	outputName  = "debricked-call-graph.javascript"
+	lockFile    = "package-lock.json"
+	packageJson = "package.json"
+)
+
+type Job struct {
+	job.BaseJob
+	entryPoints []string
+	cmdFactory  ICmdFactory
+	config      conf.IConfig
+	archive     io.IArchive
+	ctx         cgexec.IContext
+	fs          ioFs.IFileSystem
+}
+
+// NewJob creates a job generating the call graph of the package in dir, from entry points relative to dir
+func NewJob(
+	dir string,
+	entryPoints []string,
+	cmdFactory ICmdFactory,
+	writer ioFs.IFileWriter,
+	archive io.IArchive,
+	config conf.IConfig,
+	ctx cgexec.IContext,
+	fs ioFs.IFileSystem,
+) *Job {
+	return &Job{
+		BaseJob:     job.NewBaseJob(dir, entryPoints),
+		entryPoints: entryPoints,
+		cmdFactory:  cmdFactory,
+		config:      config,
+		archive:     archive,
+		ctx:         ctx,
+		fs:          fs,
+	}
+}
+
+func (j *Job) Run() {
+	workingDirectory := j.GetDir()
+	if installed := j.install(workingDirectory); len(installed) > 0 {
+		defer j.fs.RemoveAll(installed)
+	}
+	if j.Errors().HasError() {
+
+		return
+	}
+
+	callgraph := NewCallgraphBuilder(
+		workingDirectory,
+		j.entryPoints,
+		outputName,
+		j.fs,
+	)
+	j.SendStatus("generating call graph")
+	j.runCallGraph(&callgraph)
+}
+
+// install installs the dependencies of a package without node_modules if the project is built. The installed
+// node_modules is returned to be removed after generation. Packages without dependencies are analysed as they are
+func (j *Job) install(workingDirectory string) string {
+	if !j.config.Build() {
+		return ""
+	}
+	nodeModulesPath := filepath.Join(workingDirectory, nodeModules)
+	if _, err := j.fs.Stat(nodeModulesPath); err == nil {
+		return ""
+	}
+	content, err := j.fs.ReadFile(filepath.Join(workingDirectory, packageJson))
+	if err != nil || !hasDependencies(content) {
+		return ""
+	}
+
+	_, err = j.fs.Stat(filepath.Join(workingDirectory, lockFile))
+	j.SendStatus("installing dependencies")
+	osCmd, err := j.cmdFactory.MakeInstallCmd(workingDirectory, err == nil, j.ctx)
+	if err == nil {
+		err = cgexec.RunCommand(*cgexec.NewCommand(osCmd), j.ctx)
+	}
+	if err != nil {
+		j.Errors().Critical(fmt.Errorf("failed to install dependencies: %w", err))
+	}
+
+	return nodeModulesPath
+}
+
+// hasDependencies returns true if the package.json content declares dependencies which are installed in production
+func hasDependencies(content []byte) bool {
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return false
+	}
+
+	return len(pkg.Dependencies)+len(pkg.OptionalDependencies) > 0
+}
+
+func (j *Job) runCallGraph(callgraph ICallgraphBuilder) {
+	outputFullPath, err := callgraph.RunCallGraph()
+
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+	outputFullPathZip := outputFullPath + ".zip"
+
+	j.SendStatus("zipping callgraph")
+	err = j.archive.ZipFile(outputFullPath, outputFullPathZip, outputName)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+
+	j.SendStatus("base64 encoding zipped callgraph")
+	err = j.archive.B64(outputFullPathZip, outputFullPath)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+
+	j.SendStatus("cleanup")
+	err = j.archive.Cleanup(outputFullPathZip)
+	if err != nil {
+		e, ok := err.(*os.PathError)
+		if ok && e.Err == syscall.ENOENT {
+			return
+		} else {
+			j.Errors().Critical(err)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package javascript
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	jobTestdata "github.com/debricked/cli/internal/callgraph/job/testdata"
+	"github.com/debricked/cli/internal/callgraph/language/javascript/testdata"
+	"github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+const dir = "dir"
+
+var entryPoints = []string{filepath.Join("src", "index.ts")}
+
+func TestNewJob(t *testing.T) {
+	config := conf.Config{}
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	j := NewJob(dir, entryPoints, CmdFactory{}, io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	assert.Equal(t, entryPoints, j.GetFiles())
+	assert.Equal(t, dir, j.GetDir())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	fixture := filepath.Join("testdata", "fixture")
+	output := filepath.Join(fixture, outputName)
+	defer os.Remove(output)
+
+	for _, build := range []bool{true, false} {
+		t.Run(fmt.Sprintf("build %t", build), func(t *testing.T) {
+			config := conf.NewConfig("javascript", nil, nil, build, "npm", "")
+			ctx, _ := ctxTestdata.NewContextMock()
+			j := NewJob(fixture, entryPoints, CmdFactory{}, io.FileWriter{}, io.NewArchive("."), config, ctx, io.FileSystem{})
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			assert.Empty(t, j.Errors().GetAll())
+			_, err := os.Stat(output)
+			assert.NoError(t, err)
+			// Existing node_modules are not reinstalled nor removed
+			_, err = os.Stat(filepath.Join(fixture, nodeModules))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRunInstall(t *testing.T) {
+	project := t.TempDir()
+	packageJsonContent := []byte(`{"name": "project", "dependencies": {"greeter": "1.0.0"}}`)
+	assert.NoError(t, os.WriteFile(filepath.Join(project, packageJson), packageJsonContent, 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(project, "index.js"), []byte("console.log('index')\n"), 0600))
+	config := conf.NewConfig("javascript", nil, nil, true, "npm", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	j := NewJob(project, []string{"index.js"}, testdata.NewEchoCmdFactory(), io.FileWriter{}, io.NewArchive("."), config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+	assert.Empty(t, j.Errors().GetAll())
+
+	cmdFactory := testdata.NewEchoCmdFactory()
+	cmdFactory.InstallErr = assert.AnError
+	j = NewJob(project, []string{"index.js"}, cmdFactory, io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+	assert.ErrorContains(t, j.Errors().GetCriticalErrors()[0], "failed to install dependencies")
+}
+
+func TestHasDependencies(t *testing.T) {
+	assert.True(t, hasDependencies([]byte(`{"dependencies": {"greeter": "1.0.0"}}`)))
+	assert.True(t, hasDependencies([]byte(`{"optionalDependencies": {"greeter": "1.0.0"}}`)))
+	assert.False(t, hasDependencies([]byte(`{"devDependencies": {"greeter": "1.0.0"}}`)))
+	assert.False(t, hasDependencies([]byte(`{`)))
+}
+
+func TestRunCallgraphMockError(t *testing.T) {
+	config := conf.NewConfig("javascript", nil, nil, false, "npm", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	callgraphMock := testdata.CallgraphMock{RunCallGraphError: fmt.Errorf("error")}
+
+	j := NewJob(dir, entryPoints, CmdFactory{}, io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	j.runCallGraph(callgraphMock)
+
+	assert.True(t, j.Errors().HasError())
+}
+
+func TestRunPostProcessErrors(t *testing.T) {
+	cases := map[string]ioTestData.ArchiveMock{
+		"zip":     {ZipFileError: fmt.Errorf("error")},
+		"b64":     {B64Error: fmt.Errorf("error")},
+		"cleanup": {CleanupError: fmt.Errorf("error")},
+	}
+	for name, archiveMock := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := conf.NewConfig("javascript", nil, nil, false, "npm", "")
+			ctx, _ := ctxTestdata.NewContextMock()
+
+			j := NewJob(dir, entryPoints, CmdFactory{}, io.FileWriter{}, archiveMock, config, ctx, io.FileSystem{})
+			go jobTestdata.WaitStatus(j)
+			j.runCallGraph(testdata.CallgraphMock{})
+
+			assert.True(t, j.Errors().HasError())
+		})
+	}
+}
+
+func TestRunPostProcessCleanupNoFileExistError(t *testing.T) {
+	config := conf.NewConfig("javascript", nil, nil, false, "npm", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	err := &os.PathError{}
+	err.Err = syscall.ENOENT
+	archiveMock := ioTestData.ArchiveMock{CleanupError: err}
+
+	j := NewJob(dir, entryPoints, CmdFactory{}, io.FileWriter{}, archiveMock, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.runCallGraph(testdata.CallgraphMock{})
+
+	assert.False(t, j.Errors().HasError())
+}
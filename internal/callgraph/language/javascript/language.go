@@ -0,0 +1,31 @@
+package javascript
+
+const Name = "javascript"
+const StandardVersion = "1"
+const StandardPackageManager = "npm"
+
+type Language struct {
+	name           string
+	version        string
+	packageManager string
+}
+
+func NewLanguage() Language {
+	return Language{
+		name:           Name,
+		version:        StandardVersion,
+		packageManager: StandardPackageManager,
+	}
+}
+
+func (language Language) Name() string {
+	return language.name
+}
+
+func (language Language) Version() string {
+	return language.version
+}
+
+func (language Language) PackageManager() string {
+	return language.packageManager
+}
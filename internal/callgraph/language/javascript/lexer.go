@@ -0,0 +1,317 @@
+package javascript
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenIdentifier tokenKind = iota
+	tokenPunctuator
+	tokenString
+	tokenTemplate
+	tokenNumber
+	tokenRegex
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+	// newline is true if a line break precedes the token
+	newline bool
+}
+
+func (t token) is(values ...string) bool {
+	if t.kind != tokenIdentifier && t.kind != tokenPunctuator {
+		return false
+	}
+	for _, value := range values {
+		if t.value == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// punctuators are ordered by length, so that the longest punctuator is matched
+var punctuators = []string{
+	">>>=", "...", "===", "!==", "**=", "<<=", ">>=", ">>>", "&&=", "||=", "??=",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "??", "?.", "++", "--", "+=", "-=", "*=", "/=", "%=", "&=", "|=",
+	"^=", "**", "<<", ">>",
+}
+
+// regexPrecedingKeywords are keywords after which a slash starts a regular expression rather than a division
+var regexPrecedingKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true, "new": true, "delete": true,
+	"void": true, "throw": true, "case": true, "do": true, "else": true, "yield": true, "await": true,
+}
+
+type lexer struct {
+	source string
+	pos    int
+	line   int
+	tokens []token
+	// templateDepths are the brace depths of the template literal substitutions being lexed
+	templateDepths []int
+	braceDepth     int
+	newline        bool
+}
+
+// tokenize splits JavaScript or TypeScript source into tokens. Comments are skipped, while strings, templates and
+// regular expressions are single tokens. The lexer is lenient, as the call graph is built from what it can recognise
+func tokenize(source string) []token {
+	l := &lexer{source: source, line: 1}
+	if strings.HasPrefix(source, "#!") {
+		l.skipLine()
+	}
+	for l.pos < len(l.source) {
+		l.next()
+	}
+
+	return l.tokens
+}
+
+func (l *lexer) emit(kind tokenKind, value string, line int) {
+	l.tokens = append(l.tokens, token{kind: kind, value: value, line: line, newline: l.newline})
+	l.newline = false
+}
+
+func (l *lexer) skipLine() {
+	for l.pos < len(l.source) && l.source[l.pos] != '\n' {
+		l.pos++
+	}
+}
+
+func (l *lexer) next() {
+	c := l.source[l.pos]
+	switch {
+	case c == '\n':
+		l.line++
+		l.newline = true
+		l.pos++
+	case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+		l.pos++
+	case strings.HasPrefix(l.source[l.pos:], "//"):
+		l.skipLine()
+	case strings.HasPrefix(l.source[l.pos:], "/*"):
+		l.skipBlockComment()
+	case c == '"' || c == '\'':
+		l.lexString(c)
+	case c == '`':
+		l.pos++
+		l.lexTemplate()
+	case c == '}' && len(l.templateDepths) > 0 && l.templateDepths[len(l.templateDepths)-1] == l.braceDepth:
+		// The end of a template substitution continues the template
+		l.templateDepths = l.templateDepths[:len(l.templateDepths)-1]
+		l.pos++
+		l.lexTemplate()
+	case c >= '0' && c <= '9' || c == '.' && l.pos+1 < len(l.source) && isDigit(l.source[l.pos+1]):
+		l.lexNumber()
+	case c == '/' && l.regexAllowed():
+		l.lexRegex()
+	case isIdentifierStart(l.source[l.pos:]):
+		l.lexIdentifier()
+	default:
+		l.lexPunctuator()
+	}
+}
+
+func (l *lexer) skipBlockComment() {
+	end := strings.Index(l.source[l.pos+2:], "*/")
+	if end < 0 {
+		end = len(l.source) - l.pos - 2
+	}
+	comment := l.source[l.pos : l.pos+2+end]
+	if lines := strings.Count(comment, "\n"); lines > 0 {
+		l.line += lines
+		l.newline = true
+	}
+	l.pos = min(l.pos+end+4, len(l.source))
+}
+
+// lexString lexes a string literal. Unterminated strings end at the end of the line, which limits the damage of
+// quotes in JSX text
+func (l *lexer) lexString(quote byte) {
+	line := l.line
+	start := l.pos + 1
+	l.pos++
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		if c == '\\' {
+			if l.pos+1 < len(l.source) && l.source[l.pos+1] == '\n' {
+				l.line++
+			}
+			l.pos += 2
+
+			continue
+		}
+		if c == quote || c == '\n' {
+			break
+		}
+		l.pos++
+	}
+	end := min(l.pos, len(l.source))
+	l.emit(tokenString, l.source[start:end], line)
+	if l.pos < len(l.source) && l.source[l.pos] == quote {
+		l.pos++
+	}
+}
+
+// lexTemplate lexes template literal text up to its end or its next substitution
+func (l *lexer) lexTemplate() {
+	line := l.line
+	start := l.pos
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		switch {
+		case c == '\\':
+			l.pos += 2
+
+			continue
+		case c == '\n':
+			l.line++
+		case c == '`':
+			l.emit(tokenTemplate, l.source[start:l.pos], line)
+			l.pos++
+
+			return
+		case c == '$' && l.pos+1 < len(l.source) && l.source[l.pos+1] == '{':
+			l.emit(tokenTemplate, l.source[start:l.pos], line)
+			l.pos += 2
+			l.templateDepths = append(l.templateDepths, l.braceDepth)
+
+			return
+		}
+		l.pos++
+	}
+	l.emit(tokenTemplate, l.source[start:], line)
+}
+
+func (l *lexer) lexNumber() {
+	start := l.pos
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		if isDigit(c) || c == '.' || c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			l.pos++
+
+			continue
+		}
+		// Signed exponents, such as 1e-7
+		if (c == '-' || c == '+') && (l.source[l.pos-1] == 'e' || l.source[l.pos-1] == 'E') && !strings.HasPrefix(l.source[start:], "0x") {
+			l.pos++
+
+			continue
+		}
+
+		break
+	}
+	l.emit(tokenNumber, l.source[start:l.pos], l.line)
+}
+
+func (l *lexer) regexAllowed() bool {
+	if len(l.tokens) == 0 {
+		return true
+	}
+	previous := l.tokens[len(l.tokens)-1]
+	switch previous.kind {
+	case tokenIdentifier:
+		return regexPrecedingKeywords[previous.value]
+	case tokenPunctuator:
+		return !previous.is(")", "]", "}", "++", "--")
+	default:
+		return false
+	}
+}
+
+func (l *lexer) lexRegex() {
+	start := l.pos
+	l.pos++
+	inClass := false
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		if c == '\\' {
+			l.pos += 2
+
+			continue
+		}
+		if c == '\n' {
+			break
+		}
+		if c == '[' {
+			inClass = true
+		} else if c == ']' {
+			inClass = false
+		} else if c == '/' && !inClass {
+			l.pos++
+
+			break
+		}
+		l.pos++
+	}
+	for l.pos < len(l.source) && isIdentifierPart(l.source[l.pos:]) {
+		l.pos++
+	}
+	l.pos = min(l.pos, len(l.source))
+	l.emit(tokenRegex, l.source[start:l.pos], l.line)
+}
+
+func (l *lexer) lexIdentifier() {
+	start := l.pos
+	_, size := utf8.DecodeRuneInString(l.source[l.pos:])
+	l.pos += size
+	for l.pos < len(l.source) && isIdentifierPart(l.source[l.pos:]) {
+		_, size = utf8.DecodeRuneInString(l.source[l.pos:])
+		l.pos += size
+	}
+	l.emit(tokenIdentifier, l.source[start:l.pos], l.line)
+}
+
+func (l *lexer) lexPunctuator() {
+	for _, punctuator := range punctuators {
+		if strings.HasPrefix(l.source[l.pos:], punctuator) {
+			l.pos += len(punctuator)
+			l.emit(tokenPunctuator, punctuator, l.line)
+
+			return
+		}
+	}
+	c := l.source[l.pos]
+	switch c {
+	case '{':
+		l.braceDepth++
+	case '}':
+		l.braceDepth--
+	}
+	_, size := utf8.DecodeRuneInString(l.source[l.pos:])
+	l.emit(tokenPunctuator, l.source[l.pos:l.pos+size], l.line)
+	l.pos += size
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierStart(source string) bool {
+	r, _ := utf8.DecodeRuneInString(source)
+
+	return r == '$' || r == '_' || r == '#' || unicode.IsLetter(r)
+}
+
+func isIdentifierPart(source string) bool {
+	r, _ := utf8.DecodeRuneInString(source)
+
+	return r == '$' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
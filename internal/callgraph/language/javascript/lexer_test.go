@@ -0,0 +1,51 @@
+package javascript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenValues(tokens []token) []string {
+	values := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		values = append(values, t.value)
+	}
+
+	return values
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("#!/usr/bin/env node\n// comment\nconst a = b /* comment */ ?. c('d')\n")
+	assert.Equal(t, []string{"const", "a", "=", "b", "?.", "c", "(", "d", ")"}, tokenValues(tokens))
+	assert.Equal(t, 3, tokens[0].line)
+	assert.True(t, tokens[0].newline)
+	assert.False(t, tokens[1].newline)
+	assert.Equal(t, tokenString, tokens[7].kind)
+}
+
+func TestTokenizeRegexAndDivision(t *testing.T) {
+	tokens := tokenize("x = /a{2}\\//g.test(y) ? a / b / c : 1")
+	assert.Equal(t, tokenRegex, tokens[2].kind)
+	assert.Equal(t, "/a{2}\\//g", tokens[2].value)
+	assert.Equal(t, []string{"a", "/", "b", "/", "c"}, tokenValues(tokens[9:14]))
+}
+
+func TestTokenizeTemplate(t *testing.T) {
+	tokens := tokenize("`a ${f({ b: `c ${d}` })} e`\ng()")
+	assert.Equal(t, []string{"a ", "f", "(", "{", "b", ":", "c ", "d", "", "}", ")", " e", "g", "(", ")"}, tokenValues(tokens))
+	assert.Equal(t, tokenTemplate, tokens[0].kind)
+	assert.Equal(t, 2, tokens[12].line)
+}
+
+func TestTokenizeUnterminated(t *testing.T) {
+	tokens := tokenize("a('b\nc() /* d")
+	assert.Equal(t, []string{"a", "(", "b", "c", "(", ")"}, tokenValues(tokens))
+	assert.Equal(t, 2, tokens[3].line)
+}
+
+func TestTokenizeNumbers(t *testing.T) {
+	tokens := tokenize("1e-7 + 0x1F + .5 + 1_000n")
+	assert.Equal(t, []string{"1e-7", "+", "0x1F", "+", ".5", "+", "1_000n"}, tokenValues(tokens))
+	assert.Equal(t, tokenNumber, tokens[0].kind)
+}
@@ -0,0 +1,116 @@
+package javascript
+
+import (
+	"strings"
+)
+
+const moduleScope = "<module>"
+
+// binding is a name imported from the module of a specifier. An empty name binds the module namespace
+type binding struct {
+	specifier string
+	name      string
+}
+
+type call struct {
+	chain []string
+	isNew bool
+	line  int
+}
+
+// function is a function, method or module body of a module
+type function struct {
+	qualname  string
+	lineStart int
+	lineEnd   int
+	calls     []call
+	// nested maps names of functions declared within the function to their qualified names
+	nested map[string]string
+	// instances maps local variables assigned class instances, such as const user = new User(), to the class
+	instances map[string][]string
+	parent    *function
+}
+
+// module is the index of the functions, classes, imports and exports of a source file
+type module struct {
+	path      string
+	functions map[string]*function
+	classes   map[string]bool
+	imports   map[string]binding
+	// exports maps exported names to qualified names within the module
+	exports   map[string]string
+	reexports map[string]binding
+	// starExports are specifiers of modules all of whose names are exported, by export * from
+	starExports []string
+	// dependencies are specifiers of imported modules, whose bodies run when the module is loaded
+	dependencies []dependency
+}
+
+type dependency struct {
+	specifier string
+	line      int
+}
+
+// parseModule indexes source, which is JavaScript or TypeScript
+func parseModule(path string, source string) *module {
+	m := &module{
+		path:      path,
+		functions: map[string]*function{},
+		classes:   map[string]bool{},
+		imports:   map[string]binding{},
+		exports:   map[string]string{},
+		reexports: map[string]binding{},
+	}
+	tokens := tokenize(source)
+	p := &parser{module: m, tokens: tokens, matches: matchBrackets(tokens)}
+	body := m.addFunction(moduleScope, nil, 1)
+	lastLine := strings.Count(source, "\n") + 1
+	body.lineEnd = lastLine
+	p.parse(0, len(tokens), scope{function: body, kind: scopeModule})
+
+	return m
+}
+
+func (m *module) addFunction(qualname string, parent *function, line int) *function {
+	// Redeclared names, such as overloads, keep their first declaration
+	if fn, ok := m.functions[qualname]; ok {
+		return fn
+	}
+	fn := &function{
+		qualname:  qualname,
+		lineStart: line,
+		lineEnd:   line,
+		nested:    map[string]string{},
+		instances: map[string][]string{},
+		parent:    parent,
+	}
+	m.functions[qualname] = fn
+
+	return fn
+}
+
+// matchBrackets returns the index of the matching bracket of each opening and closing bracket, or -1
+func matchBrackets(tokens []token) []int {
+	matches := make([]int, len(tokens))
+	var stack []int
+	for i, t := range tokens {
+		matches[i] = -1
+		if t.kind != tokenPunctuator {
+			continue
+		}
+		switch t.value {
+		case "(", "[", "{":
+			stack = append(stack, i)
+		case ")", "]", "}":
+			if len(stack) == 0 {
+				continue
+			}
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			matches[open] = i
+			matches[i] = open
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,161 @@
+package javascript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func callChains(fn *function) [][]string {
+	var chains [][]string
+	for _, c := range fn.calls {
+		chains = append(chains, c.chain)
+	}
+
+	return chains
+}
+
+func TestParseModuleFunctions(t *testing.T) {
+	m := parseModule("index.ts", `
+export function main(args: string[]): number {
+  const server = new Server(8080)
+  server.listen()
+  const cb = (x) => format(x)
+  items.forEach(function (x) { console.log(x) })
+  return 0
+}
+
+function helper() {
+  function inner() { util.inspect({}) }
+  inner()
+}
+
+class Server extends Base {
+  private port: number = 1
+  handler = () => this.stop()
+  constructor(port) { super(port) }
+  async listen<T>(): Promise<void> { this.start() }
+}
+
+const api = {
+  get(x) { return fetch(x) },
+  post: async (x) => { await send(x) },
+}
+
+Server.prototype.stop = function () {}
+`)
+	assert.ElementsMatch(t, []string{
+		moduleScope, "main", "main.cb", "helper", "helper.inner", "Server.handler", "Server.constructor",
+		"Server.listen", "api.get", "api.post", "Server.stop",
+	}, keys(m.functions))
+	assert.True(t, m.classes["Server"])
+
+	main := m.functions["main"]
+	assert.Equal(t, 2, main.lineStart)
+	assert.Equal(t, 8, main.lineEnd)
+	assert.Equal(t, [][]string{{"Server"}, {"server", "listen"}, {"items", "forEach"}, {"console", "log"}}, callChains(main))
+	assert.True(t, main.calls[0].isNew)
+	assert.Equal(t, []string{"Server"}, main.instances["server"])
+	assert.Equal(t, "main.cb", main.nested["cb"])
+	assert.Equal(t, [][]string{{"format"}}, callChains(m.functions["main.cb"]))
+
+	assert.Equal(t, [][]string{{"inner"}}, callChains(m.functions["helper"]))
+	assert.Equal(t, [][]string{{"this", "start"}}, callChains(m.functions["Server.listen"]))
+	assert.Equal(t, [][]string{{"this", "stop"}}, callChains(m.functions["Server.handler"]))
+	assert.Equal(t, [][]string{{"send"}}, callChains(m.functions["api.post"]))
+	assert.Equal(t, map[string]string{"main": "main"}, m.exports)
+}
+
+func TestParseModuleESM(t *testing.T) {
+	m := parseModule("index.mjs", `
+import fs from 'fs'
+import { join as j, type T } from "node:path";
+import * as lib from './lib'
+import type { X } from './x'
+import './polyfill'
+export * from './all'
+export * as ns from './ns'
+export { a as b } from './ab'
+export { j as join, lib }
+export const run = () => lib.run(), stop = 1
+export default class Server {}
+const loaded = import('./lazy')
+`)
+	assert.Equal(t, map[string]binding{
+		"fs":  {specifier: "fs", name: defaultExport},
+		"j":   {specifier: "node:path", name: "join"},
+		"lib": {specifier: "./lib"},
+	}, m.imports)
+	assert.Equal(t, map[string]string{
+		"join": "j", "lib": "lib", "run": "run", "stop": "stop", defaultExport: "Server",
+	}, m.exports)
+	assert.Equal(t, map[string]binding{
+		"ns": {specifier: "./ns"},
+		"b":  {specifier: "./ab", name: "a"},
+	}, m.reexports)
+	assert.Equal(t, []string{"./all"}, m.starExports)
+
+	var specifiers []string
+	for _, dependency := range m.dependencies {
+		specifiers = append(specifiers, dependency.specifier)
+	}
+	assert.Equal(t, []string{"fs", "node:path", "./lib", "./polyfill", "./all", "./ns", "./ab", "./lazy"}, specifiers)
+}
+
+func TestParseModuleCommonJS(t *testing.T) {
+	m := parseModule("index.js", `
+const util = require('util')
+const { format, parse: parseUrl } = require('./format')
+const helper = require('./helper').helper
+
+function main() { helper() }
+
+module.exports = { main, run: main, stop() {} }
+exports.other = function () { main() }
+module.exports.last = last
+require('./cli')(process)
+`)
+	assert.Equal(t, map[string]binding{
+		"util":             {specifier: "util"},
+		"format":           {specifier: "./format", name: "format"},
+		"parseUrl":         {specifier: "./format", name: "parse"},
+		"helper":           {specifier: "./helper", name: "helper"},
+		"require('./cli')": {specifier: "./cli"},
+	}, m.imports)
+	assert.Equal(t, map[string]string{
+		"main": "main", "run": "main", "stop": "module.exports.stop", "other": "other", "last": "last",
+	}, m.exports)
+	assert.Equal(t, [][]string{{"require('./cli')"}}, callChains(m.functions[moduleScope]))
+
+	m = parseModule("index.js", "module.exports = function run() { go() }\n")
+	assert.Equal(t, map[string]string{defaultExport: moduleExports}, m.exports)
+	assert.Equal(t, [][]string{{"go"}}, callChains(m.functions[moduleExports]))
+
+	m = parseModule("index.js", "module.exports = require('./lib')\n")
+	assert.Equal(t, []string{"./lib"}, m.starExports)
+	assert.Equal(t, binding{specifier: "./lib", name: defaultExport}, m.reexports[defaultExport])
+}
+
+func TestParseModuleTypes(t *testing.T) {
+	m := parseModule("index.ts", `
+type Handler = (e: Event) => void
+interface Service { run(): void }
+declare function declared(a: string): void
+function overload(a: string): void
+function overload(a: any) { run(a) }
+export default function () { start() }
+`)
+	assert.ElementsMatch(t, []string{moduleScope, "overload", defaultExport}, keys(m.functions))
+	assert.Equal(t, [][]string{{"run"}}, callChains(m.functions["overload"]))
+	assert.Equal(t, [][]string{{"start"}}, callChains(m.functions[defaultExport]))
+	assert.Empty(t, m.functions[moduleScope].calls)
+}
+
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+
+	return result
+}
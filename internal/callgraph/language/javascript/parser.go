@@ -0,0 +1,759 @@
+package javascript
+
+type scopeKind int
+
+const (
+	scopeModule scopeKind = iota
+	scopeFunction
+	scopeBlock
+	scopeClass
+	scopeObject
+)
+
+const (
+	defaultExport = "default"
+	moduleExports = "module.exports"
+)
+
+type scope struct {
+	function *function
+	kind     scopeKind
+	// owner is the qualified name of the class or object literal whose members are parsed
+	owner string
+	// this is the qualified name of the class or object this refers to
+	this string
+}
+
+// memberModifiers precede the names of class and object members
+var memberModifiers = map[string]bool{
+	"static": true, "async": true, "get": true, "set": true, "public": true, "private": true, "protected": true,
+	"readonly": true, "override": true, "abstract": true, "declare": true, "accessor": true, "*": true,
+}
+
+// nonCallKeywords are keywords which may be followed by parentheses without being called
+var nonCallKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "return": true, "typeof": true,
+	"function": true, "with": true, "await": true, "yield": true, "void": true, "delete": true, "in": true,
+	"of": true, "instanceof": true, "case": true, "throw": true, "else": true, "do": true, "super": true,
+	"import": true, "require": true, "new": true, "as": true, "satisfies": true, "keyof": true,
+}
+
+// expressionPrefixes are tokens after which function and class keywords start expressions rather than declarations
+var expressionPrefixes = map[string]bool{
+	"=": true, "(": true, ",": true, ":": true, "?": true, "||": true, "&&": true, "??": true, "return": true,
+	"!": true, "=>": true, "[": true, "new": true, "yield": true, "await": true, "+": true, "-": true,
+}
+
+// continuations are tokens which continue an expression on a new line
+var continuations = map[string]bool{
+	".": true, "?.": true, "?": true, ":": true, "=>": true, ",": true, ")": true, "]": true, "}": true,
+	"+": true, "-": true, "*": true, "/": true, "%": true, "**": true, "==": true, "!=": true, "===": true,
+	"!==": true, "<": true, ">": true, "<=": true, ">=": true, "&&": true, "||": true, "??": true, "&": true,
+	"|": true, "^": true, "<<": true, ">>": true, ">>>": true, "=": true, "+=": true, "-=": true,
+	"instanceof": true, "in": true, "as": true, "satisfies": true,
+}
+
+// statementKeywords start statements, which end type annotations without a semicolon
+var statementKeywords = map[string]bool{
+	"function": true, "export": true, "const": true, "let": true, "var": true, "class": true, "declare": true,
+	"import": true, "interface": true, "type": true, "async": true, "return": true, "enum": true,
+}
+
+type parser struct {
+	module  *module
+	tokens  []token
+	matches []int
+}
+
+func (p *parser) at(i int) token {
+	if i < 0 || i >= len(p.tokens) {
+		return token{kind: tokenPunctuator}
+	}
+
+	return p.tokens[i]
+}
+
+// match returns the index of the bracket matching the bracket at i, or end if it is unmatched
+func (p *parser) match(i int, end int) int {
+	if i < len(p.matches) && p.matches[i] > i && p.matches[i] < end {
+		return p.matches[i]
+	}
+
+	return end
+}
+
+func (p *parser) parse(start int, end int, sc scope) {
+	for i := start; i < end; {
+		next := p.step(i, start, end, sc)
+		if next <= i {
+			next = i + 1
+		}
+		i = next
+	}
+}
+
+// step parses the construct starting at i and returns the index after it
+func (p *parser) step(i int, start int, end int, sc scope) int {
+	t := p.tokens[i]
+	if (sc.kind == scopeClass || sc.kind == scopeObject) && p.isMemberStart(i, start) {
+		if next, ok := p.member(i, end, sc); ok {
+			return next
+		}
+	}
+	switch {
+	case t.kind == tokenPunctuator && t.value == "{":
+		return p.braces(i, end, sc, "")
+	case t.kind != tokenIdentifier:
+		return i + 1
+	case t.value == "import" && !p.at(i+1).is("(", ".") && sc.kind != scopeObject:
+		return p.importDeclaration(i, end)
+	case t.value == "export" && sc.kind == scopeModule:
+		return p.exportDeclaration(i, end, sc)
+	case t.value == "type" && p.at(i+1).kind == tokenIdentifier && !p.at(i+1).newline && p.isStatementStart(i):
+		// Type aliases are not code
+		return p.expressionEnd(i+1, end)
+	case t.value == "interface" && p.at(i+1).kind == tokenIdentifier && p.isStatementStart(i):
+		return p.skipToBraces(i, end)
+	case t.value == "function":
+		return p.functionKeyword(i, end, sc, "")
+	case t.value == "async" && p.at(i+1).is("function") && !p.at(i+1).newline:
+		return p.functionKeyword(i+1, end, sc, "")
+	case t.value == "class" && !p.at(i-1).is("."):
+		return p.classKeyword(i, end, sc, "")
+	case t.is("const", "let", "var") && (p.at(i+1).kind == tokenIdentifier || p.at(i+1).is("{")):
+		return p.variableDeclaration(i+1, end, sc)
+	}
+	if next, ok := p.assignment(i, end, sc); ok {
+		return next
+	}
+	p.recordCall(i, start, sc)
+
+	return i + 1
+}
+
+func (p *parser) isStatementStart(i int) bool {
+	previous := p.at(i - 1)
+
+	return i == 0 || p.tokens[i].newline || previous.is(";", "{", "}", "export", "declare")
+}
+
+func (p *parser) isMemberStart(i int, start int) bool {
+	return i == start || p.at(i-1).is(",", ";", "}") || p.tokens[i].newline
+}
+
+// skipToBraces skips a declaration up to and including its braces, such as an interface
+func (p *parser) skipToBraces(i int, end int) int {
+	for j := i; j < end; j++ {
+		if p.tokens[j].is("{") {
+			return p.match(j, end) + 1
+		}
+	}
+
+	return end
+}
+
+// qualify returns the qualified name of a function or class declared as name in sc
+func (p *parser) qualify(sc scope, name string) string {
+	if (sc.kind == scopeClass || sc.kind == scopeObject) && len(sc.owner) > 0 {
+		return sc.owner + "." + name
+	}
+	if sc.function.qualname == moduleScope {
+		return name
+	}
+	qualname := sc.function.qualname + "." + name
+	sc.function.nested[name] = qualname
+
+	return qualname
+}
+
+// braces parses a block or an object literal, whose members are qualified by owner
+func (p *parser) braces(i int, end int, sc scope, owner string) int {
+	closing := p.match(i, end)
+	previous := p.at(i - 1)
+	isObject := len(owner) > 0 || (previous.kind == tokenPunctuator && expressionPrefixes[previous.value] && !previous.is("=>")) ||
+		previous.is("return", "default", "yield")
+	inner := scope{function: sc.function, kind: scopeBlock, this: sc.this}
+	if isObject {
+		inner = scope{function: sc.function, kind: scopeObject, owner: owner, this: owner}
+		if len(owner) == 0 {
+			inner.this = sc.this
+		}
+		if owner == moduleExports {
+			p.exportObjectShorthands(i+1, closing)
+		}
+	}
+	p.parse(i+1, closing, inner)
+
+	return closing + 1
+}
+
+// exportObjectShorthands exports the properties of module.exports = { a, b: c } which refer to other names
+func (p *parser) exportObjectShorthands(start int, end int) {
+	for i := start; i < end; i++ {
+		t := p.tokens[i]
+		if t.is("{", "(", "[") {
+			i = p.match(i, end)
+
+			continue
+		}
+		if t.kind != tokenIdentifier || !(i == start || p.at(i-1).is(",")) {
+			continue
+		}
+		next := p.at(i + 1)
+		switch {
+		case next.is(",", "}") || i+1 == end:
+			p.module.exports[t.value] = t.value
+		case next.is(":") && p.at(i+2).kind == tokenIdentifier && (p.at(i+3).is(",", "}") || i+3 == end):
+			p.module.exports[t.value] = p.at(i + 2).value
+		}
+	}
+}
+
+// member parses a method or a property of a class or object literal assigned a function
+func (p *parser) member(i int, end int, sc scope) (int, bool) {
+	j := i
+	for memberModifiers[p.at(j).value] && p.at(j).kind != tokenString && !p.at(j+1).is("(", "=", ":", ";", "?", ",", "}", "<") {
+		j++
+	}
+	name := p.at(j)
+	if name.kind != tokenIdentifier && name.kind != tokenString && name.kind != tokenNumber {
+		return 0, false
+	}
+	j++
+	if p.at(j).is("?", "!") {
+		j++
+	}
+	switch {
+	case p.at(j).is("(") || p.at(j).is("<"):
+		if len(sc.owner) == 0 || sc.kind == scopeObject && nonCallKeywords[name.value] {
+			return 0, false
+		}
+		bodyEnd, ok := p.findBody(j, end)
+		if !ok {
+			return 0, false
+		}
+		qualname := p.qualify(sc, name.value)
+		p.declareMember(sc, name.value, qualname)
+		fn := p.module.addFunction(qualname, sc.function, name.line)
+
+		return p.functionBody(fn, bodyEnd, end, sc.owner), true
+	case p.at(j).is(":", "="):
+		valueStart := j + 1
+		if p.at(j).is(":") && sc.kind == scopeClass {
+			// Type annotation of a class property
+			valueStart = p.skipType(j+1, end, "=")
+			if !p.at(valueStart).is("=") {
+				return 0, false
+			}
+			valueStart++
+		}
+		if len(sc.owner) == 0 {
+			return 0, false
+		}
+		if !p.isFunctionValue(valueStart, end) {
+			return 0, false
+		}
+		qualname := p.qualify(sc, name.value)
+		p.declareMember(sc, name.value, qualname)
+
+		return p.functionValue(valueStart, end, sc, qualname, sc.owner), true
+	}
+
+	return 0, false
+}
+
+func (p *parser) declareMember(sc scope, name string, qualname string) {
+	if sc.owner == moduleExports {
+		p.module.exports[name] = qualname
+	}
+}
+
+// skipType skips a type annotation starting at i, up to a token in stops at the same bracket level
+func (p *parser) skipType(i int, end int, stops ...string) int {
+	angles := 0
+	for j := i; j < end; j++ {
+		t := p.tokens[j]
+		switch {
+		case angles == 0 && t.is(stops...):
+			return j
+		case t.is("(", "[", "{"):
+			j = p.match(j, end)
+		case t.is("<"):
+			angles++
+		case t.is(">") && angles > 0:
+			angles--
+		case t.is(">>") && angles > 1:
+			angles -= 2
+		case angles == 0 && (t.is(";", ")", "]", "}") || t.newline && statementKeywords[t.value]):
+			return j
+		}
+	}
+
+	return end
+}
+
+// findBody returns the index of the opening brace of the body of a function whose parameters start at i,
+// possibly after type parameters
+func (p *parser) findBody(i int, end int) (int, bool) {
+	if p.at(i).is("<") {
+		i = p.skipType(i+1, end, ">") + 1
+	}
+	if !p.at(i).is("(") {
+		return 0, false
+	}
+	j := p.match(i, end) + 1
+	if p.at(j).is(":") {
+		j = p.skipType(j+1, end, "{", "=>")
+		// Object types of return types are in braces
+		for p.at(j).is("{") && p.at(j+1).is("}", ";") {
+			j = p.skipType(p.match(j, end)+1, end, "{")
+		}
+	}
+	if !p.at(j).is("{") {
+		return 0, false
+	}
+
+	return j, true
+}
+
+// functionBody parses the body starting at the brace at i as the body of fn, and returns the index after it
+func (p *parser) functionBody(fn *function, i int, end int, this string) int {
+	closing := p.match(i, end)
+	p.parse(i+1, closing, scope{function: fn, kind: scopeFunction, this: this})
+	fn.lineEnd = p.at(closing).line
+	if closing >= len(p.tokens) {
+		fn.lineEnd = p.at(len(p.tokens) - 1).line
+	}
+
+	return closing + 1
+}
+
+// isFunctionValue returns true if the expression at i is a function or arrow function
+func (p *parser) isFunctionValue(i int, end int) bool {
+	if p.at(i).is("async") {
+		i++
+	}
+	if p.at(i).is("function") {
+		return true
+	}
+	_, ok := p.arrowAt(i, end)
+
+	return ok
+}
+
+// arrowAt returns the index of the arrow of an arrow function starting at i
+func (p *parser) arrowAt(i int, end int) (int, bool) {
+	if p.at(i).is("<") {
+		i = p.skipType(i+1, end, ">") + 1
+	}
+	var j int
+	switch {
+	case p.at(i).is("("):
+		j = p.match(i, end) + 1
+	case p.at(i).kind == tokenIdentifier && p.at(i+1).is("=>"):
+		j = i + 1
+	default:
+		return 0, false
+	}
+	if p.at(j).is(":") {
+		j = p.skipType(j+1, end, "=>")
+	}
+	if !p.at(j).is("=>") {
+		return 0, false
+	}
+
+	return j, true
+}
+
+// functionValue declares the function or arrow function at i as qualname, and returns the index after it
+func (p *parser) functionValue(i int, end int, sc scope, qualname string, this string) int {
+	if p.at(i).is("async") {
+		i++
+	}
+	fn := p.module.addFunction(qualname, sc.function, p.at(i).line)
+	if p.at(i).is("function") {
+		j := i + 1
+		if p.at(j).is("*") {
+			j++
+		}
+		if p.at(j).kind == tokenIdentifier {
+			j++
+		}
+		body, ok := p.findBody(j, end)
+		if !ok {
+			return j
+		}
+
+		return p.functionBody(fn, body, end, this)
+	}
+	arrow, _ := p.arrowAt(i, end)
+	if p.at(arrow + 1).is("{") {
+		return p.functionBody(fn, arrow+1, end, sc.this)
+	}
+	// Concise bodies are an expression
+	bodyEnd := p.expressionEnd(arrow+1, end)
+	p.parse(arrow+1, bodyEnd, scope{function: fn, kind: scopeFunction, this: sc.this})
+	fn.lineEnd = p.at(bodyEnd - 1).line
+
+	return bodyEnd
+}
+
+// expressionEnd returns the index after the expression starting at i, which ends at a semicolon, a comma,
+// a closing bracket or a line break between two statements
+func (p *parser) expressionEnd(i int, end int) int {
+	for j := i; j < end; j++ {
+		t := p.tokens[j]
+		if t.kind == tokenPunctuator {
+			switch {
+			case t.is("(", "[", "{"):
+				j = p.match(j, end)
+
+				continue
+			case t.is(";", ",", ")", "]", "}"):
+				return j
+			}
+		}
+		if j > i && t.newline && endsExpression(p.tokens[j-1]) && !(t.is(t.value) && continuations[t.value]) {
+			return j
+		}
+	}
+
+	return end
+}
+
+func endsExpression(t token) bool {
+	switch t.kind {
+	case tokenIdentifier:
+		return !continuations[t.value] && !nonCallKeywords[t.value] || t.is("super")
+	case tokenPunctuator:
+		return t.is(")", "]", "}", "++", "--")
+	default:
+		return true
+	}
+}
+
+// functionKeyword parses the function at the function keyword at i. Declarations are named by their name or
+// by name, while function expressions are part of the enclosing function
+func (p *parser) functionKeyword(i int, end int, sc scope, name string) int {
+	j := i + 1
+	if p.at(j).is("*") {
+		j++
+	}
+	declared := p.at(j)
+	if declared.kind == tokenIdentifier && !declared.is("(") {
+		j++
+	}
+	previous := p.at(i - 1)
+	if previous.is("async") {
+		previous = p.at(i - 2)
+	}
+	isExpression := previous.kind == tokenPunctuator && expressionPrefixes[previous.value] || previous.kind == tokenIdentifier && expressionPrefixes[previous.value]
+	if len(name) == 0 {
+		if declared.kind != tokenIdentifier || isExpression {
+			// Anonymous functions, such as callbacks, are attributed to the enclosing function
+			return j
+		}
+		name = declared.value
+	}
+	body, ok := p.findBody(j, end)
+	if !ok {
+		// Overload signatures and declarations have no body
+		return j
+	}
+	qualname := p.qualify(sc, name)
+	fn := p.module.addFunction(qualname, sc.function, p.at(i).line)
+
+	return p.functionBody(fn, body, end, sc.this)
+}
+
+// classKeyword parses the class at the class keyword at i, named by its name or by name
+func (p *parser) classKeyword(i int, end int, sc scope, name string) int {
+	j := i + 1
+	if len(name) == 0 {
+		if p.at(j).kind != tokenIdentifier || p.at(j).is("extends", "implements") {
+			return j
+		}
+		name = p.at(j).value
+	}
+	for ; j < end && !p.tokens[j].is("{"); j++ {
+		if p.tokens[j].is("(") {
+			j = p.match(j, end)
+		}
+	}
+	if j >= end {
+		return end
+	}
+	qualname := p.qualify(sc, name)
+	p.module.classes[qualname] = true
+	closing := p.match(j, end)
+	// Class bodies run in the enclosing function, members are parsed as functions of the class
+	p.parse(j+1, closing, scope{function: sc.function, kind: scopeClass, owner: qualname, this: qualname})
+
+	return closing + 1
+}
+
+// variableDeclaration parses the declarator at i of a const, let or var declaration
+func (p *parser) variableDeclaration(i int, end int, sc scope) int {
+	if p.at(i).is("{") {
+		return p.destructuring(i, end)
+	}
+	name := p.at(i)
+	j := i + 1
+	if p.at(j).is("!") {
+		j++
+	}
+	if p.at(j).is(":") {
+		j = p.skipType(j+1, end, "=", ",")
+	}
+	if !p.at(j).is("=") {
+		return j
+	}
+
+	return p.value(j+1, end, sc, name.value)
+}
+
+// value parses the value assigned to name at i
+func (p *parser) value(i int, end int, sc scope, name string) int {
+	switch {
+	case p.at(i).is("require") && p.at(i+1).is("(") && p.at(i+2).kind == tokenString:
+		specifier := p.at(i + 2).value
+		p.addDependency(specifier, p.at(i).line)
+		imported := binding{specifier: specifier}
+		j := p.match(i+1, end) + 1
+		if p.at(j).is(".") && p.at(j+1).kind == tokenIdentifier {
+			imported.name = p.at(j + 1).value
+			j += 2
+		}
+		if sc.function.qualname == moduleScope {
+			p.module.imports[name] = imported
+		}
+
+		return j
+	case p.at(i).is("new"):
+		var chain []string
+		for j := i + 1; j < end && p.tokens[j].kind == tokenIdentifier; j += 2 {
+			chain = append(chain, p.tokens[j].value)
+			if !p.at(j + 1).is(".") {
+				break
+			}
+		}
+		if len(chain) > 0 {
+			sc.function.instances[name] = chain
+		}
+
+		return i
+	case p.isFunctionValue(i, end):
+		return p.functionValue(i, end, sc, p.qualify(sc, name), sc.this)
+	case p.at(i).is("class"):
+		return p.classKeyword(i, end, sc, name)
+	case p.at(i).is("{") && sc.function.qualname == moduleScope:
+		return p.braces(i, end, sc, name)
+	}
+
+	return i
+}
+
+// destructuring parses const { a, b: c } = require('m') declarations
+func (p *parser) destructuring(i int, end int) int {
+	closing := p.match(i, end)
+	j := closing + 1
+	if !p.at(j).is("=") || !p.at(j+1).is("require") || !p.at(j+2).is("(") || p.at(j+3).kind != tokenString {
+		return i + 1
+	}
+	specifier := p.at(j + 3).value
+	p.addDependency(specifier, p.at(j+1).line)
+	for k := i + 1; k < closing; k++ {
+		t := p.tokens[k]
+		if t.kind != tokenIdentifier || !(k == i+1 || p.at(k-1).is(",")) {
+			continue
+		}
+		local := t.value
+		if p.at(k+1).is(":") && p.at(k+2).kind == tokenIdentifier {
+			local = p.at(k + 2).value
+		}
+		p.module.imports[local] = binding{specifier: specifier, name: t.value}
+	}
+
+	return p.match(j+2, end) + 1
+}
+
+// assignment parses assignments of functions to names and members, such as exports.run = function () {}
+func (p *parser) assignment(i int, end int, sc scope) (int, bool) {
+	if !p.isStatementStart(i) && !p.at(i-1).is(",") {
+		return 0, false
+	}
+	chain := []string{p.tokens[i].value}
+	j := i + 1
+	for p.at(j).is(".") && p.at(j+1).kind == tokenIdentifier {
+		chain = append(chain, p.at(j+1).value)
+		j += 2
+	}
+	if !p.at(j).is("=") {
+		return 0, false
+	}
+	valueStart := j + 1
+	exported, isExports := exportedName(chain)
+	switch {
+	case isExports && len(exported) == 0:
+		return p.moduleExports(valueStart, end, sc), true
+	case isExports:
+		value := p.at(valueStart)
+		if value.kind == tokenIdentifier && p.at(valueStart+1).is(";") || value.kind == tokenIdentifier && p.at(valueStart+1).newline {
+			p.module.exports[exported] = value.value
+
+			return valueStart + 1, true
+		}
+		if p.isFunctionValue(valueStart, end) {
+			p.module.exports[exported] = exported
+
+			return p.functionValue(valueStart, end, sc, exported, sc.this), true
+		}
+	case p.isFunctionValue(valueStart, end):
+		var qualname string
+		if len(chain) == 1 {
+			qualname = p.qualify(sc, chain[0])
+		} else {
+			qualname = joinChain(withoutPrototype(chain))
+		}
+
+		return p.functionValue(valueStart, end, sc, qualname, qualname[:max(0, len(qualname)-len(chain[len(chain)-1])-1)]), true
+	}
+
+	return 0, false
+}
+
+// moduleExports parses the value assigned to module.exports at i
+func (p *parser) moduleExports(i int, end int, sc scope) int {
+	value := p.at(i)
+	switch {
+	case value.is("require") && p.at(i+1).is("(") && p.at(i+2).kind == tokenString:
+		specifier := p.at(i + 2).value
+		p.addDependency(specifier, value.line)
+		p.module.starExports = append(p.module.starExports, specifier)
+		p.module.reexports[defaultExport] = binding{specifier: specifier, name: defaultExport}
+
+		return p.match(i+1, end) + 1
+	case value.is("{"):
+		return p.braces(i, end, sc, moduleExports)
+	case value.kind == tokenIdentifier && p.isFunctionValue(i, end):
+		p.module.exports[defaultExport] = moduleExports
+
+		return p.functionValue(i, end, sc, moduleExports, sc.this)
+	case value.is("class"):
+		p.module.exports[defaultExport] = moduleExports
+
+		return p.classKeyword(i, end, sc, moduleExports)
+	case value.kind == tokenIdentifier && !value.is("new"):
+		p.module.exports[defaultExport] = value.value
+	case p.isFunctionValue(i, end):
+		p.module.exports[defaultExport] = moduleExports
+
+		return p.functionValue(i, end, sc, moduleExports, sc.this)
+	}
+
+	return i
+}
+
+// exportedName returns the name exported by an assignment to exports.name or module.exports.name.
+// The name is empty for assignments to module.exports
+func exportedName(chain []string) (string, bool) {
+	switch {
+	case len(chain) == 2 && chain[0] == "exports":
+		return chain[1], true
+	case len(chain) == 2 && chain[0] == "module" && chain[1] == "exports":
+		return "", true
+	case len(chain) == 3 && chain[0] == "module" && chain[1] == "exports":
+		return chain[2], true
+	}
+
+	return "", false
+}
+
+func withoutPrototype(chain []string) []string {
+	var result []string
+	for _, part := range chain {
+		if part != "prototype" {
+			result = append(result, part)
+		}
+	}
+
+	return result
+}
+
+func joinChain(chain []string) string {
+	qualname := ""
+	for i, part := range chain {
+		if i > 0 {
+			qualname += "."
+		}
+		qualname += part
+	}
+
+	return qualname
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func (p *parser) addDependency(specifier string, line int) {
+	p.module.dependencies = append(p.module.dependencies, dependency{specifier: specifier, line: line})
+}
+
+// recordCall records the call of the identifier at i, with the member chain it ends, such as a.b.c()
+func (p *parser) recordCall(i int, start int, sc scope) {
+	t := p.tokens[i]
+	next := p.at(i + 1)
+	isCall := next.is("(") || next.is("?.") && p.at(i+2).is("(")
+	j := i
+	chain := []string{t.value}
+	for j-2 >= start && p.at(j-1).is(".", "?.") && p.at(j-2).kind == tokenIdentifier {
+		chain = append([]string{p.at(j - 2).value}, chain...)
+		j -= 2
+	}
+	if p.at(j-1).is(".", "?.") {
+		// Members of call results and other expressions are not resolved
+		return
+	}
+	isNew := p.at(j - 1).is("new")
+	if !isCall && !(isNew && !next.is(".")) {
+		p.recordDynamicImport(i, sc)
+
+		return
+	}
+	if nonCallKeywords[chain[0]] && !(chain[0] == "super" && len(chain) > 1) || p.at(j-1).is("function") {
+		p.recordDynamicImport(i, sc)
+
+		return
+	}
+	sc.function.calls = append(sc.function.calls, call{chain: chain, isNew: isNew, line: t.line})
+}
+
+// recordDynamicImport records import('m') and require('m') outside of declarations as dependencies. Calls of
+// required modules, such as require('m')() and require('m').run(), are recorded as calls of an import binding
+func (p *parser) recordDynamicImport(i int, sc scope) {
+	t := p.tokens[i]
+	if !t.is("import", "require") || !p.at(i+1).is("(") || p.at(i+2).kind != tokenString || !p.at(i+3).is(")") {
+		return
+	}
+	specifier := p.at(i + 2).value
+	p.addDependency(specifier, t.line)
+	if !t.is("require") {
+		return
+	}
+	local := "require('" + specifier + "')"
+	chain := []string{local}
+	switch {
+	case p.at(i + 4).is("("):
+	case p.at(i+4).is(".") && p.at(i+5).kind == tokenIdentifier && p.at(i+6).is("("):
+		chain = append(chain, p.at(i+5).value)
+	default:
+		return
+	}
+	p.module.imports[local] = binding{specifier: specifier}
+	sc.function.calls = append(sc.function.calls, call{chain: chain, line: t.line})
+}
@@ -0,0 +1,198 @@
+package javascript
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/finder/javascriptfinder"
+)
+
+const (
+	nodeModules   = "node_modules"
+	builtinPrefix = "node:"
+)
+
+// builtinModules are the core modules of Node.js
+var builtinModules = map[string]bool{
+	"assert": true, "async_hooks": true, "buffer": true, "child_process": true, "cluster": true, "console": true,
+	"constants": true, "crypto": true, "dgram": true, "diagnostics_channel": true, "dns": true, "domain": true,
+	"events": true, "fs": true, "http": true, "http2": true, "https": true, "inspector": true, "module": true,
+	"net": true, "os": true, "path": true, "perf_hooks": true, "process": true, "punycode": true,
+	"querystring": true, "readline": true, "repl": true, "stream": true, "string_decoder": true, "sys": true,
+	"timers": true, "tls": true, "trace_events": true, "tty": true, "url": true, "util": true, "v8": true,
+	"vm": true, "wasi": true, "worker_threads": true, "zlib": true, "test": true, "sqlite": true,
+}
+
+// exportConditions are the conditions of package exports used for resolution, in order of preference
+var exportConditions = []string{"node", "import", "require", "default"}
+
+// resolution is the source file or the builtin module a specifier resolves to
+type resolution struct {
+	path    string
+	builtin string
+}
+
+type resolver struct {
+	cache map[string]resolution
+}
+
+func newResolver() *resolver {
+	return &resolver{cache: map[string]resolution{}}
+}
+
+// resolve resolves the specifier imported by the source file from, like Node.js and TypeScript do.
+// The resolution is empty if the specifier can't be resolved to a source file
+func (r *resolver) resolve(from string, specifier string) resolution {
+	dir := filepath.Dir(from)
+	key := dir + "\x00" + specifier
+	if cached, ok := r.cache[key]; ok {
+		return cached
+	}
+	result := r.resolveUncached(dir, specifier)
+	r.cache[key] = result
+
+	return result
+}
+
+func (r *resolver) resolveUncached(dir string, specifier string) resolution {
+	if builtin, ok := builtinModule(specifier); ok {
+		return resolution{builtin: builtin}
+	}
+	if strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../") || specifier == "." || specifier == ".." {
+		return sourceResolution(javascriptfinder.ResolveSourceFile(filepath.Join(dir, filepath.FromSlash(specifier))))
+	}
+	if filepath.IsAbs(specifier) {
+		return sourceResolution(javascriptfinder.ResolveSourceFile(specifier))
+	}
+
+	name, subpath := splitPackageSpecifier(specifier)
+	for current := dir; ; current = filepath.Dir(current) {
+		if filepath.Base(current) != nodeModules {
+			packageDir := filepath.Join(current, nodeModules, filepath.FromSlash(name))
+			if info, err := os.Stat(packageDir); err == nil && info.IsDir() {
+				return sourceResolution(resolvePackage(packageDir, subpath))
+			}
+		}
+		if filepath.Dir(current) == current {
+			return resolution{}
+		}
+	}
+}
+
+// builtinModule returns the name of the core module of specifier, such as fs for node:fs and fs/promises
+func builtinModule(specifier string) (string, bool) {
+	name := strings.TrimPrefix(specifier, builtinPrefix)
+	if builtinModules[strings.SplitN(name, "/", 2)[0]] {
+		return name, true
+	}
+
+	return "", strings.HasPrefix(specifier, builtinPrefix)
+}
+
+// sourceResolution ignores files which are not sources, such as JSON
+func sourceResolution(path string) resolution {
+	for _, ext := range javascriptfinder.SourceExtensions {
+		if filepath.Ext(path) == ext {
+			return resolution{path: path}
+		}
+	}
+
+	return resolution{}
+}
+
+// splitPackageSpecifier splits a bare specifier into the package name and the subpath, such as @scope/pkg and ./sub
+func splitPackageSpecifier(specifier string) (string, string) {
+	parts := strings.Split(specifier, "/")
+	nameParts := 1
+	if strings.HasPrefix(specifier, "@") && len(parts) > 1 {
+		nameParts = 2
+	}
+	if len(parts) <= nameParts {
+		return specifier, "."
+	}
+
+	return strings.Join(parts[:nameParts], "/"), "./" + strings.Join(parts[nameParts:], "/")
+}
+
+// resolvePackage resolves subpath of the package in packageDir, by its exports if it has any,
+// or else by its main field and files
+func resolvePackage(packageDir string, subpath string) string {
+	pkg, err := javascriptfinder.ReadPackageJson(filepath.Join(packageDir, "package.json"))
+	if err == nil && pkg.Exports != nil {
+		if target, ok := exportTarget(pkg.Exports, subpath); ok {
+			return javascriptfinder.ResolveSourceFile(filepath.Join(packageDir, filepath.FromSlash(target)))
+		}
+	}
+	if subpath != "." {
+		return javascriptfinder.ResolveSourceFile(filepath.Join(packageDir, filepath.FromSlash(subpath)))
+	}
+	for _, main := range []string{pkg.Main, "index"} {
+		if len(main) == 0 {
+			continue
+		}
+		if source := javascriptfinder.ResolveSourceFile(filepath.Join(packageDir, filepath.FromSlash(main))); len(source) > 0 {
+			return source
+		}
+	}
+
+	return ""
+}
+
+// exportTarget returns the target of subpath in the exports field of a package
+func exportTarget(exports interface{}, subpath string) (string, bool) {
+	if subpaths, ok := exports.(map[string]interface{}); ok && hasSubpaths(subpaths) {
+		if target, ok := subpaths[subpath]; ok {
+			return conditionalTarget(target)
+		}
+		// Subpath patterns, such as ./features/*
+		for pattern, target := range subpaths {
+			prefix, suffix, found := strings.Cut(pattern, "*")
+			if !found || !strings.HasPrefix(subpath, prefix) || !strings.HasSuffix(subpath, suffix) || len(subpath) < len(prefix)+len(suffix) {
+				continue
+			}
+			if resolved, ok := conditionalTarget(target); ok {
+				return strings.ReplaceAll(resolved, "*", subpath[len(prefix):len(subpath)-len(suffix)]), true
+			}
+		}
+
+		return "", false
+	}
+	if subpath != "." {
+		return "", false
+	}
+
+	return conditionalTarget(exports)
+}
+
+func hasSubpaths(exports map[string]interface{}) bool {
+	for key := range exports {
+		return strings.HasPrefix(key, ".")
+	}
+
+	return false
+}
+
+// conditionalTarget returns the target of exports, which is a path, an array of fallbacks or conditions
+func conditionalTarget(exports interface{}) (string, bool) {
+	switch value := exports.(type) {
+	case string:
+		return value, true
+	case []interface{}:
+		for _, fallback := range value {
+			if target, ok := conditionalTarget(fallback); ok {
+				return target, true
+			}
+		}
+	case map[string]interface{}:
+		for _, condition := range exportConditions {
+			if target, ok := value[condition]; ok {
+				if resolved, ok := conditionalTarget(target); ok {
+					return resolved, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
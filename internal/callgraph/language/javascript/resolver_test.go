@@ -0,0 +1,68 @@
+package javascript
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	fixture, err := filepath.Abs(filepath.Join("testdata", "fixture"))
+	assert.NoError(t, err)
+	from := filepath.Join(fixture, "src", "index.ts")
+	exported := filepath.Join(fixture, nodeModules, "@scope", "exported", "dist", "esm")
+	cases := map[string]resolution{
+		"fs":                             {builtin: "fs"},
+		"node:fs/promises":               {builtin: "fs/promises"},
+		"node:unknown":                   {},
+		"./store":                        {path: filepath.Join(fixture, "src", "store.ts")},
+		"./store.js":                     {path: filepath.Join(fixture, "src", "store.ts")},
+		"../dist/index.js":               {path: filepath.Join(fixture, "src", "index.ts")},
+		"../package.json":                {},
+		"./missing":                      {},
+		"greeter":                        {path: filepath.Join(fixture, nodeModules, "greeter", "lib", "greeter.js")},
+		"greeter/lib/format":             {path: filepath.Join(fixture, nodeModules, "greeter", "lib", "format.js")},
+		"@scope/exported":                {path: filepath.Join(exported, "index.js")},
+		"@scope/exported/features/extra": {path: filepath.Join(exported, "extra.js")},
+		"missing":                        {},
+	}
+	r := newResolver()
+	for specifier, expected := range cases {
+		t.Run(specifier, func(t *testing.T) {
+			assert.Equal(t, expected, r.resolve(from, specifier))
+		})
+	}
+}
+
+func TestSplitPackageSpecifier(t *testing.T) {
+	name, subpath := splitPackageSpecifier("lodash")
+	assert.Equal(t, "lodash", name)
+	assert.Equal(t, ".", subpath)
+
+	name, subpath = splitPackageSpecifier("@scope/pkg/sub/path")
+	assert.Equal(t, "@scope/pkg", name)
+	assert.Equal(t, "./sub/path", subpath)
+}
+
+func TestExportTarget(t *testing.T) {
+	target, ok := exportTarget("./index.js", ".")
+	assert.True(t, ok)
+	assert.Equal(t, "./index.js", target)
+
+	_, ok = exportTarget("./index.js", "./sub")
+	assert.False(t, ok)
+
+	conditions := map[string]interface{}{"browser": "./browser.js", "require": "./index.cjs", "default": "./index.js"}
+	target, ok = exportTarget(conditions, ".")
+	assert.True(t, ok)
+	assert.Equal(t, "./index.cjs", target)
+
+	subpaths := map[string]interface{}{".": []interface{}{conditions}, "./sub/*": "./lib/*.js"}
+	target, ok = exportTarget(subpaths, "./sub/a/b")
+	assert.True(t, ok)
+	assert.Equal(t, "./lib/a/b.js", target)
+
+	_, ok = exportTarget(subpaths, "./other")
+	assert.False(t, ok)
+}
@@ -0,0 +1,116 @@
+package javascript
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder"
+	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/io"
+	"github.com/fatih/color"
+)
+
+type Strategy struct {
+	config     conf.IConfig
+	cmdFactory ICmdFactory
+	paths      []string
+	exclusions []string
+	inclusions []string
+	finder     finder.IFinder
+	ctx        cgexec.IContext
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+
+	if s.config == nil {
+		strategyWarning("No config is setup")
+
+		return jobs, nil
+	}
+
+	for _, path := range s.paths {
+		files, err := s.finder.FindFiles([]string{path}, s.exclusions, s.inclusions)
+		if err != nil {
+			strategyWarning("Error while finding files: " + err.Error())
+
+			return jobs, err
+		}
+
+		roots, err := s.finder.FindRoots(files)
+		if err != nil {
+			strategyWarning("Error while finding roots: " + err.Error())
+
+			return jobs, err
+		}
+
+		packages := groupByPackage(path, roots)
+		packageDirs := make([]string, 0, len(packages))
+		for packageDir := range packages {
+			packageDirs = append(packageDirs, packageDir)
+		}
+		sort.Strings(packageDirs)
+
+		for _, packageDir := range packageDirs {
+			jobs = append(jobs, NewJob(
+				packageDir,
+				packages[packageDir],
+				s.cmdFactory,
+				io.FileWriter{},
+				io.NewArchive("."),
+				s.config,
+				s.ctx,
+				io.FileSystem{},
+			),
+			)
+		}
+	}
+
+	return jobs, nil
+}
+
+// groupByPackage maps the package directories of roots to the roots within them, relative to the package directory.
+// The package directory of a root is the closest directory containing a package.json, or the directory of the root
+func groupByPackage(path string, roots []string) map[string][]string {
+	packages := map[string][]string{}
+	for _, root := range roots {
+		packageDir := findPackageDir(path, filepath.Dir(root))
+		entryPoint, err := filepath.Rel(packageDir, root)
+		if err != nil {
+			entryPoint = root
+		}
+		packages[packageDir] = append(packages[packageDir], entryPoint)
+	}
+
+	return packages
+}
+
+func findPackageDir(path string, dir string) string {
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := os.Stat(filepath.Join(current, packageJson)); err == nil {
+			return current
+		}
+		rel, err := filepath.Rel(path, current)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") || filepath.Dir(current) == current {
+			return dir
+		}
+	}
+}
+
+func NewStrategy(config conf.IConfig, paths []string, exclusions []string, inclusions []string, finder finder.IFinder, ctx cgexec.IContext) Strategy {
+	return Strategy{config, CmdFactory{}, paths, exclusions, inclusions, finder, ctx}
+}
+
+func strategyWarning(errMsg string) {
+	err := fmt.Errorf("%s", errMsg)
+	warningColor := color.New(color.FgYellow, color.Bold).SprintFunc()
+	defaultOutputWriter := log.Writer()
+	log.Println(warningColor("Warning: ") + err.Error())
+	log.SetOutput(defaultOutputWriter)
+}
@@ -0,0 +1,88 @@
+package javascript
+
+import (
+	"path/filepath"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, nil, nil, nil, nil, nil)
+	assert.NotNil(t, s)
+
+	conf := config.NewConfig("javascript", []string{"arg1"}, map[string]string{"kwarg": "val"}, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	ctx, _ := ctxTestdata.NewContextMock()
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	assert.NotNil(t, s)
+	assert.Equal(t, s.config, conf)
+}
+
+func TestInvokeNoConfig(t *testing.T) {
+	s := NewStrategy(nil, []string{}, []string{}, []string{}, nil, nil)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeGroupsRootsByPackage(t *testing.T) {
+	conf := config.NewConfig("javascript", nil, nil, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindRootsNames = []string{
+		filepath.Join("testdata", "fixture", "bin", "cli.js"),
+		filepath.Join("testdata", "fixture", "src", "index.ts"),
+		filepath.Join("testdata", "script.js"),
+	}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"testdata"}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	assert.Equal(t, "testdata", jobs[0].GetDir())
+	assert.Equal(t, []string{"script.js"}, jobs[0].GetFiles())
+	assert.Equal(t, filepath.Join("testdata", "fixture"), jobs[1].GetDir())
+	assert.Equal(t, []string{filepath.Join("bin", "cli.js"), filepath.Join("src", "index.ts")}, jobs[1].GetFiles())
+}
+
+func TestInvokeWithErrors(t *testing.T) {
+	conf := config.NewConfig("javascript", nil, nil, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindRootsNames = []string{"index.js"}
+	finder.FindRootsErr = assert.AnError
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+
+	finder.FindRootsErr = nil
+	finder.FindFilesErr = assert.AnError
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err = s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeNoRoots(t *testing.T) {
+	conf := config.NewConfig("javascript", nil, nil, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestFindPackageDir(t *testing.T) {
+	fixture := filepath.Join("testdata", "fixture")
+	assert.Equal(t, fixture, findPackageDir("testdata", filepath.Join(fixture, "src")))
+	assert.Equal(t, fixture, findPackageDir("testdata", fixture))
+	assert.Equal(t, "testdata", findPackageDir("testdata", "testdata"))
+	// Packages are not looked up outside of the scanned path
+	assert.Equal(t, filepath.Join(fixture, "src"), findPackageDir(filepath.Join(fixture, "src"), filepath.Join(fixture, "src")))
+}
@@ -0,0 +1,22 @@
+package testdata
+
+import (
+	"os/exec"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+)
+
+type CmdFactoryMock struct {
+	InstallName string
+	InstallErr  error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		InstallName: "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeInstallCmd(_ string, _ bool, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.InstallName, "Install"), f.InstallErr
+}
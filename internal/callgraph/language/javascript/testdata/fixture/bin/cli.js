@@ -0,0 +1,4 @@
+#!/usr/bin/env node
+const { main } = require('../dist/index.js')
+
+main(process.argv.slice(2))
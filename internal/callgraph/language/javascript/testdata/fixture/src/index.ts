@@ -0,0 +1,15 @@
+import { greet } from 'greeter'
+import { Store } from './store'
+import * as fs from 'node:fs'
+
+export function main(args: string[]): void {
+  const store = new Store()
+  store.save(greet(args[0]))
+  fs.writeFileSync('out.txt', 'done')
+}
+
+function unused(): void {
+  greet('nobody')
+}
+
+main(process.argv)
@@ -0,0 +1,16 @@
+export class Store {
+  private items: string[] = []
+
+  constructor() {
+    this.log('created')
+  }
+
+  save(item: string): void {
+    this.items.push(item)
+    this.log(item)
+  }
+
+  log(item: string): void {
+    console.log(item)
+  }
+}
@@ -1,14 +1,23 @@
 package language
 
-import "github.com/debricked/cli/internal/callgraph/language/java"
+import (
+	"github.com/debricked/cli/internal/callgraph/language/golang"
+	"github.com/debricked/cli/internal/callgraph/language/java"
+	"github.com/debricked/cli/internal/callgraph/language/javascript"
+	"github.com/debricked/cli/internal/callgraph/language/python"
+)
 
 type ILanguage interface {
 	Name() string
 	Version() string
+	PackageManager() string
 }
 
 func Languages() []ILanguage {
 	return []ILanguage{
 		java.NewLanguage(),
+		golang.NewLanguage(),
+		python.NewLanguage(),
+		javascript.NewLanguage(),
 	}
 }
@@ -10,6 +10,9 @@ func TestLanguages(t *testing.T) {
 	langs := Languages()
 	langNames := []string{
 		"java",
+		"golang",
+		"python",
+		"javascript",
 	}
 
 	for _, langName := range langNames {
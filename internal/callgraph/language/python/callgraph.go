@@ -0,0 +1,171 @@
+package python
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	"github.com/debricked/cli/internal/callgraph/model"
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+//go:embed embedded/callgraph.py
+var callGraphScript embed.FS
+
+const scriptName = "callgraph.py"
+
+type ICallgraphBuilder interface {
+	RunCallGraph() (string, error)
+}
+
+type CallgraphBuilder struct {
+	cmdFactory       ICmdFactory
+	filesystem       ioFs.IFileSystem
+	workingDirectory string
+	entryPoints      []string
+	python           string
+	outputName       string
+	ctx              cgexec.IContext
+}
+
+func NewCallgraphBuilder(
+	cmdFactory ICmdFactory,
+	workingDirectory string,
+	entryPoints []string,
+	python string,
+	outputName string,
+	filesystem ioFs.IFileSystem,
+	ctx cgexec.IContext,
+) CallgraphBuilder {
+	return CallgraphBuilder{
+		cmdFactory:       cmdFactory,
+		filesystem:       filesystem,
+		workingDirectory: workingDirectory,
+		entryPoints:      entryPoints,
+		python:           python,
+		outputName:       outputName,
+		ctx:              ctx,
+	}
+}
+
+// scriptNode and scriptEdge are the output of the embedded call graph script
+type scriptNode struct {
+	Symbol      string `json:"symbol"`
+	Name        string `json:"name"`
+	File        string `json:"file"`
+	Application bool   `json:"application"`
+	StdLib      bool   `json:"stdlib"`
+	LineStart   int    `json:"lineStart"`
+	LineEnd     int    `json:"lineEnd"`
+}
+
+type scriptEdge struct {
+	Caller string `json:"caller"`
+	Callee string `json:"callee"`
+	Line   int    `json:"line"`
+}
+
+type scriptOutput struct {
+	Nodes []scriptNode `json:"nodes"`
+	Edges []scriptEdge `json:"edges"`
+}
+
+func (cg *CallgraphBuilder) RunCallGraph() (string, error) {
+	tempDir, err := cg.filesystem.MkdirTemp(".debricked-python-callgraph")
+	if err != nil {
+		return "", err
+	}
+	defer cg.filesystem.RemoveAll(tempDir)
+
+	scriptPath, err := cg.writeScript(tempDir)
+	if err != nil {
+		return "", err
+	}
+
+	scriptOutputPath := filepath.Join(tempDir, "callgraph.json")
+	osCmd, err := cg.cmdFactory.MakeCallGraphGenerationCmd(
+		cg.workingDirectory,
+		cg.python,
+		scriptPath,
+		scriptOutputPath,
+		cg.entryPoints,
+		cg.ctx,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := cgexec.NewCommand(osCmd)
+	err = cgexec.RunCommand(*cmd, cg.ctx)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := cg.filesystem.ReadFile(scriptOutputPath)
+	if err != nil {
+		return "", err
+	}
+	callGraph, err := toCallGraph(content)
+	if err != nil {
+		return "", err
+	}
+
+	cgOutputBytes, err := callGraph.ToBytes()
+	if err != nil {
+		return "", err
+	}
+
+	outputFullPath := path.Join(cg.workingDirectory, cg.outputName)
+	err = cg.filesystem.FsWriteFile(outputFullPath, cgOutputBytes, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return outputFullPath, nil
+}
+
+func (cg *CallgraphBuilder) writeScript(tempDir string) (string, error) {
+	script, err := cg.filesystem.FsOpenEmbed(callGraphScript, "embedded/"+scriptName)
+	if err != nil {
+		return "", err
+	}
+	defer cg.filesystem.FsCloseFile(script)
+
+	scriptBytes, err := cg.filesystem.FsReadAll(script)
+	if err != nil {
+		return "", err
+	}
+
+	scriptPath := filepath.Join(tempDir, scriptName)
+	err = cg.filesystem.FsWriteFile(scriptPath, scriptBytes, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(scriptPath)
+}
+
+// toCallGraph converts the output of the call graph script to the call graph model
+func toCallGraph(content []byte) (*model.CallGraph, error) {
+	var output scriptOutput
+	if err := json.Unmarshal(content, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse python call graph: %w", err)
+	}
+
+	callGraph := model.NewCallGraph()
+	for _, node := range output.Nodes {
+		callGraph.AddNode(node.File, node.Name, node.Symbol, node.Application, node.StdLib, node.LineStart, node.LineEnd)
+	}
+	for _, edge := range output.Edges {
+		caller, callee := callGraph.GetNode(edge.Caller), callGraph.GetNode(edge.Callee)
+		if caller == nil || callee == nil {
+			return nil, fmt.Errorf("failed to parse python call graph: edge %s -> %s has no node", edge.Caller, edge.Callee)
+		}
+		callGraph.AddEdge(caller, callee, edge.Line)
+	}
+
+	return callGraph, nil
+}
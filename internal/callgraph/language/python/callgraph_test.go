@@ -0,0 +1,99 @@
+package python
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/language/python/testdata"
+	"github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func skipWithoutPython(t *testing.T) {
+	t.Helper()
+	if _, _, err := lookPython(); err != nil {
+		t.Skip("python is not installed")
+	}
+}
+
+func TestRunCallGraph(t *testing.T) {
+	skipWithoutPython(t)
+	fixture := filepath.Join("testdata", "fixture")
+	outputName := "debricked-call-graph.python-test"
+	defer os.Remove(filepath.Join(fixture, outputName))
+
+	ctx, _ := ctxTestdata.NewContextMock()
+	cg := NewCallgraphBuilder(CmdFactory{}, fixture, []string{"app.py"}, "", outputName, io.FileSystem{}, ctx)
+	outputPath, err := cg.RunCallGraph()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(fixture, outputName), outputPath)
+
+	content, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	output := string(content)
+	assert.Contains(t, output, `["app.main", true, false, "main", "app.py", 7, 10, [["app.<module>", 14, "app.py"]]]`)
+	assert.Contains(t, output, `["lib.models.Model.describe", true, false, "describe", "lib/models.py", 10, 11, [["app.main", 9, "app.py"]]]`)
+	assert.Contains(t, output, `["lib.helper.shout", true, false, "shout", "lib/helper.py", 1, 2, [["app.main", 9, "app.py"]]]`)
+	assert.Contains(t, output, `["json.dumps", false, true, "dumps", "json/__init__.py"`)
+	assert.NotContains(t, output, "lib.helper.unused")
+}
+
+func TestRunCallGraphErrors(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmdFactory := testdata.NewEchoCmdFactory()
+
+	fsMock := ioTestData.FileSystemMock{MkdirTempError: assert.AnError}
+	cg := NewCallgraphBuilder(cmdFactory, "dir", []string{"app.py"}, "", outputName, fsMock, ctx)
+	_, err := cg.RunCallGraph()
+	assert.ErrorIs(t, err, assert.AnError)
+
+	fsMock = ioTestData.FileSystemMock{FsWriteFileError: assert.AnError}
+	cg = NewCallgraphBuilder(cmdFactory, "dir", []string{"app.py"}, "", outputName, fsMock, ctx)
+	_, err = cg.RunCallGraph()
+	assert.ErrorIs(t, err, assert.AnError)
+
+	cmdFactory.CallGraphGenErr = assert.AnError
+	cg = NewCallgraphBuilder(cmdFactory, "dir", []string{"app.py"}, "", outputName, io.FileSystem{}, ctx)
+	_, err = cg.RunCallGraph()
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestRunCallGraphScriptError(t *testing.T) {
+	if _, err := exec.LookPath("false"); err != nil {
+		t.Skip("false is not installed")
+	}
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmdFactory := testdata.CmdFactoryMock{CallGraphGenName: "false"}
+	cg := NewCallgraphBuilder(cmdFactory, ".", []string{"app.py"}, "", outputName, io.FileSystem{}, ctx)
+	_, err := cg.RunCallGraph()
+	assert.Error(t, err)
+}
+
+func TestToCallGraph(t *testing.T) {
+	content := `{"nodes": [
+		{"symbol": "app.<module>", "name": "<module>", "file": "app.py", "application": true, "stdlib": false, "lineStart": 1, "lineEnd": 3},
+		{"symbol": "builtins.print", "name": "print", "file": "", "application": false, "stdlib": true, "lineStart": -1, "lineEnd": -1}
+	], "edges": [{"caller": "app.<module>", "callee": "builtins.print", "line": 2}]}`
+	callGraph, err := toCallGraph([]byte(content))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, callGraph.NodeCount())
+	assert.Equal(t, 1, callGraph.EdgeCount())
+
+	print := callGraph.GetNode("builtins.print")
+	assert.True(t, print.IsStdLibNode)
+	assert.False(t, print.IsApplicationNode)
+	assert.Equal(t, "app.<module>", print.Parents[0].Parent.Symbol)
+	assert.Equal(t, 2, print.Parents[0].CallLine)
+}
+
+func TestToCallGraphErrors(t *testing.T) {
+	_, err := toCallGraph([]byte("{"))
+	assert.ErrorContains(t, err, "failed to parse python call graph")
+
+	_, err = toCallGraph([]byte(`{"nodes": [], "edges": [{"caller": "a", "callee": "b", "line": 1}]}`))
+	assert.ErrorContains(t, err, "edge a -> b has no node")
+}
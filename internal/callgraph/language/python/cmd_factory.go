@@ -0,0 +1,100 @@
+package python
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	"github.com/debricked/cli/internal/runtime/os"
+)
+
+type ICmdFactory interface {
+	MakeCreateVenvCmd(workingDirectory string, venvPath string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeInstallCmd(workingDirectory string, python string, requirementsFile string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeCallGraphGenerationCmd(
+		workingDirectory string,
+		python string,
+		scriptPath string,
+		outputPath string,
+		entryPoints []string,
+		ctx cgexec.IContext,
+	) (*exec.Cmd, error)
+}
+
+type CmdFactory struct{}
+
+// lookPython returns the path of python3, or of python if python3 isn't installed
+func lookPython() (string, string, error) {
+	path, err := exec.LookPath("python3")
+	if err != nil && strings.Contains(err.Error(), "executable file not found in ") {
+		path, err = exec.LookPath("python")
+
+		return path, "python", err
+	}
+
+	return path, "python3", err
+}
+
+// VenvPython returns the path of the interpreter of the virtual environment at venvPath
+func VenvPython(venvPath string) string {
+	if runtime.GOOS == os.Windows {
+		return filepath.Join(venvPath, "Scripts", "python.exe")
+	}
+
+	return filepath.Join(venvPath, "bin", "python")
+}
+
+func (_ CmdFactory) MakeCreateVenvCmd(workingDirectory string, venvPath string, ctx cgexec.IContext) (*exec.Cmd, error) {
+	path, command, err := lookPython()
+	args := []string{
+		command,
+		"-m",
+		"venv",
+		venvPath,
+		"--clear",
+		"--system-site-packages",
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+func (_ CmdFactory) MakeInstallCmd(workingDirectory string, python string, requirementsFile string, ctx cgexec.IContext) (*exec.Cmd, error) {
+	path, err := exec.LookPath(python)
+	args := []string{
+		python,
+		"-m",
+		"pip",
+		"install",
+		"-q",
+		"-r",
+		requirementsFile,
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+// MakeCallGraphGenerationCmd runs the call graph script with python, which is the interpreter of the project
+// environment, or the interpreter on PATH if python is empty
+func (_ CmdFactory) MakeCallGraphGenerationCmd(
+	workingDirectory string,
+	python string,
+	scriptPath string,
+	outputPath string,
+	entryPoints []string,
+	ctx cgexec.IContext,
+) (*exec.Cmd, error) {
+	var path string
+	var err error
+	command := python
+	if len(python) == 0 {
+		path, command, err = lookPython()
+	} else {
+		path, err = exec.LookPath(python)
+	}
+	// The project is the working directory of the script
+	args := append([]string{command, scriptPath, ".", outputPath}, entryPoints...)
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
@@ -0,0 +1,44 @@
+package python
+
+import (
+	"path/filepath"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeCreateVenvCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, err := CmdFactory{}.MakeCreateVenvCmd(dir, "requirements.txt.venv", ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Contains(t, args, "venv")
+	assert.Contains(t, args, "requirements.txt.venv")
+	assert.Contains(t, args, "--system-site-packages")
+	assert.Equal(t, dir, cmd.Dir)
+}
+
+func TestMakeInstallCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, _ := CmdFactory{}.MakeInstallCmd(dir, "python3", "requirements.txt", ctx)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"python3", "-m", "pip", "install", "-q", "-r", "requirements.txt"}, cmd.Args)
+}
+
+func TestMakeCallGraphGenerationCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, err := CmdFactory{}.MakeCallGraphGenerationCmd(dir, "", "callgraph.py", "output.json", []string{"app.py"}, ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"callgraph.py", ".", "output.json", "app.py"}, cmd.Args[1:])
+
+	cmd, _ = CmdFactory{}.MakeCallGraphGenerationCmd(dir, "python3", "callgraph.py", "output.json", []string{"app.py"}, ctx)
+	assert.Equal(t, "python3", cmd.Args[0])
+}
+
+func TestVenvPython(t *testing.T) {
+	python := VenvPython("venv")
+	assert.Contains(t, []string{filepath.Join("venv", "bin", "python"), filepath.Join("venv", "Scripts", "python.exe")}, python)
+}
@@ -0,0 +1,452 @@
+"""Static call graph of a Python project.
+
+Starting from the entry point modules, the bodies of reachable functions are parsed with `ast` and their calls are
+resolved through the imports of each module. Modules are looked up like the interpreter running this script would,
+so dependencies are found in the site-packages of its environment. Standard library functions are leaves.
+
+Usage: python callgraph.py <project dir> <output file> <entry point>...
+"""
+
+import ast
+import builtins
+import importlib.machinery
+import json
+import os
+import sys
+import sysconfig
+
+MODULE_SCOPE = "<module>"
+INIT = "__init__"
+
+
+def real_path(path):
+    return os.path.normcase(os.path.realpath(path))
+
+
+PATHS = sysconfig.get_paths()
+STDLIB_DIRS = [real_path(PATHS[key]) for key in ("stdlib", "platstdlib") if key in PATHS]
+SITE_DIRS = [real_path(PATHS[key]) for key in ("purelib", "platlib") if key in PATHS]
+
+
+def is_within(path, dirs):
+    return any(path == d or path.startswith(d + os.sep) for d in dirs)
+
+
+def is_site_package(path):
+    # Environments with system site packages also see the site-packages of the base interpreter
+    parts = path.split(os.sep)
+
+    return is_within(path, SITE_DIRS) or "site-packages" in parts or "dist-packages" in parts
+
+
+def package_root(path):
+    """Returns the directory containing the outermost package of the module at path, and the module name parts"""
+    search_dir, parts = os.path.dirname(path), [os.path.splitext(os.path.basename(path))[0]]
+    if parts[0] == INIT:
+        parts = []
+    while os.path.isfile(os.path.join(search_dir, INIT + ".py")) and os.path.dirname(search_dir) != search_dir:
+        parts.insert(0, os.path.basename(search_dir))
+        search_dir = os.path.dirname(search_dir)
+
+    return search_dir, parts
+
+
+STDLIB_MODULES = set(getattr(sys, "stdlib_module_names", ())) | set(sys.builtin_module_names)
+
+
+class Function:
+    """A function, method or module body of a module"""
+
+    def __init__(self, module, qualname, node, class_name=None):
+        self.module = module
+        self.qualname = qualname
+        self.node = node
+        self.class_name = class_name
+        self.nested = {}
+
+    @property
+    def symbol(self):
+        return self.module.name + "." + self.qualname
+
+    def lines(self):
+        if self.qualname == MODULE_SCOPE:
+            return 1, self.module.line_count
+
+        return self.node.lineno, getattr(self.node, "end_lineno", self.node.lineno)
+
+    def statements(self):
+        if self.qualname != MODULE_SCOPE:
+            return self.node.body
+        # The body of the module and its classes runs on import
+        statements = []
+        pending = list(self.node.body)
+        while pending:
+            statement = pending.pop(0)
+            if isinstance(statement, ast.ClassDef):
+                pending[0:0] = statement.body
+                statements.extend(statement.decorator_list)
+                statements.extend(statement.bases)
+            elif isinstance(statement, (ast.FunctionDef, ast.AsyncFunctionDef)):
+                statements.extend(statement.decorator_list)
+            else:
+                statements.append(statement)
+
+        return statements
+
+
+class Module:
+    def __init__(self, name, path, search_dir, kind):
+        self.name = name
+        self.path = path
+        self.search_dir = search_dir
+        self.kind = kind
+        self.package = name if os.path.basename(path) == INIT + ".py" else name.rpartition(".")[0]
+        self.functions = {}
+        self.classes = set()
+        self.imports = {}
+        self.line_count = 0
+        self.parsed = False
+
+    def parse(self):
+        if self.parsed:
+            return
+        self.parsed = True
+        try:
+            with open(self.path, "rb") as source:
+                content = source.read()
+            tree = ast.parse(content, filename=self.path)
+        except (OSError, SyntaxError, ValueError):
+            tree = ast.Module(body=[], type_ignores=[])
+            content = b""
+        self.line_count = content.count(b"\n") + 1
+        self.functions[MODULE_SCOPE] = Function(self, MODULE_SCOPE, tree)
+        self._index(tree.body, "", None, None)
+
+    def _index(self, body, prefix, class_name, parent):
+        for statement in body:
+            if isinstance(statement, (ast.FunctionDef, ast.AsyncFunctionDef)):
+                qualname = prefix + statement.name
+                function = Function(self, qualname, statement, class_name)
+                self.functions[qualname] = function
+                if parent is not None:
+                    parent.nested[statement.name] = qualname
+                self._index(statement.body, qualname + ".<locals>.", None, function)
+            elif isinstance(statement, ast.ClassDef):
+                qualname = prefix + statement.name
+                self.classes.add(qualname)
+                self._index(statement.body, qualname + ".", qualname, parent)
+            elif isinstance(statement, ast.Import):
+                for alias in statement.names:
+                    if alias.asname:
+                        self.imports[alias.asname] = alias.name
+                    else:
+                        top = alias.name.split(".")[0]
+                        self.imports[top] = top
+            elif isinstance(statement, ast.ImportFrom):
+                base = self._import_base(statement)
+                for alias in statement.names:
+                    if alias.name != "*" and base:
+                        self.imports[alias.asname or alias.name] = base + "." + alias.name
+            else:
+                for field in ("body", "orelse", "finalbody", "handlers"):
+                    nested = getattr(statement, field, None)
+                    if isinstance(nested, list):
+                        self._index(nested, prefix, class_name, parent)
+
+    def _import_base(self, statement):
+        if not statement.level:
+            return statement.module
+        parts = self.package.split(".") if self.package else []
+        if statement.level > 1:
+            parts = parts[: -(statement.level - 1)]
+        if statement.module:
+            parts.append(statement.module)
+
+        return ".".join(parts)
+
+
+class Resolver:
+    def __init__(self, project_dir, entry_points):
+        self.project_dir = real_path(project_dir)
+        search_dirs = [self.project_dir]
+        src_dir = os.path.join(self.project_dir, "src")
+        if os.path.isdir(src_dir):
+            search_dirs.append(src_dir)
+        for entry_point in entry_points:
+            search_dirs.append(package_root(real_path(entry_point))[0])
+        # The first entry of sys.path is the directory of this script
+        search_dirs.extend(real_path(path) for path in sys.path[1:] if path)
+        self.search_dirs = list(dict.fromkeys(search_dirs))
+        self.modules = {}
+        self.namespaces = {}
+        self.missing = set()
+
+    def module_kind(self, path):
+        if is_site_package(path):
+            return "dependency"
+        if is_within(path, [self.project_dir]):
+            return "application"
+        if is_within(path, STDLIB_DIRS):
+            return "stdlib"
+
+        return "dependency"
+
+    def search_dir(self, path):
+        matches = [d for d in self.search_dirs if is_within(path, [d])]
+
+        return max(matches, key=len) if matches else os.path.dirname(path)
+
+    def find(self, name):
+        """Returns the module of the dotted name, or None if it can't be found or isn't Python source"""
+        if name in self.modules:
+            return self.modules[name]
+        if name in self.missing or not name:
+            return None
+        parent, _, child = name.rpartition(".")
+        paths = self.search_dirs
+        if parent:
+            self.find(parent)
+            paths = self._package_paths(parent)
+            if paths is None:
+                self.missing.add(name)
+
+                return None
+        spec = importlib.machinery.PathFinder.find_spec(child, paths)
+        module = None
+        if spec is not None and spec.origin and spec.origin.endswith(".py"):
+            path = real_path(spec.origin)
+            module = Module(name, path, self.search_dir(path), self.module_kind(path))
+        elif spec is not None and spec.submodule_search_locations is not None:
+            # Namespace packages have no source of their own
+            self.namespaces[name] = list(spec.submodule_search_locations)
+        if module is None:
+            self.missing.add(name)
+        else:
+            self.modules[name] = module
+
+        return module
+
+    def _package_paths(self, name):
+        if name in self.namespaces:
+            return self.namespaces[name]
+        module = self.modules.get(name)
+        if module is None or os.path.basename(module.path) != INIT + ".py":
+            return None
+
+        return [os.path.dirname(module.path)]
+
+    def module_for_file(self, path):
+        """Returns the module of an entry point, named by the packages it is located in"""
+        path = real_path(path)
+        search_dir, parts = package_root(path)
+        name = ".".join(parts) or os.path.basename(os.path.dirname(path))
+        if name not in self.modules:
+            self.modules[name] = Module(name, path, search_dir, self.module_kind(path))
+
+        return self.modules[name]
+
+    def resolve(self, dotted, depth=0):
+        """Resolves a dotted reference to the module defining it and the qualified name within the module"""
+        parts = dotted.split(".")
+        for i in range(len(parts) - 1, 0, -1):
+            module = self.find(".".join(parts[:i]))
+            if module is None:
+                continue
+            module.parse()
+            attribute = parts[i:]
+            qualname = ".".join(attribute)
+            if qualname in module.functions or qualname in module.classes:
+                return module, qualname
+            if module.kind != "stdlib" and attribute[0] in module.imports and depth < 8:
+                # Follow re-exports, such as functions imported in the __init__ of packages
+                target = ".".join([module.imports[attribute[0]]] + attribute[1:])
+                resolved = self.resolve(target, depth + 1)
+                if resolved is not None:
+                    return resolved
+
+            return module, qualname
+
+        return None
+
+
+class CallGraph:
+    def __init__(self, resolver):
+        self.resolver = resolver
+        self.nodes = {}
+        self.edges = set()
+        self.pending = []
+
+    def add_function(self, function):
+        symbol = function.symbol
+        if symbol not in self.nodes:
+            start, end = function.lines()
+            self.nodes[symbol] = self._node(function.module, function.qualname.rpartition(".")[2], start, end)
+            if function.module.kind != "stdlib":
+                self.pending.append(function)
+
+        return symbol
+
+    def add_symbol(self, module, qualname):
+        module.parse()
+        if qualname in module.classes:
+            # Calling a class calls its constructor
+            qualname = qualname + "." + INIT if qualname + "." + INIT in module.functions else qualname
+        function = module.functions.get(qualname)
+        if function is not None:
+            return self.add_function(function)
+        symbol = module.name + "." + qualname
+        if symbol not in self.nodes:
+            self.nodes[symbol] = self._node(module, qualname.rpartition(".")[2], -1, -1)
+
+        return symbol
+
+    def add_stdlib(self, symbol):
+        """Adds a standard library function without Python source, such as the builtins"""
+        if symbol not in self.nodes:
+            self.nodes[symbol] = {
+                "name": symbol.rpartition(".")[2],
+                "file": "",
+                "application": False,
+                "stdlib": True,
+                "lineStart": -1,
+                "lineEnd": -1,
+            }
+
+        return symbol
+
+    def _node(self, module, name, start, end):
+        if module.kind == "application":
+            filename = os.path.relpath(module.path, self.resolver.project_dir)
+        else:
+            filename = os.path.relpath(module.path, module.search_dir)
+
+        return {
+            "name": name,
+            "file": filename.replace(os.sep, "/"),
+            "application": module.kind == "application",
+            "stdlib": module.kind == "stdlib",
+            "lineStart": start,
+            "lineEnd": end,
+        }
+
+    def build(self, entry_points):
+        for entry_point in entry_points:
+            module = self.resolver.module_for_file(entry_point)
+            module.parse()
+            self.add_function(module.functions[MODULE_SCOPE])
+        while self.pending:
+            function = self.pending.pop()
+            for callee, line in self.calls(function):
+                self.edges.add((function.symbol, callee, line))
+
+    def calls(self, function):
+        instances = self.instances(function)
+        pending = list(function.statements())
+        while pending:
+            node = pending.pop()
+            if isinstance(node, (ast.FunctionDef, ast.AsyncFunctionDef)):
+                # Nested functions are analysed when they are called, their decorators run on definition
+                pending.extend(node.decorator_list)
+                continue
+            if isinstance(node, ast.ClassDef):
+                pending.extend(node.decorator_list)
+                pending.extend(node.bases)
+                continue
+            if isinstance(node, ast.Call):
+                callee = self.callee(function, node.func, instances)
+                if callee is not None:
+                    yield callee, node.lineno
+            pending.extend(ast.iter_child_nodes(node))
+
+    def instances(self, function):
+        """Returns the classes of local variables assigned an instance, such as model = Model()"""
+        instances = {}
+        for node in ast.walk(ast.Module(body=list(function.statements()), type_ignores=[])):
+            if not isinstance(node, ast.Assign) or not isinstance(node.value, ast.Call):
+                continue
+            target = self.reference(function, node.value.func, {})
+            if target is None or target[0] is None:
+                continue
+            module, qualname = target
+            module.parse()
+            if qualname not in module.classes:
+                continue
+            for name in node.targets:
+                if isinstance(name, ast.Name):
+                    instances[name.id] = target
+
+        return instances
+
+    def callee(self, function, expression, instances):
+        target = self.reference(function, expression, instances)
+        if target is None:
+            return None
+        module, qualname = target
+        if module is None:
+            return self.add_stdlib(qualname)
+
+        return self.add_symbol(module, qualname)
+
+    def reference(self, function, expression, instances):
+        """Resolves a called expression to its module and qualified name. Standard library functions without
+        source are returned without module, as a symbol"""
+        parts = []
+        while isinstance(expression, ast.Attribute):
+            parts.insert(0, expression.attr)
+            expression = expression.value
+        if not isinstance(expression, ast.Name):
+            return None
+        parts.insert(0, expression.id)
+        first, module = parts[0], function.module
+        if first in ("self", "cls") and function.class_name is not None and len(parts) > 1:
+            return module, ".".join([function.class_name] + parts[1:])
+        if first in instances and len(parts) > 1:
+            instance_module, class_name = instances[first]
+
+            return instance_module, ".".join([class_name] + parts[1:])
+        if first in function.nested and len(parts) == 1:
+            return module, function.nested[first]
+        if first in module.functions or first in module.classes:
+            return module, ".".join(parts)
+        if first in module.imports:
+            target = ".".join([module.imports[first]] + parts[1:])
+            resolved = self.resolver.resolve(target)
+            if resolved is not None:
+                return resolved
+            if target.split(".")[0] in STDLIB_MODULES:
+                return None, target
+
+            return None
+        if len(parts) == 1 and hasattr(builtins, first):
+            return None, "builtins." + first
+
+        return None
+
+    def to_json(self):
+        nodes = []
+        for symbol, node in sorted(self.nodes.items()):
+            node = dict(node)
+            node["symbol"] = symbol
+            nodes.append(node)
+        edges = [{"caller": caller, "callee": callee, "line": line} for caller, callee, line in sorted(self.edges)]
+
+        return {"nodes": nodes, "edges": edges}
+
+
+def main(arguments):
+    if len(arguments) < 3:
+        sys.stderr.write(__doc__)
+
+        return 2
+    project_dir, output_file, entry_points = arguments[0], arguments[1], arguments[2:]
+    entry_points = [os.path.join(project_dir, entry_point) for entry_point in entry_points]
+    call_graph = CallGraph(Resolver(project_dir, entry_points))
+    call_graph.build(entry_points)
+    with open(output_file, "w", encoding="utf-8") as output:
+        json.dump(call_graph.to_json(), output)
+
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main(sys.argv[1:]))
@@ -0,0 +1,180 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/io"
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+const (
+	outputName       = "debricked-call-graph.python"
+	requirementsFile = "requirements.txt"
+	venvConfig       = "pyvenv.cfg"
+)
+
+type Job struct {
+	job.BaseJob
+	entryPoints []string
+	cmdFactory  ICmdFactory
+	config      conf.IConfig
+	archive     io.IArchive
+	ctx         cgexec.IContext
+	fs          ioFs.IFileSystem
+}
+
+// NewJob creates a job generating the call graph of the project in dir, from entry points relative to dir
+func NewJob(
+	dir string,
+	entryPoints []string,
+	cmdFactory ICmdFactory,
+	writer ioFs.IFileWriter,
+	archive io.IArchive,
+	config conf.IConfig,
+	ctx cgexec.IContext,
+	fs ioFs.IFileSystem,
+) *Job {
+	return &Job{
+		BaseJob:     job.NewBaseJob(dir, entryPoints),
+		entryPoints: entryPoints,
+		cmdFactory:  cmdFactory,
+		config:      config,
+		archive:     archive,
+		ctx:         ctx,
+		fs:          fs,
+	}
+}
+
+func (j *Job) Run() {
+	workingDirectory := j.GetDir()
+	python, venvPath := j.setupEnvironment(workingDirectory)
+	if len(venvPath) > 0 {
+		defer j.fs.RemoveAll(venvPath)
+	}
+	if j.Errors().HasError() {
+
+		return
+	}
+
+	callgraph := NewCallgraphBuilder(
+		j.cmdFactory,
+		workingDirectory,
+		j.entryPoints,
+		python,
+		outputName,
+		j.fs,
+		j.ctx,
+	)
+	j.SendStatus("generating call graph")
+	j.runCallGraph(&callgraph)
+}
+
+// setupEnvironment returns the interpreter with the dependencies of the project installed. If the project is built,
+// requirements are installed in a new virtual environment, like the pip resolver does, which is returned to be removed
+// after generation. Otherwise an existing environment of the project is used, falling back to the python on PATH
+func (j *Job) setupEnvironment(workingDirectory string) (string, string) {
+	absDirectory, err := filepath.Abs(workingDirectory)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return "", ""
+	}
+
+	requirements := filepath.Join(absDirectory, requirementsFile)
+	if _, err = j.fs.Stat(requirements); j.config.Build() && err == nil {
+		venvPath := requirements + ".venv"
+
+		return j.createVenv(workingDirectory, venvPath, requirements), venvPath
+	}
+
+	if venv := findVenv(j.fs, absDirectory); len(venv) > 0 {
+		return VenvPython(venv), ""
+	}
+
+	return "", ""
+}
+
+func (j *Job) createVenv(workingDirectory string, venvPath string, requirements string) string {
+	j.SendStatus("creating venv")
+	osCmd, err := j.cmdFactory.MakeCreateVenvCmd(workingDirectory, venvPath, j.ctx)
+	if err == nil {
+		err = cgexec.RunCommand(*cgexec.NewCommand(osCmd), j.ctx)
+	}
+	if err != nil {
+		j.Errors().Critical(fmt.Errorf("failed to create venv: %w", err))
+
+		return ""
+	}
+
+	python := VenvPython(venvPath)
+	j.SendStatus("installing requirements")
+	osCmd, err = j.cmdFactory.MakeInstallCmd(workingDirectory, python, requirements, j.ctx)
+	if err == nil {
+		err = cgexec.RunCommand(*cgexec.NewCommand(osCmd), j.ctx)
+	}
+	if err != nil {
+		j.Errors().Critical(fmt.Errorf("failed to install %s: %w", requirementsFile, err))
+
+		return ""
+	}
+
+	return python
+}
+
+// findVenv returns the virtual environment in the project dir, such as .venv, or an empty string if there is none
+func findVenv(fs ioFs.IFileSystem, dir string) string {
+	for _, name := range []string{".venv", "venv", ".env", "env"} {
+		venv := filepath.Join(dir, name)
+		if _, err := fs.Stat(filepath.Join(venv, venvConfig)); err == nil {
+			return venv
+		}
+	}
+
+	return ""
+}
+
+func (j *Job) runCallGraph(callgraph ICallgraphBuilder) {
+	outputFullPath, err := callgraph.RunCallGraph()
+
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+	outputFullPathZip := outputFullPath + ".zip"
+
+	j.SendStatus("zipping callgraph")
+	err = j.archive.ZipFile(outputFullPath, outputFullPathZip, outputName)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+
+	j.SendStatus("base64 encoding zipped callgraph")
+	err = j.archive.B64(outputFullPathZip, outputFullPath)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+
+	j.SendStatus("cleanup")
+	err = j.archive.Cleanup(outputFullPathZip)
+	if err != nil {
+		e, ok := err.(*os.PathError)
+		if ok && e.Err == syscall.ENOENT {
+			return
+		} else {
+			j.Errors().Critical(err)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	jobTestdata "github.com/debricked/cli/internal/callgraph/job/testdata"
+	"github.com/debricked/cli/internal/callgraph/language/python/testdata"
+	"github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+const dir = "dir"
+
+func TestNewJob(t *testing.T) {
+	config := conf.Config{}
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	j := NewJob(dir, []string{"app.py"}, CmdFactory{}, io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	assert.Equal(t, []string{"app.py"}, j.GetFiles())
+	assert.Equal(t, dir, j.GetDir())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	skipWithoutPython(t)
+	fixture := filepath.Join("testdata", "fixture")
+	output := filepath.Join(fixture, outputName)
+	defer os.Remove(output)
+
+	for _, build := range []bool{true, false} {
+		t.Run(fmt.Sprintf("build %t", build), func(t *testing.T) {
+			config := conf.NewConfig("python", nil, nil, build, "pip", "")
+			ctx, _ := ctxTestdata.NewContextMock()
+			j := NewJob(fixture, []string{"app.py"}, CmdFactory{}, io.FileWriter{}, io.NewArchive("."), config, ctx, io.FileSystem{})
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			assert.Empty(t, j.Errors().GetAll())
+			_, err := os.Stat(output)
+			assert.NoError(t, err)
+			_, err = os.Stat(filepath.Join(fixture, requirementsFile+".venv"))
+			assert.True(t, os.IsNotExist(err), "venv should be removed")
+		})
+	}
+}
+
+func TestRunVenvErrors(t *testing.T) {
+	fixture := filepath.Join("testdata", "fixture")
+	config := conf.NewConfig("python", nil, nil, true, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	cmdFactory := testdata.NewEchoCmdFactory()
+	cmdFactory.CreateVenvErr = assert.AnError
+	j := NewJob(fixture, []string{"app.py"}, cmdFactory, io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+	assert.ErrorContains(t, j.Errors().GetCriticalErrors()[0], "failed to create venv")
+
+	cmdFactory = testdata.NewEchoCmdFactory()
+	cmdFactory.InstallErr = assert.AnError
+	j = NewJob(fixture, []string{"app.py"}, cmdFactory, io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+	assert.ErrorContains(t, j.Errors().GetCriticalErrors()[0], "failed to install requirements.txt")
+}
+
+func TestFindVenv(t *testing.T) {
+	project := t.TempDir()
+	assert.Empty(t, findVenv(io.FileSystem{}, project))
+
+	venv := filepath.Join(project, ".venv")
+	assert.NoError(t, os.Mkdir(venv, 0700))
+	assert.Empty(t, findVenv(io.FileSystem{}, project))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(venv, venvConfig), []byte{}, 0600))
+	assert.Equal(t, venv, findVenv(io.FileSystem{}, project))
+}
+
+func TestRunCallgraphMockError(t *testing.T) {
+	config := conf.NewConfig("python", nil, nil, false, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	callgraphMock := testdata.CallgraphMock{RunCallGraphError: fmt.Errorf("error")}
+
+	j := NewJob(dir, []string{"app.py"}, CmdFactory{}, io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	j.runCallGraph(callgraphMock)
+
+	assert.True(t, j.Errors().HasError())
+}
+
+func TestRunPostProcessErrors(t *testing.T) {
+	cases := map[string]ioTestData.ArchiveMock{
+		"zip":     {ZipFileError: fmt.Errorf("error")},
+		"b64":     {B64Error: fmt.Errorf("error")},
+		"cleanup": {CleanupError: fmt.Errorf("error")},
+	}
+	for name, archiveMock := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := conf.NewConfig("python", nil, nil, false, "pip", "")
+			ctx, _ := ctxTestdata.NewContextMock()
+
+			j := NewJob(dir, []string{"app.py"}, CmdFactory{}, io.FileWriter{}, archiveMock, config, ctx, io.FileSystem{})
+			go jobTestdata.WaitStatus(j)
+			j.runCallGraph(testdata.CallgraphMock{})
+
+			assert.True(t, j.Errors().HasError())
+		})
+	}
+}
+
+func TestRunPostProcessCleanupNoFileExistError(t *testing.T) {
+	config := conf.NewConfig("python", nil, nil, false, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	err := &os.PathError{}
+	err.Err = syscall.ENOENT
+	archiveMock := ioTestData.ArchiveMock{CleanupError: err}
+
+	j := NewJob(dir, []string{"app.py"}, CmdFactory{}, io.FileWriter{}, archiveMock, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.runCallGraph(testdata.CallgraphMock{})
+
+	assert.False(t, j.Errors().HasError())
+}
@@ -0,0 +1,121 @@
+package python
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder"
+	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/io"
+	"github.com/fatih/color"
+)
+
+// projectFiles mark the root directory of a Python project
+var projectFiles = []string{requirementsFile, "setup.py", "setup.cfg", "pyproject.toml"}
+
+type Strategy struct {
+	config     conf.IConfig
+	cmdFactory ICmdFactory
+	paths      []string
+	exclusions []string
+	inclusions []string
+	finder     finder.IFinder
+	ctx        cgexec.IContext
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+
+	if s.config == nil {
+		strategyWarning("No config is setup")
+
+		return jobs, nil
+	}
+
+	for _, path := range s.paths {
+		files, err := s.finder.FindFiles([]string{path}, s.exclusions, s.inclusions)
+		if err != nil {
+			strategyWarning("Error while finding files: " + err.Error())
+
+			return jobs, err
+		}
+
+		roots, err := s.finder.FindRoots(files)
+		if err != nil {
+			strategyWarning("Error while finding roots: " + err.Error())
+
+			return jobs, err
+		}
+
+		projects := groupByProject(path, roots)
+		projectDirs := make([]string, 0, len(projects))
+		for projectDir := range projects {
+			projectDirs = append(projectDirs, projectDir)
+		}
+		sort.Strings(projectDirs)
+
+		for _, projectDir := range projectDirs {
+			jobs = append(jobs, NewJob(
+				projectDir,
+				projects[projectDir],
+				s.cmdFactory,
+				io.FileWriter{},
+				io.NewArchive("."),
+				s.config,
+				s.ctx,
+				io.FileSystem{},
+			),
+			)
+		}
+	}
+
+	return jobs, nil
+}
+
+// groupByProject maps the project directories of roots to the roots within them, relative to the project directory.
+// The project directory of a root is the closest directory containing a project file, or the directory of the root
+func groupByProject(path string, roots []string) map[string][]string {
+	projects := map[string][]string{}
+	for _, root := range roots {
+		projectDir := findProjectDir(path, filepath.Dir(root))
+		entryPoint, err := filepath.Rel(projectDir, root)
+		if err != nil {
+			entryPoint = root
+		}
+		projects[projectDir] = append(projects[projectDir], entryPoint)
+	}
+
+	return projects
+}
+
+func findProjectDir(path string, dir string) string {
+	for current := dir; ; current = filepath.Dir(current) {
+		for _, projectFile := range projectFiles {
+			if _, err := os.Stat(filepath.Join(current, projectFile)); err == nil {
+				return current
+			}
+		}
+		rel, err := filepath.Rel(path, current)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") || filepath.Dir(current) == current {
+			return dir
+		}
+	}
+}
+
+func NewStrategy(config conf.IConfig, paths []string, exclusions []string, inclusions []string, finder finder.IFinder, ctx cgexec.IContext) Strategy {
+	return Strategy{config, CmdFactory{}, paths, exclusions, inclusions, finder, ctx}
+}
+
+func strategyWarning(errMsg string) {
+	err := fmt.Errorf("%s", errMsg)
+	warningColor := color.New(color.FgYellow, color.Bold).SprintFunc()
+	defaultOutputWriter := log.Writer()
+	log.Println(warningColor("Warning: ") + err.Error())
+	log.SetOutput(defaultOutputWriter)
+}
@@ -0,0 +1,88 @@
+package python
+
+import (
+	"path/filepath"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, nil, nil, nil, nil, nil)
+	assert.NotNil(t, s)
+
+	conf := config.NewConfig("python", []string{"arg1"}, map[string]string{"kwarg": "val"}, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	ctx, _ := ctxTestdata.NewContextMock()
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	assert.NotNil(t, s)
+	assert.Equal(t, s.config, conf)
+}
+
+func TestInvokeNoConfig(t *testing.T) {
+	s := NewStrategy(nil, []string{}, []string{}, []string{}, nil, nil)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeGroupsRootsByProject(t *testing.T) {
+	conf := config.NewConfig("python", nil, nil, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindRootsNames = []string{
+		filepath.Join("testdata", "fixture", "app.py"),
+		filepath.Join("testdata", "fixture", "lib", "helper.py"),
+		filepath.Join("testdata", "script.py"),
+	}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"testdata"}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	assert.Equal(t, "testdata", jobs[0].GetDir())
+	assert.Equal(t, []string{"script.py"}, jobs[0].GetFiles())
+	assert.Equal(t, filepath.Join("testdata", "fixture"), jobs[1].GetDir())
+	assert.Equal(t, []string{"app.py", filepath.Join("lib", "helper.py")}, jobs[1].GetFiles())
+}
+
+func TestInvokeWithErrors(t *testing.T) {
+	conf := config.NewConfig("python", nil, nil, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindRootsNames = []string{"app.py"}
+	finder.FindRootsErr = assert.AnError
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+
+	finder.FindRootsErr = nil
+	finder.FindFilesErr = assert.AnError
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err = s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeNoRoots(t *testing.T) {
+	conf := config.NewConfig("python", nil, nil, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestFindProjectDir(t *testing.T) {
+	fixture := filepath.Join("testdata", "fixture")
+	assert.Equal(t, fixture, findProjectDir("testdata", filepath.Join(fixture, "lib")))
+	assert.Equal(t, fixture, findProjectDir("testdata", fixture))
+	assert.Equal(t, "testdata", findProjectDir("testdata", "testdata"))
+	// Projects are not looked up outside of the scanned path
+	assert.Equal(t, filepath.Join(fixture, "lib"), findProjectDir(filepath.Join(fixture, "lib"), filepath.Join(fixture, "lib")))
+}
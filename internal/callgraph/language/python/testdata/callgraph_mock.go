@@ -0,0 +1,10 @@
+package testdata
+
+type CallgraphMock struct {
+	RunCallGraphOutput string
+	RunCallGraphError  error
+}
+
+func (cm CallgraphMock) RunCallGraph() (string, error) {
+	return cm.RunCallGraphOutput, cm.RunCallGraphError
+}
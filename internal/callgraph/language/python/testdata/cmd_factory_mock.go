@@ -0,0 +1,36 @@
+package testdata
+
+import (
+	"os/exec"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+)
+
+type CmdFactoryMock struct {
+	CreateVenvName   string
+	CreateVenvErr    error
+	InstallName      string
+	InstallErr       error
+	CallGraphGenName string
+	CallGraphGenErr  error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		CreateVenvName:   "echo",
+		InstallName:      "echo",
+		CallGraphGenName: "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeCreateVenvCmd(_ string, _ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.CreateVenvName, "CreateVenv"), f.CreateVenvErr
+}
+
+func (f CmdFactoryMock) MakeInstallCmd(_ string, _ string, _ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.InstallName, "Install"), f.InstallErr
+}
+
+func (f CmdFactoryMock) MakeCallGraphGenerationCmd(_ string, _ string, _ string, _ string, _ []string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.CallGraphGenName, "CallGraphGen"), f.CallGraphGenErr
+}
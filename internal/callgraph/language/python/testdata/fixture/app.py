@@ -0,0 +1,14 @@
+import json
+
+from lib import shout
+from lib.models import Model
+
+
+def main():
+    model = Model("fixture")
+    print(shout(model.describe()))
+    json.dumps({"name": model.name})
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1 @@
+from .helper import shout
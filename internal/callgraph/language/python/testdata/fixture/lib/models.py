@@ -0,0 +1,11 @@
+class Model:
+    def __init__(self, name):
+        self.name = name
+        self.validate()
+
+    def validate(self):
+        if not self.name:
+            raise ValueError("name is required")
+
+    def describe(self):
+        return "Model " + self.name
@@ -2,7 +2,12 @@ package model
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 )
@@ -30,7 +35,7 @@ type Node struct {
 func (n *Node) ToBytes() []byte {
 	var buffer bytes.Buffer
 
-	buffer.WriteString(fmt.Sprintf("[\"%s\", ", n.Symbol))
+	buffer.WriteString(fmt.Sprintf("[%s, ", jsonString(n.Symbol)))
 
 	if n.IsApplicationNode {
 		buffer.WriteString("true, ")
@@ -44,15 +49,23 @@ func (n *Node) ToBytes() []byte {
 		buffer.WriteString("false, ")
 	}
 
-	buffer.WriteString(fmt.Sprintf("\"%s\", \"%s\", %d, %d, [", n.Name, n.Filename, n.LineStart, n.LineEnd))
+	buffer.WriteString(fmt.Sprintf("%s, %s, %d, %d, [", jsonString(n.Name), jsonString(n.Filename), n.LineStart, n.LineEnd))
 
-	sort.Slice(n.Parents, func(i, j int) bool {
-		return n.Parents[i].Parent.Symbol < n.Parents[j].Parent.Symbol
+	sort.SliceStable(n.Parents, func(i, j int) bool {
+		a, b := n.Parents[i], n.Parents[j]
+		if a.Parent.Symbol != b.Parent.Symbol {
+			return a.Parent.Symbol < b.Parent.Symbol
+		}
+		if a.CallLine != b.CallLine {
+			return a.CallLine < b.CallLine
+		}
+
+		return a.Parent.Filename < b.Parent.Filename
 	})
 
 	parents := make([]string, len(n.Parents))
 	for i, parent := range n.Parents {
-		parents[i] = fmt.Sprintf("[\"%s\", %d, \"%s\"]", parent.Parent.Symbol, parent.CallLine, parent.Parent.Filename)
+		parents[i] = fmt.Sprintf("[%s, %d, %s]", jsonString(parent.Parent.Symbol), parent.CallLine, jsonString(parent.Parent.Filename))
 	}
 
 	buffer.WriteString(strings.Join(parents, ", "))
@@ -61,6 +74,17 @@ func (n *Node) ToBytes() []byte {
 	return buffer.Bytes()
 }
 
+// jsonString quotes value as a JSON string. HTML characters are kept, as they are common in symbols such as
+// <module> and Java method signatures
+func jsonString(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
 type CallGraph struct {
 	Nodes   map[string]*Node
 	Version string
@@ -107,7 +131,23 @@ func (cg *CallGraph) GetNode(symbol string) *Node {
 	return cg.Nodes[symbol]
 }
 
+// ToBytes writes the call graph in its canonical form, where nodes are ordered by symbol and parents by symbol, call
+// line and filename, so that equal call graphs are written identically. The header holds the hash of the data
 func (cg *CallGraph) ToBytes() ([]byte, error) {
+	data := cg.dataBytes()
+	output := []byte("{\"version\": \"" + cg.Version + "\", \"hash\": \"" + hashData(data) + "\", \"data\": ")
+	output = append(output, data...)
+	output = append(output, []byte("}")...)
+
+	return output, nil
+}
+
+// Hash returns the SHA-256 hash of the data of the call graph, which identifies its content
+func (cg *CallGraph) Hash() string {
+	return hashData(cg.dataBytes())
+}
+
+func (cg *CallGraph) dataBytes() []byte {
 	output := []byte{}
 
 	keys := make([]string, 0, len(cg.Nodes))
@@ -129,12 +169,32 @@ func (cg *CallGraph) ToBytes() ([]byte, error) {
 		output = output[:len(output)-1]
 	}
 
-	output = append(output, []byte("]")...)
+	return append(output, []byte("]")...)
+}
 
-	output = append([]byte("{\"version\": \""+cg.Version+"\", \"data\": "), output...)
-	output = append(output, []byte("}")...)
+func hashData(data []byte) string {
+	sum := sha256.Sum256(data)
 
-	return output, nil
+	return hex.EncodeToString(sum[:])
+}
+
+// RelativizeFilenames makes the absolute filenames of nodes within rootDir, such as those of dependencies copied to
+// a temporary folder in the project, relative to rootDir. Filenames use forward slashes, so that call graphs do not
+// depend on where or on which platform the project is checked out
+func (cg *CallGraph) RelativizeFilenames(rootDir string) {
+	absRootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return
+	}
+	for _, node := range cg.Nodes {
+		if !filepath.IsAbs(node.Filename) {
+			continue
+		}
+		rel, err := filepath.Rel(absRootDir, node.Filename)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			node.Filename = filepath.ToSlash(rel)
+		}
+	}
 }
 
 func (cg *CallGraph) NodeCount() int {
@@ -149,3 +209,72 @@ func (cg *CallGraph) EdgeCount() int {
 
 	return count
 }
+
+var ErrInvalidCallGraph = errors.New("invalid call graph")
+
+// ParseCallGraph parses a call graph written by ToBytes
+func ParseCallGraph(data []byte) (*CallGraph, error) {
+	var document struct {
+		Version string              `json:"version"`
+		Data    [][]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCallGraph, err.Error())
+	}
+
+	cg := NewCallGraph()
+	cg.Version = document.Version
+	parents := make(map[*Node][][]json.RawMessage, len(document.Data))
+	for _, fields := range document.Data {
+		node, nodeParents, err := parseNode(fields)
+		if err != nil {
+			return nil, err
+		}
+		node = cg.AddNode(node.Filename, node.Name, node.Symbol, node.IsApplicationNode, node.IsStdLibNode, node.LineStart, node.LineEnd)
+		parents[node] = append(parents[node], nodeParents...)
+	}
+
+	// Parents are added once all nodes are known, as nodes are written in symbol order rather than call order
+	for node, nodeParents := range parents {
+		for _, fields := range nodeParents {
+			var symbol, filename string
+			var callLine int
+			if len(fields) != 3 || json.Unmarshal(fields[0], &symbol) != nil ||
+				json.Unmarshal(fields[1], &callLine) != nil || json.Unmarshal(fields[2], &filename) != nil {
+				return nil, fmt.Errorf("%w: invalid parent of %s", ErrInvalidCallGraph, node.Symbol)
+			}
+			parent := cg.GetNode(symbol)
+			if parent == nil {
+				parent = cg.AddNode(filename, symbol, symbol, false, false, 0, 0)
+			}
+			cg.AddEdge(parent, node, callLine)
+		}
+	}
+
+	return cg, nil
+}
+
+func parseNode(fields []json.RawMessage) (*Node, [][]json.RawMessage, error) {
+	node := &Node{}
+	var parents [][]json.RawMessage
+	targets := []interface{}{
+		&node.Symbol,
+		&node.IsApplicationNode,
+		&node.IsStdLibNode,
+		&node.Name,
+		&node.Filename,
+		&node.LineStart,
+		&node.LineEnd,
+		&parents,
+	}
+	if len(fields) != len(targets) {
+		return nil, nil, fmt.Errorf("%w: nodes have %d fields, not %d", ErrInvalidCallGraph, len(fields), len(targets))
+	}
+	for i, target := range targets {
+		if err := json.Unmarshal(fields[i], target); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidCallGraph, err.Error())
+		}
+	}
+
+	return node, parents, nil
+}
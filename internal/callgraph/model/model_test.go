@@ -1,6 +1,9 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -102,5 +105,105 @@ func TestCallgRaphToBytes(t *testing.T) {
 	bytes, err := cg.ToBytes()
 	assert.Nil(t, err)
 	assert.NotNil(t, bytes)
-	assert.Equal(t, "{\"version\": \"5\", \"data\": [[\"symbol1\", false, false, \"Node1\", \"file.go\", 1, 10, []],[\"symbol2\", false, false, \"Node2\", \"file.go\", 11, 20, [[\"symbol1\", 10, \"file.go\"], [\"symbol1\", 20, \"file.go\"]]]]}", string(bytes))
+	data := "[[\"symbol1\", false, false, \"Node1\", \"file.go\", 1, 10, []],[\"symbol2\", false, false, \"Node2\", \"file.go\", 11, 20, [[\"symbol1\", 10, \"file.go\"], [\"symbol1\", 20, \"file.go\"]]]]"
+	sum := sha256.Sum256([]byte(data))
+	hash := hex.EncodeToString(sum[:])
+	assert.Equal(t, hash, cg.Hash())
+	assert.Equal(t, "{\"version\": \"5\", \"hash\": \""+hash+"\", \"data\": "+data+"}", string(bytes))
+}
+
+func TestCallGraphToBytesCanonical(t *testing.T) {
+	first := NewCallGraph()
+	a := first.AddNode("a.go", "A", "a", true, false, 1, 2)
+	b := first.AddNode("b.go", "B", "b", false, false, 3, 4)
+	c := first.AddNode("c.go", "C", "c", false, false, 5, 6)
+	first.AddEdge(a, c, 20)
+	first.AddEdge(b, c, 1)
+	first.AddEdge(a, c, 10)
+
+	second := NewCallGraph()
+	c = second.AddNode("c.go", "C", "c", false, false, 5, 6)
+	b = second.AddNode("b.go", "B", "b", false, false, 3, 4)
+	a = second.AddNode("a.go", "A", "a", true, false, 1, 2)
+	second.AddEdge(a, c, 10)
+	second.AddEdge(a, c, 20)
+	second.AddEdge(b, c, 1)
+
+	firstBytes, err := first.ToBytes()
+	assert.NoError(t, err)
+	secondBytes, err := second.ToBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, string(firstBytes), string(secondBytes))
+	assert.Equal(t, first.Hash(), second.Hash())
+
+	second.AddEdge(b, c, 2)
+	assert.NotEqual(t, first.Hash(), second.Hash())
+}
+
+func TestNodeToBytesEscapes(t *testing.T) {
+	node := &Node{Filename: "dir\\file.js", Name: "<module>", Symbol: "file.js#\"quoted\"", Parents: []Edge{}}
+
+	bytes := node.ToBytes()
+
+	assert.Equal(t, `["file.js#\"quoted\"", false, false, "<module>", "dir\\file.js", 0, 0, []]`, string(bytes))
+}
+
+func TestRelativizeFilenames(t *testing.T) {
+	rootDir, err := filepath.Abs("project")
+	assert.NoError(t, err)
+	cg := NewCallGraph()
+	app := cg.AddNode(filepath.Join(rootDir, "src", "App.java"), "main", "app", true, false, 1, 2)
+	dependency := cg.AddNode(filepath.Join(rootDir, ".debrickedTmpFolder", "lib.jar"), "run", "lib", false, false, 0, 0)
+	outside := cg.AddNode(filepath.Join(filepath.Dir(rootDir), "projectile", "Other.java"), "other", "other", false, false, 0, 0)
+	relative := cg.AddNode("src/Rel.java", "rel", "rel", true, false, 0, 0)
+
+	cg.RelativizeFilenames("project")
+
+	assert.Equal(t, "src/App.java", app.Filename)
+	assert.Equal(t, ".debrickedTmpFolder/lib.jar", dependency.Filename)
+	assert.Equal(t, filepath.Join(filepath.Dir(rootDir), "projectile", "Other.java"), outside.Filename)
+	assert.Equal(t, "src/Rel.java", relative.Filename)
+}
+
+func TestParseCallGraph(t *testing.T) {
+	cg := NewCallGraph()
+	caller := cg.AddNode("main.go", "main", "main.main", true, false, 1, 10)
+	callee := cg.AddNode("lib.go", "Do", "lib.Do", false, false, 3, 5)
+	cg.AddEdge(caller, callee, 7)
+	bytes, err := cg.ToBytes()
+	assert.NoError(t, err)
+
+	parsed, err := ParseCallGraph(bytes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, CURRENT_VERSION, parsed.Version)
+	assert.Equal(t, 2, parsed.NodeCount())
+	assert.Equal(t, 1, parsed.EdgeCount())
+	parsedCallee := parsed.GetNode("lib.Do")
+	assert.Equal(t, "lib.go", parsedCallee.Filename)
+	assert.Equal(t, 3, parsedCallee.LineStart)
+	assert.Equal(t, 5, parsedCallee.LineEnd)
+	assert.False(t, parsedCallee.IsApplicationNode)
+	assert.Equal(t, parsed.GetNode("main.main"), parsedCallee.Parents[0].Parent)
+	assert.Equal(t, 7, parsedCallee.Parents[0].CallLine)
+	assert.True(t, parsedCallee.Parents[0].Parent.IsApplicationNode)
+}
+
+func TestParseCallGraphInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":       "version 5",
+		"missing fields": `{"version": "5", "data": [["symbol", true]]}`,
+		"invalid field":  `{"version": "5", "data": [["symbol", "true", false, "Node", "file.go", 1, 10, []]]}`,
+		"invalid parent": `{"version": "5", "data": [["symbol", true, false, "Node", "file.go", 1, 10, [["parent"]]]]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseCallGraph([]byte(data))
+			assert.ErrorIs(t, err, ErrInvalidCallGraph)
+		})
+	}
+}
+
+func TestParseCallGraphMissingFields(t *testing.T) {
+	_, err := ParseCallGraph([]byte(`{"version": "5", "data": [["symbol", true]]}`))
+	assert.ErrorContains(t, err, "nodes have 2 fields, not 8")
 }
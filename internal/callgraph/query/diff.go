@@ -0,0 +1,143 @@
+package query
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+)
+
+// CallEdge is a call from Caller to Callee, identified by their symbols. Call lines are left out, as they change
+// with any edit above the call
+type CallEdge struct {
+	Caller string
+	Callee string
+}
+
+// FileDiff holds the reachable calls added to and removed from a call graph file. Path is the path of the file
+// relative to the compared directories, or the path of the new file if files were compared on their own
+type FileDiff struct {
+	Path    string
+	Added   []CallEdge
+	Removed []CallEdge
+}
+
+func (diff FileDiff) Changed() bool {
+	return len(diff.Added) > 0 || len(diff.Removed) > 0
+}
+
+// DiffFiles pairs the call graph files of oldFiles and newFiles by their paths relative to oldRoot and newRoot,
+// and returns the reachable calls added and removed in each changed pair. Calls of files that only exist on one side
+// are all added or removed. Files that could not be decoded are left out
+func DiffFiles(oldRoot string, oldFiles []CallGraphFile, newRoot string, newFiles []CallGraphFile) []FileDiff {
+	oldByPath := filesByPath(oldRoot, oldFiles)
+	newByPath := filesByPath(newRoot, newFiles)
+	paths := map[string]bool{}
+	for path := range oldByPath {
+		paths[path] = true
+	}
+	for path := range newByPath {
+		paths[path] = true
+	}
+
+	var diffs []FileDiff
+	for path := range paths {
+		oldFile, newFile := oldByPath[path], newByPath[path]
+		if oldFile.Err != nil || newFile.Err != nil {
+			continue
+		}
+		if oldFile.Graph != nil && newFile.Graph != nil && oldFile.Graph.Hash() == newFile.Graph.Hash() {
+			continue
+		}
+		diff := FileDiff{Path: path}
+		if len(path) == 0 {
+			diff.Path = newFile.Path
+		}
+		diff.Added, diff.Removed = DiffGraphs(oldFile.Graph, newFile.Graph)
+		if diff.Changed() {
+			diffs = append(diffs, diff)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+
+	return diffs
+}
+
+// DiffGraphs returns the reachable calls of newGraph that are not in oldGraph, and the reachable calls of oldGraph
+// that are not in newGraph. Either graph may be nil
+func DiffGraphs(oldGraph *model.CallGraph, newGraph *model.CallGraph) ([]CallEdge, []CallEdge) {
+	oldEdges, newEdges := ReachableEdges(oldGraph), ReachableEdges(newGraph)
+
+	return missingEdges(newEdges, oldEdges), missingEdges(oldEdges, newEdges)
+}
+
+// ReachableEdges returns the calls of graph whose callers are reachable from application code, including the calls
+// made by application code itself
+func ReachableEdges(graph *model.CallGraph) map[CallEdge]bool {
+	edges := map[CallEdge]bool{}
+	if graph == nil {
+		return edges
+	}
+	// The graph is stored from callees to callers, so the callees of each node are collected first
+	callees := map[*model.Node][]*model.Node{}
+	var queue []*model.Node
+	visited := map[*model.Node]bool{}
+	for _, node := range sortedNodes(graph) {
+		for _, edge := range node.Parents {
+			callees[edge.Parent] = append(callees[edge.Parent], node)
+		}
+		if node.IsApplicationNode {
+			queue = append(queue, node)
+			visited[node] = true
+		}
+	}
+	for len(queue) > 0 {
+		caller := queue[0]
+		queue = queue[1:]
+		for _, callee := range callees[caller] {
+			edges[CallEdge{Caller: caller.Symbol, Callee: callee.Symbol}] = true
+			if !visited[callee] {
+				visited[callee] = true
+				queue = append(queue, callee)
+			}
+		}
+	}
+
+	return edges
+}
+
+// missingEdges returns the edges of edges that are not in other, sorted by caller and callee
+func missingEdges(edges map[CallEdge]bool, other map[CallEdge]bool) []CallEdge {
+	var missing []CallEdge
+	for edge := range edges {
+		if !other[edge] {
+			missing = append(missing, edge)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Caller != missing[j].Caller {
+			return missing[i].Caller < missing[j].Caller
+		}
+
+		return missing[i].Callee < missing[j].Callee
+	})
+
+	return missing
+}
+
+// filesByPath maps files to their paths relative to root. A file compared on its own has root as path, and is mapped
+// to the empty path, so that it is compared to the other file regardless of its name
+func filesByPath(root string, files []CallGraphFile) map[string]CallGraphFile {
+	byPath := map[string]CallGraphFile{}
+	for _, callGraphFile := range files {
+		path := ""
+		if rel, err := filepath.Rel(root, callGraphFile.Path); err == nil && rel != "." {
+			path = filepath.ToSlash(rel)
+		}
+		byPath[path] = callGraphFile
+	}
+
+	return byPath
+}
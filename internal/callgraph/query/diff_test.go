@@ -0,0 +1,118 @@
+package query
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDiffGraph returns main -> helper -> lib.Do -> lib.internal, with an unreachable lib.Unused -> lib.internal
+func newDiffGraph() *model.CallGraph {
+	cg := model.NewCallGraph()
+	main := cg.AddNode("main.go", "main", "main.main", true, false, 1, 5)
+	helper := cg.AddNode("util.go", "helper", "main.helper", true, false, 1, 3)
+	do := cg.AddNode("lib/do.go", "Do", "lib.Do", false, false, 1, 9)
+	internal := cg.AddNode("lib/do.go", "internal", "lib.internal", false, false, 10, 12)
+	unused := cg.AddNode("lib/unused.go", "Unused", "lib.Unused", false, false, 1, 2)
+	cg.AddEdge(main, helper, 2)
+	cg.AddEdge(helper, do, 2)
+	cg.AddEdge(do, internal, 4)
+	cg.AddEdge(unused, internal, 1)
+
+	return cg
+}
+
+func writeGraph(t *testing.T, path string, cg *model.CallGraph) {
+	t.Helper()
+	output, err := cg.ToBytes()
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	assert.NoError(t, os.WriteFile(path, output, 0600))
+}
+
+func TestReachableEdges(t *testing.T) {
+	edges := ReachableEdges(newDiffGraph())
+
+	assert.Equal(t, map[CallEdge]bool{
+		{Caller: "main.main", Callee: "main.helper"}: true,
+		{Caller: "main.helper", Callee: "lib.Do"}:    true,
+		{Caller: "lib.Do", Callee: "lib.internal"}:   true,
+	}, edges)
+	assert.Empty(t, ReachableEdges(nil))
+}
+
+func TestDiffGraphs(t *testing.T) {
+	oldGraph := newDiffGraph()
+	newGraph := newDiffGraph()
+	// helper now calls lib.Unused instead of lib.Do, and the call of main moved
+	helper, do, unused := newGraph.GetNode("main.helper"), newGraph.GetNode("lib.Do"), newGraph.GetNode("lib.Unused")
+	do.Parents = nil
+	newGraph.AddEdge(helper, unused, 3)
+	helper.Parents[0].CallLine = 4
+
+	added, removed := DiffGraphs(oldGraph, newGraph)
+
+	assert.Equal(t, []CallEdge{
+		{Caller: "lib.Unused", Callee: "lib.internal"},
+		{Caller: "main.helper", Callee: "lib.Unused"},
+	}, added)
+	assert.Equal(t, []CallEdge{
+		{Caller: "lib.Do", Callee: "lib.internal"},
+		{Caller: "main.helper", Callee: "lib.Do"},
+	}, removed)
+}
+
+func TestDiffFiles(t *testing.T) {
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	unchanged := filepath.Join("api", "debricked-call-graph.golang")
+	changed := "debricked-call-graph.golang"
+	writeGraph(t, filepath.Join(oldDir, unchanged), newDiffGraph())
+	writeGraph(t, filepath.Join(newDir, unchanged), newDiffGraph())
+	writeGraph(t, filepath.Join(oldDir, changed), newDiffGraph())
+	changedGraph := newDiffGraph()
+	changedGraph.AddEdge(changedGraph.GetNode("main.main"), changedGraph.GetNode("lib.Unused"), 3)
+	writeGraph(t, filepath.Join(newDir, changed), changedGraph)
+	writeGraph(t, filepath.Join(newDir, "web", "debricked-call-graph.javascript"), newDiffGraph())
+	assert.NoError(t, os.MkdirAll(filepath.Join(oldDir, "broken"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(oldDir, "broken", "debricked-call-graph.java"), []byte("invalid"), 0600))
+	writeGraph(t, filepath.Join(newDir, "broken", "debricked-call-graph.java"), newDiffGraph())
+
+	oldFiles, err := NewLoader().Load(DebrickedOptions{Path: oldDir})
+	assert.NoError(t, err)
+	newFiles, err := NewLoader().Load(DebrickedOptions{Path: newDir})
+	assert.NoError(t, err)
+
+	diffs := DiffFiles(oldDir, oldFiles, newDir, newFiles)
+
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "debricked-call-graph.golang", diffs[0].Path)
+	assert.Equal(t, []CallEdge{
+		{Caller: "lib.Unused", Callee: "lib.internal"},
+		{Caller: "main.main", Callee: "lib.Unused"},
+	}, diffs[0].Added)
+	assert.Empty(t, diffs[0].Removed)
+	assert.Equal(t, "web/debricked-call-graph.javascript", diffs[1].Path)
+	assert.Len(t, diffs[1].Added, 3)
+	assert.Empty(t, diffs[1].Removed)
+}
+
+func TestDiffFilesSingleFiles(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old.golang"), filepath.Join(dir, "new.golang")
+	writeGraph(t, oldPath, newDiffGraph())
+	writeGraph(t, newPath, model.NewCallGraph())
+	oldFiles, err := NewLoader().Load(DebrickedOptions{Path: oldPath})
+	assert.NoError(t, err)
+	newFiles, err := NewLoader().Load(DebrickedOptions{Path: newPath})
+	assert.NoError(t, err)
+
+	diffs := DiffFiles(oldPath, oldFiles, newPath, newFiles)
+
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, newPath, diffs[0].Path)
+	assert.Empty(t, diffs[0].Added)
+	assert.Len(t, diffs[0].Removed, 3)
+}
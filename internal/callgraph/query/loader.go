@@ -0,0 +1,144 @@
+package query
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/debricked/cli/internal/file"
+)
+
+// callGraphName prefixes the names of the call graph files written by the call graph generation, such as
+// debricked-call-graph.java
+const callGraphName = "debricked-call-graph"
+
+var ErrNoCallGraphs = errors.New("no call graphs found, generate one with `debricked callgraph`")
+
+// CallGraphFile is the call graph of one call graph file. Err is set if the file could not be decoded
+type CallGraphFile struct {
+	Path  string
+	Graph *model.CallGraph
+	Err   error
+}
+
+type DebrickedOptions struct {
+	Path       string
+	Exclusions []string
+	Inclusions []string
+}
+
+type ILoader interface {
+	Load(options DebrickedOptions) ([]CallGraphFile, error)
+}
+
+type Loader struct{}
+
+func NewLoader() *Loader {
+	return &Loader{}
+}
+
+// Load decodes the call graph file at options.Path, or all call graph files in the directory at options.Path
+func (loader *Loader) Load(options DebrickedOptions) ([]CallGraphFile, error) {
+	path := options.Path
+	if len(path) == 0 {
+		path = "."
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{path}
+	if info.IsDir() {
+		paths, err = findCallGraphFiles(path, options.Exclusions, options.Inclusions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(paths) == 0 {
+		return nil, ErrNoCallGraphs
+	}
+
+	var files []CallGraphFile
+	for _, callGraphPath := range paths {
+		graph, decodeErr := decodeFile(callGraphPath)
+		files = append(files, CallGraphFile{Path: callGraphPath, Graph: graph, Err: decodeErr})
+	}
+
+	return files, nil
+}
+
+func findCallGraphFiles(root string, exclusions []string, inclusions []string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Directories are skipped if call graph files within them would be excluded
+		if entry.IsDir() {
+			if path != root && file.Excluded(exclusions, inclusions, filepath.Join(path, callGraphName)) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		name := entry.Name()
+		if strings.HasPrefix(name, callGraphName) && filepath.Ext(name) != ".zip" && !file.Excluded(exclusions, inclusions, path) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	sort.Strings(paths)
+
+	return paths, err
+}
+
+func decodeFile(path string) (*model.CallGraph, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode(content)
+}
+
+// Decode parses a call graph, which is either plain or zipped and base64 encoded as written by the call graph generation
+func Decode(content []byte) (*model.CallGraph, error) {
+	content = bytes.TrimSpace(content)
+	if bytes.HasPrefix(content, []byte("{")) {
+		return model.ParseCallGraph(content)
+	}
+
+	zipped, err := base64.StdEncoding.DecodeString(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a plain or base64 encoded call graph", model.ErrInvalidCallGraph)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(zipped), int64(len(zipped)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrInvalidCallGraph, err.Error())
+	}
+	if len(reader.File) != 1 {
+		return nil, fmt.Errorf("%w: the archive has %d files, not 1", model.ErrInvalidCallGraph, len(reader.File))
+	}
+	zippedFile, err := reader.File[0].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer zippedFile.Close()
+	unzipped, err := io.ReadAll(zippedFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ParseCallGraph(unzipped)
+}
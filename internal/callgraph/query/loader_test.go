@@ -0,0 +1,63 @@
+package query
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/debricked/cli/internal/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDirectory(t *testing.T) {
+	loader := NewLoader()
+
+	files, err := loader.Load(DebrickedOptions{Path: filepath.Join("testdata", "project"), Exclusions: file.DefaultExclusions()})
+
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, filepath.Join("testdata", "project", "api", "debricked-call-graph.golang"), files[0].Path)
+	assert.NoError(t, files[0].Err)
+	assert.Equal(t, 2, files[0].Graph.NodeCount())
+	assert.Equal(t, filepath.Join("testdata", "project", "debricked-call-graph.javascript"), files[1].Path)
+	assert.NoError(t, files[1].Err)
+	assert.Equal(t, 8, files[1].Graph.NodeCount())
+	assert.Equal(t, 7, files[1].Graph.EdgeCount())
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join("testdata", "invalid", "debricked-call-graph.java")
+
+	files, err := NewLoader().Load(DebrickedOptions{Path: path})
+
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, path, files[0].Path)
+	assert.ErrorIs(t, files[0].Err, model.ErrInvalidCallGraph)
+}
+
+func TestLoadNoCallGraphs(t *testing.T) {
+	_, err := NewLoader().Load(DebrickedOptions{Path: t.TempDir()})
+
+	assert.ErrorIs(t, err, ErrNoCallGraphs)
+}
+
+func TestLoadNotExisting(t *testing.T) {
+	_, err := NewLoader().Load(DebrickedOptions{Path: filepath.Join("testdata", "missing")})
+
+	assert.Error(t, err)
+}
+
+func TestDecodePlain(t *testing.T) {
+	graph, err := Decode([]byte(" {\"version\": \"5\", \"data\": []}\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "5", graph.Version)
+	assert.Equal(t, 0, graph.NodeCount())
+}
+
+func TestDecodeInvalidArchive(t *testing.T) {
+	_, err := Decode([]byte("bm90IGEgemlw"))
+
+	assert.ErrorIs(t, err, model.ErrInvalidCallGraph)
+}
@@ -0,0 +1,182 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+)
+
+// Step is a node of a call chain. CallLine is the line of the call from the previous node of the chain
+type Step struct {
+	Node     *model.Node
+	CallLine int
+}
+
+// Chain is a call chain, starting with the calling application node
+type Chain []Step
+
+// Result holds the shortest call chain to Target in a call graph file. Chain is empty if Target is unreachable
+type Result struct {
+	File   CallGraphFile
+	Target string
+	Chain  Chain
+}
+
+func (result Result) Reachable() bool {
+	return len(result.Chain) > 0
+}
+
+// FindSymbol returns the shortest call chain from an application entry point to each node matching symbol
+func FindSymbol(files []CallGraphFile, symbol string) []Result {
+	var results []Result
+	for _, callGraphFile := range parsed(files) {
+		for _, node := range MatchSymbol(callGraphFile.Graph, symbol) {
+			chain := ShortestChain([]*model.Node{node}, IsEntryPoint)
+			results = append(results, Result{File: callGraphFile, Target: node.Symbol, Chain: chain})
+		}
+	}
+
+	return results
+}
+
+// FindPackage returns the shortest call chain from application code into pkg in each call graph containing pkg
+func FindPackage(files []CallGraphFile, pkg string) []Result {
+	var results []Result
+	for _, callGraphFile := range parsed(files) {
+		var targets []*model.Node
+		for _, node := range sortedNodes(callGraphFile.Graph) {
+			if InPackage(node, pkg) {
+				targets = append(targets, node)
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		chain := ShortestChain(targets, func(node *model.Node) bool { return node.IsApplicationNode })
+		results = append(results, Result{File: callGraphFile, Target: pkg, Chain: chain})
+	}
+
+	return results
+}
+
+// MatchSymbol returns the node of graph with symbol. If there is none, the nodes whose symbols contain symbol
+// are returned
+func MatchSymbol(graph *model.CallGraph, symbol string) []*model.Node {
+	if node := graph.GetNode(symbol); node != nil {
+		return []*model.Node{node}
+	}
+	var matches []*model.Node
+	for _, node := range sortedNodes(graph) {
+		if strings.Contains(node.Symbol, symbol) {
+			matches = append(matches, node)
+		}
+	}
+
+	return matches
+}
+
+// IsEntryPoint returns true for application nodes without callers, such as main functions and module bodies
+func IsEntryPoint(node *model.Node) bool {
+	return node.IsApplicationNode && len(node.Parents) == 0
+}
+
+// InPackage returns true if node is a dependency node whose symbol contains pkg as a whole name, such as lodash in
+// lodash/merge.js#merge or org.apache.commons.text in <org.apache.commons.text.StringSubstitutor: ...>
+func InPackage(node *model.Node, pkg string) bool {
+	if node.IsApplicationNode || len(pkg) == 0 {
+		return false
+	}
+	symbol := node.Symbol
+	for offset := 0; offset < len(symbol); {
+		i := strings.Index(symbol[offset:], pkg)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(pkg)
+		if (start == 0 || isSeparator(symbol[start-1])) && (end == len(symbol) || isSeparator(symbol[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+
+	return false
+}
+
+func isSeparator(c byte) bool {
+	r := rune(c)
+
+	return !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || c >= 0x80)
+}
+
+// ShortestChain searches the callers of targets breadth first, and returns the shortest chain from a node
+// satisfying isStart to any of targets, or nil if there is none
+func ShortestChain(targets []*model.Node, isStart func(*model.Node) bool) Chain {
+	// next maps visited callers to the step towards the targets
+	next := map[*model.Node]Step{}
+	visited := map[*model.Node]bool{}
+	queue := append([]*model.Node{}, targets...)
+	for _, target := range targets {
+		visited[target] = true
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if isStart(current) {
+			chain := Chain{{Node: current}}
+			for step, ok := next[current]; ok; step, ok = next[step.Node] {
+				chain = append(chain, step)
+			}
+
+			return chain
+		}
+		for _, edge := range sortedParents(current) {
+			if visited[edge.Parent] {
+				continue
+			}
+			visited[edge.Parent] = true
+			next[edge.Parent] = Step{Node: current, CallLine: edge.CallLine}
+			queue = append(queue, edge.Parent)
+		}
+	}
+
+	return nil
+}
+
+func sortedNodes(graph *model.CallGraph) []*model.Node {
+	nodes := make([]*model.Node, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Symbol < nodes[j].Symbol
+	})
+
+	return nodes
+}
+
+// sortedParents returns the callers of node sorted by symbol and call line, which makes chains deterministic
+func sortedParents(node *model.Node) []model.Edge {
+	parents := append([]model.Edge{}, node.Parents...)
+	sort.SliceStable(parents, func(i, j int) bool {
+		if parents[i].Parent.Symbol != parents[j].Parent.Symbol {
+			return parents[i].Parent.Symbol < parents[j].Parent.Symbol
+		}
+
+		return parents[i].CallLine < parents[j].CallLine
+	})
+
+	return parents
+}
+
+func parsed(files []CallGraphFile) []CallGraphFile {
+	var parsedFiles []CallGraphFile
+	for _, callGraphFile := range files {
+		if callGraphFile.Err == nil && callGraphFile.Graph != nil {
+			parsedFiles = append(parsedFiles, callGraphFile)
+		}
+	}
+
+	return parsedFiles
+}
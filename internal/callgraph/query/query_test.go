@@ -0,0 +1,109 @@
+package query
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadProject(t *testing.T) []CallGraphFile {
+	files, err := NewLoader().Load(DebrickedOptions{Path: filepath.Join("testdata", "project", "debricked-call-graph.javascript")})
+	assert.NoError(t, err)
+
+	return files
+}
+
+func symbols(chain Chain) []string {
+	var chainSymbols []string
+	for _, step := range chain {
+		chainSymbols = append(chainSymbols, step.Node.Symbol)
+	}
+
+	return chainSymbols
+}
+
+func TestFindSymbol(t *testing.T) {
+	results := FindSymbol(loadProject(t), "lodash/merge.js#baseMerge")
+
+	assert.Len(t, results, 1)
+	assert.True(t, results[0].Reachable())
+	assert.Equal(t, "lodash/merge.js#baseMerge", results[0].Target)
+	assert.Equal(t, []string{
+		"src/index.js#<module>",
+		"src/index.js#main",
+		"src/util.js#helper",
+		"lodash/merge.js#merge",
+		"lodash/merge.js#baseMerge",
+	}, symbols(results[0].Chain))
+	assert.Equal(t, 0, results[0].Chain[0].CallLine)
+	assert.Equal(t, 12, results[0].Chain[1].CallLine)
+	assert.Equal(t, 8, results[0].Chain[4].CallLine)
+}
+
+func TestFindSymbolUnreachable(t *testing.T) {
+	results := FindSymbol(loadProject(t), "lodash/clone.js#clone")
+
+	assert.Len(t, results, 1)
+	assert.False(t, results[0].Reachable())
+}
+
+func TestFindSymbolPartial(t *testing.T) {
+	results := FindSymbol(loadProject(t), "merge.js")
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, "lodash/merge.js#baseMerge", results[0].Target)
+	assert.Equal(t, "lodash/merge.js#merge", results[1].Target)
+}
+
+func TestFindSymbolNotFound(t *testing.T) {
+	assert.Empty(t, FindSymbol(loadProject(t), "missing"))
+}
+
+func TestFindPackage(t *testing.T) {
+	results := FindPackage(loadProject(t), "lodash")
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "lodash", results[0].Target)
+	// The shortest chain into the package starts at the closest application node, which is not an entry point
+	assert.Equal(t, []string{"src/dead.js#dead", "lodash/clone.js#clone"}, symbols(results[0].Chain))
+	assert.Equal(t, 4, results[0].Chain[1].CallLine)
+}
+
+func TestFindPackageNotFound(t *testing.T) {
+	assert.Empty(t, FindPackage(loadProject(t), "lodash-es"))
+}
+
+func TestFindSkipsInvalidFiles(t *testing.T) {
+	files := []CallGraphFile{{Path: "debricked-call-graph.java", Err: model.ErrInvalidCallGraph}}
+
+	assert.Empty(t, FindSymbol(files, "main"))
+	assert.Empty(t, FindPackage(files, "lodash"))
+}
+
+func TestInPackage(t *testing.T) {
+	cases := map[string]bool{
+		"lodash/merge.js#merge":                          true,
+		"lodash.merge/index.js#merge":                    true,
+		"lodash-es/merge.js#merge":                       false,
+		"mylodash/merge.js#merge":                        false,
+		"github.com/pkg/errors.New":                      false,
+		"<org.lodash.Util: void run()>":                  true,
+		"(*github.com/lodash/lodash/merge.Merger).Merge": true,
+	}
+	for symbol, expected := range cases {
+		t.Run(symbol, func(t *testing.T) {
+			assert.Equal(t, expected, InPackage(&model.Node{Symbol: symbol}, "lodash"))
+		})
+	}
+	assert.False(t, InPackage(&model.Node{Symbol: "lodash/merge.js#merge", IsApplicationNode: true}, "lodash"))
+}
+
+func TestShortestChainTargetIsStart(t *testing.T) {
+	node := &model.Node{Symbol: "main.main", IsApplicationNode: true}
+
+	chain := ShortestChain([]*model.Node{node}, IsEntryPoint)
+
+	assert.Equal(t, []string{"main.main"}, symbols(chain))
+}
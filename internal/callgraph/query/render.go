@@ -0,0 +1,165 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+)
+
+const (
+	FormatText = "text"
+	FormatJson = "json"
+)
+
+var UnsupportedFormatErr = errors.New("unsupported format, supported formats are: text and json")
+
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatText, FormatJson:
+		return nil
+	default:
+		return UnsupportedFormatErr
+	}
+}
+
+type jsonStep struct {
+	Symbol    string `json:"symbol"`
+	Name      string `json:"name"`
+	File      string `json:"file"`
+	LineStart int    `json:"lineStart"`
+	LineEnd   int    `json:"lineEnd"`
+	// CallLine is the line of the call from the previous step, which is 0 for the first step
+	CallLine int `json:"callLine"`
+}
+
+type jsonResult struct {
+	CallGraph string     `json:"callGraph"`
+	Target    string     `json:"target"`
+	Reachable bool       `json:"reachable"`
+	Chain     []jsonStep `json:"chain"`
+}
+
+// WriteResults writes the call chains of results. Unreachable targets are described by unreachable, such as
+// "is not reachable from any application entry point"
+func WriteResults(w io.Writer, results []Result, format string, unreachable string) error {
+	if format == FormatJson {
+		output := []jsonResult{}
+		for _, result := range results {
+			output = append(output, newJsonResult(result))
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		// Symbols such as <module> and Java method signatures are kept readable
+		encoder.SetEscapeHTML(false)
+
+		return encoder.Encode(output)
+	}
+
+	for _, result := range results {
+		fmt.Fprintln(w, result.File.Path)
+		if !result.Reachable() {
+			fmt.Fprintf(w, "%s %s\n", result.Target, unreachable)
+
+			continue
+		}
+		fmt.Fprintf(w, "%s is reachable by a chain of %d call(s)\n", result.Target, len(result.Chain)-1)
+		for i, step := range result.Chain {
+			fmt.Fprintf(w, "  %d. %s (%s:%d)", i+1, step.Node.Symbol, step.Node.Filename, step.Node.LineStart)
+			if i > 0 {
+				fmt.Fprintf(w, ", called at %s:%d", result.Chain[i-1].Node.Filename, step.CallLine)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	return nil
+}
+
+type jsonCallEdge struct {
+	Caller string `json:"caller"`
+	Callee string `json:"callee"`
+}
+
+type jsonDiff struct {
+	CallGraph string         `json:"callGraph"`
+	Added     []jsonCallEdge `json:"added"`
+	Removed   []jsonCallEdge `json:"removed"`
+}
+
+// WriteDiffs writes the reachable calls added and removed in diffs
+func WriteDiffs(w io.Writer, diffs []FileDiff, format string) error {
+	if format == FormatJson {
+		output := []jsonDiff{}
+		for _, diff := range diffs {
+			output = append(output, jsonDiff{
+				CallGraph: diff.Path,
+				Added:     newJsonCallEdges(diff.Added),
+				Removed:   newJsonCallEdges(diff.Removed),
+			})
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+
+		return encoder.Encode(output)
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "No reachable calls were added or removed")
+
+		return nil
+	}
+	for _, diff := range diffs {
+		fmt.Fprintln(w, diff.Path)
+		for _, edge := range diff.Added {
+			fmt.Fprintf(w, "  + %s -> %s\n", edge.Caller, edge.Callee)
+		}
+		for _, edge := range diff.Removed {
+			fmt.Fprintf(w, "  - %s -> %s\n", edge.Caller, edge.Callee)
+		}
+	}
+
+	return nil
+}
+
+// WriteWarnings writes the call graph files that could not be decoded
+func WriteWarnings(w io.Writer, files []CallGraphFile) {
+	for _, callGraphFile := range files {
+		if callGraphFile.Err != nil {
+			fmt.Fprintf(w, "%s Failed to decode %s: %s\n", color.YellowString("⚠️"), callGraphFile.Path, callGraphFile.Err.Error())
+		}
+	}
+}
+
+func newJsonResult(result Result) jsonResult {
+	output := jsonResult{
+		CallGraph: result.File.Path,
+		Target:    result.Target,
+		Reachable: result.Reachable(),
+		Chain:     []jsonStep{},
+	}
+	for _, step := range result.Chain {
+		output.Chain = append(output.Chain, jsonStep{
+			Symbol:    step.Node.Symbol,
+			Name:      step.Node.Name,
+			File:      step.Node.Filename,
+			LineStart: step.Node.LineStart,
+			LineEnd:   step.Node.LineEnd,
+			CallLine:  step.CallLine,
+		})
+	}
+
+	return output
+}
+
+func newJsonCallEdges(edges []CallEdge) []jsonCallEdge {
+	output := []jsonCallEdge{}
+	for _, edge := range edges {
+		output = append(output, jsonCallEdge{Caller: edge.Caller, Callee: edge.Callee})
+	}
+
+	return output
+}
@@ -0,0 +1,101 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFormat(t *testing.T) {
+	assert.NoError(t, ValidateFormat(FormatText))
+	assert.NoError(t, ValidateFormat(FormatJson))
+	assert.ErrorIs(t, ValidateFormat("dot"), UnsupportedFormatErr)
+}
+
+func TestWriteResultsText(t *testing.T) {
+	files := loadProject(t)
+	results := append(FindSymbol(files, "lodash/merge.js#merge"), FindSymbol(files, "lodash/clone.js#clone")...)
+	var output bytes.Buffer
+
+	err := WriteResults(&output, results, FormatText, "is not reachable")
+
+	assert.NoError(t, err)
+	text := output.String()
+	assert.Contains(t, text, "lodash/merge.js#merge is reachable by a chain of 3 call(s)")
+	assert.Contains(t, text, "  1. src/index.js#<module> (src/index.js:1)\n")
+	assert.Contains(t, text, "  2. src/index.js#main (src/index.js:3), called at src/index.js:12\n")
+	assert.Contains(t, text, "  4. lodash/merge.js#merge (node_modules/lodash/merge.js:3), called at src/util.js:2\n")
+	assert.Contains(t, text, "lodash/clone.js#clone is not reachable\n")
+}
+
+func TestWriteResultsJson(t *testing.T) {
+	results := FindSymbol(loadProject(t), "lodash/merge.js#merge")
+	var output bytes.Buffer
+
+	err := WriteResults(&output, results, FormatJson, "is not reachable")
+
+	assert.NoError(t, err)
+	var decoded []jsonResult
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Len(t, decoded, 1)
+	assert.True(t, decoded[0].Reachable)
+	assert.Len(t, decoded[0].Chain, 4)
+	assert.Equal(t, "src/util.js#helper", decoded[0].Chain[2].Symbol)
+	assert.Equal(t, "src/util.js", decoded[0].Chain[2].File)
+	assert.Equal(t, 5, decoded[0].Chain[2].CallLine)
+}
+
+func TestWriteWarnings(t *testing.T) {
+	var output bytes.Buffer
+
+	WriteWarnings(&output, []CallGraphFile{{Path: "debricked-call-graph.java", Err: errors.New("invalid")}, {Path: "ok"}})
+
+	assert.Contains(t, output.String(), "Failed to decode debricked-call-graph.java: invalid")
+	assert.NotContains(t, output.String(), "ok")
+}
+
+func TestWriteDiffsText(t *testing.T) {
+	diffs := []FileDiff{{
+		Path:    "debricked-call-graph.golang",
+		Added:   []CallEdge{{Caller: "main.main", Callee: "lib.Do"}},
+		Removed: []CallEdge{{Caller: "main.main", Callee: "lib.Old"}},
+	}}
+	var output bytes.Buffer
+
+	err := WriteDiffs(&output, diffs, FormatText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "debricked-call-graph.golang\n  + main.main -> lib.Do\n  - main.main -> lib.Old\n", output.String())
+}
+
+func TestWriteDiffsTextNoChanges(t *testing.T) {
+	var output bytes.Buffer
+
+	err := WriteDiffs(&output, nil, FormatText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "No reachable calls were added or removed\n", output.String())
+}
+
+func TestWriteDiffsJson(t *testing.T) {
+	diffs := []FileDiff{{
+		Path:  "debricked-call-graph.java",
+		Added: []CallEdge{{Caller: "<App: void main()>", Callee: "<Lib: void run()>"}},
+	}}
+	var output bytes.Buffer
+
+	err := WriteDiffs(&output, diffs, FormatJson)
+
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), `"caller": "<App: void main()>"`)
+	var decoded []jsonDiff
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Equal(t, []jsonDiff{{
+		CallGraph: "debricked-call-graph.java",
+		Added:     []jsonCallEdge{{Caller: "<App: void main()>", Callee: "<Lib: void run()>"}},
+		Removed:   []jsonCallEdge{},
+	}}, decoded)
+}
@@ -0,0 +1 @@
+not a call graph
\ No newline at end of file
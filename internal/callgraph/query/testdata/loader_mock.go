@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"github.com/debricked/cli/internal/callgraph/query"
+)
+
+type LoaderMock struct {
+	Options query.DebrickedOptions
+	Files   []query.CallGraphFile
+	Err     error
+}
+
+func (loader *LoaderMock) Load(options query.DebrickedOptions) ([]query.CallGraphFile, error) {
+	loader.Options = options
+
+	return loader.Files, loader.Err
+}
@@ -7,8 +7,12 @@ import (
 	conf "github.com/debricked/cli/internal/callgraph/config"
 	golangfinder "github.com/debricked/cli/internal/callgraph/finder/golangfinder"
 	"github.com/debricked/cli/internal/callgraph/finder/javafinder"
+	"github.com/debricked/cli/internal/callgraph/finder/javascriptfinder"
+	"github.com/debricked/cli/internal/callgraph/finder/pythonfinder"
 	"github.com/debricked/cli/internal/callgraph/language/golang"
 	"github.com/debricked/cli/internal/callgraph/language/java"
+	"github.com/debricked/cli/internal/callgraph/language/javascript"
+	"github.com/debricked/cli/internal/callgraph/language/python"
 )
 
 type IFactory interface {
@@ -34,6 +38,10 @@ func (sf Factory) Make(
 		return java.NewStrategy(config, paths, exclusions, inclusions, javafinder.JavaFinder{}, ctx), nil
 	case golang.Name:
 		return golang.NewStrategy(config, paths, exclusions, inclusions, golangfinder.GolangFinder{}, ctx), nil
+	case python.Name:
+		return python.NewStrategy(config, paths, exclusions, inclusions, pythonfinder.PythonFinder{}, ctx), nil
+	case javascript.Name:
+		return javascript.NewStrategy(config, paths, exclusions, inclusions, javascriptfinder.JavascriptFinder{}, ctx), nil
 	default:
 		return nil, fmt.Errorf("failed to make strategy from %s", name)
 	}
@@ -5,7 +5,11 @@ import (
 
 	"github.com/debricked/cli/internal/callgraph/config"
 	"github.com/debricked/cli/internal/callgraph/finder/javafinder"
+	"github.com/debricked/cli/internal/callgraph/finder/javascriptfinder"
+	"github.com/debricked/cli/internal/callgraph/finder/pythonfinder"
 	"github.com/debricked/cli/internal/callgraph/language/java"
+	"github.com/debricked/cli/internal/callgraph/language/javascript"
+	"github.com/debricked/cli/internal/callgraph/language/python"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,13 +27,18 @@ func TestMakeErr(t *testing.T) {
 }
 
 func TestMake(t *testing.T) {
-	conf := config.NewConfig(java.Name, nil, nil, true, "", "")
+	javaConf := config.NewConfig(java.Name, nil, nil, true, "", "")
+	pythonConf := config.NewConfig(python.Name, nil, nil, true, "", "")
+	javascriptConf := config.NewConfig(javascript.Name, nil, nil, true, "", "")
 	cases := map[string]IStrategy{
-		java.Name: java.NewStrategy(conf, []string{}, []string{}, []string{}, javafinder.JavaFinder{}, nil),
+		java.Name:       java.NewStrategy(javaConf, []string{}, []string{}, []string{}, javafinder.JavaFinder{}, nil),
+		python.Name:     python.NewStrategy(pythonConf, []string{}, []string{}, []string{}, pythonfinder.PythonFinder{}, nil),
+		javascript.Name: javascript.NewStrategy(javascriptConf, []string{}, []string{}, []string{}, javascriptfinder.JavascriptFinder{}, nil),
 	}
 	f := NewStrategyFactory()
 	for name, strategy := range cases {
 		t.Run(name, func(t *testing.T) {
+			conf := config.NewConfig(name, nil, nil, true, "", "")
 			s, err := f.Make(conf, []string{}, []string{}, []string{}, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, strategy, s)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/debricked/cli/internal/ci/env"
 	"github.com/debricked/cli/internal/ci/util"
@@ -26,6 +27,16 @@ func (_ Ci) Map() (env.Env, error) {
 	e.Repository = fmt.Sprintf("%s/%s", owner, os.Getenv("BUILD_REPOSITORY_NAME"))
 	e.Commit = os.Getenv("BUILD_SOURCEVERSION")
 	e.Branch = os.Getenv("BUILD_SOURCEBRANCHNAME")
+	e.TargetBranch = strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH"), "refs/heads/")
+	// Pull requests from GitHub are numbered, while pull requests in Azure Repos only have ids. The source branch
+	// name of pull request builds is merge, as in refs/pull/12/merge
+	e.PullRequest = os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER")
+	if len(e.PullRequest) == 0 {
+		e.PullRequest = os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTID")
+	}
+	if len(e.PullRequest) > 0 {
+		e.Branch = strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"), "refs/heads/")
+	}
 	e.RepositoryUrl = os.Getenv("BUILD_REPOSITORY_URI")
 	e.Integration = Integration
 	e.Author = os.Getenv("BUILD_REQUESTEDFOREMAIL")
@@ -8,14 +8,15 @@ import (
 )
 
 var azureEnv = map[string]string{
-	"TF_BUILD":                "azure",
-	"SYSTEM_COLLECTIONURI":    "dir/debricked/",
-	"BUILD_REPOSITORY_NAME":   "cli",
-	"BUILD_SOURCEVERSION":     "commit",
-	"BUILD_SOURCEBRANCHNAME":  "main",
-	"BUILD_SOURCESDIRECTORY":  ".",
-	"BUILD_REPOSITORY_URI":    "https://github.com/debricked/cli",
-	"BUILD_REQUESTEDFOREMAIL": "viktigpetterr <test@test.com>",
+	"TF_BUILD":                        "azure",
+	"SYSTEM_COLLECTIONURI":            "dir/debricked/",
+	"BUILD_REPOSITORY_NAME":           "cli",
+	"BUILD_SOURCEVERSION":             "commit",
+	"BUILD_SOURCEBRANCHNAME":          "main",
+	"BUILD_SOURCESDIRECTORY":          ".",
+	"BUILD_REPOSITORY_URI":            "https://github.com/debricked/cli",
+	"BUILD_REQUESTEDFOREMAIL":         "viktigpetterr <test@test.com>",
+	"SYSTEM_PULLREQUEST_TARGETBRANCH": "refs/heads/master",
 }
 
 func TestIdentify(t *testing.T) {
@@ -33,8 +34,33 @@ func TestParse(t *testing.T) {
 	assert.Equal(t, Integration, env.Integration)
 	assert.Equal(t, azureEnv["BUILD_REQUESTEDFOREMAIL"], env.Author)
 	assert.Equal(t, azureEnv["BUILD_SOURCEBRANCHNAME"], env.Branch)
+	assert.Equal(t, "master", env.TargetBranch)
+	assert.Empty(t, env.PullRequest)
 	assert.Equal(t, azureEnv["BUILD_REPOSITORY_URI"], env.RepositoryUrl)
 	assert.Equal(t, azureEnv["BUILD_SOURCEVERSION"], env.Commit)
 	assert.Equal(t, "debricked/cli", env.Repository)
 
 }
+
+func TestParsePullRequest(t *testing.T) {
+	cases := map[string]map[string]string{
+		"GitHub":      {"SYSTEM_PULLREQUEST_PULLREQUESTNUMBER": "12", "SYSTEM_PULLREQUEST_PULLREQUESTID": "1234"},
+		"Azure Repos": {"SYSTEM_PULLREQUEST_PULLREQUESTID": "12"},
+	}
+	for name, pullRequestEnv := range cases {
+		t.Run(name, func(t *testing.T) {
+			pullRequestEnv["BUILD_SOURCEBRANCHNAME"] = "merge"
+			pullRequestEnv["SYSTEM_PULLREQUEST_SOURCEBRANCH"] = "refs/heads/feature/azure"
+			testdata.SetUpCiEnv(t, azureEnv)
+			defer testdata.ResetEnv(t, azureEnv)
+			testdata.SetUpCiEnv(t, pullRequestEnv)
+			defer testdata.ResetEnv(t, pullRequestEnv)
+
+			env, _ := Ci{}.Map()
+
+			assert.Equal(t, "12", env.PullRequest)
+			assert.Equal(t, "feature/azure", env.Branch)
+			assert.Equal(t, "master", env.TargetBranch)
+		})
+	}
+}
@@ -25,6 +25,8 @@ func (_ Ci) Map() (env.Env, error) {
 	e.Repository = fmt.Sprintf("%s/%s", os.Getenv("BITBUCKET_REPO_OWNER"), os.Getenv("BITBUCKET_REPO_SLUG"))
 	e.Commit = os.Getenv("BITBUCKET_COMMIT")
 	e.Branch = os.Getenv("BITBUCKET_BRANCH")
+	e.TargetBranch = os.Getenv("BITBUCKET_PR_DESTINATION_BRANCH")
+	e.PullRequest = os.Getenv("BITBUCKET_PR_ID")
 	e.RepositoryUrl = os.Getenv("BITBUCKET_GIT_HTTP_ORIGIN")
 	e.Integration = Integration
 	repo, err := git.FindRepository(e.Filepath)
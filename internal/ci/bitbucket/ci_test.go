@@ -9,12 +9,14 @@ import (
 )
 
 var bitbucketEnv = map[string]string{
-	"BITBUCKET_BUILD_NUMBER":    "2",
-	"BITBUCKET_REPO_OWNER":      "debricked",
-	"BITBUCKET_REPO_SLUG":       "cli",
-	"BITBUCKET_COMMIT":          "commit",
-	"BITBUCKET_BRANCH":          "main",
-	"BITBUCKET_GIT_HTTP_ORIGIN": "https://github.com/debricked/cli",
+	"BITBUCKET_BUILD_NUMBER":          "2",
+	"BITBUCKET_REPO_OWNER":            "debricked",
+	"BITBUCKET_REPO_SLUG":             "cli",
+	"BITBUCKET_COMMIT":                "commit",
+	"BITBUCKET_BRANCH":                "main",
+	"BITBUCKET_GIT_HTTP_ORIGIN":       "https://github.com/debricked/cli",
+	"BITBUCKET_PR_DESTINATION_BRANCH": "master",
+	"BITBUCKET_PR_ID":                 "12",
 }
 
 func TestIdentify(t *testing.T) {
@@ -41,6 +43,8 @@ func assertEnv(env env.Env, t *testing.T) {
 	assert.Equal(t, Integration, env.Integration)
 	assert.NotEmpty(t, env.Author)
 	assert.Equal(t, bitbucketEnv["BITBUCKET_BRANCH"], env.Branch)
+	assert.Equal(t, bitbucketEnv["BITBUCKET_PR_DESTINATION_BRANCH"], env.TargetBranch)
+	assert.Equal(t, bitbucketEnv["BITBUCKET_PR_ID"], env.PullRequest)
 	assert.Equal(t, bitbucketEnv["BITBUCKET_GIT_HTTP_ORIGIN"], env.RepositoryUrl)
 	assert.Equal(t, bitbucketEnv["BITBUCKET_COMMIT"], env.Commit)
 	assert.Equal(t, "debricked/cli", env.Repository)
@@ -26,6 +26,11 @@ func (ci Ci) Map() (env.Env, error) {
 	e.Repository = ci.MapRepository(os.Getenv("BUILDKITE_REPO"))
 	e.Commit = os.Getenv("BUILDKITE_COMMIT")
 	e.Branch = os.Getenv("BUILDKITE_BRANCH")
+	e.TargetBranch = os.Getenv("BUILDKITE_PULL_REQUEST_BASE_BRANCH")
+	// BUILDKITE_PULL_REQUEST is false for builds not triggered by pull requests
+	if pr := os.Getenv("BUILDKITE_PULL_REQUEST"); pr != "false" {
+		e.PullRequest = pr
+	}
 	e.RepositoryUrl = ci.MapRepositoryUrl(os.Getenv("BUILDKITE_REPO"))
 	e.Integration = Integration
 	repo, err := git.FindRepository(e.Filepath)
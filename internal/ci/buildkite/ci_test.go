@@ -14,10 +14,12 @@ const (
 )
 
 var buildkiteEnv = map[string]string{
-	"BUILDKITE":        "buildkite",
-	"BUILDKITE_COMMIT": "commit",
-	"BUILDKITE_BRANCH": "main",
-	"BUILDKITE_REPO":   "https://github.com/debricked/cli.git",
+	"BUILDKITE":                          "buildkite",
+	"BUILDKITE_COMMIT":                   "commit",
+	"BUILDKITE_BRANCH":                   "main",
+	"BUILDKITE_REPO":                     "https://github.com/debricked/cli.git",
+	"BUILDKITE_PULL_REQUEST_BASE_BRANCH": "master",
+	"BUILDKITE_PULL_REQUEST":             "12",
 }
 
 func TestIdentify(t *testing.T) {
@@ -39,6 +41,20 @@ func TestParse(t *testing.T) {
 	assertEnv(e, t)
 }
 
+func TestParseWithoutPullRequest(t *testing.T) {
+	pushEnv := map[string]string{"BUILDKITE_PULL_REQUEST": "false"}
+	testdata.SetUpCiEnv(t, buildkiteEnv)
+	defer testdata.ResetEnv(t, buildkiteEnv)
+	testdata.SetUpCiEnv(t, pushEnv)
+
+	cwd := testdata.SetUpGitRepository(t, true)
+	defer testdata.TearDownGitRepository(cwd, t)
+
+	e, _ := Ci{}.Map()
+
+	assert.Empty(t, e.PullRequest)
+}
+
 func TestMapRepository(t *testing.T) {
 	ci := Ci{}
 	cases := []string{
@@ -80,6 +96,8 @@ func assertEnv(env env.Env, t *testing.T) {
 	assert.Equal(t, Integration, env.Integration)
 	assert.NotEmpty(t, env.Author)
 	assert.Equal(t, buildkiteEnv["BUILDKITE_BRANCH"], env.Branch)
+	assert.Equal(t, buildkiteEnv["BUILDKITE_PULL_REQUEST_BASE_BRANCH"], env.TargetBranch)
+	assert.Equal(t, buildkiteEnv["BUILDKITE_PULL_REQUEST"], env.PullRequest)
 	assert.Equal(t, debrickedCliUrl, env.RepositoryUrl)
 	assert.Equal(t, buildkiteEnv["BUILDKITE_COMMIT"], env.Commit)
 	assert.Equal(t, debrickedCli, env.Repository)
@@ -3,6 +3,7 @@ package circleci
 import (
 	"fmt"
 	"os"
+	"path"
 	"regexp"
 
 	"github.com/debricked/cli/internal/ci/env"
@@ -26,6 +27,11 @@ func (ci Ci) Map() (env.Env, error) {
 	e.Repository = fmt.Sprintf("%s/%s", os.Getenv("CIRCLE_PROJECT_USERNAME"), os.Getenv("CIRCLE_PROJECT_REPONAME"))
 	e.Commit = os.Getenv("CIRCLE_SHA1")
 	e.Branch = os.Getenv("CIRCLE_BRANCH")
+	// CIRCLE_PR_NUMBER is only set for pull requests from forks, while CIRCLE_PULL_REQUEST is the URL of the pull request
+	e.PullRequest = os.Getenv("CIRCLE_PR_NUMBER")
+	if pullRequestUrl := os.Getenv("CIRCLE_PULL_REQUEST"); len(e.PullRequest) == 0 && len(pullRequestUrl) > 0 {
+		e.PullRequest = path.Base(pullRequestUrl)
+	}
 	e.RepositoryUrl = ci.MapRepositoryUrl(os.Getenv("CIRCLE_REPOSITORY_URL"))
 	e.Integration = Integration
 	repo, err := git.FindRepository(e.Filepath)
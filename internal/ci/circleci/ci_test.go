@@ -19,6 +19,7 @@ var circleCiEnv = map[string]string{
 	"CIRCLE_SHA1":             "commit",
 	"CIRCLE_BRANCH":           "main",
 	"CIRCLE_REPOSITORY_URL":   "https://github.com/debricked/cli.git",
+	"CIRCLE_PULL_REQUEST":     "https://github.com/debricked/cli/pull/12",
 }
 
 func TestIdentify(t *testing.T) {
@@ -41,6 +42,21 @@ func TestParse(t *testing.T) {
 	assertEnv(e, t)
 }
 
+func TestParseForkPullRequest(t *testing.T) {
+	forkEnv := map[string]string{"CIRCLE_PR_NUMBER": "13"}
+	testdata.SetUpCiEnv(t, circleCiEnv)
+	defer testdata.ResetEnv(t, circleCiEnv)
+	testdata.SetUpCiEnv(t, forkEnv)
+	defer testdata.ResetEnv(t, forkEnv)
+
+	cwd := testdata.SetUpGitRepository(t, true)
+	defer testdata.TearDownGitRepository(cwd, t)
+
+	e, _ := Ci{}.Map()
+
+	assert.Equal(t, "13", e.PullRequest)
+}
+
 func TestMapRepositoryUrl(t *testing.T) {
 	ci := Ci{}
 	cases := map[string]string{
@@ -64,6 +80,7 @@ func assertEnv(env env.Env, t *testing.T) {
 	assert.Equal(t, Integration, env.Integration)
 	assert.NotEmpty(t, env.Author)
 	assert.Equal(t, circleCiEnv["CIRCLE_BRANCH"], env.Branch)
+	assert.Equal(t, "12", env.PullRequest)
 	assert.Equal(t, debrickedUrl, env.RepositoryUrl)
 	assert.Equal(t, circleCiEnv["CIRCLE_SHA1"], env.Commit)
 	assert.Equal(t, "debricked/cli", env.Repository)
@@ -0,0 +1,37 @@
+package codebuild
+
+import (
+	"os"
+	"strings"
+
+	"github.com/debricked/cli/internal/ci/env"
+	"github.com/debricked/cli/internal/ci/util"
+)
+
+const (
+	EnvKey      = "CODEBUILD_BUILD_ID"
+	Integration = "awsCodeBuild"
+)
+
+type Ci struct{}
+
+func (_ Ci) Identify() bool {
+	return util.EnvKeyIsSet(EnvKey)
+}
+
+func (_ Ci) Map() (env.Env, error) {
+	e := env.Env{}
+	e.Repository = util.MapRepository(os.Getenv("CODEBUILD_SOURCE_REPO_URL"))
+	e.Commit = os.Getenv("CODEBUILD_RESOLVED_SOURCE_VERSION")
+	// Branches are only given for builds started by webhooks, as refs/heads/master
+	e.Branch = strings.TrimPrefix(os.Getenv("CODEBUILD_WEBHOOK_HEAD_REF"), "refs/heads/")
+	e.TargetBranch = strings.TrimPrefix(os.Getenv("CODEBUILD_WEBHOOK_BASE_REF"), "refs/heads/")
+	// Pull request builds are triggered by pr/<number>, and pushes by branch/<name>
+	if trigger := os.Getenv("CODEBUILD_WEBHOOK_TRIGGER"); strings.HasPrefix(trigger, "pr/") {
+		e.PullRequest = strings.TrimPrefix(trigger, "pr/")
+	}
+	e.RepositoryUrl = util.MapRepositoryUrl(os.Getenv("CODEBUILD_SOURCE_REPO_URL"))
+	e.Integration = Integration
+
+	return e, nil
+}
@@ -0,0 +1,90 @@
+package codebuild
+
+import (
+	"os"
+	"testing"
+
+	"github.com/debricked/cli/internal/ci/testdata"
+	"github.com/debricked/cli/internal/ci/util"
+	"github.com/stretchr/testify/assert"
+)
+
+var codeBuildEnv = map[string]string{
+	"CODEBUILD_BUILD_ID":                "debricked:0b2f8a3e",
+	"CODEBUILD_SOURCE_REPO_URL":         "https://github.com/debricked/cli.git",
+	"CODEBUILD_RESOLVED_SOURCE_VERSION": "commit",
+	"CODEBUILD_WEBHOOK_HEAD_REF":        "refs/heads/feature/codebuild",
+	"CODEBUILD_WEBHOOK_BASE_REF":        "refs/heads/main",
+	"CODEBUILD_WEBHOOK_TRIGGER":         "pr/12",
+}
+
+func TestIdentify(t *testing.T) {
+	ci := Ci{}
+	value := os.Getenv(EnvKey)
+	if util.EnvKeyIsSet(EnvKey) {
+		if !ci.Identify() {
+			t.Error("failed to assert that CI was identified")
+		}
+		_ = os.Unsetenv(EnvKey)
+		defer os.Setenv(EnvKey, value)
+
+		if ci.Identify() {
+			t.Error("failed to assert that CI was not identified")
+		}
+	} else {
+		testdata.AssertIdentify(t, ci.Identify, EnvKey)
+	}
+}
+
+type parseCase struct {
+	name         string
+	env          map[string]string
+	branch       string
+	targetBranch string
+	pullRequest  string
+}
+
+func TestParse(t *testing.T) {
+	cases := []parseCase{
+		{
+			name:         "pull request",
+			env:          map[string]string{},
+			branch:       "feature/codebuild",
+			targetBranch: "main",
+			pullRequest:  "12",
+		},
+		{
+			name:         "push",
+			env:          map[string]string{"CODEBUILD_WEBHOOK_TRIGGER": "branch/main"},
+			branch:       "feature/codebuild",
+			targetBranch: "main",
+		},
+		{
+			name: "without webhook",
+			env:  map[string]string{"CODEBUILD_WEBHOOK_HEAD_REF": "", "CODEBUILD_WEBHOOK_BASE_REF": "", "CODEBUILD_WEBHOOK_TRIGGER": ""},
+		},
+	}
+
+	ci := Ci{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testdata.SetUpCiEnv(t, codeBuildEnv)
+			defer testdata.ResetEnv(t, codeBuildEnv)
+			testdata.SetUpCiEnv(t, c.env)
+			defer testdata.ResetEnv(t, c.env)
+
+			env, err := ci.Map()
+
+			assert.NoError(t, err)
+			assert.Empty(t, env.Filepath)
+			assert.Equal(t, Integration, env.Integration)
+			assert.Empty(t, env.Author)
+			assert.Equal(t, c.branch, env.Branch)
+			assert.Equal(t, c.targetBranch, env.TargetBranch)
+			assert.Equal(t, c.pullRequest, env.PullRequest)
+			assert.Equal(t, "https://github.com/debricked/cli", env.RepositoryUrl)
+			assert.Equal(t, codeBuildEnv["CODEBUILD_RESOLVED_SOURCE_VERSION"], env.Commit)
+			assert.Equal(t, "debricked/cli", env.Repository)
+		})
+	}
+}
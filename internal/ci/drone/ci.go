@@ -0,0 +1,39 @@
+package drone
+
+import (
+	"os"
+
+	"github.com/debricked/cli/internal/ci/env"
+	"github.com/debricked/cli/internal/ci/util"
+)
+
+const (
+	EnvKey      = "DRONE"
+	Integration = "drone"
+)
+
+type Ci struct{}
+
+func (_ Ci) Identify() bool {
+	return util.EnvKeyIsSet(EnvKey)
+}
+
+func (_ Ci) Map() (env.Env, error) {
+	e := env.Env{}
+	e.Repository = os.Getenv("DRONE_REPO")
+	e.Commit = os.Getenv("DRONE_COMMIT_SHA")
+	// DRONE_SOURCE_BRANCH is the branch of pull requests, and equals DRONE_COMMIT_BRANCH for pushes
+	e.Branch = os.Getenv("DRONE_SOURCE_BRANCH")
+	if len(e.Branch) == 0 {
+		e.Branch = os.Getenv("DRONE_COMMIT_BRANCH")
+	}
+	if util.EnvKeyIsSet("DRONE_PULL_REQUEST") {
+		e.TargetBranch = os.Getenv("DRONE_TARGET_BRANCH")
+		e.PullRequest = os.Getenv("DRONE_PULL_REQUEST")
+	}
+	e.RepositoryUrl = os.Getenv("DRONE_REPO_LINK")
+	e.Integration = Integration
+	e.Author = util.Author(os.Getenv("DRONE_COMMIT_AUTHOR_NAME"), os.Getenv("DRONE_COMMIT_AUTHOR_EMAIL"))
+
+	return e, nil
+}
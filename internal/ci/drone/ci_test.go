@@ -0,0 +1,93 @@
+package drone
+
+import (
+	"os"
+	"testing"
+
+	"github.com/debricked/cli/internal/ci/testdata"
+	"github.com/debricked/cli/internal/ci/util"
+	"github.com/stretchr/testify/assert"
+)
+
+var droneEnv = map[string]string{
+	"DRONE":                     "true",
+	"DRONE_REPO":                "debricked/cli",
+	"DRONE_COMMIT_SHA":          "commit",
+	"DRONE_COMMIT_BRANCH":       "main",
+	"DRONE_SOURCE_BRANCH":       "main",
+	"DRONE_TARGET_BRANCH":       "main",
+	"DRONE_REPO_LINK":           "https://github.com/debricked/cli",
+	"DRONE_COMMIT_AUTHOR_NAME":  "viktigpetterr",
+	"DRONE_COMMIT_AUTHOR_EMAIL": "test@test.com",
+}
+
+func TestIdentify(t *testing.T) {
+	ci := Ci{}
+	value := os.Getenv(EnvKey)
+	if util.EnvKeyIsSet(EnvKey) {
+		if !ci.Identify() {
+			t.Error("failed to assert that CI was identified")
+		}
+		_ = os.Unsetenv(EnvKey)
+		defer os.Setenv(EnvKey, value)
+
+		if ci.Identify() {
+			t.Error("failed to assert that CI was not identified")
+		}
+	} else {
+		testdata.AssertIdentify(t, ci.Identify, EnvKey)
+	}
+}
+
+type parseCase struct {
+	name         string
+	env          map[string]string
+	branch       string
+	targetBranch string
+	pullRequest  string
+}
+
+func TestParse(t *testing.T) {
+	cases := []parseCase{
+		{
+			name:   "push",
+			env:    map[string]string{},
+			branch: "main",
+		},
+		{
+			name:   "push without DRONE_SOURCE_BRANCH",
+			env:    map[string]string{"DRONE_SOURCE_BRANCH": ""},
+			branch: "main",
+		},
+		{
+			name:         "pull request",
+			env:          map[string]string{"DRONE_PULL_REQUEST": "12", "DRONE_SOURCE_BRANCH": "feature/drone"},
+			branch:       "feature/drone",
+			targetBranch: "main",
+			pullRequest:  "12",
+		},
+	}
+
+	ci := Ci{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testdata.SetUpCiEnv(t, droneEnv)
+			defer testdata.ResetEnv(t, droneEnv)
+			testdata.SetUpCiEnv(t, c.env)
+			defer testdata.ResetEnv(t, c.env)
+
+			env, err := ci.Map()
+
+			assert.NoError(t, err)
+			assert.Empty(t, env.Filepath)
+			assert.Equal(t, Integration, env.Integration)
+			assert.Equal(t, "viktigpetterr <test@test.com>", env.Author)
+			assert.Equal(t, c.branch, env.Branch)
+			assert.Equal(t, c.targetBranch, env.TargetBranch)
+			assert.Equal(t, c.pullRequest, env.PullRequest)
+			assert.Equal(t, droneEnv["DRONE_REPO_LINK"], env.RepositoryUrl)
+			assert.Equal(t, droneEnv["DRONE_COMMIT_SHA"], env.Commit)
+			assert.Equal(t, "debricked/cli", env.Repository)
+		})
+	}
+}
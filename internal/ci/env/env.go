@@ -4,6 +4,9 @@ type Env struct {
 	Repository    string
 	Commit        string
 	Branch        string
+	TargetBranch  string
+	PullRequest   string
+	BaseCommit    string
 	Author        string
 	RepositoryUrl string
 	Integration   string
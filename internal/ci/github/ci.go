@@ -1,8 +1,10 @@
 package github
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/debricked/cli/internal/ci/env"
@@ -33,7 +35,13 @@ func (_ Ci) Map() (env.Env, error) {
 	if strings.Contains(branch, "/merge") {
 		branch = os.Getenv("GITHUB_HEAD_REF")
 	}
+	// GitHub gives pull requests as refs/pull/18/merge
+	if strings.HasPrefix(gitHubRef, "refs/pull/") {
+		e.PullRequest = strings.TrimSuffix(strings.TrimPrefix(gitHubRef, "refs/pull/"), "/merge")
+		e.BaseCommit = findBaseCommit(os.Getenv("GITHUB_EVENT_PATH"))
+	}
 	e.Branch = branch
+	e.TargetBranch = os.Getenv("GITHUB_BASE_REF")
 
 	e.RepositoryUrl = fmt.Sprintf("https://github.com/%s", os.Getenv("GITHUB_REPOSITORY"))
 	e.Integration = Integration
@@ -41,3 +49,29 @@ func (_ Ci) Map() (env.Env, error) {
 
 	return e, nil
 }
+
+type pullRequestEvent struct {
+	PullRequest struct {
+		Base struct {
+			Sha string `json:"sha"`
+		} `json:"base"`
+	} `json:"pull_request"`
+}
+
+// findBaseCommit returns the commit of the target branch from the pull request event at eventPath, or an empty
+// string if it could not be read
+func findBaseCommit(eventPath string) string {
+	if len(eventPath) == 0 {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Clean(eventPath))
+	if err != nil {
+		return ""
+	}
+	var event pullRequestEvent
+	if err = json.Unmarshal(data, &event); err != nil {
+		return ""
+	}
+
+	return event.PullRequest.Base.Sha
+}
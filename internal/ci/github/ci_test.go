@@ -2,6 +2,7 @@ package github
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/ci/testdata"
@@ -16,6 +17,7 @@ var gitHubActionsEnv = map[string]string{
 	"GITHUB_REF":        "main",
 	"GITHUB_ACTOR":      "viktigpetterr <test@test.com>",
 	"GITHUB_HEAD_REF":   "main",
+	"GITHUB_BASE_REF":   "master",
 }
 
 func TestIdentify(t *testing.T) {
@@ -78,6 +80,7 @@ func TestParse(t *testing.T) {
 			assert.Equal(t, Integration, env.Integration)
 			assert.Equal(t, gitHubActionsEnv["GITHUB_ACTOR"], env.Author)
 			assert.Equal(t, gitHubActionsEnv["GITHUB_HEAD_REF"], env.Branch)
+			assert.Equal(t, gitHubActionsEnv["GITHUB_BASE_REF"], env.TargetBranch)
 			assert.Equal(t, "https://github.com/debricked/cli", env.RepositoryUrl)
 			assert.Equal(t, gitHubActionsEnv["GITHUB_SHA"], env.Commit)
 			assert.Equal(t, "debricked/cli", env.Repository)
@@ -85,3 +88,51 @@ func TestParse(t *testing.T) {
 	}
 
 }
+
+func TestParsePullRequest(t *testing.T) {
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	assert.NoError(t, os.WriteFile(eventPath, []byte(`{"pull_request": {"base": {"sha": "base-commit"}}}`), 0600))
+	pullRequestEnv := map[string]string{
+		"GITHUB_REF":        "refs/pull/18/merge",
+		"GITHUB_EVENT_PATH": eventPath,
+	}
+	testdata.SetUpCiEnv(t, gitHubActionsEnv)
+	defer testdata.ResetEnv(t, gitHubActionsEnv)
+	testdata.SetUpCiEnv(t, pullRequestEnv)
+	defer testdata.ResetEnv(t, pullRequestEnv)
+
+	env, _ := Ci{}.Map()
+
+	assert.Equal(t, "18", env.PullRequest)
+	assert.Equal(t, "base-commit", env.BaseCommit)
+	assert.Equal(t, gitHubActionsEnv["GITHUB_BASE_REF"], env.TargetBranch)
+}
+
+func TestParsePush(t *testing.T) {
+	pushEnv := map[string]string{
+		"GITHUB_REF":        "refs/heads/main",
+		"GITHUB_EVENT_PATH": filepath.Join(t.TempDir(), "missing.json"),
+	}
+	testdata.SetUpCiEnv(t, gitHubActionsEnv)
+	defer testdata.ResetEnv(t, gitHubActionsEnv)
+	testdata.SetUpCiEnv(t, pushEnv)
+	defer testdata.ResetEnv(t, pushEnv)
+
+	env, _ := Ci{}.Map()
+
+	assert.Empty(t, env.PullRequest)
+	assert.Empty(t, env.BaseCommit)
+}
+
+func TestFindBaseCommit(t *testing.T) {
+	dir := t.TempDir()
+	invalidEvent := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalidEvent, []byte("{"), 0600))
+	pushEvent := filepath.Join(dir, "push.json")
+	assert.NoError(t, os.WriteFile(pushEvent, []byte(`{"ref": "refs/heads/main"}`), 0600))
+
+	assert.Empty(t, findBaseCommit(""))
+	assert.Empty(t, findBaseCommit(filepath.Join(dir, "missing.json")))
+	assert.Empty(t, findBaseCommit(invalidEvent))
+	assert.Empty(t, findBaseCommit(pushEvent))
+}
@@ -23,6 +23,9 @@ func (_ Ci) Map() (env.Env, error) {
 	e.Repository = os.Getenv("CI_PROJECT_PATH")
 	e.Commit = os.Getenv("CI_COMMIT_SHA")
 	e.Branch = os.Getenv("CI_COMMIT_REF_NAME")
+	e.TargetBranch = os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME")
+	e.PullRequest = os.Getenv("CI_MERGE_REQUEST_IID")
+	e.BaseCommit = os.Getenv("CI_MERGE_REQUEST_DIFF_BASE_SHA")
 	e.RepositoryUrl = os.Getenv("CI_PROJECT_URL")
 	e.Integration = Integration
 	e.Filepath = os.Getenv("CI_PROJECT_DIR")
@@ -8,13 +8,16 @@ import (
 )
 
 var gitLabEnv = map[string]string{
-	"GITLAB_CI":          "gitlab",
-	"CI_PROJECT_PATH":    "debricked/cli",
-	"CI_COMMIT_SHA":      "commit",
-	"CI_COMMIT_REF_NAME": "main",
-	"CI_PROJECT_DIR":     "/",
-	"CI_PROJECT_URL":     "https://gitlab.com/debricked/cli",
-	"CI_COMMIT_AUTHOR":   "viktigpetterr <test@test.com>",
+	"GITLAB_CI":                           "gitlab",
+	"CI_PROJECT_PATH":                     "debricked/cli",
+	"CI_COMMIT_SHA":                       "commit",
+	"CI_COMMIT_REF_NAME":                  "main",
+	"CI_PROJECT_DIR":                      "/",
+	"CI_PROJECT_URL":                      "https://gitlab.com/debricked/cli",
+	"CI_COMMIT_AUTHOR":                    "viktigpetterr <test@test.com>",
+	"CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "master",
+	"CI_MERGE_REQUEST_IID":                "12",
+	"CI_MERGE_REQUEST_DIFF_BASE_SHA":      "base-commit",
 }
 
 func TestIdentify(t *testing.T) {
@@ -32,6 +35,9 @@ func TestParse(t *testing.T) {
 	assert.Equal(t, Integration, env.Integration)
 	assert.Equal(t, gitLabEnv["CI_COMMIT_AUTHOR"], env.Author)
 	assert.Equal(t, gitLabEnv["CI_COMMIT_REF_NAME"], env.Branch)
+	assert.Equal(t, gitLabEnv["CI_MERGE_REQUEST_TARGET_BRANCH_NAME"], env.TargetBranch)
+	assert.Equal(t, gitLabEnv["CI_MERGE_REQUEST_IID"], env.PullRequest)
+	assert.Equal(t, gitLabEnv["CI_MERGE_REQUEST_DIFF_BASE_SHA"], env.BaseCommit)
 	assert.Equal(t, "https://gitlab.com/debricked/cli", env.RepositoryUrl)
 	assert.Equal(t, gitLabEnv["CI_COMMIT_SHA"], env.Commit)
 	assert.Equal(t, "debricked/cli", env.Repository)
@@ -0,0 +1,45 @@
+package jenkins
+
+import (
+	"os"
+	"strings"
+
+	"github.com/debricked/cli/internal/ci/env"
+	"github.com/debricked/cli/internal/ci/util"
+)
+
+const (
+	EnvKey      = "JENKINS_URL"
+	Integration = "jenkins"
+)
+
+type Ci struct{}
+
+func (_ Ci) Identify() bool {
+	return util.EnvKeyIsSet(EnvKey)
+}
+
+func (_ Ci) Map() (env.Env, error) {
+	e := env.Env{}
+	e.Repository = util.MapRepository(os.Getenv("GIT_URL"))
+	e.Commit = os.Getenv("GIT_COMMIT")
+
+	// Multibranch pipelines name pull request builds PR-<number> in BRANCH_NAME, and give the source and target
+	// branches in CHANGE_BRANCH and CHANGE_TARGET. The Git plugin gives branches as origin/master in GIT_BRANCH.
+	switch {
+	case util.EnvKeyIsSet("CHANGE_BRANCH"):
+		e.Branch = os.Getenv("CHANGE_BRANCH")
+		e.TargetBranch = os.Getenv("CHANGE_TARGET")
+		e.PullRequest = os.Getenv("CHANGE_ID")
+	case util.EnvKeyIsSet("BRANCH_NAME"):
+		e.Branch = os.Getenv("BRANCH_NAME")
+	default:
+		e.Branch = strings.TrimPrefix(os.Getenv("GIT_BRANCH"), "origin/")
+	}
+
+	e.RepositoryUrl = util.MapRepositoryUrl(os.Getenv("GIT_URL"))
+	e.Integration = Integration
+	e.Author = util.Author(os.Getenv("GIT_AUTHOR_NAME"), os.Getenv("GIT_AUTHOR_EMAIL"))
+
+	return e, nil
+}
@@ -0,0 +1,90 @@
+package jenkins
+
+import (
+	"os"
+	"testing"
+
+	"github.com/debricked/cli/internal/ci/testdata"
+	"github.com/debricked/cli/internal/ci/util"
+	"github.com/stretchr/testify/assert"
+)
+
+var jenkinsEnv = map[string]string{
+	"JENKINS_URL":      "https://jenkins.debricked.com/",
+	"GIT_URL":          "git@github.com:debricked/cli.git",
+	"GIT_COMMIT":       "commit",
+	"GIT_BRANCH":       "origin/main",
+	"GIT_AUTHOR_NAME":  "viktigpetterr",
+	"GIT_AUTHOR_EMAIL": "test@test.com",
+}
+
+func TestIdentify(t *testing.T) {
+	ci := Ci{}
+	value := os.Getenv(EnvKey)
+	if util.EnvKeyIsSet(EnvKey) {
+		if !ci.Identify() {
+			t.Error("failed to assert that CI was identified")
+		}
+		_ = os.Unsetenv(EnvKey)
+		defer os.Setenv(EnvKey, value)
+
+		if ci.Identify() {
+			t.Error("failed to assert that CI was not identified")
+		}
+	} else {
+		testdata.AssertIdentify(t, ci.Identify, EnvKey)
+	}
+}
+
+type parseCase struct {
+	name         string
+	env          map[string]string
+	branch       string
+	targetBranch string
+	pullRequest  string
+}
+
+func TestParse(t *testing.T) {
+	cases := []parseCase{
+		{
+			name:   "GIT_BRANCH",
+			env:    map[string]string{},
+			branch: "main",
+		},
+		{
+			name:   "BRANCH_NAME of multibranch pipelines",
+			env:    map[string]string{"BRANCH_NAME": "feature/jenkins"},
+			branch: "feature/jenkins",
+		},
+		{
+			name:         "CHANGE_BRANCH of multibranch pipelines",
+			env:          map[string]string{"BRANCH_NAME": "PR-12", "CHANGE_ID": "12", "CHANGE_BRANCH": "feature/jenkins", "CHANGE_TARGET": "main"},
+			branch:       "feature/jenkins",
+			targetBranch: "main",
+			pullRequest:  "12",
+		},
+	}
+
+	ci := Ci{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testdata.SetUpCiEnv(t, jenkinsEnv)
+			defer testdata.ResetEnv(t, jenkinsEnv)
+			testdata.SetUpCiEnv(t, c.env)
+			defer testdata.ResetEnv(t, c.env)
+
+			env, err := ci.Map()
+
+			assert.NoError(t, err)
+			assert.Empty(t, env.Filepath)
+			assert.Equal(t, Integration, env.Integration)
+			assert.Equal(t, "viktigpetterr <test@test.com>", env.Author)
+			assert.Equal(t, c.branch, env.Branch)
+			assert.Equal(t, c.targetBranch, env.TargetBranch)
+			assert.Equal(t, c.pullRequest, env.PullRequest)
+			assert.Equal(t, "https://github.com/debricked/cli", env.RepositoryUrl)
+			assert.Equal(t, jenkinsEnv["GIT_COMMIT"], env.Commit)
+			assert.Equal(t, "debricked/cli", env.Repository)
+		})
+	}
+}
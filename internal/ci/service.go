@@ -9,10 +9,16 @@ import (
 	"github.com/debricked/cli/internal/ci/bitbucket"
 	"github.com/debricked/cli/internal/ci/buildkite"
 	"github.com/debricked/cli/internal/ci/circleci"
+	"github.com/debricked/cli/internal/ci/codebuild"
+	"github.com/debricked/cli/internal/ci/drone"
 	"github.com/debricked/cli/internal/ci/env"
 	"github.com/debricked/cli/internal/ci/github"
 	"github.com/debricked/cli/internal/ci/gitlab"
+	"github.com/debricked/cli/internal/ci/jenkins"
+	"github.com/debricked/cli/internal/ci/teamcity"
+	"github.com/debricked/cli/internal/ci/tekton"
 	"github.com/debricked/cli/internal/ci/travis"
+	"github.com/debricked/cli/internal/ci/woodpecker"
 )
 
 type IService interface {
@@ -34,9 +40,18 @@ func NewService(cis []ICi) *Service {
 				bitbucket.Ci{},
 				buildkite.Ci{},
 				circleci.Ci{},
+				codebuild.Ci{},
+				// Woodpecker sets the variables of Drone in older versions
+				woodpecker.Ci{},
+				drone.Ci{},
 				github.Ci{},
 				gitlab.Ci{},
+				jenkins.Ci{},
+				teamcity.Ci{},
 				travis.Ci{},
+				// Tekton is identified by its directory, which other CIs running on Tekton would also have, and by
+				// DEBRICKED_GIT_URL, which Argo Workflows also uses
+				tekton.Ci{},
 			},
 		}
 	}
@@ -16,7 +16,7 @@ func TestNewService(t *testing.T) {
 	assert.Empty(t, s.cis)
 
 	s = NewService(nil)
-	assert.Len(t, s.cis, 8)
+	assert.Len(t, s.cis, 14)
 
 	s.cis = []ICi{gitlab.Ci{}}
 	assert.Len(t, s.cis, 1)
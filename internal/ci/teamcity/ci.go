@@ -0,0 +1,29 @@
+package teamcity
+
+import (
+	"os"
+
+	"github.com/debricked/cli/internal/ci/env"
+	"github.com/debricked/cli/internal/ci/util"
+)
+
+const (
+	EnvKey      = "TEAMCITY_VERSION"
+	Integration = "teamcity"
+)
+
+type Ci struct{}
+
+func (_ Ci) Identify() bool {
+	return util.EnvKeyIsSet(EnvKey)
+}
+
+// Map maps the commit of the build. TeamCity only passes other VCS details to builds as configuration parameters,
+// so the repository, branch and author are found in the checked out repository
+func (_ Ci) Map() (env.Env, error) {
+	e := env.Env{}
+	e.Commit = os.Getenv("BUILD_VCS_NUMBER")
+	e.Integration = Integration
+
+	return e, nil
+}
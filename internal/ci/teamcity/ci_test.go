@@ -0,0 +1,70 @@
+package teamcity
+
+import (
+	"os"
+	"testing"
+
+	"github.com/debricked/cli/internal/ci/testdata"
+	"github.com/debricked/cli/internal/ci/util"
+	"github.com/stretchr/testify/assert"
+)
+
+var teamCityEnv = map[string]string{
+	"TEAMCITY_VERSION": "2023.11.1 (build 147412)",
+	"BUILD_VCS_NUMBER": "commit",
+}
+
+func TestIdentify(t *testing.T) {
+	ci := Ci{}
+	value := os.Getenv(EnvKey)
+	if util.EnvKeyIsSet(EnvKey) {
+		if !ci.Identify() {
+			t.Error("failed to assert that CI was identified")
+		}
+		_ = os.Unsetenv(EnvKey)
+		defer os.Setenv(EnvKey, value)
+
+		if ci.Identify() {
+			t.Error("failed to assert that CI was not identified")
+		}
+	} else {
+		testdata.AssertIdentify(t, ci.Identify, EnvKey)
+	}
+}
+
+type parseCase struct {
+	name   string
+	env    map[string]string
+	commit string
+}
+
+func TestParse(t *testing.T) {
+	cases := []parseCase{
+		{
+			name:   "BUILD_VCS_NUMBER",
+			env:    teamCityEnv,
+			commit: teamCityEnv["BUILD_VCS_NUMBER"],
+		},
+		{
+			name: "without BUILD_VCS_NUMBER",
+			env:  map[string]string{"TEAMCITY_VERSION": teamCityEnv["TEAMCITY_VERSION"]},
+		},
+	}
+
+	ci := Ci{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testdata.SetUpCiEnv(t, c.env)
+			defer testdata.ResetEnv(t, c.env)
+
+			env, err := ci.Map()
+
+			assert.NoError(t, err)
+			assert.Empty(t, env.Filepath)
+			assert.Equal(t, Integration, env.Integration)
+			assert.Equal(t, c.commit, env.Commit)
+			assert.Empty(t, env.Repository)
+			assert.Empty(t, env.Branch)
+		})
+	}
+}
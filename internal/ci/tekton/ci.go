@@ -0,0 +1,40 @@
+package tekton
+
+import (
+	"os"
+
+	"github.com/debricked/cli/internal/ci/env"
+	"github.com/debricked/cli/internal/ci/util"
+)
+
+const (
+	EnvKey      = "DEBRICKED_GIT_URL"
+	Integration = "tekton"
+)
+
+// tektonDir is mounted into all step containers of Tekton tasks
+var tektonDir = "/tekton"
+
+type Ci struct{}
+
+// Identify checks both DEBRICKED_GIT_URL and the Tekton directory, as Tekton gives steps no variables of its own.
+// Tasks that do not set DEBRICKED_GIT_URL are not identified, as a directory alone could belong to any container
+func (_ Ci) Identify() bool {
+	if !util.EnvKeyIsSet(EnvKey) {
+		return false
+	}
+	info, err := os.Stat(tektonDir)
+
+	return err == nil && info.IsDir()
+}
+
+// Map maps the URL of the repository from DEBRICKED_GIT_URL, which can be set from the url result of the git-clone
+// task. The commit, branch and author are found in the checked out repository
+func (_ Ci) Map() (env.Env, error) {
+	e := env.Env{}
+	e.Repository = util.MapRepository(os.Getenv(EnvKey))
+	e.RepositoryUrl = util.MapRepositoryUrl(os.Getenv(EnvKey))
+	e.Integration = Integration
+
+	return e, nil
+}
@@ -0,0 +1,82 @@
+package tekton
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/ci/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+var tektonEnv = map[string]string{
+	"DEBRICKED_GIT_URL": "https://github.com/debricked/cli.git",
+}
+
+func TestIdentify(t *testing.T) {
+	ci := Ci{}
+	value := os.Getenv(EnvKey)
+	if ci.Identify() {
+		_ = os.Unsetenv(EnvKey)
+		defer os.Setenv(EnvKey, value)
+
+		if ci.Identify() {
+			t.Error("failed to assert that CI was not identified")
+		}
+	} else {
+		defaultDir := tektonDir
+		defer func() { tektonDir = defaultDir }()
+		tektonDir = t.TempDir()
+
+		testdata.AssertIdentify(t, ci.Identify, EnvKey)
+	}
+}
+
+func TestIdentifyWithoutDir(t *testing.T) {
+	defaultDir := tektonDir
+	defer func() { tektonDir = defaultDir }()
+	testdata.SetUpCiEnv(t, tektonEnv)
+	defer testdata.ResetEnv(t, tektonEnv)
+	ci := Ci{}
+
+	tektonDir = filepath.Join(t.TempDir(), "tekton")
+	assert.False(t, ci.Identify(), "failed to assert that CI was not identified")
+
+	assert.NoError(t, os.WriteFile(tektonDir, nil, 0600))
+	assert.False(t, ci.Identify(), "failed to assert that CI was not identified by a file")
+}
+
+type parseCase struct {
+	name string
+	env  map[string]string
+}
+
+func TestParse(t *testing.T) {
+	cases := []parseCase{
+		{
+			name: "DEBRICKED_GIT_URL with https",
+			env:  tektonEnv,
+		},
+		{
+			name: "DEBRICKED_GIT_URL with ssh",
+			env:  map[string]string{"DEBRICKED_GIT_URL": "git@github.com:debricked/cli.git"},
+		},
+	}
+
+	ci := Ci{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testdata.SetUpCiEnv(t, c.env)
+			defer testdata.ResetEnv(t, c.env)
+
+			env, err := ci.Map()
+
+			assert.NoError(t, err)
+			assert.Empty(t, env.Filepath)
+			assert.Equal(t, Integration, env.Integration)
+			assert.Equal(t, "https://github.com/debricked/cli", env.RepositoryUrl)
+			assert.Equal(t, "debricked/cli", env.Repository)
+			assert.Empty(t, env.Commit)
+		})
+	}
+}
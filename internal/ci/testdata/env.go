@@ -74,7 +74,7 @@ func TearDownGitRepository(dir string, t *testing.T) {
 }
 
 func ResetEnv(t *testing.T, ciEnv map[string]string) {
-	for _, variable := range ciEnv {
+	for variable := range ciEnv {
 		UnsetEnvVar(t, variable)
 	}
 }
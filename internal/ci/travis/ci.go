@@ -25,6 +25,12 @@ func (_ Ci) Map() (env.Env, error) {
 	e.Repository = os.Getenv("TRAVIS_REPO_SLUG")
 	e.Commit = os.Getenv("TRAVIS_COMMIT")
 	e.Branch = os.Getenv("TRAVIS_BRANCH")
+	// TRAVIS_BRANCH is the target branch in pull request builds
+	if pr := os.Getenv("TRAVIS_PULL_REQUEST"); len(pr) > 0 && pr != "false" {
+		e.PullRequest = pr
+		e.TargetBranch = e.Branch
+		e.Branch = os.Getenv("TRAVIS_PULL_REQUEST_BRANCH")
+	}
 	e.RepositoryUrl = fmt.Sprintf("https://github.com/%s", e.Repository)
 	e.Integration = Integration
 	//# The absolute path to the directory where the repository being built has been copied on the worker.
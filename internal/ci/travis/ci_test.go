@@ -9,10 +9,11 @@ import (
 )
 
 var travisEnv = map[string]string{
-	"TRAVIS_REPO_SLUG": "debricked/cli",
-	"TRAVIS_BRANCH":    "main",
-	"TRAVIS_COMMIT":    "commit",
-	"TRAVIS_BUILD_DIR": ".",
+	"TRAVIS_REPO_SLUG":    "debricked/cli",
+	"TRAVIS_BRANCH":       "main",
+	"TRAVIS_COMMIT":       "commit",
+	"TRAVIS_BUILD_DIR":    ".",
+	"TRAVIS_PULL_REQUEST": "false",
 }
 
 func TestIdentify(t *testing.T) {
@@ -34,11 +35,34 @@ func TestParse(t *testing.T) {
 	assertEnv(t, e)
 }
 
+func TestParsePullRequest(t *testing.T) {
+	pullRequestEnv := map[string]string{}
+	for variable, value := range travisEnv {
+		pullRequestEnv[variable] = value
+	}
+	pullRequestEnv["TRAVIS_PULL_REQUEST"] = "12"
+	pullRequestEnv["TRAVIS_PULL_REQUEST_BRANCH"] = "feature/travis"
+	testdata.SetUpCiEnv(t, pullRequestEnv)
+	defer testdata.ResetEnv(t, pullRequestEnv)
+	defer testdata.UnsetEnvVar(t, "TRAVIS_PULL_REQUEST")
+
+	cwd := testdata.SetUpGitRepository(t, true)
+	defer testdata.TearDownGitRepository(cwd, t)
+
+	e, _ := Ci{}.Map()
+
+	assert.Equal(t, "12", e.PullRequest)
+	assert.Equal(t, "feature/travis", e.Branch)
+	assert.Equal(t, travisEnv["TRAVIS_BRANCH"], e.TargetBranch)
+}
+
 func assertEnv(t *testing.T, env env.Env) {
 	assert.Equal(t, travisEnv["TRAVIS_BUILD_DIR"], env.Filepath)
 	assert.Equal(t, Integration, env.Integration)
 	assert.NotEmpty(t, env.Author)
 	assert.Equal(t, travisEnv["TRAVIS_BRANCH"], env.Branch)
+	assert.Empty(t, env.TargetBranch)
+	assert.Empty(t, env.PullRequest)
 	assert.Equal(t, "https://github.com/debricked/cli", env.RepositoryUrl)
 	assert.Equal(t, travisEnv["TRAVIS_COMMIT"], env.Commit)
 	assert.Equal(t, "debricked/cli", env.Repository)
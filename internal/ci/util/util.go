@@ -1,6 +1,16 @@
 package util
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+var (
+	httpRepositoryRegex = regexp.MustCompile(`^https?://[^/]+/(.+?)(?:\.git)?/?$`)
+	sshRepositoryRegex  = regexp.MustCompile(`^(?:ssh://)?[^@/]+@([^:/]+)[:/](?:[0-9]+/)?(.+?)(?:\.git)?/?$`)
+	httpUrlRegex        = regexp.MustCompile(`^(https?://.+?)(?:\.git)?/?$`)
+)
 
 func EnvKeyIsSet(key string) bool {
 	value, isPresent := os.LookupEnv(key)
@@ -10,3 +20,41 @@ func EnvKeyIsSet(key string) bool {
 
 	return false
 }
+
+// MapRepository returns the repository path of gitUrl, such as "debricked/cli" for both
+// "https://github.com/debricked/cli.git" and "git@github.com:debricked/cli.git". Other values are returned as is
+func MapRepository(gitUrl string) string {
+	if matches := httpRepositoryRegex.FindStringSubmatch(gitUrl); len(matches) == 2 {
+		return matches[1]
+	}
+	if matches := sshRepositoryRegex.FindStringSubmatch(gitUrl); len(matches) == 3 {
+		return matches[2]
+	}
+
+	return gitUrl
+}
+
+// MapRepositoryUrl returns the web URL of gitUrl, such as "https://github.com/debricked/cli" for both
+// "https://github.com/debricked/cli.git" and "git@github.com:debricked/cli.git". Other values are returned as is
+func MapRepositoryUrl(gitUrl string) string {
+	if matches := httpUrlRegex.FindStringSubmatch(gitUrl); len(matches) == 2 {
+		return matches[1]
+	}
+	if matches := sshRepositoryRegex.FindStringSubmatch(gitUrl); len(matches) == 3 {
+		return fmt.Sprintf("https://%s/%s", matches[1], matches[2])
+	}
+
+	return gitUrl
+}
+
+// Author formats a commit author as git does, "name <email>", leaving out the parts that are not set
+func Author(name string, email string) string {
+	switch {
+	case len(email) == 0:
+		return name
+	case len(name) == 0:
+		return fmt.Sprintf("<%s>", email)
+	}
+
+	return fmt.Sprintf("%s <%s>", name, email)
+}
@@ -20,3 +20,44 @@ func TestEnvKeyIsSet(t *testing.T) {
 	_ = os.Setenv(envKey, "value")
 	assert.True(t, EnvKeyIsSet(envKey), "failed to assert that env key was set")
 }
+
+func TestMapRepository(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/debricked/cli.git":            "debricked/cli",
+		"https://github.com/debricked/cli":                "debricked/cli",
+		"http://gitlab.com/debricked/sub/cli.git":         "debricked/sub/cli",
+		"git@github.com:debricked/cli.git":                "debricked/cli",
+		"ssh://git@bitbucket.org:7999/debricked/cli.git":  "debricked/cli",
+		"ssh://git@scm.com/debricked/cli.git":             "debricked/cli",
+		"https://git-codecommit.eu-west-1.amazonaws.com/": "https://git-codecommit.eu-west-1.amazonaws.com/",
+		"": "",
+	}
+	for gitUrl, repository := range cases {
+		t.Run(gitUrl, func(t *testing.T) {
+			assert.Equal(t, repository, MapRepository(gitUrl))
+		})
+	}
+}
+
+func TestMapRepositoryUrl(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/debricked/cli.git":           "https://github.com/debricked/cli",
+		"https://github.com/debricked/cli":               "https://github.com/debricked/cli",
+		"http://gitlab.com/debricked/sub/cli.git":        "http://gitlab.com/debricked/sub/cli",
+		"git@github.com:debricked/cli.git":               "https://github.com/debricked/cli",
+		"ssh://git@bitbucket.org:7999/debricked/cli.git": "https://bitbucket.org/debricked/cli",
+		"cli": "cli",
+	}
+	for gitUrl, repositoryUrl := range cases {
+		t.Run(gitUrl, func(t *testing.T) {
+			assert.Equal(t, repositoryUrl, MapRepositoryUrl(gitUrl))
+		})
+	}
+}
+
+func TestAuthor(t *testing.T) {
+	assert.Equal(t, "viktigpetterr <test@test.com>", Author("viktigpetterr", "test@test.com"))
+	assert.Equal(t, "viktigpetterr", Author("viktigpetterr", ""))
+	assert.Equal(t, "<test@test.com>", Author("", "test@test.com"))
+	assert.Empty(t, Author("", ""))
+}
@@ -0,0 +1,40 @@
+package woodpecker
+
+import (
+	"os"
+
+	"github.com/debricked/cli/internal/ci/env"
+	"github.com/debricked/cli/internal/ci/util"
+)
+
+const (
+	EnvKey      = "CI"
+	EnvValue    = "woodpecker"
+	Integration = "woodpecker"
+)
+
+type Ci struct{}
+
+// Identify checks the value of CI, as Woodpecker sets no variable of its own. It also sets the DRONE variables
+// of Drone in older versions, so it must be identified before Drone
+func (_ Ci) Identify() bool {
+	return os.Getenv(EnvKey) == EnvValue
+}
+
+func (_ Ci) Map() (env.Env, error) {
+	e := env.Env{}
+	e.Repository = os.Getenv("CI_REPO")
+	e.Commit = os.Getenv("CI_COMMIT_SHA")
+	// CI_COMMIT_BRANCH is the target branch of pull requests
+	e.Branch = os.Getenv("CI_COMMIT_BRANCH")
+	if util.EnvKeyIsSet("CI_COMMIT_PULL_REQUEST") {
+		e.Branch = os.Getenv("CI_COMMIT_SOURCE_BRANCH")
+		e.TargetBranch = os.Getenv("CI_COMMIT_TARGET_BRANCH")
+		e.PullRequest = os.Getenv("CI_COMMIT_PULL_REQUEST")
+	}
+	e.RepositoryUrl = os.Getenv("CI_REPO_URL")
+	e.Integration = Integration
+	e.Author = util.Author(os.Getenv("CI_COMMIT_AUTHOR"), os.Getenv("CI_COMMIT_AUTHOR_EMAIL"))
+
+	return e, nil
+}
@@ -0,0 +1,94 @@
+package woodpecker
+
+import (
+	"os"
+	"testing"
+
+	"github.com/debricked/cli/internal/ci/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+var woodpeckerEnv = map[string]string{
+	"CI":                     "woodpecker",
+	"CI_REPO":                "debricked/cli",
+	"CI_COMMIT_SHA":          "commit",
+	"CI_COMMIT_BRANCH":       "main",
+	"CI_REPO_URL":            "https://codeberg.org/debricked/cli",
+	"CI_COMMIT_AUTHOR":       "viktigpetterr",
+	"CI_COMMIT_AUTHOR_EMAIL": "test@test.com",
+}
+
+func TestIdentify(t *testing.T) {
+	ci := Ci{}
+	value, isSet := os.LookupEnv(EnvKey)
+	if isSet {
+		defer os.Setenv(EnvKey, value)
+	}
+	if ci.Identify() {
+		_ = os.Unsetenv(EnvKey)
+
+		if ci.Identify() {
+			t.Error("failed to assert that CI was not identified")
+		}
+	} else {
+		// Other CIs set CI to true
+		_ = os.Setenv(EnvKey, "true")
+		assert.False(t, ci.Identify(), "failed to assert that CI was not identified by other CIs setting CI")
+		_ = os.Unsetenv(EnvKey)
+
+		assert.False(t, ci.Identify(), "failed to assert that CI was not identified")
+		_ = os.Setenv(EnvKey, EnvValue)
+		if !isSet {
+			defer testdata.UnsetEnvVar(t, EnvKey)
+		}
+		assert.True(t, ci.Identify(), "failed to assert that CI was identified")
+	}
+}
+
+type parseCase struct {
+	name         string
+	env          map[string]string
+	branch       string
+	targetBranch string
+	pullRequest  string
+}
+
+func TestParse(t *testing.T) {
+	cases := []parseCase{
+		{
+			name:   "push",
+			env:    map[string]string{},
+			branch: "main",
+		},
+		{
+			name:         "pull request",
+			env:          map[string]string{"CI_COMMIT_PULL_REQUEST": "12", "CI_COMMIT_SOURCE_BRANCH": "feature/woodpecker", "CI_COMMIT_TARGET_BRANCH": "main"},
+			branch:       "feature/woodpecker",
+			targetBranch: "main",
+			pullRequest:  "12",
+		},
+	}
+
+	ci := Ci{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testdata.SetUpCiEnv(t, woodpeckerEnv)
+			defer testdata.ResetEnv(t, woodpeckerEnv)
+			testdata.SetUpCiEnv(t, c.env)
+			defer testdata.ResetEnv(t, c.env)
+
+			env, err := ci.Map()
+
+			assert.NoError(t, err)
+			assert.Empty(t, env.Filepath)
+			assert.Equal(t, Integration, env.Integration)
+			assert.Equal(t, "viktigpetterr <test@test.com>", env.Author)
+			assert.Equal(t, c.branch, env.Branch)
+			assert.Equal(t, c.targetBranch, env.TargetBranch)
+			assert.Equal(t, c.pullRequest, env.PullRequest)
+			assert.Equal(t, woodpeckerEnv["CI_REPO_URL"], env.RepositoryUrl)
+			assert.Equal(t, woodpeckerEnv["CI_COMMIT_SHA"], env.Commit)
+			assert.Equal(t, "debricked/cli", env.Repository)
+		})
+	}
+}
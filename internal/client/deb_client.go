@@ -11,6 +11,7 @@ import (
 	"github.com/debricked/cli/internal/auth"
 
 	"github.com/fatih/color"
+	"github.com/hashicorp/go-retryablehttp"
 )
 
 const DefaultDebrickedUri = "https://debricked.com"
@@ -20,6 +21,9 @@ const enterpriseCheckUri = "/api/1.0/open/user-profile/get-billing-info"
 type IDebClient interface {
 	// Post makes a POST request to one of Debricked's API endpoints
 	Post(uri string, contentType string, body *bytes.Buffer, timeout int) (*http.Response, error)
+	// PostStream makes a POST request to one of Debricked's API endpoints, streaming the body.
+	// body is invoked once for every attempt, so that the request can be retried without buffering the body
+	PostStream(uri string, contentType string, body func() (io.Reader, error), timeout int) (*http.Response, error)
 	// Get makes a GET request to one of Debricked's API endpoints
 	Get(uri string, format string) (*http.Response, error)
 	SetAccessToken(accessToken *string)
@@ -63,6 +67,14 @@ func (debClient *DebClient) Post(uri string, contentType string, body *bytes.Buf
 	return post(uri, debClient, contentType, body, true)
 }
 
+func (debClient *DebClient) PostStream(uri string, contentType string, body func() (io.Reader, error), timeout int) (*http.Response, error) {
+	if timeout > 0 {
+		return postWithTimeout(uri, debClient, contentType, retryablehttp.ReaderFunc(body), true, timeout)
+	}
+
+	return post(uri, debClient, contentType, retryablehttp.ReaderFunc(body), true)
+}
+
 func (debClient *DebClient) Get(uri string, format string) (*http.Response, error) {
 	return get(uri, debClient, true, format)
 }
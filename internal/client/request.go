@@ -30,7 +30,7 @@ func get(uri string, debClient *DebClient, retry bool, format string) (*http.Res
 	return interpret(res, req, debClient, retry)
 }
 
-func post(uri string, debClient *DebClient, contentType string, body *bytes.Buffer, retry bool) (*http.Response, error) {
+func post(uri string, debClient *DebClient, contentType string, body interface{}, retry bool) (*http.Response, error) {
 	request, err := newRequest("POST", *debClient.host+uri, debClient.jwtToken, "application/json", body)
 	if err != nil {
 		return nil, err
@@ -48,7 +48,7 @@ func post(uri string, debClient *DebClient, contentType string, body *bytes.Buff
 	return interpret(res, req, debClient, retry)
 }
 
-func postWithTimeout(uri string, debClient *DebClient, contentType string, body *bytes.Buffer, retry bool, timeout int) (*http.Response, error) {
+func postWithTimeout(uri string, debClient *DebClient, contentType string, body interface{}, retry bool, timeout int) (*http.Response, error) {
 	request, err := newRequest("POST", *debClient.host+uri, debClient.jwtToken, "application/json", body)
 	if err != nil {
 		return nil, err
@@ -72,7 +72,7 @@ func postWithTimeout(uri string, debClient *DebClient, contentType string, body
 }
 
 // newRequest creates a new HTTP request with necessary headers added
-func newRequest(method string, url string, jwtToken string, format string, body io.Reader) (*retryablehttp.Request, error) {
+func newRequest(method string, url string, jwtToken string, format string, body interface{}) (*retryablehttp.Request, error) {
 	req, err := retryablehttp.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
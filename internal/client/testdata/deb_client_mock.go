@@ -60,6 +60,16 @@ func (mock *DebClientMock) Post(uri string, format string, body *bytes.Buffer, t
 	return mock.realDebClient.Post(uri, format, body, timeout)
 }
 
+func (mock *DebClientMock) PostStream(uri string, format string, body func() (io.Reader, error), timeout int) (*http.Response, error) {
+	response, err := mock.popResponse(mock.RemoveQueryParamsFromUri(uri))
+
+	if response != nil || !mock.serviceUp {
+		return response, err
+	}
+
+	return mock.realDebClient.PostStream(uri, format, body, timeout)
+}
+
 func (mock *DebClientMock) SetAccessToken(_ *string) {}
 
 type MockResponse struct {
@@ -9,23 +9,38 @@ import (
 	"github.com/spf13/viper"
 )
 
+var device bool
+
+const DeviceFlag = "device"
+
 func NewLoginCmd(authenticator auth.IAuthenticator) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate debricked user",
-		Long:  `Start authentication flow to generate access token.`,
+		Long: `Start authentication flow to generate access token.
+A browser is opened to log in. Use --device on machines without a browser, such as over SSH or in containers`,
 		PreRun: func(cmd *cobra.Command, _ []string) {
 			_ = viper.BindPFlags(cmd.Flags())
 		},
 		RunE: RunE(authenticator),
 	}
+	cmd.Flags().BoolVar(&device, DeviceFlag, false, `authenticate using a verification URL and code, entered in a browser on another device.
+Example:
+$ debricked auth login --device
+`)
+	viper.MustBindEnv(DeviceFlag)
 
 	return cmd
 }
 
 func RunE(a auth.IAuthenticator) func(_ *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
-		err := a.Authenticate()
+		var err error
+		if viper.GetBool(DeviceFlag) {
+			err = a.AuthenticateDevice()
+		} else {
+			err = a.Authenticate()
+		}
 		if err != nil {
 			return err
 		}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/debricked/cli/internal/auth"
 	"github.com/debricked/cli/internal/auth/testdata"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,3 +40,32 @@ func TestRunEError(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestNewLoginCmdDeviceFlag(t *testing.T) {
+	cmd := NewLoginCmd(testdata.MockAuthenticator{})
+
+	flag := cmd.Flags().Lookup(DeviceFlag)
+
+	assert.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
+
+func TestRunEDevice(t *testing.T) {
+	viper.Set(DeviceFlag, true)
+	defer viper.Set(DeviceFlag, false)
+	runE := RunE(testdata.MockAuthenticator{})
+
+	err := runE(nil, []string{})
+
+	assert.NoError(t, err)
+}
+
+func TestRunEDeviceError(t *testing.T) {
+	viper.Set(DeviceFlag, true)
+	defer viper.Set(DeviceFlag, false)
+	runE := RunE(testdata.ErrorMockAuthenticator{})
+
+	err := runE(nil, []string{})
+
+	assert.Error(t, err)
+}
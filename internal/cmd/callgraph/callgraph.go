@@ -9,6 +9,10 @@ import (
 	"github.com/debricked/cli/internal/callgraph"
 	cg "github.com/debricked/cli/internal/callgraph"
 	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/language"
+	"github.com/debricked/cli/internal/callgraph/query"
+	diffCmd "github.com/debricked/cli/internal/cmd/callgraph/diff"
+	queryCmd "github.com/debricked/cli/internal/cmd/callgraph/query"
 	"github.com/debricked/cli/internal/file"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -20,11 +24,8 @@ var (
 	buildDisabled      bool
 	generateTimeout    int
 	languages          string
-	supportedLanguages = []string{"java", "golang"}
-	languageMap        = map[string]string{
-		"java":   "maven",
-		"golang": "go",
-	}
+	supportedLanguages = languageNames()
+	languageMap        = languagePackageManagers()
 )
 
 const (
@@ -35,7 +36,7 @@ const (
 	LanguagesFlag       = "languages"
 )
 
-func NewCallgraphCmd(generator cg.IGenerator) *cobra.Command {
+func NewCallgraphCmd(generator cg.IGenerator, queryLoader query.ILoader) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "callgraph [path]",
 		Short: "Generate a static call graph for the given directory and subdirectories",
@@ -85,9 +86,31 @@ https://docs.debricked.com/tools-and-integrations/cli/debricked-cli#callgraph`)
 
 	viper.MustBindEnv(ExclusionFlag)
 
+	cmd.AddCommand(queryCmd.NewQueryCmd(queryLoader))
+	cmd.AddCommand(diffCmd.NewDiffCmd(queryLoader))
+
 	return cmd
 }
 
+func languageNames() []string {
+	var names []string
+	for _, lang := range language.Languages() {
+		names = append(names, lang.Name())
+	}
+
+	return names
+}
+
+// languagePackageManagers maps the supported languages to the package manager used to build them
+func languagePackageManagers() map[string]string {
+	packageManagers := map[string]string{}
+	for _, lang := range language.Languages() {
+		packageManagers[lang.Name()] = lang.PackageManager()
+	}
+
+	return packageManagers
+}
+
 func parseAndValidateLanguages(languages string) ([]string, error) {
 	if languages == "" {
 		// default to all supported languages
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/debricked/cli/internal/callgraph"
+	queryTestdata "github.com/debricked/cli/internal/callgraph/query/testdata"
 	callgraphTestdata "github.com/debricked/cli/internal/callgraph/testdata"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -12,10 +13,10 @@ import (
 
 func TestNewCallgraphCmd(t *testing.T) {
 	var callgraphGenerator callgraph.IGenerator
-	cmd := NewCallgraphCmd(callgraphGenerator)
+	cmd := NewCallgraphCmd(callgraphGenerator, &queryTestdata.LoaderMock{})
 
 	commands := cmd.Commands()
-	nbrOfCommands := 0
+	nbrOfCommands := 2
 	assert.Len(t, commands, nbrOfCommands)
 
 	flags := cmd.Flags()
@@ -94,9 +95,17 @@ func TestParseAndValidateLanguages(t *testing.T) {
 	parsedLanguages, err = parseAndValidateLanguages(languages)
 
 	assert.Nil(t, err)
-	assert.Equal(t, []string{"java", "golang"}, parsedLanguages)
+	assert.Equal(t, []string{"java", "golang", "python", "javascript"}, parsedLanguages)
 
 	languages = "java,golang,python2"
 	_, err = parseAndValidateLanguages(languages)
 	assert.Error(t, err)
 }
+
+func TestLanguagePackageManagers(t *testing.T) {
+	packageManagers := languagePackageManagers()
+	assert.Equal(t, "maven", packageManagers["java"])
+	assert.Equal(t, "go", packageManagers["golang"])
+	assert.Equal(t, "pip", packageManagers["python"])
+	assert.Equal(t, "npm", packageManagers["javascript"])
+}
@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/callgraph/query"
+	"github.com/debricked/cli/internal/file"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exclusions = file.Exclusions()
+var format string
+
+const (
+	ExclusionFlag = "exclusion"
+	FormatFlag    = "format"
+)
+
+func NewDiffCmd(loader query.ILoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "List the reachable calls added and removed between two call graphs",
+		Long: `List the reachable calls added and removed between the call graphs written by debricked callgraph, in two
+files or directories. Call graph files in directories are compared by their paths within the directories.
+No requests are sent to Debricked.
+
+Reachable calls are calls made by application code, or by functions called from application code.
+Call graph files with equal content hashes are unchanged and skipped.
+Examples:
+$ debricked callgraph diff main/debricked-call-graph.java feature/debricked-call-graph.java
+$ debricked callgraph diff --format json ../main .`,
+		Args: cobra.ExactArgs(2),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(loader),
+	}
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Exclude paths, see `debricked files find --help` for supported terms")
+	cmd.Flags().StringVarP(&format, FormatFlag, "f", query.FormatText, "Output format: text or json")
+
+	return cmd
+}
+
+func RunE(loader query.ILoader) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		format := viper.GetString(FormatFlag)
+		if err := query.ValidateFormat(format); err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		oldPath, newPath := args[0], args[1]
+		exclusions := viper.GetStringSlice(ExclusionFlag)
+		oldFiles, err := loader.Load(query.DebrickedOptions{Path: oldPath, Exclusions: exclusions})
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		newFiles, err := loader.Load(query.DebrickedOptions{Path: newPath, Exclusions: exclusions})
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		query.WriteWarnings(os.Stderr, oldFiles)
+		query.WriteWarnings(os.Stderr, newFiles)
+
+		return query.WriteDiffs(os.Stdout, query.DiffFiles(oldPath, oldFiles, newPath, newFiles), format)
+	}
+}
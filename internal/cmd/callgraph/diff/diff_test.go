@@ -0,0 +1,75 @@
+package diff
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/debricked/cli/internal/callgraph/query"
+	"github.com/debricked/cli/internal/callgraph/query/testdata"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFiles() []query.CallGraphFile {
+	graph := model.NewCallGraph()
+	main := graph.AddNode("main.go", "main", "main.main", true, false, 5, 12)
+	dependency := graph.AddNode("errors.go", "New", "github.com/pkg/errors.New", false, false, 100, 105)
+	graph.AddEdge(main, dependency, 9)
+
+	return []query.CallGraphFile{{Path: "debricked-call-graph.golang", Graph: graph}}
+}
+
+func TestNewDiffCmd(t *testing.T) {
+	cmd := NewDiffCmd(&testdata.LoaderMock{})
+
+	commands := cmd.Commands()
+	assert.Len(t, commands, 0)
+
+	flags := cmd.Flags()
+	for name, shorthand := range map[string]string{ExclusionFlag: "e", FormatFlag: "f"} {
+		flag := flags.Lookup(name)
+		assert.NotNil(t, flag)
+		assert.Equal(t, shorthand, flag.Shorthand)
+	}
+	assert.Equal(t, query.FormatText, flags.Lookup(FormatFlag).DefValue)
+	assert.Error(t, cmd.Args(cmd, []string{"old"}))
+	assert.NoError(t, cmd.Args(cmd, []string{"old", "new"}))
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewDiffCmd(&testdata.LoaderMock{})
+	cmd.PreRun(cmd, nil)
+
+	assert.Equal(t, query.FormatText, viper.GetString(FormatFlag))
+}
+
+func TestRunE(t *testing.T) {
+	loader := &testdata.LoaderMock{Files: newFiles()}
+	runE := RunE(loader)
+
+	err := runE(&cobra.Command{}, []string{"old", "new"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new", loader.Options.Path)
+}
+
+func TestRunEUnsupportedFormat(t *testing.T) {
+	viper.Set(FormatFlag, "dot")
+	defer viper.Set(FormatFlag, query.FormatText)
+	runE := RunE(&testdata.LoaderMock{Files: newFiles()})
+
+	err := runE(&cobra.Command{}, []string{"old", "new"})
+
+	assert.ErrorContains(t, err, query.UnsupportedFormatErr.Error())
+}
+
+func TestRunELoadErr(t *testing.T) {
+	loadErr := errors.New("load error")
+	runE := RunE(&testdata.LoaderMock{Err: loadErr})
+
+	err := runE(&cobra.Command{}, []string{"old", "new"})
+
+	assert.ErrorContains(t, err, loadErr.Error())
+}
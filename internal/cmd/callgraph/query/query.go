@@ -0,0 +1,91 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/callgraph/query"
+	"github.com/debricked/cli/internal/file"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exclusions = file.Exclusions()
+var symbol string
+var pkg string
+var format string
+
+const (
+	ExclusionFlag = "exclusion"
+	SymbolFlag    = "symbol"
+	PackageFlag   = "package"
+	FormatFlag    = "format"
+)
+
+var ErrQuery = errors.New("either --symbol or --package must be set")
+
+func NewQueryCmd(loader query.ILoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query [path]",
+		Short: "Query generated call graphs for reachability",
+		Long: `Query the call graphs written by debricked callgraph, in the given file or directory.
+No requests are sent to Debricked.
+
+With --symbol, print the shortest call chain from an application entry point, an application function without callers,
+to each symbol matching the query. Symbols are matched exactly, or else by substring.
+With --package, print the shortest call chain from application code into the dependency package.
+Examples:
+$ debricked callgraph query --symbol 'lodash/merge.js#merge' .
+$ debricked callgraph query --package org.apache.commons.text .`,
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(loader),
+	}
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Exclude paths, see `debricked files find --help` for supported terms")
+	cmd.Flags().StringVarP(&symbol, SymbolFlag, "s", "", "Symbol to check the reachability of")
+	cmd.Flags().StringVarP(&pkg, PackageFlag, "p", "", "Dependency package to find the call chain into, such as lodash or org.apache.commons.text")
+	cmd.Flags().StringVarP(&format, FormatFlag, "f", query.FormatText, "Output format: text or json")
+
+	return cmd
+}
+
+func RunE(loader query.ILoader) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		symbol := viper.GetString(SymbolFlag)
+		pkg := viper.GetString(PackageFlag)
+		if (len(symbol) == 0) == (len(pkg) == 0) {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), ErrQuery.Error())
+		}
+		format := viper.GetString(FormatFlag)
+		if err := query.ValidateFormat(format); err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		files, err := loader.Load(query.DebrickedOptions{Path: path, Exclusions: viper.GetStringSlice(ExclusionFlag)})
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		query.WriteWarnings(os.Stderr, files)
+
+		target := symbol
+		results := query.FindSymbol(files, symbol)
+		unreachable := "is not reachable from any application entry point"
+		if len(pkg) > 0 {
+			target = pkg
+			results = query.FindPackage(files, pkg)
+			unreachable = "is not called from application code"
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("%s %s was not found in any call graph\n", color.RedString("⨯"), target)
+		}
+
+		return query.WriteResults(os.Stdout, results, format, unreachable)
+	}
+}
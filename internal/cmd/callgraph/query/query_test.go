@@ -0,0 +1,119 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/debricked/cli/internal/callgraph/query"
+	"github.com/debricked/cli/internal/callgraph/query/testdata"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFiles() []query.CallGraphFile {
+	graph := model.NewCallGraph()
+	main := graph.AddNode("main.go", "main", "main.main", true, false, 5, 12)
+	dependency := graph.AddNode("errors.go", "New", "github.com/pkg/errors.New", false, false, 100, 105)
+	graph.AddEdge(main, dependency, 9)
+
+	return []query.CallGraphFile{{Path: "debricked-call-graph.golang", Graph: graph}}
+}
+
+func TestNewQueryCmd(t *testing.T) {
+	cmd := NewQueryCmd(&testdata.LoaderMock{})
+
+	commands := cmd.Commands()
+	assert.Len(t, commands, 0)
+
+	flags := cmd.Flags()
+	for name, shorthand := range map[string]string{ExclusionFlag: "e", SymbolFlag: "s", PackageFlag: "p", FormatFlag: "f"} {
+		flag := flags.Lookup(name)
+		assert.NotNil(t, flag)
+		assert.Equal(t, shorthand, flag.Shorthand)
+	}
+	assert.Equal(t, query.FormatText, flags.Lookup(FormatFlag).DefValue)
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewQueryCmd(&testdata.LoaderMock{})
+	cmd.PreRun(cmd, nil)
+
+	assert.Equal(t, query.FormatText, viper.GetString(FormatFlag))
+}
+
+func TestRunESymbol(t *testing.T) {
+	viper.Set(SymbolFlag, "errors.New")
+	defer viper.Set(SymbolFlag, "")
+	loader := &testdata.LoaderMock{Files: newFiles()}
+	runE := RunE(loader)
+
+	err := runE(&cobra.Command{}, []string{"."})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ".", loader.Options.Path)
+}
+
+func TestRunEPackage(t *testing.T) {
+	viper.Set(PackageFlag, "github.com/pkg/errors")
+	defer viper.Set(PackageFlag, "")
+	runE := RunE(&testdata.LoaderMock{Files: newFiles()})
+
+	err := runE(&cobra.Command{}, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestRunENoQuery(t *testing.T) {
+	runE := RunE(&testdata.LoaderMock{Files: newFiles()})
+
+	err := runE(&cobra.Command{}, nil)
+
+	assert.ErrorContains(t, err, ErrQuery.Error())
+}
+
+func TestRunEBothQueries(t *testing.T) {
+	viper.Set(SymbolFlag, "errors.New")
+	viper.Set(PackageFlag, "github.com/pkg/errors")
+	defer viper.Set(SymbolFlag, "")
+	defer viper.Set(PackageFlag, "")
+	runE := RunE(&testdata.LoaderMock{Files: newFiles()})
+
+	err := runE(&cobra.Command{}, nil)
+
+	assert.ErrorContains(t, err, ErrQuery.Error())
+}
+
+func TestRunEUnsupportedFormat(t *testing.T) {
+	viper.Set(SymbolFlag, "errors.New")
+	viper.Set(FormatFlag, "dot")
+	defer viper.Set(SymbolFlag, "")
+	defer viper.Set(FormatFlag, query.FormatText)
+	runE := RunE(&testdata.LoaderMock{Files: newFiles()})
+
+	err := runE(&cobra.Command{}, nil)
+
+	assert.ErrorContains(t, err, query.UnsupportedFormatErr.Error())
+}
+
+func TestRunELoadErr(t *testing.T) {
+	viper.Set(SymbolFlag, "errors.New")
+	defer viper.Set(SymbolFlag, "")
+	loadErr := errors.New("load error")
+	runE := RunE(&testdata.LoaderMock{Err: loadErr})
+
+	err := runE(&cobra.Command{}, nil)
+
+	assert.ErrorContains(t, err, loadErr.Error())
+}
+
+func TestRunENotFound(t *testing.T) {
+	viper.Set(PackageFlag, "lodash")
+	defer viper.Set(PackageFlag, "")
+	runE := RunE(&testdata.LoaderMock{Files: newFiles()})
+
+	err := runE(&cobra.Command{}, nil)
+
+	assert.ErrorContains(t, err, "lodash was not found in any call graph")
+}
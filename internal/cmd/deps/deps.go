@@ -0,0 +1,32 @@
+package deps
+
+import (
+	"github.com/debricked/cli/internal/cmd/deps/diff"
+	"github.com/debricked/cli/internal/cmd/deps/list"
+	"github.com/debricked/cli/internal/cmd/deps/paths"
+	"github.com/debricked/cli/internal/cmd/deps/tree"
+	"github.com/debricked/cli/internal/cmd/deps/why"
+	"github.com/debricked/cli/internal/deps"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDepsCmd(loader deps.ILoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Short: "Query resolved dependency graphs",
+		Long: `Query the dependency graphs of lock files on disk, such as package-lock.json and the *.debricked.lock files written by resolve.
+No requests are sent to Debricked.`,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+	}
+
+	cmd.AddCommand(list.NewListCmd(loader))
+	cmd.AddCommand(tree.NewTreeCmd(loader))
+	cmd.AddCommand(why.NewWhyCmd(loader))
+	cmd.AddCommand(paths.NewPathsCmd(loader))
+	cmd.AddCommand(diff.NewDiffCmd(loader))
+
+	return cmd
+}
@@ -0,0 +1,20 @@
+package deps
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/deps/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDepsCmd(t *testing.T) {
+	cmd := NewDepsCmd(&testdata.LoaderMock{})
+	commands := cmd.Commands()
+	nbrOfCommands := 5
+	assert.Lenf(t, commands, nbrOfCommands, "failed to assert that there were %d sub commands connected", nbrOfCommands)
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewDepsCmd(&testdata.LoaderMock{})
+	cmd.PreRun(cmd, nil)
+}
@@ -0,0 +1,74 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/deps"
+	"github.com/debricked/cli/internal/file"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exclusions = file.Exclusions()
+var format string
+
+const (
+	ExclusionFlag = "exclusion"
+	FormatFlag    = "format"
+)
+
+func NewDiffCmd(loader deps.ILoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <base> <head> [path]",
+		Short: "Compare the resolved dependencies of two revisions",
+		Long: `Compare the lock files of two revisions of the git repository containing path, and list the packages added,
+removed, upgraded and downgraded, and whether they are direct or transitive dependencies.
+Revisions are branches, tags or commits. The lock files are read from git, so the working tree is left untouched.
+Example:
+$ debricked deps diff main HEAD --format markdown > dependency-changes.md`,
+		Args: cobra.RangeArgs(2, 3),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(loader),
+	}
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Exclude paths, see `debricked files find --help` for supported terms")
+	cmd.Flags().StringVarP(&format, FormatFlag, "f", deps.FormatMarkdown, "Output format: markdown or json")
+
+	return cmd
+}
+
+func RunE(loader deps.ILoader) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 2 {
+			path = args[2]
+		}
+		format := viper.GetString(FormatFlag)
+		if err := deps.ValidateDiffFormat(format); err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		options := deps.DebrickedOptions{Path: path, Exclusions: viper.GetStringSlice(ExclusionFlag), Offline: true}
+
+		var roots []string
+		var revisions [][]deps.Project
+		for _, revision := range args[:2] {
+			root, err := os.MkdirTemp("", "debricked-deps-diff-")
+			if err != nil {
+				return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+			}
+			defer os.RemoveAll(root)
+			projects, err := deps.LoadRevision(loader, options, revision, root)
+			if err != nil {
+				return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+			}
+			deps.WriteWarnings(os.Stderr, projects)
+			roots = append(roots, root)
+			revisions = append(revisions, projects)
+		}
+
+		return deps.WriteDiffs(os.Stdout, deps.Diff(roots[0], revisions[0], roots[1], revisions[1]), format)
+	}
+}
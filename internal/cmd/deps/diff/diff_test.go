@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/deps"
+	"github.com/debricked/cli/internal/deps/testdata"
+	"github.com/debricked/cli/internal/lockfile"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// lockFileLoader loads the package-lock.json in the root of the loaded path
+type lockFileLoader struct{}
+
+func (lockFileLoader) Load(options deps.DebrickedOptions) ([]deps.Project, error) {
+	lockFile := filepath.Join(options.Path, "package-lock.json")
+	if _, err := os.Stat(lockFile); err != nil {
+		return nil, nil
+	}
+	graph, err := lockfile.Parse(lockFile)
+
+	return []deps.Project{{LockFile: lockFile, Graph: graph, Err: err}}, nil
+}
+
+// setUpRepository commits a manifest file, and then a lock file, and returns the directory of the repository
+func setUpRepository(t *testing.T) string {
+	lockFileData, err := os.ReadFile(filepath.Join("..", "..", "..", "lockfile", "testdata", "npm", "v3", "package-lock.json"))
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	for name, data := range map[string][]byte{"package.json": []byte("{}"), "package-lock.json": lockFileData} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0600))
+		_, err = worktree.Add(name)
+		assert.NoError(t, err)
+		_, err = worktree.Commit("add "+name, &git.CommitOptions{Author: &object.Signature{Name: "debricked", When: time.Now()}})
+		assert.NoError(t, err)
+	}
+
+	return dir
+}
+
+func TestNewDiffCmd(t *testing.T) {
+	cmd := NewDiffCmd(&testdata.LoaderMock{})
+
+	commands := cmd.Commands()
+	assert.Len(t, commands, 0)
+
+	flags := cmd.Flags()
+	for name, shorthand := range map[string]string{ExclusionFlag: "e", FormatFlag: "f"} {
+		flag := flags.Lookup(name)
+		assert.NotNil(t, flag)
+		assert.Equal(t, shorthand, flag.Shorthand)
+	}
+	assert.Equal(t, deps.FormatMarkdown, flags.Lookup(FormatFlag).DefValue)
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewDiffCmd(&testdata.LoaderMock{})
+	cmd.PreRun(cmd, nil)
+
+	assert.Equal(t, deps.FormatMarkdown, viper.GetString(FormatFlag))
+}
+
+func TestRunE(t *testing.T) {
+	viper.Set(FormatFlag, deps.FormatJson)
+	defer viper.Set(FormatFlag, deps.FormatMarkdown)
+	dir := setUpRepository(t)
+	runE := RunE(lockFileLoader{})
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runE(&cobra.Command{}, []string{"HEAD~1", "HEAD", dir})
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `"lockFile": "package-lock.json"`)
+	assert.Contains(t, string(output), `"change": "added"`)
+	assert.NotContains(t, string(output), `"change": "removed"`)
+}
+
+func TestRunENoChanges(t *testing.T) {
+	dir := setUpRepository(t)
+	runE := RunE(lockFileLoader{})
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runE(&cobra.Command{}, []string{"HEAD", "HEAD", dir})
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Equal(t, "No dependency changes\n", string(output))
+}
+
+func TestRunEUnsupportedFormat(t *testing.T) {
+	viper.Set(FormatFlag, deps.FormatDot)
+	defer viper.Set(FormatFlag, deps.FormatMarkdown)
+	runE := RunE(&testdata.LoaderMock{})
+
+	err := runE(&cobra.Command{}, []string{"main", "HEAD"})
+
+	assert.ErrorContains(t, err, deps.UnsupportedDiffFormatErr.Error())
+}
+
+func TestRunEUnknownRevision(t *testing.T) {
+	runE := RunE(&testdata.LoaderMock{})
+
+	err := runE(&cobra.Command{}, []string{"missing", "HEAD", setUpRepository(t)})
+
+	assert.ErrorContains(t, err, "failed to resolve missing")
+}
+
+func TestRunELoadErr(t *testing.T) {
+	loadErr := errors.New("load error")
+	runE := RunE(&testdata.LoaderMock{Err: loadErr})
+
+	err := runE(&cobra.Command{}, []string{"HEAD~1", "HEAD", setUpRepository(t)})
+
+	assert.ErrorContains(t, err, loadErr.Error())
+}
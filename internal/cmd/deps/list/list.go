@@ -0,0 +1,59 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/deps"
+	"github.com/debricked/cli/internal/file"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exclusions = file.Exclusions()
+var format string
+
+const (
+	ExclusionFlag = "exclusion"
+	FormatFlag    = "format"
+)
+
+func NewListCmd(loader deps.ILoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [path]",
+		Short: "List all resolved dependencies",
+		Long: `List all dependencies of the lock files in path, marking direct and development dependencies.
+Example:
+$ debricked deps list . --format json`,
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(loader),
+	}
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Exclude paths, see `debricked files find --help` for supported terms")
+	cmd.Flags().StringVarP(&format, FormatFlag, "f", deps.FormatText, "Output format: text, json or dot")
+
+	return cmd
+}
+
+func RunE(loader deps.ILoader) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		format := viper.GetString(FormatFlag)
+		if err := deps.ValidateFormat(format); err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		projects, err := loader.Load(deps.DebrickedOptions{Path: path, Exclusions: viper.GetStringSlice(ExclusionFlag)})
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		deps.WriteWarnings(os.Stderr, projects)
+
+		return deps.WriteList(os.Stdout, projects, format)
+	}
+}
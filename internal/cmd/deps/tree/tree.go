@@ -0,0 +1,63 @@
+package tree
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/deps"
+	"github.com/debricked/cli/internal/file"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exclusions = file.Exclusions()
+var format string
+var depth int
+
+const (
+	ExclusionFlag = "exclusion"
+	FormatFlag    = "format"
+	DepthFlag     = "depth"
+)
+
+func NewTreeCmd(loader deps.ILoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tree [path]",
+		Short: "Print resolved dependency trees",
+		Long: `Print the dependency tree of each lock file in path, starting from the direct dependencies.
+Dependencies already printed are marked with (*) instead of being expanded again.
+Example:
+$ debricked deps tree . --depth 2`,
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(loader),
+	}
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Exclude paths, see `debricked files find --help` for supported terms")
+	cmd.Flags().StringVarP(&format, FormatFlag, "f", deps.FormatText, "Output format: text, json or dot")
+	cmd.Flags().IntVarP(&depth, DepthFlag, "d", 0, "Maximum depth of the trees, 0 prints the full trees")
+
+	return cmd
+}
+
+func RunE(loader deps.ILoader) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		format := viper.GetString(FormatFlag)
+		if err := deps.ValidateFormat(format); err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		projects, err := loader.Load(deps.DebrickedOptions{Path: path, Exclusions: viper.GetStringSlice(ExclusionFlag)})
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		deps.WriteWarnings(os.Stderr, projects)
+
+		return deps.WriteTree(os.Stdout, projects, format, viper.GetInt(DepthFlag))
+	}
+}
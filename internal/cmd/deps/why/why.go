@@ -0,0 +1,65 @@
+package why
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/deps"
+	"github.com/debricked/cli/internal/file"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exclusions = file.Exclusions()
+var format string
+
+const (
+	ExclusionFlag = "exclusion"
+	FormatFlag    = "format"
+)
+
+func NewWhyCmd(loader deps.ILoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "why <package> [path]",
+		Short: "Explain which direct dependencies pull in a dependency",
+		Long: `Print the shortest path from each direct dependency pulling in the dependency.
+The dependency is matched by name, or by name@version.
+Example:
+$ debricked deps why lodash .`,
+		Args: cobra.RangeArgs(1, 2),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(loader),
+	}
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Exclude paths, see `debricked files find --help` for supported terms")
+	cmd.Flags().StringVarP(&format, FormatFlag, "f", deps.FormatText, "Output format: text, json or dot")
+
+	return cmd
+}
+
+func RunE(loader deps.ILoader) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 1 {
+			path = args[1]
+		}
+		format := viper.GetString(FormatFlag)
+		if err := deps.ValidateFormat(format); err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		projects, err := loader.Load(deps.DebrickedOptions{Path: path, Exclusions: viper.GetStringSlice(ExclusionFlag)})
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		deps.WriteWarnings(os.Stderr, projects)
+
+		results := deps.FindPaths(projects, args[0], true)
+		if len(results) == 0 {
+			return fmt.Errorf("%s %s was not found in any dependency graph\n", color.RedString("⨯"), args[0])
+		}
+
+		return deps.WritePaths(os.Stdout, results, format)
+	}
+}
@@ -0,0 +1,80 @@
+package why
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/debricked/cli/internal/deps"
+	"github.com/debricked/cli/internal/deps/testdata"
+	"github.com/debricked/cli/internal/lockfile"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProjects() []deps.Project {
+	graph := lockfile.NewGraph(lockfile.EcosystemNpm, "package-lock.json")
+	graph.Add("lodash", "4.17.21")
+	graph.AddRoot("lodash@4.17.21")
+
+	return []deps.Project{{ManifestFile: "package.json", LockFile: "package-lock.json", Graph: graph}}
+}
+
+func TestNewWhyCmd(t *testing.T) {
+	cmd := NewWhyCmd(&testdata.LoaderMock{})
+
+	commands := cmd.Commands()
+	assert.Len(t, commands, 0)
+
+	flags := cmd.Flags()
+	for name, shorthand := range map[string]string{ExclusionFlag: "e", FormatFlag: "f"} {
+		flag := flags.Lookup(name)
+		assert.NotNil(t, flag)
+		assert.Equal(t, shorthand, flag.Shorthand)
+	}
+	assert.Equal(t, deps.FormatText, flags.Lookup(FormatFlag).DefValue)
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewWhyCmd(&testdata.LoaderMock{})
+	cmd.PreRun(cmd, nil)
+
+	assert.Equal(t, deps.FormatText, viper.GetString(FormatFlag))
+}
+
+func TestRunE(t *testing.T) {
+	loader := &testdata.LoaderMock{Projects: newProjects()}
+	runE := RunE(loader)
+
+	err := runE(&cobra.Command{}, []string{"lodash", "."})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ".", loader.Options.Path)
+}
+
+func TestRunEUnsupportedFormat(t *testing.T) {
+	viper.Set(FormatFlag, "xml")
+	defer viper.Set(FormatFlag, deps.FormatText)
+	runE := RunE(&testdata.LoaderMock{Projects: newProjects()})
+
+	err := runE(&cobra.Command{}, []string{"lodash"})
+
+	assert.ErrorContains(t, err, deps.UnsupportedFormatErr.Error())
+}
+
+func TestRunELoadErr(t *testing.T) {
+	loadErr := errors.New("load error")
+	runE := RunE(&testdata.LoaderMock{Err: loadErr})
+
+	err := runE(&cobra.Command{}, []string{"lodash"})
+
+	assert.ErrorContains(t, err, loadErr.Error())
+}
+
+func TestRunENotFound(t *testing.T) {
+	runE := RunE(&testdata.LoaderMock{Projects: newProjects()})
+
+	err := runE(&cobra.Command{}, []string{"express"})
+
+	assert.ErrorContains(t, err, "express was not found in any dependency graph")
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/lockfile"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -16,6 +18,7 @@ var inclusions []string
 var jsonPrint bool
 var lockfileOnly bool
 var strictness int
+var dependencies bool
 
 const (
 	ExclusionFlag    = "exclusion"
@@ -23,8 +26,22 @@ const (
 	JsonFlag         = "json"
 	LockfileOnlyFlag = "lockfile"
 	StrictFlag       = "strict"
+	DependenciesFlag = "dependencies"
 )
 
+type dependencyCount struct {
+	LockFile           string `json:"lockFile"`
+	Dependencies       int    `json:"dependencies"`
+	DirectDependencies int    `json:"directDependencies"`
+	Error              string `json:"error,omitempty"`
+	graph              *lockfile.Graph
+}
+
+type groupWithDependencyCounts struct {
+	file.Group
+	DependencyCounts []dependencyCount `json:"dependencyCounts"`
+}
+
 func NewFindCmd(finder file.IFinder) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "find [path]",
@@ -78,12 +95,15 @@ Strictness Level | Meaning
 1                | Returns only lock files and pairs of manifest and lock file
 2                | Returns only pairs of manifest and lock file
 `)
+	cmd.Flags().BoolVarP(&dependencies, DependenciesFlag, "d", false, `If set, lock files are parsed to report the number of resolved dependencies.
+Supported lock files: `+strings.Join(lockfile.SupportedFileNames(), ", "))
 
 	viper.MustBindEnv(ExclusionFlag)
 	viper.MustBindEnv(InclusionFlag)
 	viper.MustBindEnv(JsonFlag)
 	viper.MustBindEnv(LockfileOnlyFlag)
 	viper.MustBindEnv(StrictFlag)
+	viper.MustBindEnv(DependenciesFlag)
 
 	return cmd
 }
@@ -112,7 +132,9 @@ func RunE(f file.IFinder) func(_ *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		if viper.GetBool(JsonFlag) {
+		if viper.GetBool(DependenciesFlag) {
+			printDependencyCounts(fileGroups.ToSlice(), viper.GetBool(JsonFlag))
+		} else if viper.GetBool(JsonFlag) {
 			jsonFileGroups, _ := json.Marshal(fileGroups.ToSlice())
 			fmt.Println(string(jsonFileGroups))
 		} else {
@@ -125,6 +147,43 @@ func RunE(f file.IFinder) func(_ *cobra.Command, args []string) error {
 	}
 }
 
+func printDependencyCounts(fileGroups []file.Group, jsonPrint bool) {
+	groups := make([]groupWithDependencyCounts, 0, len(fileGroups))
+	for _, fileGroup := range fileGroups {
+		group := groupWithDependencyCounts{Group: fileGroup, DependencyCounts: []dependencyCount{}}
+		for _, lockFile := range lockfile.FindInGroup(fileGroup) {
+			count := dependencyCount{LockFile: lockFile}
+			graph, err := lockfile.Parse(lockFile)
+			if err != nil {
+				count.Error = err.Error()
+			} else {
+				count.Dependencies = graph.Size()
+				count.DirectDependencies = len(graph.Roots)
+				count.graph = graph
+			}
+			group.DependencyCounts = append(group.DependencyCounts, count)
+		}
+		groups = append(groups, group)
+	}
+
+	if jsonPrint {
+		jsonFileGroups, _ := json.Marshal(groups)
+		fmt.Println(string(jsonFileGroups))
+
+		return
+	}
+	for _, group := range groups {
+		group.Print()
+		for _, count := range group.DependencyCounts {
+			if len(count.Error) > 0 {
+				fmt.Printf("   %s: %s\n", count.LockFile, count.Error)
+			} else {
+				fmt.Printf("   %s: %s\n", count.LockFile, lockfile.Summary(count.graph))
+			}
+		}
+	}
+}
+
 func AssertFlagsAreValid() error {
 	if viper.GetBool(LockfileOnlyFlag) && viper.GetInt(StrictFlag) != file.StrictAll {
 		return errors.New("'lockfile' and 'strict' flags are mutually exclusive")
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/file"
@@ -150,6 +151,73 @@ func TestRunEJson(t *testing.T) {
 	assert.JSONEq(t, string(groupsJson), string(output))
 }
 
+func TestRunEDependencies(t *testing.T) {
+	lockFileDir := filepath.Join("..", "..", "..", "lockfile", "testdata")
+	composerLock := filepath.Join(lockFileDir, "composer", "composer.lock")
+	invalidLock := filepath.Join(lockFileDir, "invalid", "package-lock.json")
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: filepath.Join(lockFileDir, "composer", "composer.json"), LockFiles: []string{composerLock}})
+	groups.Add(file.Group{LockFiles: []string{invalidLock}})
+	f.SetGetGroupsReturnMock(groups, nil)
+	viper.Set(DependenciesFlag, true)
+	viper.Set(JsonFlag, true)
+	defer viper.Set(DependenciesFlag, false)
+	defer viper.Set(JsonFlag, false)
+
+	runE := RunE(f)
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runE(nil, []string{"."})
+	assert.NoError(t, err)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	var result []groupWithDependencyCounts
+	assert.NoError(t, json.Unmarshal(output, &result))
+	counts := map[string]dependencyCount{}
+	for _, group := range result {
+		for _, count := range group.DependencyCounts {
+			counts[count.LockFile] = count
+		}
+	}
+	assert.Len(t, counts, 2)
+	assert.Equal(t, 3, counts[composerLock].Dependencies)
+	assert.Equal(t, 2, counts[composerLock].DirectDependencies)
+	assert.Empty(t, counts[composerLock].Error)
+	assert.Contains(t, counts[invalidLock].Error, "failed to parse")
+}
+
+func TestRunEDependenciesText(t *testing.T) {
+	lockFile := filepath.Join("..", "..", "..", "lockfile", "testdata", "gradle", "gradle.lockfile")
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{LockFiles: []string{lockFile}})
+	f.SetGetGroupsReturnMock(groups, nil)
+	viper.Set(DependenciesFlag, true)
+	defer viper.Set(DependenciesFlag, false)
+
+	runE := RunE(f)
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runE(nil, []string{"."})
+	assert.NoError(t, err)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.Contains(t, string(output), lockFile+": 3 dependencies")
+}
+
 func TestPreRun(t *testing.T) {
 	cmd := NewFindCmd(nil)
 	cmd.PreRun(cmd, nil)
@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
+	"github.com/debricked/cli/internal/cmd/fingerprint/match"
 	"github.com/debricked/cli/internal/fingerprint"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -16,6 +19,10 @@ var shouldFingerprintCompressedContent bool
 var outputDir string
 var minFingerprintContentLength int
 var shouldRegenerateFingerprintFile bool
+var workers int
+var cacheFile string
+var maxArchiveDepth int
+var maxArchiveSizeMB int64
 
 const (
 	ExclusionFlag                   = "exclusion"
@@ -24,9 +31,13 @@ const (
 	OutputDirFlag                   = "output-dir"
 	MinFingerprintContentLengthFlag = "min-fingerprint-content-length"
 	RegenerateFingerprintFile       = "regenerate"
+	WorkersFlag                     = "workers"
+	CacheFileFlag                   = "cache-file"
+	MaxArchiveDepthFlag             = "max-archive-depth"
+	MaxArchiveSizeFlag              = "max-archive-size"
 )
 
-func NewFingerprintCmd(fingerprinter fingerprint.IFingerprint) *cobra.Command {
+func NewFingerprintCmd(fingerprinter fingerprint.IFingerprint, matcher fingerprint.IMatcher) *cobra.Command {
 
 	short := "Fingerprints files to match against the Debricked knowledge base."
 	long := fmt.Sprintf("Fingerprint files for identification in a given path and writes it to %s.\nThis hashes all files to be used for matching against the Debricked knowledge base.", fingerprint.OutputFileNameFingerprints)
@@ -60,13 +71,21 @@ $ debricked files fingerprint . `+exampleFlags)
 		`Forces inclusion of specified terms, see exclusion flag for more information on supported terms.
 Examples: 
 $ debricked scan . --include '**/node_modules/**'`)
-	cmd.Flags().BoolVar(&shouldFingerprintCompressedContent, FingerprintCompressedContent, false, `Fingerprint the contents of compressed files by unpacking them in memory, Supported files: `+fmt.Sprintf("%v", fingerprint.ZIP_FILE_ENDINGS))
+	cmd.Flags().BoolVar(&shouldFingerprintCompressedContent, FingerprintCompressedContent, false, `Fingerprint the contents of compressed files by unpacking them in memory, including archives nested within them. Supported files: `+fmt.Sprintf("%v", fingerprint.ArchiveFileEndings()))
+	cmd.Flags().IntVar(&maxArchiveDepth, MaxArchiveDepthFlag, fingerprint.DefaultMaxArchiveDepth, "Number of nested archive levels to unpack when fingerprinting compressed content. Deeper archives are only hashed")
+	cmd.Flags().Int64Var(&maxArchiveSizeMB, MaxArchiveSizeFlag, fingerprint.DefaultMaxArchiveSize>>20, "Maximum number of uncompressed megabytes read from each archive when fingerprinting compressed content, guarding against zip bombs")
 	cmd.Flags().StringVar(&outputDir, OutputDirFlag, ".", "The directory to write the output file to")
 	cmd.Flags().IntVar(&minFingerprintContentLength, MinFingerprintContentLengthFlag, 45, "Set minimum content length (in bytes) for files to fingerprint. Defaults to 45 bytes.")
 	cmd.Flags().BoolVar(&shouldRegenerateFingerprintFile, RegenerateFingerprintFile, true, `Toggle if generated fingerprint file should be overwritten on subequent scans. Defaults to true`)
+	cmd.Flags().IntVar(&workers, WorkersFlag, 0, "Number of files fingerprinted in parallel. Defaults to the number of CPUs")
+	cmd.Flags().StringVar(&cacheFile, CacheFileFlag, "", `File to cache fingerprints in between runs. Files with unchanged path, size and modification time are not hashed again.
+Example:
+$ debricked files fingerprint . --cache-file .debricked/fingerprint-cache.json`)
 
 	viper.MustBindEnv(ExclusionFlag)
 
+	cmd.AddCommand(match.NewMatchCmd(matcher))
+
 	return cmd
 }
 
@@ -85,6 +104,10 @@ func RunE(f fingerprint.IFingerprint) func(_ *cobra.Command, args []string) erro
 			Inclusions:                   inclusions,
 			FingerprintCompressedContent: shouldFingerprintCompressedContent,
 			MinFingerprintContentLength:  minFingerprintContentLength,
+			Workers:                      workers,
+			CachePath:                    cacheFile,
+			MaxArchiveDepth:              maxArchiveDepth,
+			MaxArchiveSize:               maxArchiveSizeMB << 20,
 		}
 		output, err := f.FingerprintFiles(options)
 		if err != nil {
@@ -105,7 +128,21 @@ func RunE(f fingerprint.IFingerprint) func(_ *cobra.Command, args []string) erro
 		if err != nil {
 			return err
 		}
+		printStats(output.Stats, len(cacheFile) > 0)
 
 		return nil
 	}
 }
+
+func printStats(stats fingerprint.Stats, cached bool) {
+	fmt.Printf(
+		"%s Fingerprinted %d files in %s (%.1f MB/s)\n",
+		color.GreenString("✔"),
+		stats.Files,
+		stats.Duration.Round(time.Millisecond),
+		stats.Throughput()/1e6,
+	)
+	if cached {
+		fmt.Printf("%d files were found in the cache\n", stats.CacheHits)
+	}
+}
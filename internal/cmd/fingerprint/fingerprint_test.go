@@ -13,10 +13,10 @@ import (
 
 func TestNewFingerprintCmd(t *testing.T) {
 	var f fingerprint.IFingerprint
-	cmd := NewFingerprintCmd(f)
+	cmd := NewFingerprintCmd(f, &testdata.MatcherMock{})
 
 	commands := cmd.Commands()
-	nbrOfCommands := 0
+	nbrOfCommands := 1
 	assert.Len(t, commands, nbrOfCommands)
 
 	flags := cmd.Flags()
@@ -0,0 +1,94 @@
+package match
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/fingerprint"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exclusions = fingerprint.DefaultExclusionsFingerprint()
+var inclusions []string
+var databasePath string
+var shouldFingerprintCompressedContent bool
+var minFingerprintContentLength int
+var jsonPath string
+var workers int
+var cacheFile string
+
+const (
+	DatabaseFlag                    = "db"
+	ExclusionFlag                   = "exclusion"
+	InclusionFlag                   = "inclusion"
+	FingerprintCompressedContent    = "fingerprint-compressed-content"
+	MinFingerprintContentLengthFlag = "min-fingerprint-content-length"
+	JsonPathFlag                    = "json-path"
+	WorkersFlag                     = "workers"
+	CacheFileFlag                   = "cache-file"
+)
+
+func NewMatchCmd(matcher fingerprint.IMatcher) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "match [path]",
+		Short: "Match fingerprints against a local component database",
+		Long: fmt.Sprintf(`Match the fingerprints of files in path against a directory of known artifacts, such as JAR, wheel and tgz files.
+Reports which files match which component and version. No requests are sent to Debricked.
+
+Purls of artifacts are read from %s in the database directory, or else inferred from artifact names:
+components:
+  - path: junit-4.13.2.jar
+    purl: pkg:maven/junit/junit@4.13.2
+
+Example:
+$ debricked fingerprint match . --db third-party-artifacts/`, fingerprint.ComponentsFileName),
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(matcher),
+	}
+	cmd.Flags().StringVar(&databasePath, DatabaseFlag, "", "Directory of known artifacts to match against")
+	_ = cmd.MarkFlagRequired(DatabaseFlag)
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Exclude paths, see `debricked fingerprint --help` for supported terms")
+	cmd.Flags().StringArrayVar(&inclusions, InclusionFlag, []string{}, "Forces inclusion of specified terms, see exclusion flag for more information on supported terms")
+	cmd.Flags().BoolVar(&shouldFingerprintCompressedContent, FingerprintCompressedContent, false, "Match the contents of compressed files in path, such as vendored JAR files")
+	cmd.Flags().IntVar(&minFingerprintContentLength, MinFingerprintContentLengthFlag, 45, "Set minimum content length (in bytes) for files to match. Defaults to 45 bytes.")
+	cmd.Flags().StringVar(&jsonPath, JsonPathFlag, "", "write the matches as JSON to provided path")
+	cmd.Flags().IntVar(&workers, WorkersFlag, 0, "Number of files fingerprinted in parallel. Defaults to the number of CPUs")
+	cmd.Flags().StringVar(&cacheFile, CacheFileFlag, "", "File to cache fingerprints in between runs, see `debricked fingerprint --help`")
+
+	return cmd
+}
+
+func RunE(matcher fingerprint.IMatcher) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		result, err := matcher.Match(fingerprint.MatchOptions{
+			Path:                         path,
+			DatabasePath:                 viper.GetString(DatabaseFlag),
+			Exclusions:                   viper.GetStringSlice(ExclusionFlag),
+			Inclusions:                   viper.GetStringSlice(InclusionFlag),
+			FingerprintCompressedContent: viper.GetBool(FingerprintCompressedContent),
+			MinFingerprintContentLength:  viper.GetInt(MinFingerprintContentLengthFlag),
+			Workers:                      viper.GetInt(WorkersFlag),
+			CachePath:                    viper.GetString(CacheFileFlag),
+		})
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+		fingerprint.WriteMatches(os.Stdout, result)
+		if path := viper.GetString(JsonPathFlag); len(path) > 0 {
+			if err = result.ToFile(path); err != nil {
+				return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package match
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/fingerprint"
+	"github.com/debricked/cli/internal/fingerprint/testdata"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMatchCmd(t *testing.T) {
+	cmd := NewMatchCmd(&testdata.MatcherMock{})
+
+	flags := cmd.Flags()
+	flagAssertions := map[string]string{
+		DatabaseFlag:                    "",
+		ExclusionFlag:                   "e",
+		InclusionFlag:                   "",
+		FingerprintCompressedContent:    "",
+		MinFingerprintContentLengthFlag: "",
+		JsonPathFlag:                    "",
+		WorkersFlag:                     "",
+		CacheFileFlag:                   "",
+	}
+	for name, shorthand := range flagAssertions {
+		flag := flags.Lookup(name)
+		assert.NotNil(t, flag)
+		assert.Equal(t, shorthand, flag.Shorthand)
+	}
+}
+
+func TestRunE(t *testing.T) {
+	viper.Set(DatabaseFlag, "db")
+	jsonFile := filepath.Join(t.TempDir(), "matches.json")
+	viper.Set(JsonPathFlag, jsonFile)
+	defer func() {
+		viper.Set(DatabaseFlag, "")
+		viper.Set(JsonPathFlag, "")
+	}()
+	matcherMock := &testdata.MatcherMock{Result: fingerprint.MatchResult{Files: 1}}
+	runE := RunE(matcherMock)
+
+	err := runE(nil, []string{"."})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ".", matcherMock.Options.Path)
+	assert.Equal(t, "db", matcherMock.Options.DatabasePath)
+	assert.FileExists(t, jsonFile)
+}
+
+func TestRunEError(t *testing.T) {
+	matcherMock := &testdata.MatcherMock{Error: fingerprint.ComponentDatabaseNotFoundErr}
+	runE := RunE(matcherMock)
+
+	err := runE(nil, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), fingerprint.ComponentDatabaseNotFoundErr.Error())
+}
@@ -0,0 +1,83 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/policy"
+	"github.com/debricked/cli/internal/scan"
+	"github.com/debricked/cli/internal/tui"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var policyPath string
+
+const PolicyFlag = "policy"
+
+func NewCheckCmd(checker policy.IChecker) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check <file>",
+		Short: "Check scan results or an SBOM against a local policy",
+		Long: `Evaluates the rules of a local policy file against the JSON written by "debricked scan --json-path",
+or a CycloneDX or SPDX SBOM exported by "debricked export sbom".
+The command fails if a triggered rule has the failPipeline action.
+
+Example policy:
+rules:
+  - description: Critical vulnerabilities are not allowed
+    actions: [failPipeline]
+    cvss3: 9.0
+  - description: Copyleft licenses must be approved by legal
+    actions: [warnPipeline]
+    licenseDeny: ["GPL-*", "AGPL-*"]
+  - description: Only approved npm packages may be used
+    actions: [failPipeline]
+    dependencyAllow: ["pkg:npm/@acme/*"]
+    dependencyDeny: ["pkg:npm/event-stream"]
+
+Patterns are case-insensitive and "*" matches any sequence of characters.
+Dependency patterns are matched against purls with and without version, and the dependency name.
+Scan result JSON files only include dependencies that triggered a Debricked automation rule, and no purls.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(checker),
+	}
+
+	cmd.Flags().StringVarP(&policyPath, PolicyFlag, "p", policy.DefaultPolicyFileName, "Set path of the policy file")
+
+	return cmd
+}
+
+func RunE(c policy.IChecker) func(_ *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if c == nil {
+			return errors.New("policy checker was nil")
+		}
+
+		rules, err := c.Check(viper.GetString(PolicyFlag), args[0])
+		if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+
+		for _, rule := range rules {
+			tui.NewRuleCard(os.Stdout, rule).Render()
+		}
+		fmt.Println(policy.Summary(rules))
+
+		if policy.FailPipeline(rules) {
+			if cmd != nil {
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+			}
+
+			return scan.FailPipelineErr
+		}
+
+		return nil
+	}
+}
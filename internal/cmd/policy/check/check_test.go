@@ -0,0 +1,87 @@
+package check
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/policy"
+	"github.com/debricked/cli/internal/policy/testdata"
+	"github.com/debricked/cli/internal/scan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCheckCmd(t *testing.T) {
+	cmd := NewCheckCmd(&testdata.CheckerMock{})
+
+	commands := cmd.Commands()
+	assert.Len(t, commands, 0)
+
+	flag := cmd.Flags().Lookup(PolicyFlag)
+	assert.NotNil(t, flag)
+	assert.Equal(t, "p", flag.Shorthand)
+	assert.Equal(t, policy.DefaultPolicyFileName, flag.DefValue)
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewCheckCmd(&testdata.CheckerMock{})
+	cmd.PreRun(cmd, nil)
+
+	assert.Equal(t, policy.DefaultPolicyFileName, viper.GetString(PolicyFlag))
+}
+
+func TestRunE(t *testing.T) {
+	viper.Set(PolicyFlag, "policy.yaml")
+	defer viper.Set(PolicyFlag, policy.DefaultPolicyFileName)
+	rule := automation.Rule{Triggered: true, RuleActions: []string{policy.WarnPipelineAction}}
+	mock := &testdata.CheckerMock{Rules: []automation.Rule{rule}}
+	runE := RunE(mock)
+
+	err := runE(&cobra.Command{}, []string{"result.json"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "policy.yaml", mock.PolicyPath)
+	assert.Equal(t, "result.json", mock.InputPath)
+}
+
+func TestRunEFailPipelineErr(t *testing.T) {
+	rule := automation.Rule{Triggered: true, RuleActions: []string{policy.FailPipelineAction}}
+	mock := &testdata.CheckerMock{Rules: []automation.Rule{rule}}
+	runE := RunE(mock)
+	cmd := &cobra.Command{}
+
+	err := runE(cmd, []string{"result.json"})
+
+	assert.ErrorIs(t, err, scan.FailPipelineErr)
+	assert.True(t, cmd.SilenceUsage, "failed to assert that usage was silenced")
+	assert.True(t, cmd.SilenceErrors, "failed to assert that errors were silenced")
+}
+
+func TestRunEUntriggeredFailPipeline(t *testing.T) {
+	rule := automation.Rule{Triggered: false, RuleActions: []string{policy.FailPipelineAction}}
+	mock := &testdata.CheckerMock{Rules: []automation.Rule{rule}}
+	runE := RunE(mock)
+
+	err := runE(&cobra.Command{}, []string{"result.json"})
+
+	assert.NoError(t, err)
+}
+
+func TestRunEError(t *testing.T) {
+	mock := &testdata.CheckerMock{Error: errors.New("policy does not contain any rules")}
+	runE := RunE(mock)
+
+	err := runE(&cobra.Command{}, []string{"result.json"})
+
+	assert.ErrorContains(t, err, "⨯ policy does not contain any rules")
+}
+
+func TestRunENilChecker(t *testing.T) {
+	runE := RunE(nil)
+
+	err := runE(&cobra.Command{}, []string{"result.json"})
+
+	assert.ErrorContains(t, err, "policy checker was nil")
+}
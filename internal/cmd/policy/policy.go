@@ -0,0 +1,24 @@
+package policy
+
+import (
+	"github.com/debricked/cli/internal/cmd/policy/check"
+	"github.com/debricked/cli/internal/policy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewPolicyCmd(checker policy.IChecker) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Evaluate local policies against scan results",
+		Long: `Evaluate policies defined in a local policy file against scan results or SBOMs.
+This allows gating merges with organisation specific rules, without configuring them in Debricked.`,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+	}
+
+	cmd.AddCommand(check.NewCheckCmd(checker))
+
+	return cmd
+}
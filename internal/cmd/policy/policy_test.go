@@ -0,0 +1,20 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/policy/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPolicyCmd(t *testing.T) {
+	cmd := NewPolicyCmd(&testdata.CheckerMock{})
+	commands := cmd.Commands()
+	nbrOfCommands := 1
+	assert.Lenf(t, commands, nbrOfCommands, "failed to assert that there were %d sub commands connected", nbrOfCommands)
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewPolicyCmd(&testdata.CheckerMock{})
+	cmd.PreRun(cmd, nil)
+}
@@ -6,7 +6,10 @@ import (
 	"strings"
 
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/junit"
+	"github.com/debricked/cli/internal/lockfile"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,6 +21,8 @@ var (
 	npmPreferred         bool
 	regenerate           int
 	resolutionStrictness int
+	junitPath            string
+	noExec               bool
 )
 
 const (
@@ -27,6 +32,8 @@ const (
 	NpmPreferredFlag     = "prefer-npm"
 	RegenerateFlag       = "regenerate"
 	ResolutionStrictFlag = "resolution-strictness"
+	JUnitFlag            = "junit"
+	NoExecFlag           = "no-exec"
 )
 
 func NewResolveCmd(resolver resolution.IResolver) *cobra.Command {
@@ -85,6 +92,7 @@ $ debricked scan . --include '**/node_modules/**'`)
 	npmPreferredDoc := strings.Join(
 		[]string{
 			"This flag allows you to select which package manager will be used as a resolver: Yarn (default) or NPM.",
+			"Projects with a pnpm-lock.yaml or pnpm-workspace.yaml file are always resolved with pnpm.",
 			"Example: debricked resolve --prefer-npm",
 		}, "\n")
 
@@ -99,6 +107,16 @@ Strictness Level | Meaning
 3                | Exit with code 1 if all files failed to resolve, if any but not all files failed to resolve exit with code 3, otherwise exit with code 0
 `)
 
+	cmd.Flags().StringVar(&junitPath, JUnitFlag, "", "write resolution jobs as a JUnit XML report to provided path")
+	noExecDoc := strings.Join(
+		[]string{
+			"Parse existing lock files into dependency graphs instead of invoking package managers.",
+			"Manifest files without a supported lock file are skipped.",
+			"Supported lock files: " + strings.Join(lockfile.SupportedFileNames(), ", "),
+			"\nExample:\n$ debricked resolve . --no-exec",
+		}, "\n")
+	cmd.Flags().BoolVar(&noExec, NoExecFlag, false, noExecDoc)
+
 	viper.MustBindEnv(ExclusionFlag)
 	viper.MustBindEnv(NpmPreferredFlag)
 
@@ -121,9 +139,26 @@ func RunE(resolver resolution.IResolver) func(_ *cobra.Command, args []string) e
 			Regenerate:           viper.GetInt(RegenerateFlag),
 			NpmPreferred:         viper.GetBool(NpmPreferredFlag),
 			ResolutionStrictness: strictness,
+			NoExec:               viper.GetBool(NoExecFlag),
+		}
+		res, err := resolver.Resolve(args, options)
+		if options.NoExec && res != nil {
+			printGraphSummaries(res)
+		}
+		if path := viper.GetString(JUnitFlag); path != "" && res != nil {
+			if junitErr := junit.Write(path, junit.NewResolutionSuite(res.Jobs())); junitErr != nil && err == nil {
+				err = junitErr
+			}
 		}
-		_, err = resolver.Resolve(args, options)
 
 		return err
 	}
 }
+
+func printGraphSummaries(res resolution.IResolution) {
+	for _, j := range res.Jobs() {
+		if lockFileJob, ok := j.(*lockfile.Job); ok && lockFileJob.Graph() != nil {
+			fmt.Printf("%s %s: %s\n", color.GreenString("✔"), j.GetFile(), lockfile.Summary(lockFileJob.Graph()))
+		}
+	}
+}
@@ -2,11 +2,17 @@ package resolve
 
 import (
 	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/file/testdata"
+	"github.com/debricked/cli/internal/lockfile"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/job"
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
 	resolveTestdata "github.com/debricked/cli/internal/resolution/testdata"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -102,3 +108,60 @@ func TestRunEErrorInvalidStrictness(t *testing.T) {
 
 	assert.EqualError(t, err, "invalid strictness level: 123", "error doesn't match expected")
 }
+
+func TestRunEWithJUnit(t *testing.T) {
+	junitPath := filepath.Join(t.TempDir(), "junit.xml")
+	viper.Set(JUnitFlag, junitPath)
+	defer viper.Set(JUnitFlag, "")
+	resolutionStrictness = int(resolution.FailIfAllFail)
+	r := &resolveTestdata.ResolverMock{}
+	runE := RunE(r)
+
+	err := runE(nil, []string{"."})
+
+	assert.NoError(t, err)
+	assert.FileExists(t, junitPath)
+}
+
+func TestRunEWithJUnitWriteError(t *testing.T) {
+	viper.Set(JUnitFlag, filepath.Join(t.TempDir(), "missing", "junit.xml"))
+	defer viper.Set(JUnitFlag, "")
+	resolutionStrictness = int(resolution.FailIfAllFail)
+	r := &resolveTestdata.ResolverMock{}
+	runE := RunE(r)
+
+	err := runE(nil, []string{"."})
+
+	assert.Error(t, err)
+}
+
+func TestRunEWithNoExec(t *testing.T) {
+	viper.Set(NoExecFlag, true)
+	defer viper.Set(NoExecFlag, false)
+	resolutionStrictness = int(resolution.FailIfAllFail)
+	r := &resolveTestdata.ResolverMock{}
+	runE := RunE(r)
+
+	err := runE(nil, []string{"."})
+
+	assert.NoError(t, err)
+}
+
+func TestPrintGraphSummaries(t *testing.T) {
+	lockFile := filepath.Join("..", "..", "lockfile", "testdata", "nuget", "packages.lock.json")
+	lockFileJob := lockfile.NewJob(lockFile)
+	go jobTestdata.WaitStatus(lockFileJob)
+	lockFileJob.Run()
+	res := resolution.NewResolution([]job.IJob{lockFileJob, jobTestdata.NewJobMock("go.mod")})
+
+	rescueStdout := os.Stdout
+	reader, writer, _ := os.Pipe()
+	os.Stdout = writer
+	printGraphSummaries(res)
+	_ = writer.Close()
+	output, _ := io.ReadAll(reader)
+	os.Stdout = rescueStdout
+
+	assert.Contains(t, string(output), lockFile+": 3 dependencies (2 direct)")
+	assert.NotContains(t, string(output), "go.mod")
+}
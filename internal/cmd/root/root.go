@@ -3,11 +3,14 @@ package root
 import (
 	"github.com/debricked/cli/internal/cmd/auth"
 	"github.com/debricked/cli/internal/cmd/callgraph"
+	"github.com/debricked/cli/internal/cmd/deps"
 	"github.com/debricked/cli/internal/cmd/files"
 	"github.com/debricked/cli/internal/cmd/fingerprint"
+	"github.com/debricked/cli/internal/cmd/policy"
 	"github.com/debricked/cli/internal/cmd/report"
 	"github.com/debricked/cli/internal/cmd/resolve"
 	"github.com/debricked/cli/internal/cmd/scan"
+	"github.com/debricked/cli/internal/cmd/uploadbundle"
 	"github.com/debricked/cli/internal/wire"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -49,10 +52,13 @@ Read more: https://docs.debricked.com/product/administration/generate-access-tok
 	rootCmd.AddCommand(report.NewReportCmd(container.LicenseReporter(), container.VulnerabilityReporter(), container.SBOMReporter()))
 	rootCmd.AddCommand(files.NewFilesCmd(container.Finder()))
 	rootCmd.AddCommand(scan.NewScanCmd(container.Scanner()))
-	rootCmd.AddCommand(fingerprint.NewFingerprintCmd(container.Fingerprinter()))
+	rootCmd.AddCommand(uploadbundle.NewUploadBundleCmd(container.BundleReplayer()))
+	rootCmd.AddCommand(fingerprint.NewFingerprintCmd(container.Fingerprinter(), container.FingerprintMatcher()))
 	rootCmd.AddCommand(resolve.NewResolveCmd(container.Resolver()))
-	rootCmd.AddCommand(callgraph.NewCallgraphCmd(container.CallgraphGenerator()))
+	rootCmd.AddCommand(callgraph.NewCallgraphCmd(container.CallgraphGenerator(), container.CallgraphQueryLoader()))
 	rootCmd.AddCommand(auth.NewAuthCmd(container.Authenticator()))
+	rootCmd.AddCommand(policy.NewPolicyCmd(container.PolicyChecker()))
+	rootCmd.AddCommand(deps.NewDepsCmd(container.DepsLoader()))
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -8,8 +8,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/debricked/cli/internal/bundle"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/scan"
+	"github.com/debricked/cli/internal/upload"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -19,6 +21,7 @@ var branchName string
 var callgraph bool
 var callgraphGenerateTimeout int
 var callgraphUploadTimeout int
+var cacheFile string
 var commitAuthor string
 var commitName string
 var generateCommitName bool
@@ -27,6 +30,8 @@ var exclusions = file.Exclusions()
 var inclusions []string
 var integrationName string
 var jsonFilePath string
+var sarifPath string
+var junitPath string
 var minFingerprintContentLength int
 var noFingerprint bool
 var noResolve bool
@@ -41,9 +46,15 @@ var sbom string
 var sbomOutput string
 var tagCommitAsRelease bool
 var experimental bool
+var offline bool
+var bundleOutput string
+var uploadWorkers int
+var imagePath string
+var since string
 
 const (
 	BranchFlag                      = "branch"
+	CacheFileFlag                   = "cache-file"
 	CallGraphFlag                   = "callgraph"
 	CallGraphGenerateTimeoutFlag    = "callgraph-generate-timeout"
 	CallGraphUploadTimeoutFlag      = "callgraph-upload-timeout"
@@ -54,6 +65,8 @@ const (
 	IntegrationFlag                 = "integration"
 	InclusionFlag                   = "inclusion"
 	JsonFilePathFlag                = "json-path"
+	SarifFlag                       = "sarif"
+	JUnitFlag                       = "junit"
 	MinFingerprintContentLengthFlag = "min-fingerprint-content-length"
 	NoResolveFlag                   = "no-resolve"
 	NoFingerprintFlag               = "no-fingerprint"
@@ -70,6 +83,11 @@ const (
 	TagCommitAsReleaseEnv           = "TAG_COMMIT_AS_RELEASE"
 	ExperimentalFlag                = "experimental"
 	GenerateCommitNameFlag          = "generate-commit-name"
+	OfflineFlag                     = "offline"
+	BundleOutputFlag                = "bundle-output"
+	UploadWorkersFlag               = "upload-workers"
+	ImageFlag                       = "image"
+	SinceFlag                       = "since"
 )
 
 var scanCmdError error
@@ -102,6 +120,18 @@ If the given path contains a git repository all flags but "integration" will be
 		`name of integration used to trigger scan. For example "GitHub Actions"`,
 	)
 	cmd.Flags().StringVarP(&jsonFilePath, JsonFilePathFlag, "j", "", "write upload result as json to provided path")
+	cmd.Flags().StringVar(
+		&sarifPath,
+		SarifFlag,
+		"",
+		"write triggered automation rules as SARIF 2.1.0 to provided path, for use in code-scanning dashboards",
+	)
+	cmd.Flags().StringVar(
+		&junitPath,
+		JUnitFlag,
+		"",
+		"write resolution jobs and automation rules as a JUnit XML report to provided path, for use in CI test report views",
+	)
 	fileExclusionExample := filepath.Join("'*", "**.lock'")
 	dirExclusionExample := filepath.Join("'**", "node_modules", "**'")
 	exampleFlags := fmt.Sprintf("-e \"%s\" -e \"%s\"", fileExclusionExample, dirExclusionExample)
@@ -168,9 +198,13 @@ For example, if there is a "go.mod" in the target path, its dependencies are goi
 	cmd.Flags().IntVar(&callgraphUploadTimeout, CallGraphUploadTimeoutFlag, 10*60, "Set a timeout (in seconds) on call graph upload.")
 	cmd.Flags().IntVar(&callgraphGenerateTimeout, CallGraphGenerateTimeoutFlag, 60*60, "Set a timeout (in seconds) on call graph generation.")
 	cmd.Flags().IntVar(&minFingerprintContentLength, MinFingerprintContentLengthFlag, 0, "Set minimum content length (in bytes) for files to fingerprint.")
+	cmd.Flags().StringVar(&cacheFile, CacheFileFlag, "", `File to cache fingerprints in between scans. Files with unchanged path, size and modification time are not hashed again.
+Example:
+$ debricked scan . --cache-file .debricked/fingerprint-cache.json`)
 	npmPreferredDoc := strings.Join(
 		[]string{
 			"This flag allows you to select which package manager will be used as a resolver: Yarn (default) or NPM.",
+			"Projects with a pnpm-lock.yaml or pnpm-workspace.yaml file are always resolved with pnpm.",
 			"Example: debricked resolve --prefer-npm",
 		}, "\n")
 	cmd.Flags().BoolP(NpmPreferredFlag, "", npmPreferred, npmPreferredDoc)
@@ -186,6 +220,40 @@ Leaving the field empty results in no SBOM generation.`,
 		"Set to true to tag commit as a release. This will store the scan data indefinitely. Enterprise is required for this flag. Please visit https://debricked.com/pricing/ for more info. Can be overridden by "+TagCommitAsReleaseEnv+" environment variable.",
 	)
 
+	offlineDoc := strings.Join(
+		[]string{
+			"Run resolution, file matching and fingerprinting without contacting Debricked.",
+			"All matched files are written to a bundle which can later be uploaded from a connected machine using \"debricked upload-bundle\".",
+			"\nExample:\n$ debricked scan . --offline --bundle-output debricked-bundle.tar.gz",
+		}, "\n")
+	cmd.Flags().BoolVar(&offline, OfflineFlag, false, offlineDoc)
+	cmd.Flags().StringVar(&bundleOutput, BundleOutputFlag, bundle.DefaultOutputFileName, "Set output path of the bundle written by an offline scan")
+	cmd.Flags().IntVar(
+		&uploadWorkers,
+		UploadWorkersFlag,
+		upload.DefaultUploadWorkers,
+		"Set the number of files uploaded concurrently. Interrupted uploads are resumed on the next scan of the same commit",
+	)
+
+	imageDoc := strings.Join(
+		[]string{
+			"Scan a container image or JAR file instead of path. Images are OCI image layout directories or tar files written by \"docker save\".",
+			"OS packages (dpkg, apk and rpm) and language packages (JARs, Python distributions and npm packages) are uploaded as a CycloneDX SBOM,",
+			"and files of the image are fingerprinted including the contents of archives. Resolution and call graph generation are disabled.",
+			"\nExample:\n$ docker save app:1.2.0 -o app.tar && debricked scan --image app.tar",
+		}, "\n")
+	cmd.Flags().StringVar(&imagePath, ImageFlag, "", imageDoc)
+
+	sinceDoc := strings.Join(
+		[]string{
+			"Only resolve and upload dependency files changed since the merge base of the given revision and HEAD.",
+			"Groups whose manifest and lock files are unchanged are skipped. The revision is a branch, tag or commit, and branches are also looked up on the origin remote.",
+			"Use \"auto\" for the target branch of the pull request in CI pipelines. All files are scanned outside of pull request pipelines.",
+			"All files are scanned by default, also in pull request pipelines, so \"auto\" has to be added to pipelines to only scan changed files.",
+			"\nExamples:\n$ debricked scan . --since main\n$ debricked scan . --since auto",
+		}, "\n")
+	cmd.Flags().StringVar(&since, SinceFlag, "", sinceDoc)
+
 	viper.MustBindEnv(RepositoryFlag)
 	viper.MustBindEnv(CommitFlag)
 	viper.MustBindEnv(BranchFlag)
@@ -231,6 +299,7 @@ func RunE(s *scan.IScanner) func(_ *cobra.Command, args []string) error {
 
 		options := scan.DebrickedOptions{
 			Path:                        path,
+			Image:                       viper.GetString(ImageFlag),
 			Resolve:                     !viper.GetBool(NoResolveFlag),
 			Fingerprint:                 !viper.GetBool(NoFingerprintFlag),
 			SBOM:                        viper.GetString(SBOMFlag),
@@ -249,14 +318,22 @@ func RunE(s *scan.IScanner) func(_ *cobra.Command, args []string) error {
 			RepositoryUrl:               viper.GetString(RepositoryUrlFlag),
 			IntegrationName:             viper.GetString(IntegrationFlag),
 			JsonFilePath:                viper.GetString(JsonFilePathFlag),
+			SarifPath:                   viper.GetString(SarifFlag),
+			JUnitPath:                   viper.GetString(JUnitFlag),
 			NpmPreferred:                viper.GetBool(NpmPreferredFlag),
 			PassOnTimeOut:               viper.GetBool(PassOnTimeOut),
 			CallGraph:                   viper.GetBool(CallGraphFlag),
 			CallGraphUploadTimeout:      viper.GetInt(CallGraphUploadTimeoutFlag),
 			CallGraphGenerateTimeout:    viper.GetInt(CallGraphGenerateTimeoutFlag),
 			MinFingerprintContentLength: viper.GetInt(MinFingerprintContentLengthFlag),
+			FingerprintCachePath:        viper.GetString(CacheFileFlag),
 			TagCommitAsRelease:          tagCommitAsRelease,
 			Experimental:                viper.GetBool(ExperimentalFlag),
+			Offline:                     viper.GetBool(OfflineFlag),
+			BundleOutput:                viper.GetString(BundleOutputFlag),
+			UploadWorkers:               viper.GetInt(UploadWorkersFlag),
+			Since:                       viper.GetString(SinceFlag),
+			Version:                     viper.GetString("cliVersion"),
 		}
 		if s != nil {
 			scanCmdError = (*s).Scan(options)
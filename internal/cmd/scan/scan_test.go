@@ -25,6 +25,14 @@ func TestNewScanCmd(t *testing.T) {
 		CallGraphFlag:                "",
 		CallGraphUploadTimeoutFlag:   "",
 		CallGraphGenerateTimeoutFlag: "",
+		OfflineFlag:                  "",
+		BundleOutputFlag:             "",
+		UploadWorkersFlag:            "",
+		ImageFlag:                    "",
+		SinceFlag:                    "",
+		SarifFlag:                    "",
+		JUnitFlag:                    "",
+		CacheFileFlag:                "",
 	}
 	flags := cmd.Flags()
 	for name, shorthand := range flagAssertions {
@@ -0,0 +1,69 @@
+package uploadbundle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/debricked/cli/internal/bundle"
+	"github.com/debricked/cli/internal/client"
+	"github.com/debricked/cli/internal/scan"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var passOnDowntime bool
+
+const (
+	PassOnTimeOut = "pass-on-timeout"
+)
+
+func NewUploadBundleCmd(replayer bundle.IReplayer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upload-bundle <file>",
+		Short: "Upload a bundle created by an offline scan",
+		Long: `Uploads a bundle created by "debricked scan --offline" and starts a Debricked dependency scan.
+This allows scanning on machines that cannot reach Debricked, uploading the result from a connected machine.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(replayer),
+	}
+
+	cmd.Flags().BoolVarP(&passOnDowntime, PassOnTimeOut, "p", false, "pass scan if there is a service access timeout")
+
+	return cmd
+}
+
+func RunE(r bundle.IReplayer) func(_ *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if r == nil {
+			return errors.New("bundle replayer was nil")
+		}
+
+		result, err := r.Replay(args[0])
+		if err == client.NoResErr && viper.GetBool(PassOnTimeOut) {
+			fmt.Println(err)
+
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+
+		if result.LongQueue {
+			fmt.Println("Progress polling terminated due to long scan times. Please try again later")
+			fmt.Printf("For full details, visit: %s\n\n", color.BlueString(result.DetailsUrl))
+
+			return nil
+		}
+
+		err = scan.ReportResult(result)
+		if err == scan.FailPipelineErr && cmd != nil {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,108 @@
+package uploadbundle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/client"
+	"github.com/debricked/cli/internal/scan"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUploadBundleCmd(t *testing.T) {
+	cmd := NewUploadBundleCmd(&replayerMock{})
+
+	commands := cmd.Commands()
+	assert.Len(t, commands, 0)
+
+	flags := cmd.Flags()
+	flagAssertions := map[string]string{
+		PassOnTimeOut: "p",
+	}
+	for name, shorthand := range flagAssertions {
+		flag := flags.Lookup(name)
+		assert.NotNil(t, flag)
+		assert.Equal(t, shorthand, flag.Shorthand)
+	}
+}
+
+func TestRunE(t *testing.T) {
+	mock := &replayerMock{result: &upload.UploadResult{DetailsUrl: "https://debricked.com/details"}}
+	runE := RunE(mock)
+
+	err := runE(nil, []string{"debricked-bundle.tar.gz"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "debricked-bundle.tar.gz", mock.bundlePath)
+}
+
+func TestRunELongQueue(t *testing.T) {
+	mock := &replayerMock{result: &upload.UploadResult{LongQueue: true}}
+	runE := RunE(mock)
+
+	err := runE(nil, []string{"debricked-bundle.tar.gz"})
+
+	assert.NoError(t, err)
+}
+
+func TestRunEFailPipelineErr(t *testing.T) {
+	rule := automation.Rule{Triggered: true, RuleActions: []string{"failPipeline"}}
+	mock := &replayerMock{result: &upload.UploadResult{AutomationRules: []automation.Rule{rule}}}
+	runE := RunE(mock)
+	cmd := &cobra.Command{}
+
+	err := runE(cmd, []string{"debricked-bundle.tar.gz"})
+
+	assert.ErrorIs(t, err, scan.FailPipelineErr)
+	assert.True(t, cmd.SilenceUsage, "failed to assert that usage was silenced")
+	assert.True(t, cmd.SilenceErrors, "failed to assert that errors were silenced")
+}
+
+func TestRunEError(t *testing.T) {
+	mock := &replayerMock{err: errors.New("bundle does not exist")}
+	runE := RunE(mock)
+
+	err := runE(nil, []string{"debricked-bundle.tar.gz"})
+
+	assert.ErrorContains(t, err, "⨯ bundle does not exist")
+}
+
+func TestRunEPassOnTimeOut(t *testing.T) {
+	viper.Set(PassOnTimeOut, true)
+	defer viper.Set(PassOnTimeOut, false)
+	mock := &replayerMock{err: client.NoResErr}
+	runE := RunE(mock)
+
+	err := runE(nil, []string{"debricked-bundle.tar.gz"})
+
+	assert.NoError(t, err)
+}
+
+func TestRunENilReplayer(t *testing.T) {
+	runE := RunE(nil)
+
+	err := runE(nil, []string{"debricked-bundle.tar.gz"})
+
+	assert.ErrorContains(t, err, "bundle replayer was nil")
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewUploadBundleCmd(nil)
+	cmd.PreRun(cmd, nil)
+}
+
+type replayerMock struct {
+	bundlePath string
+	result     *upload.UploadResult
+	err        error
+}
+
+func (r *replayerMock) Replay(bundlePath string) (*upload.UploadResult, error) {
+	r.bundlePath = bundlePath
+
+	return r.result, r.err
+}
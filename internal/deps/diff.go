@@ -0,0 +1,238 @@
+package deps
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/debricked/cli/internal/lockfile"
+)
+
+const (
+	ChangeAdded      = "added"
+	ChangeRemoved    = "removed"
+	ChangeUpgraded   = "upgraded"
+	ChangeDowngraded = "downgraded"
+)
+
+// PackageChange is a change of a package between two dependency graphs. OldVersion is empty for added packages,
+// and NewVersion is empty for removed packages
+type PackageChange struct {
+	Name       string
+	Change     string
+	OldVersion string
+	NewVersion string
+	Direct     bool
+}
+
+// ProjectDiff holds the package changes of a lock file. LockFile is relative to the compared directories
+type ProjectDiff struct {
+	LockFile  string
+	Ecosystem string
+	Changes   []PackageChange
+}
+
+// Diff pairs the projects of oldProjects and newProjects by the paths of their lock files relative to oldRoot and
+// newRoot, and returns the package changes of each changed lock file. Lock files only existing on one side have all
+// their packages added or removed. Lock files that could not be parsed are left out
+func Diff(oldRoot string, oldProjects []Project, newRoot string, newProjects []Project) []ProjectDiff {
+	oldByPath := projectsByPath(oldRoot, oldProjects)
+	newByPath := projectsByPath(newRoot, newProjects)
+	paths := map[string]bool{}
+	for path := range oldByPath {
+		paths[path] = true
+	}
+	for path := range newByPath {
+		paths[path] = true
+	}
+
+	var diffs []ProjectDiff
+	for path := range paths {
+		oldProject, newProject := oldByPath[path], newByPath[path]
+		if oldProject.Err != nil || newProject.Err != nil {
+			continue
+		}
+		diff := ProjectDiff{LockFile: path, Changes: DiffGraphs(oldProject.Graph, newProject.Graph)}
+		if newProject.Graph != nil {
+			diff.Ecosystem = newProject.Graph.Ecosystem
+		} else {
+			diff.Ecosystem = oldProject.Graph.Ecosystem
+		}
+		if len(diff.Changes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].LockFile < diffs[j].LockFile
+	})
+
+	return diffs
+}
+
+// DiffGraphs returns the packages added, removed, upgraded and downgraded from oldGraph to newGraph, sorted by name.
+// A package with a single version replaced by another is upgraded or downgraded, while other version changes of a
+// package are reported as added and removed versions. Either graph may be nil
+func DiffGraphs(oldGraph *lockfile.Graph, newGraph *lockfile.Graph) []PackageChange {
+	oldVersions, oldDirect := versionsByName(oldGraph)
+	newVersions, newDirect := versionsByName(newGraph)
+	names := map[string]bool{}
+	for name := range oldVersions {
+		names[name] = true
+	}
+	for name := range newVersions {
+		names[name] = true
+	}
+
+	var changes []PackageChange
+	for name := range names {
+		removed := missingVersions(oldVersions[name], newVersions[name])
+		added := missingVersions(newVersions[name], oldVersions[name])
+		if len(removed) == 1 && len(added) == 1 {
+			change := PackageChange{
+				Name:       name,
+				Change:     ChangeUpgraded,
+				OldVersion: removed[0],
+				NewVersion: added[0],
+				Direct:     newDirect[lockfile.Id(name, added[0])],
+			}
+			if CompareVersions(added[0], removed[0]) < 0 {
+				change.Change = ChangeDowngraded
+			}
+			changes = append(changes, change)
+
+			continue
+		}
+		for _, version := range removed {
+			changes = append(changes, PackageChange{
+				Name:       name,
+				Change:     ChangeRemoved,
+				OldVersion: version,
+				Direct:     oldDirect[lockfile.Id(name, version)],
+			})
+		}
+		for _, version := range added {
+			changes = append(changes, PackageChange{
+				Name:       name,
+				Change:     ChangeAdded,
+				NewVersion: version,
+				Direct:     newDirect[lockfile.Id(name, version)],
+			})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+
+		return changes[i].OldVersion+changes[i].NewVersion < changes[j].OldVersion+changes[j].NewVersion
+	})
+
+	return changes
+}
+
+// CompareVersions compares two versions by their numeric and alphabetic parts, and returns -1, 0 or 1 if a is lower
+// than, equal to or greater than b. A leading v is ignored, and pre-releases such as 1.0.0-rc1 are lower than
+// their releases
+func CompareVersions(a string, b string) int {
+	aRelease, aPreRelease, aHasPreRelease := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	bRelease, bPreRelease, bHasPreRelease := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+	if result := compareVersionParts(versionParts(aRelease), versionParts(bRelease)); result != 0 {
+		return result
+	}
+	switch {
+	case aHasPreRelease && !bHasPreRelease:
+		return -1
+	case !aHasPreRelease && bHasPreRelease:
+		return 1
+	}
+
+	return compareVersionParts(versionParts(aPreRelease), versionParts(bPreRelease))
+}
+
+// versionParts splits version into runs of digits and runs of letters, dropping separators
+func versionParts(version string) []string {
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func compareVersionParts(a []string, b []string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		// Missing parts count as zero, so that 1.0 equals 1.0.0
+		aPart, bPart := "0", "0"
+		if i < len(a) {
+			aPart = a[i]
+		}
+		if i < len(b) {
+			bPart = b[i]
+		}
+		if result := compareVersionPart(aPart, bPart); result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+func compareVersionPart(a string, b string) int {
+	aNumber, aErr := strconv.ParseUint(a, 10, 64)
+	bNumber, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		if aNumber == bNumber {
+			return 0
+		} else if aNumber < bNumber {
+			return -1
+		}
+
+		return 1
+	case aErr == nil:
+		// Numbers are greater than qualifiers, such as 1.0.1 and 1.0.beta
+		return 1
+	case bErr == nil:
+		return -1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// versionsByName returns the versions of each package of graph, and the set of direct dependency ids
+func versionsByName(graph *lockfile.Graph) (map[string][]string, map[string]bool) {
+	versions := map[string][]string{}
+	if graph == nil {
+		return versions, map[string]bool{}
+	}
+	for _, dependency := range graph.Sorted() {
+		versions[dependency.Name] = append(versions[dependency.Name], dependency.Version)
+	}
+
+	return versions, toSet(EntryPoints(graph))
+}
+
+func missingVersions(versions []string, other []string) []string {
+	otherSet := toSet(other)
+	var missing []string
+	for _, version := range versions {
+		if !otherSet[version] {
+			missing = append(missing, version)
+		}
+	}
+
+	return missing
+}
+
+// projectsByPath maps projects to the paths of their lock files relative to root
+func projectsByPath(root string, projects []Project) map[string]Project {
+	byPath := map[string]Project{}
+	for _, project := range projects {
+		path := project.LockFile
+		if rel, err := filepath.Rel(root, project.LockFile); err == nil {
+			path = rel
+		}
+		byPath[filepath.ToSlash(path)] = project
+	}
+
+	return byPath
+}
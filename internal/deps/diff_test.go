@@ -0,0 +1,109 @@
+package deps
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/lockfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// newChangedGraph returns the diamond graph with a upgraded, b downgraded, c removed and e added
+func newChangedGraph() *lockfile.Graph {
+	graph := lockfile.NewGraph(lockfile.EcosystemNpm, "package-lock.json")
+	graph.Add("a", "1.10.0")
+	graph.Add("b", "0.9.0")
+	graph.Add("d", "1.0.0")
+	graph.Add("e", "2.0.0")
+	graph.AddRoot("a@1.10.0")
+	graph.AddRoot("b@0.9.0")
+	graph.AddEdge("a@1.10.0", "d@1.0.0")
+	graph.AddEdge("a@1.10.0", "e@2.0.0")
+	graph.AddEdge("b@0.9.0", "d@1.0.0")
+
+	return graph
+}
+
+func TestDiffGraphs(t *testing.T) {
+	changes := DiffGraphs(newDiamondGraph(), newChangedGraph())
+
+	assert.Equal(t, []PackageChange{
+		{Name: "a", Change: ChangeUpgraded, OldVersion: "1.0.0", NewVersion: "1.10.0", Direct: true},
+		{Name: "b", Change: ChangeDowngraded, OldVersion: "1.0.0", NewVersion: "0.9.0", Direct: true},
+		{Name: "c", Change: ChangeRemoved, OldVersion: "1.0.0"},
+		{Name: "e", Change: ChangeAdded, NewVersion: "2.0.0"},
+	}, changes)
+}
+
+func TestDiffGraphsSeveralVersions(t *testing.T) {
+	oldGraph := lockfile.NewGraph(lockfile.EcosystemNpm, "package-lock.json")
+	oldGraph.Add("a", "1.0.0")
+	newGraph := lockfile.NewGraph(lockfile.EcosystemNpm, "package-lock.json")
+	newGraph.Add("a", "1.0.0")
+	newGraph.Add("a", "2.0.0")
+	newGraph.Add("a", "3.0.0")
+
+	changes := DiffGraphs(oldGraph, newGraph)
+
+	assert.Equal(t, []PackageChange{
+		{Name: "a", Change: ChangeAdded, NewVersion: "2.0.0", Direct: true},
+		{Name: "a", Change: ChangeAdded, NewVersion: "3.0.0", Direct: true},
+	}, changes)
+}
+
+func TestDiffGraphsNil(t *testing.T) {
+	assert.Len(t, DiffGraphs(nil, newDiamondGraph()), 4)
+	assert.Len(t, DiffGraphs(newDiamondGraph(), nil), 4)
+	assert.Empty(t, DiffGraphs(newDiamondGraph(), newDiamondGraph()))
+}
+
+func TestDiff(t *testing.T) {
+	oldRoot, newRoot := filepath.Join("tmp", "old"), filepath.Join("tmp", "new")
+	oldProjects := []Project{
+		{LockFile: filepath.Join(oldRoot, "web", "package-lock.json"), Graph: newDiamondGraph()},
+		{LockFile: filepath.Join(oldRoot, "api", "package-lock.json"), Graph: newDiamondGraph()},
+		{LockFile: filepath.Join(oldRoot, "removed", "package-lock.json"), Graph: newDiamondGraph()},
+		{LockFile: filepath.Join(oldRoot, "composer.lock"), Err: errors.New("failed to parse composer.lock")},
+	}
+	newProjects := []Project{
+		{LockFile: filepath.Join(newRoot, "web", "package-lock.json"), Graph: newChangedGraph()},
+		{LockFile: filepath.Join(newRoot, "api", "package-lock.json"), Graph: newDiamondGraph()},
+		{LockFile: filepath.Join(newRoot, "composer.lock"), Graph: lockfile.NewGraph(lockfile.EcosystemComposer, "composer.lock")},
+	}
+
+	diffs := Diff(oldRoot, oldProjects, newRoot, newProjects)
+
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "removed/package-lock.json", diffs[0].LockFile)
+	assert.Equal(t, lockfile.EcosystemNpm, diffs[0].Ecosystem)
+	assert.Len(t, diffs[0].Changes, 4)
+	for _, change := range diffs[0].Changes {
+		assert.Equal(t, ChangeRemoved, change.Change)
+	}
+	assert.Equal(t, "web/package-lock.json", diffs[1].LockFile)
+	assert.Len(t, diffs[1].Changes, 4)
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b   string
+		result int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"2.0.0", "10.0.0", -1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+		{"5.3.1.RELEASE", "5.3.10.RELEASE", -1},
+		{"1.0.1", "1.0.beta", 1},
+		{"v0.0.0-20230101000000-abcdef", "v0.0.0-20240101000000-123456", -1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.result, CompareVersions(c.a, c.b), "%s compared to %s", c.a, c.b)
+		assert.Equal(t, -c.result, CompareVersions(c.b, c.a), "%s compared to %s", c.b, c.a)
+	}
+}
@@ -0,0 +1,93 @@
+package deps
+
+import (
+	"sort"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/lockfile"
+)
+
+// Project is the dependency graph of one lock file. Err is set if the lock file could not be parsed
+type Project struct {
+	ManifestFile string
+	LockFile     string
+	Graph        *lockfile.Graph
+	Err          error
+}
+
+type DebrickedOptions struct {
+	Path       string
+	Exclusions []string
+	Inclusions []string
+	// Offline matches files with the formats bundled with the CLI, instead of fetching them from Debricked
+	Offline bool
+}
+
+type ILoader interface {
+	Load(options DebrickedOptions) ([]Project, error)
+}
+
+type Loader struct {
+	finder file.IFinder
+}
+
+func NewLoader(finder file.IFinder) *Loader {
+	return &Loader{finder: finder}
+}
+
+// Load parses the lock files, both package manager native and resolved Debricked lock files, of all file groups in options.Path
+func (loader *Loader) Load(options DebrickedOptions) ([]Project, error) {
+	fileGroups, err := loader.finder.GetGroups(
+		file.DebrickedOptions{
+			RootPath:     options.Path,
+			Exclusions:   options.Exclusions,
+			Inclusions:   options.Inclusions,
+			LockFileOnly: false,
+			Strictness:   file.StrictAll,
+			Offline:      options.Offline,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []Project
+	// Lock files found next to manifest files can belong to several groups, they are only parsed once
+	loaded := map[string]bool{}
+	for _, fileGroup := range fileGroups.ToSlice() {
+		for _, lockFile := range lockfile.FindInGroup(fileGroup) {
+			if loaded[lockFile] {
+				continue
+			}
+			loaded[lockFile] = true
+			graph, parseErr := lockfile.Parse(lockFile)
+			projects = append(projects, Project{
+				ManifestFile: fileGroup.ManifestFile,
+				LockFile:     lockFile,
+				Graph:        graph,
+				Err:          parseErr,
+			})
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].LockFile < projects[j].LockFile
+	})
+
+	return projects, nil
+}
+
+// LoadRevision writes the lock files of revision, and the manifest files read when parsing them, from the repository
+// containing options.Path to dir, and loads them. Lock files are loaded from dir, with paths relative to options.Path
+func LoadRevision(loader ILoader, options DebrickedOptions, revision string, dir string) ([]Project, error) {
+	path := options.Path
+	if len(path) == 0 {
+		path = "."
+	}
+	if err := git.WriteRevisionFiles(path, revision, dir, lockfile.UsedByParsers); err != nil {
+		return nil, err
+	}
+	options.Path = dir
+
+	return loader.Load(options)
+}
@@ -0,0 +1,123 @@
+package deps
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/file/testdata"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	npmLockFile     = "../lockfile/testdata/npm/v3/package-lock.json"
+	invalidLockFile = "../lockfile/testdata/invalid/package-lock.json"
+)
+
+func TestNewLoader(t *testing.T) {
+	loader := NewLoader(testdata.NewFinderMock())
+
+	assert.NotNil(t, loader)
+}
+
+func TestLoad(t *testing.T) {
+	finder := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{LockFiles: []string{invalidLockFile}})
+	groups.Add(file.Group{LockFiles: []string{npmLockFile, "go.mod.debricked-fingerprints.txt"}})
+	finder.SetGetGroupsReturnMock(groups, nil)
+	loader := NewLoader(finder)
+
+	projects, err := loader.Load(DebrickedOptions{Path: "."})
+
+	assert.NoError(t, err)
+	assert.Len(t, projects, 2)
+	assert.Equal(t, invalidLockFile, projects[0].LockFile)
+	assert.Nil(t, projects[0].Graph)
+	assert.ErrorContains(t, projects[0].Err, "failed to parse")
+	assert.Equal(t, npmLockFile, projects[1].LockFile)
+	assert.NoError(t, projects[1].Err)
+	assert.Equal(t, 5, projects[1].Graph.Size())
+}
+
+func TestLoadLockFileInSeveralGroups(t *testing.T) {
+	finder := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: "package.json", LockFiles: []string{npmLockFile}})
+	groups.Add(file.Group{ManifestFile: "bower.json", LockFiles: []string{npmLockFile}})
+	finder.SetGetGroupsReturnMock(groups, nil)
+	loader := NewLoader(finder)
+
+	projects, err := loader.Load(DebrickedOptions{Path: "."})
+
+	assert.NoError(t, err)
+	assert.Len(t, projects, 1)
+	assert.Equal(t, npmLockFile, projects[0].LockFile)
+}
+
+func TestLoadGetGroupsErr(t *testing.T) {
+	finder := testdata.NewFinderMock()
+	getGroupsErr := errors.New("get groups error")
+	finder.SetGetGroupsReturnMock(file.Groups{}, getGroupsErr)
+	loader := NewLoader(finder)
+
+	projects, err := loader.Load(DebrickedOptions{Path: "."})
+
+	assert.ErrorIs(t, err, getGroupsErr)
+	assert.Empty(t, projects)
+}
+
+type recordingLoader struct {
+	options DebrickedOptions
+}
+
+func (loader *recordingLoader) Load(options DebrickedOptions) ([]Project, error) {
+	loader.options = options
+
+	return []Project{}, nil
+}
+
+func TestLoadRevision(t *testing.T) {
+	repositoryDir := t.TempDir()
+	repository, err := git.PlainInit(repositoryDir, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	for _, name := range []string{"web/package.json", "web/package-lock.json", "web/README.md", "api/pom.xml"} {
+		path := filepath.Join(repositoryDir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+		_, err = worktree.Add(name)
+		assert.NoError(t, err)
+	}
+	_, err = worktree.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "debricked", When: time.Now()}})
+	assert.NoError(t, err)
+	loader := &recordingLoader{}
+	dir := t.TempDir()
+
+	projects, err := LoadRevision(loader, DebrickedOptions{Path: repositoryDir, Exclusions: []string{"**/api/**"}}, "HEAD", dir)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, projects)
+	assert.Equal(t, dir, loader.options.Path)
+	assert.Equal(t, []string{"**/api/**"}, loader.options.Exclusions)
+	assert.FileExists(t, filepath.Join(dir, "web", "package-lock.json"))
+	assert.FileExists(t, filepath.Join(dir, "web", "package.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "web", "README.md"))
+	assert.NoFileExists(t, filepath.Join(dir, "api", "pom.xml"))
+}
+
+func TestLoadRevisionErr(t *testing.T) {
+	loader := &recordingLoader{}
+
+	projects, err := LoadRevision(loader, DebrickedOptions{Path: t.TempDir()}, "HEAD", t.TempDir())
+
+	assert.ErrorIs(t, err, git.ErrRepositoryNotExists)
+	assert.Nil(t, projects)
+	assert.Empty(t, loader.options.Path)
+}
@@ -0,0 +1,173 @@
+package deps
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/lockfile"
+)
+
+// MaxPaths limits the number of paths returned for each dependency, as large graphs can have exponentially many
+const MaxPaths = 100
+
+// Match returns the dependencies of graph matching query, which is either a name or name@version.
+// Names are matched case-insensitively, and Maven style `group:artifact` names also match on the artifact
+func Match(graph *lockfile.Graph, query string) []*lockfile.Dependency {
+	name, version := query, ""
+	if i := strings.LastIndex(query, "@"); i > 0 {
+		name, version = query[:i], query[i+1:]
+	}
+	var matches []*lockfile.Dependency
+	for _, dependency := range graph.Sorted() {
+		if !matchesName(dependency.Name, name) {
+			continue
+		}
+		if len(version) == 0 || dependency.Version == version {
+			matches = append(matches, dependency)
+		}
+	}
+
+	return matches
+}
+
+func matchesName(dependencyName string, name string) bool {
+	if strings.EqualFold(dependencyName, name) {
+		return true
+	}
+	_, artifact, isMaven := strings.Cut(dependencyName, ":")
+
+	return isMaven && !strings.Contains(name, ":") && strings.EqualFold(artifact, name)
+}
+
+// EntryPoints returns the direct dependencies of graph. Graphs of lock files not recording direct dependencies
+// use the dependencies no other dependency depends on
+func EntryPoints(graph *lockfile.Graph) []string {
+	if len(graph.Roots) > 0 {
+		return graph.Roots
+	}
+	dependedOn := map[string]bool{}
+	for _, dependency := range graph.Dependencies {
+		for _, id := range dependency.Dependencies {
+			dependedOn[id] = true
+		}
+	}
+	var entryPoints []string
+	for _, dependency := range graph.Sorted() {
+		if !dependedOn[dependency.Id()] {
+			entryPoints = append(entryPoints, dependency.Id())
+		}
+	}
+
+	return entryPoints
+}
+
+// Paths returns at most limit paths from the direct dependencies of graph to the dependency with id.
+// Each path starts with a direct dependency and ends with id. Only dependencies pulling in id are walked, as walking
+// the other paths of the graph takes exponential time when id is pulled in by few paths, or none
+func Paths(graph *lockfile.Graph, id string, limit int) [][]string {
+	var paths [][]string
+	pullsIn := dependents(graph, id)
+	onPath := map[string]bool{}
+	var path []string
+
+	var walk func(current string)
+	walk = func(current string) {
+		if len(paths) >= limit || onPath[current] || !pullsIn[current] {
+			return
+		}
+		path = append(path, current)
+		onPath[current] = true
+		if current == id {
+			paths = append(paths, append([]string{}, path...))
+		} else if dependency, ok := graph.Get(current); ok {
+			for _, next := range dependency.Dependencies {
+				walk(next)
+			}
+		}
+		onPath[current] = false
+		path = path[:len(path)-1]
+	}
+	for _, entryPoint := range EntryPoints(graph) {
+		walk(entryPoint)
+	}
+	sortPaths(paths)
+
+	return paths
+}
+
+// dependents returns the dependencies that directly or transitively depend on the dependency with id, and id itself
+func dependents(graph *lockfile.Graph, id string) map[string]bool {
+	dependedOnBy := map[string][]string{}
+	for from, dependency := range graph.Dependencies {
+		for _, to := range dependency.Dependencies {
+			dependedOnBy[to] = append(dependedOnBy[to], from)
+		}
+	}
+	found := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range dependedOnBy[current] {
+			if !found[next] {
+				found[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return found
+}
+
+// Why returns the shortest path from each direct dependency pulling in the dependency with id
+func Why(graph *lockfile.Graph, id string) [][]string {
+	var paths [][]string
+	for _, entryPoint := range EntryPoints(graph) {
+		if path := shortestPath(graph, entryPoint, id); path != nil {
+			paths = append(paths, path)
+		}
+	}
+	sortPaths(paths)
+
+	return paths
+}
+
+func shortestPath(graph *lockfile.Graph, from string, to string) []string {
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var path []string
+			for ; len(current) > 0; current = previous[current] {
+				path = append([]string{current}, path...)
+			}
+
+			return path
+		}
+		dependency, ok := graph.Get(current)
+		if !ok {
+			continue
+		}
+		for _, next := range dependency.Dependencies {
+			if _, visited := previous[next]; !visited {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortPaths sorts paths by length, and then alphabetically
+func sortPaths(paths [][]string) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) < len(paths[j])
+		}
+
+		return strings.Join(paths[i], " ") < strings.Join(paths[j], " ")
+	})
+}
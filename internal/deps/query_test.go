@@ -0,0 +1,139 @@
+package deps
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/debricked/cli/internal/lockfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDiamondGraph returns a graph where both direct dependencies a and b pull in d, a through c
+func newDiamondGraph() *lockfile.Graph {
+	graph := lockfile.NewGraph(lockfile.EcosystemNpm, "package-lock.json")
+	for _, name := range []string{"a", "b", "c", "d"} {
+		graph.Add(name, "1.0.0")
+	}
+	graph.AddRoot("a@1.0.0")
+	graph.AddRoot("b@1.0.0")
+	graph.AddEdge("a@1.0.0", "c@1.0.0")
+	graph.AddEdge("a@1.0.0", "d@1.0.0")
+	graph.AddEdge("c@1.0.0", "d@1.0.0")
+	graph.AddEdge("b@1.0.0", "d@1.0.0")
+
+	return graph
+}
+
+func TestMatch(t *testing.T) {
+	graph := lockfile.NewGraph(lockfile.EcosystemMaven, "maven.debricked.lock")
+	graph.Add("org.slf4j:slf4j-api", "2.0.9")
+	graph.Add("org.slf4j:slf4j-api", "1.7.36")
+	graph.Add("Django", "4.2")
+
+	cases := []struct {
+		query    string
+		expected []string
+	}{
+		{"org.slf4j:slf4j-api", []string{"org.slf4j:slf4j-api@1.7.36", "org.slf4j:slf4j-api@2.0.9"}},
+		{"slf4j-api@2.0.9", []string{"org.slf4j:slf4j-api@2.0.9"}},
+		{"django", []string{"Django@4.2"}},
+		{"other:slf4j-api", nil},
+		{"django@5.0", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			var ids []string
+			for _, dependency := range Match(graph, c.query) {
+				ids = append(ids, dependency.Id())
+			}
+			assert.Equal(t, c.expected, ids)
+		})
+	}
+}
+
+func TestMatchScopedPackage(t *testing.T) {
+	graph := lockfile.NewGraph(lockfile.EcosystemNpm, "package-lock.json")
+	graph.Add("@babel/core", "7.0.0")
+
+	assert.Len(t, Match(graph, "@babel/core"), 1)
+	assert.Len(t, Match(graph, "@babel/core@7.0.0"), 1)
+	assert.Empty(t, Match(graph, "@babel/core@6.0.0"))
+}
+
+func TestEntryPoints(t *testing.T) {
+	graph := newDiamondGraph()
+	assert.Equal(t, []string{"a@1.0.0", "b@1.0.0"}, EntryPoints(graph))
+
+	graph.Roots = nil
+	assert.Equal(t, []string{"a@1.0.0", "b@1.0.0"}, EntryPoints(graph))
+}
+
+func TestPaths(t *testing.T) {
+	paths := Paths(newDiamondGraph(), "d@1.0.0", MaxPaths)
+
+	assert.Equal(t, [][]string{
+		{"a@1.0.0", "d@1.0.0"},
+		{"b@1.0.0", "d@1.0.0"},
+		{"a@1.0.0", "c@1.0.0", "d@1.0.0"},
+	}, paths)
+}
+
+func TestPathsLimit(t *testing.T) {
+	paths := Paths(newDiamondGraph(), "d@1.0.0", 1)
+
+	assert.Len(t, paths, 1)
+}
+
+func TestPathsCycle(t *testing.T) {
+	graph := newDiamondGraph()
+	graph.AddEdge("d@1.0.0", "a@1.0.0")
+
+	paths := Paths(graph, "c@1.0.0", MaxPaths)
+
+	assert.Equal(t, [][]string{
+		{"a@1.0.0", "c@1.0.0"},
+		{"b@1.0.0", "d@1.0.0", "a@1.0.0", "c@1.0.0"},
+	}, paths)
+}
+
+func TestPathsUnreachable(t *testing.T) {
+	// Each of the 40 layers of diamonds doubles the number of paths through the graph
+	graph := lockfile.NewGraph(lockfile.EcosystemNpm, "package-lock.json")
+	graph.Add("target", "1.0.0")
+	var previous []string
+	for layer := 0; layer < 40; layer++ {
+		var current []string
+		for _, name := range []string{"left", "right"} {
+			current = append(current, graph.Add(fmt.Sprintf("%s-%d", name, layer), "1.0.0").Id())
+		}
+		for _, from := range previous {
+			for _, to := range current {
+				graph.AddEdge(from, to)
+			}
+		}
+		previous = current
+	}
+	graph.AddRoot("left-0@1.0.0")
+	graph.AddRoot("right-0@1.0.0")
+
+	assert.Empty(t, Paths(graph, "target@1.0.0", MaxPaths))
+}
+
+func TestWhy(t *testing.T) {
+	paths := Why(newDiamondGraph(), "d@1.0.0")
+
+	assert.Equal(t, [][]string{
+		{"a@1.0.0", "d@1.0.0"},
+		{"b@1.0.0", "d@1.0.0"},
+	}, paths)
+}
+
+func TestWhyDirectDependency(t *testing.T) {
+	paths := Why(newDiamondGraph(), "b@1.0.0")
+
+	assert.Equal(t, [][]string{{"b@1.0.0"}}, paths)
+}
+
+func TestWhyNotFound(t *testing.T) {
+	assert.Empty(t, Why(newDiamondGraph(), "e@1.0.0"))
+}
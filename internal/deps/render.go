@@ -0,0 +1,494 @@
+package deps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/debricked/cli/internal/lockfile"
+	"github.com/fatih/color"
+)
+
+const (
+	FormatText     = "text"
+	FormatJson     = "json"
+	FormatDot      = "dot"
+	FormatMarkdown = "markdown"
+)
+
+var (
+	UnsupportedFormatErr     = errors.New("unsupported format, supported formats are: text, json and dot")
+	UnsupportedDiffFormatErr = errors.New("unsupported format, supported formats are: markdown and json")
+)
+
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatText, FormatJson, FormatDot:
+		return nil
+	default:
+		return UnsupportedFormatErr
+	}
+}
+
+// ValidateDiffFormat validates the format of diffs, which are written as Markdown tables for pull request comments
+func ValidateDiffFormat(format string) error {
+	switch format {
+	case FormatMarkdown, FormatJson:
+		return nil
+	default:
+		return UnsupportedDiffFormatErr
+	}
+}
+
+type jsonDependency struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	Direct       bool     `json:"direct"`
+	Dev          bool     `json:"dev"`
+	Dependencies []string `json:"dependencies"`
+}
+
+type jsonProject struct {
+	ManifestFile string           `json:"manifestFile"`
+	LockFile     string           `json:"lockFile"`
+	Ecosystem    string           `json:"ecosystem"`
+	Dependencies []jsonDependency `json:"dependencies"`
+}
+
+type jsonTreeNode struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Dev     bool   `json:"dev"`
+	// Repeated is true if the dependencies of the node are already part of the tree
+	Repeated     bool           `json:"repeated,omitempty"`
+	Dependencies []jsonTreeNode `json:"dependencies,omitempty"`
+}
+
+type jsonTree struct {
+	ManifestFile string         `json:"manifestFile"`
+	LockFile     string         `json:"lockFile"`
+	Ecosystem    string         `json:"ecosystem"`
+	Dependencies []jsonTreeNode `json:"dependencies"`
+}
+
+type jsonPaths struct {
+	ManifestFile string     `json:"manifestFile"`
+	LockFile     string     `json:"lockFile"`
+	Ecosystem    string     `json:"ecosystem"`
+	Name         string     `json:"name"`
+	Version      string     `json:"version"`
+	Paths        [][]string `json:"paths"`
+}
+
+type jsonPackageChange struct {
+	Name       string `json:"name"`
+	Change     string `json:"change"`
+	OldVersion string `json:"oldVersion,omitempty"`
+	NewVersion string `json:"newVersion,omitempty"`
+	Direct     bool   `json:"direct"`
+}
+
+type jsonProjectDiff struct {
+	LockFile  string              `json:"lockFile"`
+	Ecosystem string              `json:"ecosystem"`
+	Changes   []jsonPackageChange `json:"changes"`
+}
+
+// PathResult holds the paths from the direct dependencies of a project to a dependency
+type PathResult struct {
+	Project    Project
+	Dependency *lockfile.Dependency
+	Paths      [][]string
+}
+
+// FindPaths returns the paths to all dependencies matching query. If shortest is true,
+// only the shortest path from each direct dependency is returned
+func FindPaths(projects []Project, query string, shortest bool) []PathResult {
+	var results []PathResult
+	for _, project := range parsed(projects) {
+		for _, dependency := range Match(project.Graph, query) {
+			var paths [][]string
+			if shortest {
+				paths = Why(project.Graph, dependency.Id())
+			} else {
+				paths = Paths(project.Graph, dependency.Id(), MaxPaths)
+			}
+			results = append(results, PathResult{Project: project, Dependency: dependency, Paths: paths})
+		}
+	}
+
+	return results
+}
+
+// WriteList writes all dependencies of projects
+func WriteList(w io.Writer, projects []Project, format string) error {
+	switch format {
+	case FormatJson:
+		output := []jsonProject{}
+		for _, project := range parsed(projects) {
+			output = append(output, newJsonProject(project))
+		}
+
+		return writeJson(w, output)
+	case FormatDot:
+		return writeDot(w, projects)
+	}
+
+	for _, project := range parsed(projects) {
+		fmt.Fprintf(w, "%s: %s\n", projectTitle(project), lockfile.Summary(project.Graph))
+		direct := toSet(project.Graph.Roots)
+		for _, dependency := range project.Graph.Sorted() {
+			fmt.Fprintf(w, "  %s %s%s\n", dependency.Name, dependency.Version, describe(dependency, direct[dependency.Id()]))
+		}
+	}
+
+	return nil
+}
+
+// WriteTree writes the dependency trees of projects, starting from their direct dependencies.
+// A depth of 0 writes the full trees, while subtrees already written are only written once
+func WriteTree(w io.Writer, projects []Project, format string, depth int) error {
+	switch format {
+	case FormatJson:
+		output := []jsonTree{}
+		for _, project := range parsed(projects) {
+			output = append(output, newJsonTree(project, depth))
+		}
+
+		return writeJson(w, output)
+	case FormatDot:
+		return writeDot(w, projects)
+	}
+
+	for _, project := range parsed(projects) {
+		fmt.Fprintln(w, projectTitle(project))
+		expanded := map[string]bool{}
+		var write func(id string, prefix string, last bool, level int)
+		write = func(id string, prefix string, last bool, level int) {
+			dependency, _ := project.Graph.Get(id)
+			branch, indentation := "├── ", "│   "
+			if last {
+				branch, indentation = "└── ", "    "
+			}
+			repeated := expanded[id] && len(dependency.Dependencies) > 0
+			suffix := describe(dependency, false)
+			if repeated {
+				suffix += " (*)"
+			}
+			fmt.Fprintf(w, "%s%s%s%s\n", prefix, branch, id, suffix)
+			if repeated || (depth > 0 && level >= depth) {
+				return
+			}
+			expanded[id] = true
+			for i, next := range dependency.Dependencies {
+				write(next, prefix+indentation, i == len(dependency.Dependencies)-1, level+1)
+			}
+		}
+		entryPoints := EntryPoints(project.Graph)
+		for i, id := range entryPoints {
+			write(id, "", i == len(entryPoints)-1, 1)
+		}
+	}
+
+	return nil
+}
+
+// WritePaths writes the paths of results, each path starting with a direct dependency
+func WritePaths(w io.Writer, results []PathResult, format string) error {
+	switch format {
+	case FormatJson:
+		output := []jsonPaths{}
+		for _, result := range results {
+			output = append(output, jsonPaths{
+				ManifestFile: result.Project.ManifestFile,
+				LockFile:     result.Project.LockFile,
+				Ecosystem:    result.Project.Graph.Ecosystem,
+				Name:         result.Dependency.Name,
+				Version:      result.Dependency.Version,
+				Paths:        nonNilPaths(result.Paths),
+			})
+		}
+
+		return writeJson(w, output)
+	case FormatDot:
+		return writePathsDot(w, results)
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\n%s is pulled in by %d path(s)\n", projectTitle(result.Project), result.Dependency.Id(), len(result.Paths))
+		for _, path := range result.Paths {
+			fmt.Fprintf(w, "  %s\n", strings.Join(path, " > "))
+		}
+		if len(result.Paths) >= MaxPaths {
+			fmt.Fprintf(w, "  ... only the first %d paths are shown\n", MaxPaths)
+		}
+	}
+
+	return nil
+}
+
+// WriteDiffs writes the package changes of diffs, as a Markdown table per lock file or as JSON
+func WriteDiffs(w io.Writer, diffs []ProjectDiff, format string) error {
+	if format == FormatJson {
+		output := []jsonProjectDiff{}
+		for _, diff := range diffs {
+			jsonDiff := jsonProjectDiff{LockFile: diff.LockFile, Ecosystem: diff.Ecosystem, Changes: []jsonPackageChange{}}
+			for _, change := range diff.Changes {
+				jsonDiff.Changes = append(jsonDiff.Changes, jsonPackageChange(change))
+			}
+			output = append(output, jsonDiff)
+		}
+
+		return writeJson(w, output)
+	}
+
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(w, "No dependency changes")
+
+		return err
+	}
+	var builder strings.Builder
+	builder.WriteString("## Dependency changes\n")
+	for _, diff := range diffs {
+		builder.WriteString(fmt.Sprintf("\n**%s** (%s): %s\n\n", markdownCell(diff.LockFile), diff.Ecosystem, changeSummary(diff.Changes)))
+		builder.WriteString("| Package | Change | Old version | New version | Dependency |\n")
+		builder.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, change := range diff.Changes {
+			dependencyType := "transitive"
+			if change.Direct {
+				dependencyType = "direct"
+			}
+			builder.WriteString(fmt.Sprintf(
+				"| %s | %s | %s | %s | %s |\n",
+				markdownCell(change.Name),
+				change.Change,
+				markdownCell(change.OldVersion),
+				markdownCell(change.NewVersion),
+				dependencyType,
+			))
+		}
+	}
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+// changeSummary counts the changes of each kind, such as "2 added, 1 upgraded"
+func changeSummary(changes []PackageChange) string {
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Change]++
+	}
+	var summary []string
+	for _, kind := range []string{ChangeAdded, ChangeRemoved, ChangeUpgraded, ChangeDowngraded} {
+		if counts[kind] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+
+	return strings.Join(summary, ", ")
+}
+
+// markdownCell escapes the characters of value that would break a Markdown table
+func markdownCell(value string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(value)
+}
+
+func newJsonProject(project Project) jsonProject {
+	direct := toSet(project.Graph.Roots)
+	output := jsonProject{
+		ManifestFile: project.ManifestFile,
+		LockFile:     project.LockFile,
+		Ecosystem:    project.Graph.Ecosystem,
+		Dependencies: []jsonDependency{},
+	}
+	for _, dependency := range project.Graph.Sorted() {
+		output.Dependencies = append(output.Dependencies, jsonDependency{
+			Name:         dependency.Name,
+			Version:      dependency.Version,
+			Direct:       direct[dependency.Id()],
+			Dev:          dependency.Dev,
+			Dependencies: dependency.Dependencies,
+		})
+	}
+
+	return output
+}
+
+func newJsonTree(project Project, depth int) jsonTree {
+	expanded := map[string]bool{}
+	var node func(id string, level int) jsonTreeNode
+	node = func(id string, level int) jsonTreeNode {
+		dependency, _ := project.Graph.Get(id)
+		n := jsonTreeNode{Name: dependency.Name, Version: dependency.Version, Dev: dependency.Dev}
+		if expanded[id] && len(dependency.Dependencies) > 0 {
+			n.Repeated = true
+
+			return n
+		}
+		if depth > 0 && level >= depth {
+			return n
+		}
+		expanded[id] = true
+		for _, next := range dependency.Dependencies {
+			n.Dependencies = append(n.Dependencies, node(next, level+1))
+		}
+
+		return n
+	}
+	tree := jsonTree{
+		ManifestFile: project.ManifestFile,
+		LockFile:     project.LockFile,
+		Ecosystem:    project.Graph.Ecosystem,
+		Dependencies: []jsonTreeNode{},
+	}
+	for _, id := range EntryPoints(project.Graph) {
+		tree.Dependencies = append(tree.Dependencies, node(id, 1))
+	}
+
+	return tree
+}
+
+func writeJson(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// writeDot writes the graphs of projects in the DOT language, with one cluster per project
+func writeDot(w io.Writer, projects []Project) error {
+	var builder strings.Builder
+	builder.WriteString("digraph dependencies {\n  rankdir=LR;\n")
+	for i, project := range parsed(projects) {
+		writeDotClusterStart(&builder, i, project)
+		for _, dependency := range project.Graph.Sorted() {
+			writeDotNode(&builder, i, dependency)
+		}
+		for _, id := range EntryPoints(project.Graph) {
+			writeDotEdge(&builder, i, "", id)
+		}
+		for _, dependency := range project.Graph.Sorted() {
+			for _, id := range dependency.Dependencies {
+				writeDotEdge(&builder, i, dependency.Id(), id)
+			}
+		}
+		builder.WriteString("  }\n")
+	}
+	builder.WriteString("}\n")
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+// writePathsDot writes the subgraph formed by the paths of results in the DOT language
+func writePathsDot(w io.Writer, results []PathResult) error {
+	var builder strings.Builder
+	builder.WriteString("digraph dependencies {\n  rankdir=LR;\n")
+	for i, result := range results {
+		writeDotClusterStart(&builder, i, result.Project)
+		nodes := map[string]bool{}
+		edges := map[string]bool{}
+		for _, path := range result.Paths {
+			from := ""
+			for _, id := range path {
+				if !nodes[id] {
+					nodes[id] = true
+					dependency, _ := result.Project.Graph.Get(id)
+					writeDotNode(&builder, i, dependency)
+				}
+				if edge := from + "\n" + id; !edges[edge] {
+					edges[edge] = true
+					writeDotEdge(&builder, i, from, id)
+				}
+				from = id
+			}
+		}
+		builder.WriteString("  }\n")
+	}
+	builder.WriteString("}\n")
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+func writeDotClusterStart(builder *strings.Builder, cluster int, project Project) {
+	builder.WriteString(fmt.Sprintf("  subgraph cluster_%d {\n    label=%q;\n", cluster, project.LockFile))
+	builder.WriteString(fmt.Sprintf("    %q [label=%q, shape=box];\n", dotNodeId(cluster, ""), project.LockFile))
+}
+
+func writeDotNode(builder *strings.Builder, cluster int, dependency *lockfile.Dependency) {
+	style := ""
+	if dependency.Dev {
+		style = ", style=dashed"
+	}
+	builder.WriteString(fmt.Sprintf("    %q [label=%q%s];\n", dotNodeId(cluster, dependency.Id()), dependency.Id(), style))
+}
+
+func writeDotEdge(builder *strings.Builder, cluster int, from string, to string) {
+	builder.WriteString(fmt.Sprintf("    %q -> %q;\n", dotNodeId(cluster, from), dotNodeId(cluster, to)))
+}
+
+// dotNodeId prefixes ids by their cluster, as the same dependency can be part of several projects.
+// The empty id is the project itself
+func dotNodeId(cluster int, id string) string {
+	return fmt.Sprintf("%d/%s", cluster, id)
+}
+
+func projectTitle(project Project) string {
+	return fmt.Sprintf("%s (%s)", project.LockFile, project.Graph.Ecosystem)
+}
+
+func describe(dependency *lockfile.Dependency, direct bool) string {
+	var attributes []string
+	if direct {
+		attributes = append(attributes, "direct")
+	}
+	if dependency.Dev {
+		attributes = append(attributes, "dev")
+	}
+	if len(attributes) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(attributes, ", ") + ")"
+}
+
+func parsed(projects []Project) []Project {
+	var parsedProjects []Project
+	for _, project := range projects {
+		if project.Err == nil && project.Graph != nil {
+			parsedProjects = append(parsedProjects, project)
+		}
+	}
+
+	return parsedProjects
+}
+
+func toSet(values []string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
+
+func nonNilPaths(paths [][]string) [][]string {
+	if paths == nil {
+		return [][]string{}
+	}
+
+	return paths
+}
+
+// WriteWarnings writes a warning for each project whose lock file could not be parsed
+func WriteWarnings(w io.Writer, projects []Project) {
+	for _, project := range projects {
+		if project.Err != nil {
+			fmt.Fprintf(w, "%s Skipping %s: %s\n", color.YellowString("⚠️"), project.LockFile, project.Err.Error())
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package deps
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDiamondProjects() []Project {
+	return []Project{
+		{ManifestFile: "package.json", LockFile: "package-lock.json", Graph: newDiamondGraph()},
+		{ManifestFile: "composer.json", LockFile: "composer.lock", Err: errors.New("failed to parse composer.lock")},
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range []string{FormatText, FormatJson, FormatDot} {
+		assert.NoError(t, ValidateFormat(format))
+	}
+	assert.ErrorIs(t, ValidateFormat("xml"), UnsupportedFormatErr)
+}
+
+func TestWriteListText(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteList(&buf, newDiamondProjects(), FormatText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `package-lock.json (npm): 4 dependencies (2 direct)
+  a 1.0.0 (direct)
+  b 1.0.0 (direct)
+  c 1.0.0
+  d 1.0.0
+`, buf.String())
+}
+
+func TestWriteListJson(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteList(&buf, newDiamondProjects(), FormatJson)
+
+	assert.NoError(t, err)
+	var output []jsonProject
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "package-lock.json", output[0].LockFile)
+	assert.Equal(t, "npm", output[0].Ecosystem)
+	assert.Len(t, output[0].Dependencies, 4)
+	assert.True(t, output[0].Dependencies[0].Direct)
+	assert.Equal(t, []string{"c@1.0.0", "d@1.0.0"}, output[0].Dependencies[0].Dependencies)
+	assert.Equal(t, []string{}, output[0].Dependencies[3].Dependencies)
+}
+
+func TestWriteListDot(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteList(&buf, newDiamondProjects(), FormatDot)
+
+	assert.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "digraph dependencies {")
+	assert.Contains(t, output, `"0/" -> "0/a@1.0.0";`)
+	assert.Contains(t, output, `"0/c@1.0.0" -> "0/d@1.0.0";`)
+	assert.NotContains(t, output, "composer.lock")
+}
+
+func TestWriteTreeText(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteTree(&buf, newDiamondProjects(), FormatText, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `package-lock.json (npm)
+├── a@1.0.0
+│   ├── c@1.0.0
+│   │   └── d@1.0.0
+│   └── d@1.0.0
+└── b@1.0.0
+    └── d@1.0.0
+`, buf.String())
+}
+
+func TestWriteTreeTextRepeated(t *testing.T) {
+	projects := newDiamondProjects()
+	projects[0].Graph.AddEdge("b@1.0.0", "c@1.0.0")
+	var buf bytes.Buffer
+
+	err := WriteTree(&buf, projects, FormatText, 0)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "    └── c@1.0.0 (*)\n")
+}
+
+func TestWriteTreeTextDepth(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteTree(&buf, newDiamondProjects(), FormatText, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `package-lock.json (npm)
+├── a@1.0.0
+└── b@1.0.0
+`, buf.String())
+}
+
+func TestWriteTreeJson(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteTree(&buf, newDiamondProjects(), FormatJson, 2)
+
+	assert.NoError(t, err)
+	var output []jsonTree
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Len(t, output, 1)
+	assert.Len(t, output[0].Dependencies, 2)
+	a := output[0].Dependencies[0]
+	assert.Equal(t, "a", a.Name)
+	assert.Len(t, a.Dependencies, 2)
+	assert.Empty(t, a.Dependencies[0].Dependencies)
+}
+
+func TestWritePathsText(t *testing.T) {
+	var buf bytes.Buffer
+	results := FindPaths(newDiamondProjects(), "d", true)
+
+	err := WritePaths(&buf, results, FormatText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `package-lock.json (npm)
+d@1.0.0 is pulled in by 2 path(s)
+  a@1.0.0 > d@1.0.0
+  b@1.0.0 > d@1.0.0
+`, buf.String())
+}
+
+func TestWritePathsJson(t *testing.T) {
+	var buf bytes.Buffer
+	results := FindPaths(newDiamondProjects(), "d@1.0.0", false)
+
+	err := WritePaths(&buf, results, FormatJson)
+
+	assert.NoError(t, err)
+	var output []jsonPaths
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "d", output[0].Name)
+	assert.Len(t, output[0].Paths, 3)
+}
+
+func TestWritePathsDot(t *testing.T) {
+	var buf bytes.Buffer
+	results := FindPaths(newDiamondProjects(), "c", false)
+
+	err := WritePaths(&buf, results, FormatDot)
+
+	assert.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, `"0/a@1.0.0" -> "0/c@1.0.0";`)
+	assert.NotContains(t, output, `"0/b@1.0.0"`)
+}
+
+func TestFindPathsNotFound(t *testing.T) {
+	assert.Empty(t, FindPaths(newDiamondProjects(), "e", true))
+}
+
+func TestWriteWarnings(t *testing.T) {
+	var buf bytes.Buffer
+
+	WriteWarnings(&buf, newDiamondProjects())
+
+	assert.Contains(t, buf.String(), "Skipping composer.lock: failed to parse composer.lock")
+	assert.NotContains(t, buf.String(), "package-lock.json")
+}
+
+func TestValidateDiffFormat(t *testing.T) {
+	for _, format := range []string{FormatMarkdown, FormatJson} {
+		assert.NoError(t, ValidateDiffFormat(format))
+	}
+	assert.ErrorIs(t, ValidateDiffFormat(FormatText), UnsupportedDiffFormatErr)
+}
+
+func TestWriteDiffsMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	diffs := []ProjectDiff{{LockFile: "web/package-lock.json", Ecosystem: "npm", Changes: DiffGraphs(newDiamondGraph(), newChangedGraph())}}
+
+	err := WriteDiffs(&buf, diffs, FormatMarkdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `## Dependency changes
+
+**web/package-lock.json** (npm): 1 added, 1 removed, 1 upgraded, 1 downgraded
+
+| Package | Change | Old version | New version | Dependency |
+| --- | --- | --- | --- | --- |
+| a | upgraded | 1.0.0 | 1.10.0 | direct |
+| b | downgraded | 1.0.0 | 0.9.0 | direct |
+| c | removed | 1.0.0 |  | transitive |
+| e | added |  | 2.0.0 | transitive |
+`, buf.String())
+}
+
+func TestWriteDiffsMarkdownEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	diffs := []ProjectDiff{{LockFile: "a|b.lock", Ecosystem: "npm", Changes: []PackageChange{{Name: "x|y", Change: ChangeAdded, NewVersion: "1"}}}}
+
+	err := WriteDiffs(&buf, diffs, FormatMarkdown)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `**a\|b.lock**`)
+	assert.Contains(t, buf.String(), `| x\|y | added |`)
+}
+
+func TestWriteDiffsNoChanges(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteDiffs(&buf, nil, FormatMarkdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "No dependency changes\n", buf.String())
+}
+
+func TestWriteDiffsJson(t *testing.T) {
+	var buf bytes.Buffer
+	diffs := []ProjectDiff{{LockFile: "web/package-lock.json", Ecosystem: "npm", Changes: DiffGraphs(newDiamondGraph(), newChangedGraph())}}
+
+	err := WriteDiffs(&buf, diffs, FormatJson)
+
+	assert.NoError(t, err)
+	var output []jsonProjectDiff
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Len(t, output, 1)
+	assert.Equal(t, "web/package-lock.json", output[0].LockFile)
+	assert.Equal(t, jsonPackageChange{Name: "a", Change: ChangeUpgraded, OldVersion: "1.0.0", NewVersion: "1.10.0", Direct: true}, output[0].Changes[0])
+	assert.NotContains(t, buf.String(), `"newVersion": ""`)
+
+	buf.Reset()
+	assert.NoError(t, WriteDiffs(&buf, nil, FormatJson))
+	assert.Equal(t, "[]\n", buf.String())
+}
@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"github.com/debricked/cli/internal/deps"
+)
+
+type LoaderMock struct {
+	Options  deps.DebrickedOptions
+	Projects []deps.Project
+	Err      error
+}
+
+func (loader *LoaderMock) Load(options deps.DebrickedOptions) ([]deps.Project, error) {
+	loader.Options = options
+
+	return loader.Projects, loader.Err
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/debricked/cli/internal/client"
 	ioFs "github.com/debricked/cli/internal/io"
@@ -28,6 +29,7 @@ type DebrickedOptions struct {
 	Inclusions   []string
 	LockFileOnly bool
 	Strictness   int
+	Offline      bool
 }
 
 type IFinder interface {
@@ -173,7 +175,13 @@ func reportExclusions(excludedFiles []string) {
 func (finder *Finder) GetGroups(options DebrickedOptions) (Groups, error) {
 	var groups Groups
 
-	formats, err := finder.GetSupportedFormats()
+	var formats []*CompiledFormat
+	var err error
+	if options.Offline {
+		formats, err = finder.GetSupportedFormatsOffline()
+	} else {
+		formats, err = finder.GetSupportedFormats()
+	}
 	if err != nil {
 
 		return groups, err
@@ -207,8 +215,22 @@ func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 		return nil, err
 	}
 
+	return compileFormats(body)
+}
+
+// GetSupportedFormatsOffline returns the supported dependency file formats bundled with the CLI, without contacting the server
+func (finder *Finder) GetSupportedFormatsOffline() ([]*CompiledFormat, error) {
+	body, err := finder.GetSupportedFormatsFallbackJson()
+	if err != nil {
+		return nil, err
+	}
+
+	return compileFormats(body)
+}
+
+func compileFormats(body []byte) ([]*CompiledFormat, error) {
 	var formats []*Format
-	err = json.Unmarshal(body, &formats)
+	err := json.Unmarshal(body, &formats)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +242,10 @@ func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 	}
 
 	formats = append(formats, sbtEntry)
+	formats = append(formats, pythonProjectFormats(formats)...)
+	addPnpmLockFile(formats)
+	formats = append(formats, cargoFormats(formats)...)
+	formats = append(formats, cycloneDXFormats(formats)...)
 
 	var compiledDependencyFileFormats []*CompiledFormat
 	for _, format := range formats {
@@ -234,6 +260,97 @@ func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 	return compiledDependencyFileFormats, nil
 }
 
+// pythonProjectFormats returns the formats of Poetry, Pipenv and PDM manifest files, which are resolved to pip lock files.
+// Manifest files already matched by formats are skipped, to not group them twice
+func pythonProjectFormats(formats []*Format) []*Format {
+	var pythonFormats []*Format
+	for _, manifestFile := range []string{"pyproject.toml", "Pipfile"} {
+		if matchesAnyFormat(formats, manifestFile) {
+			continue
+		}
+		pythonFormats = append(pythonFormats, &Format{
+			ManifestFileRegex: "^" + regexp.QuoteMeta(manifestFile) + "$",
+			DocumentationUrl:  "https://docs.debricked.com/overview/language-support/python-pip",
+			LockFileRegexes:   []string{"^" + regexp.QuoteMeta(manifestFile+".pip.debricked.lock") + "$"},
+		})
+	}
+
+	return pythonFormats
+}
+
+// cargoFormats returns the format of Cargo.toml files, unless it is already matched by formats
+func cargoFormats(formats []*Format) []*Format {
+	if matchesAnyFormat(formats, "Cargo.toml") {
+		return nil
+	}
+
+	return []*Format{
+		{
+			ManifestFileRegex: "^Cargo\\.toml$",
+			DocumentationUrl:  "",
+			LockFileRegexes:   []string{"^Cargo\\.lock$"},
+		},
+	}
+}
+
+// cycloneDXFormats returns the format of CycloneDX SBOMs, such as those written when scanning images,
+// unless they are already lock files of formats
+func cycloneDXFormats(formats []*Format) []*Format {
+	for _, format := range formats {
+		for _, lockFileRegex := range format.LockFileRegexes {
+			if matchesRegex(lockFileRegex, "bom.cdx.json") {
+				return nil
+			}
+		}
+	}
+
+	return []*Format{
+		{
+			ManifestFileRegex: "",
+			DocumentationUrl:  "",
+			LockFileRegexes:   []string{"^.+\\.cdx\\.json$"},
+		},
+	}
+}
+
+// addPnpmLockFile adds pnpm-lock.yaml as a lock file of package.json, unless it is already a lock file of formats
+func addPnpmLockFile(formats []*Format) {
+	for _, format := range formats {
+		for _, lockFileRegex := range format.LockFileRegexes {
+			if matchesRegex(lockFileRegex, "pnpm-lock.yaml") {
+				return
+			}
+		}
+	}
+	for _, format := range formats {
+		if matchesRegex(format.ManifestFileRegex, "package.json") {
+			format.LockFileRegexes = append(format.LockFileRegexes, "pnpm-lock\\.yaml")
+
+			return
+		}
+	}
+}
+
+func matchesAnyFormat(formats []*Format, manifestFile string) bool {
+	for _, format := range formats {
+		if matchesRegex(format.ManifestFileRegex, manifestFile) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesRegex returns true if the non-empty expression compiles and matches name
+func matchesRegex(expression string, name string) bool {
+	if len(expression) == 0 {
+		return false
+	}
+	regex, err := regexp.Compile(expression)
+
+	return err == nil && regex.MatchString(name)
+}
+
 func (finder *Finder) GetSupportedFormatsJson() ([]byte, error) {
 	res, err := finder.debClient.Get(SupportedFormatsUri, "application/json")
 
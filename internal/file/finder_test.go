@@ -29,6 +29,10 @@ func (mock *debClientMock) Post(_ string, _ string, _ *bytes.Buffer, _ int) (*ht
 	return &http.Response{}, nil
 }
 
+func (mock *debClientMock) PostStream(_ string, _ string, _ func() (io.Reader, error), _ int) (*http.Response, error) {
+	return &http.Response{}, nil
+}
+
 var authorized bool
 
 func (mock *debClientMock) Get(_ string, _ string) (*http.Response, error) {
@@ -114,12 +118,23 @@ func TestGetSupportedFormatsEndpointUnaccessible(t *testing.T) {
 	}
 }
 
+func TestGetSupportedFormatsOffline(t *testing.T) {
+	setUp(true)
+	formats, err := finder.GetSupportedFormatsOffline()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(formats), 1)
+	for _, format := range formats {
+		hasContent := format.ManifestFileRegex != nil || len(format.LockFileRegexes) > 0
+		assert.True(t, hasContent, "failed to assert that format had content")
+	}
+}
+
 func TestGetGroups(t *testing.T) {
 	setUp(true)
 	path := ""
 
 	excludedFiles := []string{"testdata/go/go.mod", "testdata/misc/requirements.txt", "testdata/misc/Cargo.lock"}
-	const nbrOfGroups = 12
+	const nbrOfGroups = 15
 
 	fileGroups, err := finder.GetGroups(
 		DebrickedOptions{
@@ -439,3 +454,35 @@ func TestGetGroupsWithStrictFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestPythonProjectFormats(t *testing.T) {
+	formats := pythonProjectFormats([]*Format{{ManifestFileRegex: "requirements.*(?:\\.txt)"}})
+	assert.Len(t, formats, 2)
+	assert.Equal(t, "^pyproject\\.toml$", formats[0].ManifestFileRegex)
+	assert.Equal(t, []string{"^pyproject\\.toml\\.pip\\.debricked\\.lock$"}, formats[0].LockFileRegexes)
+	assert.Equal(t, "^Pipfile$", formats[1].ManifestFileRegex)
+
+	formats = pythonProjectFormats([]*Format{{ManifestFileRegex: "Pipfile"}, {ManifestFileRegex: "(?<=x)invalid"}})
+	assert.Len(t, formats, 1)
+	assert.Equal(t, "^pyproject\\.toml$", formats[0].ManifestFileRegex)
+}
+
+func TestAddPnpmLockFile(t *testing.T) {
+	packageJson := &Format{ManifestFileRegex: "package\\.json", LockFileRegexes: []string{"package-lock\\.json", "yarn\\.lock"}}
+	addPnpmLockFile([]*Format{{ManifestFileRegex: "composer\\.json"}, packageJson})
+	assert.Equal(t, []string{"package-lock\\.json", "yarn\\.lock", "pnpm-lock\\.yaml"}, packageJson.LockFileRegexes)
+
+	addPnpmLockFile([]*Format{packageJson})
+	assert.Len(t, packageJson.LockFileRegexes, 3, "failed to assert that pnpm-lock.yaml was only added once")
+}
+
+func TestCycloneDXFormats(t *testing.T) {
+	formats := cycloneDXFormats([]*Format{{ManifestFileRegex: "package\\.json", LockFileRegexes: []string{"yarn\\.lock"}}})
+	assert.Len(t, formats, 1)
+	assert.Empty(t, formats[0].ManifestFileRegex)
+	assert.True(t, matchesRegex(formats[0].LockFileRegexes[0], "debricked-image.cdx.json"))
+	assert.False(t, matchesRegex(formats[0].LockFileRegexes[0], "cdx.json"))
+
+	formats = cycloneDXFormats([]*Format{{LockFileRegexes: []string{".*\\.cdx\\.json"}}})
+	assert.Empty(t, formats)
+}
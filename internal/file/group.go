@@ -47,6 +47,16 @@ func (fileGroup *Group) GetAllFiles() []string {
 	return append(files, fileGroup.LockFiles...)
 }
 
+func (fileGroup *Group) hasChangedFile(changed map[string]bool) bool {
+	for _, filePath := range fileGroup.GetAllFiles() {
+		if changed[filepath.Clean(filePath)] {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (fileGroup *Group) matchLockFile(lockFile, dir string) bool {
 	if !fileGroup.HasFile() {
 
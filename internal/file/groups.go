@@ -90,6 +90,28 @@ func (gs *Groups) FilterGroupsByStrictness(strictness int) {
 	gs.groups = groups
 }
 
+// FilterGroupsByChanges keeps the groups with a changed manifest or lock file among changedFiles, and returns the
+// groups that were filtered out
+func (gs *Groups) FilterGroupsByChanges(changedFiles []string) []Group {
+	changed := map[string]bool{}
+	for _, changedFile := range changedFiles {
+		changed[filepath.Clean(changedFile)] = true
+	}
+
+	var groups []*Group
+	var skipped []Group
+	for _, group := range gs.groups {
+		if group.hasChangedFile(changed) {
+			groups = append(groups, group)
+		} else {
+			skipped = append(skipped, *group)
+		}
+	}
+	gs.groups = groups
+
+	return skipped
+}
+
 func (gs *Groups) ToSlice() []Group {
 	var groups []Group
 	for _, g := range gs.groups {
@@ -131,6 +153,9 @@ func (gs *Groups) matchWorkspace(workspaceManifest WorkspaceManifest) {
 func (gs *Groups) AddWorkspaceLockFiles() {
 	for _, group := range gs.groups {
 		workspaces, err := getPackageJSONWorkspaces(group.ManifestFile)
+		if err == nil {
+			workspaces = append(workspaces, getPnpmWorkspaces(group.ManifestFile)...)
+		}
 		if err == nil && group.HasLockFiles() {
 			workspaceManifest := WorkspaceManifest{
 				LockFiles:         group.LockFiles,
@@ -1,6 +1,7 @@
 package file
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -239,3 +240,53 @@ func TestAddWorkspaceLockFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestAddWorkspaceLockFilesPnpm(t *testing.T) {
+	g1 := NewGroup(
+		"testdata/workspace/pnpm/package.json",
+		nil,
+		[]string{"testdata/workspace/pnpm/pnpm-lock.yaml"},
+	)
+	g2 := NewGroup("testdata/workspace/pnpm/packages/package_one/package.json", nil, []string{})
+	g3 := NewGroup("testdata/workspace/pnpm/packages/excluded/package.json", nil, []string{})
+
+	gs := Groups{}
+	gs.Add(*g1)
+	gs.Add(*g2)
+	gs.Add(*g3)
+	gs.AddWorkspaceLockFiles()
+
+	assert.Equal(t, g1.LockFiles, gs.groups[1].LockFiles)
+	assert.Empty(t, gs.groups[2].LockFiles, "failed to assert that the negated workspace pattern was excluded")
+}
+
+func TestFilterGroupsByChanges(t *testing.T) {
+	gs := Groups{}
+	gs.Add(*NewGroup(filepath.Join("web", "package.json"), nil, []string{filepath.Join("web", "yarn.lock")}))
+	gs.Add(*NewGroup(filepath.Join("api", "go.mod"), nil, []string{filepath.Join("api", "go.sum")}))
+	gs.Add(*NewGroup("", nil, []string{filepath.Join("lib", "composer.lock")}))
+	gs.Add(*NewGroup(filepath.Join("docs", "requirements.txt"), nil, nil))
+
+	skipped := gs.FilterGroupsByChanges([]string{
+		filepath.Join("web", "package.json"),
+		"./" + filepath.Join("api", "go.sum"),
+		filepath.Join("docs", "README.md"),
+	})
+
+	assert.Equal(t, 2, gs.Size())
+	assert.Equal(t, filepath.Join("web", "package.json"), gs.ToSlice()[0].ManifestFile)
+	assert.Equal(t, filepath.Join("api", "go.mod"), gs.ToSlice()[1].ManifestFile)
+	assert.Len(t, skipped, 2)
+	assert.Equal(t, []string{filepath.Join("lib", "composer.lock")}, skipped[0].LockFiles)
+	assert.Equal(t, filepath.Join("docs", "requirements.txt"), skipped[1].ManifestFile)
+}
+
+func TestFilterGroupsByChangesNoChanges(t *testing.T) {
+	gs := Groups{}
+	gs.Add(*NewGroup("package.json", nil, nil))
+
+	skipped := gs.FilterGroupsByChanges([]string{})
+
+	assert.Equal(t, 0, gs.Size())
+	assert.Len(t, skipped, 1)
+}
@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/becheran/wildmatch-go"
+	"gopkg.in/yaml.v3"
 )
 
+const pnpmWorkspaceFile = "pnpm-workspace.yaml"
+
 type WorkspaceManifest struct {
 	RootManifest      string
 	LockFiles         []string
@@ -27,6 +31,11 @@ type NestledPackageJSON struct {
 	} `json:"workspaces"`
 } // Rare format
 
+// pnpm Workspaces docs: https://pnpm.io/pnpm-workspace_yaml
+type PnpmWorkspaceYAML struct {
+	Packages []string `yaml:"packages"`
+}
+
 func (workspaceManifest *WorkspaceManifest) matchManifest(manifestPath string) bool {
 	manifestPath = filepath.ToSlash(manifestPath) // Normalize
 	relativeManifestPath, err := filepath.Rel(
@@ -38,14 +47,16 @@ func (workspaceManifest *WorkspaceManifest) matchManifest(manifestPath string) b
 	}
 	relativeManifestPath = filepath.ToSlash(relativeManifestPath)
 	for _, workspacePattern := range workspaceManifest.WorkspacePatterns {
-		workspacePattern = filepath.ToSlash(workspacePattern)
-		pattern := wildmatch.NewWildMatch(workspacePattern)
-		if pattern.IsMatch(relativeManifestPath) {
-			return true
+		// pnpm supports excluding packages by negated patterns
+		excludePattern, isExclusion := strings.CutPrefix(workspacePattern, "!")
+		if isExclusion && workspaceManifest.matchPattern(excludePattern, relativeManifestPath) {
+			return false
 		}
-		if workspacePattern == filepath.ToSlash(filepath.Dir(relativeManifestPath)) {
+	}
+	for _, workspacePattern := range workspaceManifest.WorkspacePatterns {
+		if !strings.HasPrefix(workspacePattern, "!") && workspaceManifest.matchPattern(workspacePattern, relativeManifestPath) {
 			return true
-		} // Check if specific directory match
+		}
 	}
 
 	return false
@@ -71,3 +82,27 @@ func getPackageJSONWorkspaces(rootManifest string) ([]string, error) {
 
 	return packageJson.Workspaces, nil
 }
+
+func (workspaceManifest *WorkspaceManifest) matchPattern(workspacePattern string, relativeManifestPath string) bool {
+	workspacePattern = filepath.ToSlash(workspacePattern)
+	if wildmatch.NewWildMatch(workspacePattern).IsMatch(relativeManifestPath) {
+		return true
+	}
+
+	// Check if specific directory match
+	return workspacePattern == filepath.ToSlash(filepath.Dir(relativeManifestPath))
+}
+
+// getPnpmWorkspaces returns the workspace patterns of the pnpm-workspace.yaml file next to rootManifest, if any
+func getPnpmWorkspaces(rootManifest string) []string {
+	yamlData, err := os.ReadFile(filepath.Join(filepath.Dir(rootManifest), pnpmWorkspaceFile))
+	if err != nil {
+		return nil
+	}
+	var pnpmWorkspace PnpmWorkspaceYAML
+	if err = yaml.Unmarshal(yamlData, &pnpmWorkspace); err != nil {
+		return nil
+	}
+
+	return pnpmWorkspace.Packages
+}
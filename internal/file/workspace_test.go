@@ -114,3 +114,23 @@ func TestGetWorkspacesNoFile(t *testing.T) {
 	_, err := getPackageJSONWorkspaces("testdata/non_existing_folder/package.json")
 	assert.Error(t, err)
 }
+
+func TestMatchManifestNegatedPattern(t *testing.T) {
+	wm := WorkspaceManifest{
+		RootManifest:      "package.json",
+		LockFiles:         []string{"pnpm-lock.yaml"},
+		WorkspacePatterns: []string{"packages/*", "!packages/excluded"},
+	}
+
+	assert.True(t, wm.matchManifest("packages/included/package.json"))
+	assert.False(t, wm.matchManifest("packages/excluded/package.json"))
+}
+
+func TestGetPnpmWorkspaces(t *testing.T) {
+	workspaces := getPnpmWorkspaces("testdata/workspace/pnpm/package.json")
+	assert.Equal(t, []string{"packages/*", "!packages/excluded"}, workspaces)
+}
+
+func TestGetPnpmWorkspacesNoFile(t *testing.T) {
+	assert.Empty(t, getPnpmWorkspaces("testdata/workspace/common/package.json"))
+}
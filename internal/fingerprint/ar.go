@@ -0,0 +1,77 @@
+package fingerprint
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	arMagic      = "!<arch>\n"
+	arHeaderSize = 60
+)
+
+// walkAr calls fn for each file of the ar archive read by r, which is the container format of Debian packages
+func walkAr(r io.Reader, fn func(entry archiveEntry) error) error {
+	reader := bufio.NewReader(r)
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || string(magic) != arMagic {
+		return errInvalidArchive
+	}
+	header := make([]byte, arHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if string(header[58:60]) != "`\n" {
+			return errInvalidArchive
+		}
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil || size < 0 {
+			return errInvalidArchive
+		}
+		// File data is aligned to even offsets
+		padded := size%2 == 1
+		data := io.LimitReader(reader, size)
+		name := strings.TrimSpace(string(header[0:16]))
+		// BSD ar stores long names at the start of the file data
+		if strings.HasPrefix(name, "#1/") {
+			nameLength, err := strconv.ParseInt(name[3:], 10, 64)
+			if err != nil || nameLength > size {
+				return errInvalidArchive
+			}
+			longName := make([]byte, nameLength)
+			if _, err = io.ReadFull(data, longName); err != nil {
+				return err
+			}
+			name = strings.TrimRight(string(longName), "\x00")
+			size -= nameLength
+		}
+		// GNU ar terminates names with a slash, and uses / and // for its symbol and name tables
+		name = strings.TrimSuffix(name, "/")
+		if len(name) > 0 && name != "/" {
+			err = fn(archiveEntry{
+				name:    name,
+				size:    size,
+				regular: true,
+				open: func() (io.ReadCloser, error) {
+					return io.NopCloser(data), nil
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if _, err = io.Copy(io.Discard, data); err != nil {
+			return err
+		}
+		if padded {
+			if _, err = reader.Discard(1); err != nil && err != io.EOF {
+				return err
+			}
+		}
+	}
+}
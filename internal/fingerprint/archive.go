@@ -0,0 +1,479 @@
+package fingerprint
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	// DefaultMaxArchiveDepth is the number of archive levels unpacked, nested archives deeper than that are only hashed
+	DefaultMaxArchiveDepth = 4
+	// DefaultMaxArchiveSize is the number of uncompressed bytes read from an archive, including its nested archives
+	DefaultMaxArchiveSize int64 = 4 << 30
+	// maxNestedZipSize is the size of the largest nested zip archive unpacked, as zip archives are read into memory
+	maxNestedZipSize int64 = 512 << 20
+	// maxCompressionRatio guards against zip bombs, entries compressed more than this are skipped
+	maxCompressionRatio = 200
+	// minCompressionRatioSize is the number of uncompressed bytes below which the compression ratio is not checked
+	minCompressionRatioSize int64 = 1 << 20
+	// ArchivePathSeparator separates the path of an archive from the path of a file within it
+	ArchivePathSeparator = "!/"
+)
+
+var TAR_XZ_FILE_ENDINGS = []string{".tar.xz", ".txz"}
+var TAR_ZSTD_FILE_ENDINGS = []string{".tar.zst", ".tzst"}
+var TAR_FILE_ENDINGS = []string{".tar", ".gem"}
+var DEB_FILE_ENDINGS = []string{".deb"}
+var RPM_FILE_ENDINGS = []string{".rpm"}
+
+var (
+	errInvalidArchive   = errors.New("invalid archive")
+	errArchiveTooLarge  = errors.New("archive size limit reached")
+	errCompressionRatio = errors.New("archive compression ratio limit reached")
+)
+
+type archiveFormat int
+
+const (
+	notArchive archiveFormat = iota
+	zipArchive
+	tarArchive
+	tarGZipArchive
+	tarBZip2Archive
+	tarXzArchive
+	tarZstdArchive
+	debArchive
+	rpmArchive
+)
+
+// ArchiveFileEndings returns the file endings of all archives whose contents can be fingerprinted
+func ArchiveFileEndings() []string {
+	var endings []string
+	for _, formatEndings := range [][]string{
+		ZIP_FILE_ENDINGS, TAR_GZIP_FILE_ENDINGS, TAR_BZIP2_FILE_ENDINGS, TAR_XZ_FILE_ENDINGS,
+		TAR_ZSTD_FILE_ENDINGS, TAR_FILE_ENDINGS, DEB_FILE_ENDINGS, RPM_FILE_ENDINGS,
+	} {
+		endings = append(endings, formatEndings...)
+	}
+
+	return endings
+}
+
+func archiveFormatOf(filename string) archiveFormat {
+	switch {
+	case isZipFile(filename):
+		return zipArchive
+	case isTarGZipFile(filename):
+		return tarGZipArchive
+	case isTarBZip2File(filename):
+		return tarBZip2Archive
+	case hasEnding(filename, TAR_XZ_FILE_ENDINGS):
+		return tarXzArchive
+	case hasEnding(filename, TAR_ZSTD_FILE_ENDINGS):
+		return tarZstdArchive
+	case hasEnding(filename, TAR_FILE_ENDINGS):
+		return tarArchive
+	case hasEnding(filename, DEB_FILE_ENDINGS):
+		return debArchive
+	case hasEnding(filename, RPM_FILE_ENDINGS):
+		return rpmArchive
+	default:
+		return notArchive
+	}
+}
+
+func isArchive(filename string) bool {
+	return archiveFormatOf(filename) != notArchive
+}
+
+func hasEnding(filename string, endings []string) bool {
+	for _, ending := range endings {
+		if strings.HasSuffix(filename, ending) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isArchiveFormatError returns true if err is caused by malformed archive content, rather than by failing to read the file
+func isArchiveFormatError(err error) bool {
+	var bzip2Err bzip2.StructuralError
+
+	return errors.Is(err, zip.ErrFormat) ||
+		errors.Is(err, zip.ErrAlgorithm) ||
+		errors.Is(err, zip.ErrChecksum) ||
+		errors.Is(err, gzip.ErrHeader) ||
+		errors.Is(err, gzip.ErrChecksum) ||
+		errors.Is(err, tar.ErrHeader) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, errInvalidArchive) ||
+		errors.Is(err, zstd.ErrMagicMismatch) ||
+		errors.Is(err, zstd.ErrCRCMismatch) ||
+		errors.As(err, &bzip2Err)
+}
+
+// archiveEntry is a file within an archive
+type archiveEntry struct {
+	name string
+	size int64
+	// compressedSize is the size of the entry within the archive, or 0 if it is unknown
+	compressedSize int64
+	regular        bool
+	open           func() (io.ReadCloser, error)
+}
+
+// archiveFingerprinter fingerprints the files within an archive, descending into nested archives
+type archiveFingerprinter struct {
+	exclusions []string
+	inclusions []string
+	maxDepth   int
+	maxSize    int64
+	// remaining is the number of uncompressed bytes left to read before the archive is considered a zip bomb
+	remaining int64
+}
+
+func newArchiveFingerprinter(options DebrickedOptions) *archiveFingerprinter {
+	maxDepth := options.MaxArchiveDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxArchiveDepth
+	}
+	maxSize := options.MaxArchiveSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxArchiveSize
+	}
+
+	return &archiveFingerprinter{
+		exclusions: options.Exclusions,
+		inclusions: options.Inclusions,
+		maxDepth:   maxDepth,
+		maxSize:    maxSize,
+		remaining:  maxSize,
+	}
+}
+
+// computeHashForArchive returns the fingerprints of the files within the archive at path, or nil if path is not an archive.
+// Each fingerprint path records the chain of archives leading to it, such as app.war!/WEB-INF/lib/x.jar!/a/B.class
+func computeHashForArchive(path string, options DebrickedOptions) ([]FileFingerprint, error) {
+	format := archiveFormatOf(path)
+	if format == notArchive {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	a := newArchiveFingerprinter(options)
+	fingerprints, err := a.fingerprintArchive(path, format, io.NewSectionReader(file, 0, fileInfo.Size()), 1)
+	if errors.Is(err, errArchiveTooLarge) {
+		fmt.Printf("WARNING: Stopped fingerprinting contents of compressed file [%s] after reaching the size limit of %d bytes\n", path, a.maxSize)
+
+		return fingerprints, nil
+	}
+	if errors.Is(err, errCompressionRatio) {
+		fmt.Printf("WARNING: Stopped fingerprinting contents of compressed file [%s] as its compression ratio is suspiciously high\n", path)
+
+		return fingerprints, nil
+	}
+
+	return fingerprints, err
+}
+
+// fingerprintArchive fingerprints the files within the archive read by r, at the given depth
+func (a *archiveFingerprinter) fingerprintArchive(path string, format archiveFormat, r io.Reader, depth int) ([]FileFingerprint, error) {
+	var fingerprints []FileFingerprint
+	err := walkArchive(format, r, a.maxSize, func(entry archiveEntry) error {
+		entryFingerprints, err := a.fingerprintEntry(path, entry, depth)
+		fingerprints = append(fingerprints, entryFingerprints...)
+
+		return err
+	})
+
+	return fingerprints, err
+}
+
+func (a *archiveFingerprinter) fingerprintEntry(archivePath string, entry archiveEntry, depth int) ([]FileFingerprint, error) {
+	name := strings.TrimPrefix(entry.name, "./")
+	if !entry.regular || filepath.IsAbs(name) || strings.HasPrefix(name, "..") {
+		return nil, nil
+	}
+	longPath := archivePath + ArchivePathSeparator + name
+	if !shouldProcessPath(a.exclusions, a.inclusions, longPath) {
+		return nil, nil
+	}
+	if entry.compressedSize > 0 && entry.size > minCompressionRatioSize && entry.size/entry.compressedSize > maxCompressionRatio {
+		fmt.Printf("WARNING: Skipped fingerprinting [%s] as its compression ratio is suspiciously high\n", longPath)
+
+		return nil, nil
+	}
+
+	rc, err := entry.open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	reader := &limitedArchiveReader{reader: rc, archive: a}
+
+	format := archiveFormatOf(name)
+	if format == notArchive || depth >= a.maxDepth {
+		fingerprint, err := hashReader(longPath, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		return []FileFingerprint{fingerprint}, nil
+	}
+
+	return a.fingerprintNestedArchive(longPath, format, reader, entry.size, depth)
+}
+
+// fingerprintNestedArchive fingerprints the files within a nested archive as well as the nested archive itself
+func (a *archiveFingerprinter) fingerprintNestedArchive(
+	path string, format archiveFormat, reader io.Reader, size int64, depth int,
+) ([]FileFingerprint, error) {
+	hasher := newHasher()
+	counter := &countingWriter{}
+	var nestedReader io.Reader
+	if format == zipArchive {
+		// Zip archives need random access, so they are read into memory
+		if size > maxNestedZipSize {
+			fingerprint, err := hashReader(path, reader)
+			if err != nil {
+				return nil, err
+			}
+
+			return []FileFingerprint{fingerprint}, nil
+		}
+		content, err := io.ReadAll(io.LimitReader(reader, maxNestedZipSize))
+		if err != nil {
+			return nil, err
+		}
+		_, _ = io.MultiWriter(hasher, counter).Write(content)
+		nestedReader = bytes.NewReader(content)
+	} else {
+		nestedReader = io.TeeReader(reader, io.MultiWriter(hasher, counter))
+	}
+
+	fingerprints, err := a.fingerprintArchive(path, format, nestedReader, depth+1)
+	if errors.Is(err, errCompressionRatio) {
+		fmt.Printf("WARNING: Stopped fingerprinting contents of compressed file [%s] as its compression ratio is suspiciously high\n", path)
+	} else if err != nil {
+		if !isArchiveFormatError(err) {
+			return fingerprints, err
+		}
+		fmt.Printf("WARNING: Could not unpack and fingerprint contents of compressed file [%s]. Error: %v\n", path, err)
+	}
+	// Whatever the nested archive walk did not consume is still part of the nested archive fingerprint
+	if _, err = io.Copy(io.MultiWriter(hasher, counter), reader); err != nil {
+		return fingerprints, err
+	}
+
+	return append(fingerprints, FileFingerprint{
+		path:          path,
+		contentLength: counter.n,
+		fingerprint:   hasher.Sum(nil),
+	}), nil
+}
+
+func hashReader(path string, reader io.Reader) (FileFingerprint, error) {
+	hasher := newHasher()
+	contentLength, err := io.Copy(hasher, reader) // #nosec
+	if err != nil {
+		return FileFingerprint{}, err
+	}
+
+	return FileFingerprint{
+		path:          path,
+		contentLength: contentLength,
+		fingerprint:   hasher.Sum(nil),
+	}, nil
+}
+
+// walkArchive calls fn for each entry of the archive read by r. Zip archives require r to be an io.ReaderAt.
+// Compressed streams fail once their decompressed size, including skipped entries, exceeds maxSize or the compression ratio limit
+func walkArchive(format archiveFormat, r io.Reader, maxSize int64, fn func(entry archiveEntry) error) error {
+	compressed := &countingReader{reader: r}
+	switch format {
+	case zipArchive:
+		return walkZip(r, fn)
+	case debArchive:
+		return walkAr(r, fn)
+	case rpmArchive:
+		payload, err := rpmPayload(compressed)
+		if err != nil {
+			return err
+		}
+		defer payload.Close()
+
+		return walkCpio(&decompressionGuard{reader: payload, compressed: compressed, maxSize: maxSize}, fn)
+	case tarArchive:
+		return walkTar(r, fn)
+	}
+
+	decompressed, err := decompress(format, compressed)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	return walkTar(&decompressionGuard{reader: decompressed, compressed: compressed, maxSize: maxSize}, fn)
+}
+
+func walkZip(r io.Reader, fn func(entry archiveEntry) error) error {
+	readerAt, ok := r.(interface {
+		io.ReaderAt
+		Size() int64
+	})
+	if !ok {
+		return errInvalidArchive
+	}
+	zipReader, err := zip.NewReader(readerAt, readerAt.Size())
+	if err != nil {
+		return err
+	}
+	for _, f := range zipReader.File {
+		err = fn(archiveEntry{
+			name:           f.Name,
+			size:           int64(f.UncompressedSize64),
+			compressedSize: int64(f.CompressedSize64),
+			regular:        f.Mode().IsRegular(),
+			open:           f.Open,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkTar(r io.Reader, fn func(entry archiveEntry) error) error {
+	tarReader := tar.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		err = fn(archiveEntry{
+			name:    header.Name,
+			size:    header.Size,
+			regular: header.Typeflag == tar.TypeReg,
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(tarReader), nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// decompress returns the decompressed stream of a tar archive
+func decompress(format archiveFormat, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case tarGZipArchive:
+		return gzip.NewReader(r)
+	case tarBZip2Archive:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case tarXzArchive:
+		xzReader, err := xz.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidArchive, err.Error())
+		}
+
+		return io.NopCloser(xzReader), nil
+	case tarZstdArchive:
+		zstdReader, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidArchive, err.Error())
+		}
+
+		return zstdReader.IOReadCloser(), nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
+
+// limitedArchiveReader fails with errArchiveTooLarge once the archive has read more uncompressed bytes than allowed
+type limitedArchiveReader struct {
+	reader  io.Reader
+	archive *archiveFingerprinter
+}
+
+func (r *limitedArchiveReader) Read(p []byte) (int, error) {
+	if r.archive.remaining <= 0 {
+		return 0, errArchiveTooLarge
+	}
+	if int64(len(p)) > r.archive.remaining {
+		p = p[:r.archive.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.archive.remaining -= int64(n)
+
+	return n, err
+}
+
+// countingReader counts the bytes read from reader
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+
+	return n, err
+}
+
+// decompressionGuard fails with errArchiveTooLarge once more than maxSize bytes have been decompressed, and with
+// errCompressionRatio once the decompressed stream is more than maxCompressionRatio times larger than what was read from compressed
+type decompressionGuard struct {
+	reader     io.Reader
+	compressed *countingReader
+	maxSize    int64
+	n          int64
+}
+
+func (g *decompressionGuard) Read(p []byte) (int, error) {
+	n, err := g.reader.Read(p)
+	g.n += int64(n)
+	if g.n > g.maxSize {
+		return n, errArchiveTooLarge
+	}
+	if g.n > minCompressionRatioSize && g.n > g.compressed.n*maxCompressionRatio {
+		return n, errCompressionRatio
+	}
+
+	return n, err
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+
+	return len(p), nil
+}
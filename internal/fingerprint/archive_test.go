@@ -0,0 +1,425 @@
+package fingerprint
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/ulikunitz/xz"
+)
+
+const archiveTestContent = "public class B { public static void main(String[] args) {} }"
+
+type testArchiveFile struct {
+	name    string
+	content []byte
+}
+
+func makeZip(t *testing.T, files ...testArchiveFile) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, file := range files {
+		w, err := writer.Create(file.name)
+		assert.NoError(t, err)
+		_, err = w.Write(file.content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func makeTar(t *testing.T, files ...testArchiveFile) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for _, file := range files {
+		assert.NoError(t, writer.WriteHeader(&tar.Header{Name: file.name, Mode: 0600, Size: int64(len(file.content)), Typeflag: tar.TypeReg}))
+		_, err := writer.Write(file.content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func xzBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer, err := xz.NewWriter(&buffer)
+	assert.NoError(t, err)
+	_, err = writer.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func zstdBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+	writer, err := zstd.NewWriter(nil)
+	assert.NoError(t, err)
+	defer writer.Close()
+
+	return writer.EncodeAll(content, nil)
+}
+
+func makeAr(files ...testArchiveFile) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(arMagic)
+	for _, file := range files {
+		buffer.WriteString(fmt.Sprintf("%-16s%-12d%-6d%-6d%-8s%-10d`\n", file.name, 0, 0, 0, "100644", len(file.content)))
+		buffer.Write(file.content)
+		if len(file.content)%2 == 1 {
+			buffer.WriteByte('\n')
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+func makeCpio(files ...testArchiveFile) []byte {
+	var buffer bytes.Buffer
+	write := func(name string, mode int, content []byte) {
+		buffer.WriteString(fmt.Sprintf("070701%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x%08x",
+			0, mode, 0, 0, 1, 0, len(content), 0, 0, 0, 0, len(name)+1, 0))
+		buffer.WriteString(name + "\x00")
+		buffer.Write(make([]byte, cpioPadding(int64(cpioHeaderSize+len(name)+1))))
+		buffer.Write(content)
+		buffer.Write(make([]byte, cpioPadding(int64(len(content)))))
+	}
+	write("./usr", 0040755, nil)
+	for _, file := range files {
+		write(file.name, 0100644, file.content)
+	}
+	write(cpioTrailer, 0, nil)
+
+	return buffer.Bytes()
+}
+
+func makeRpm(payload []byte) []byte {
+	var buffer bytes.Buffer
+	lead := make([]byte, rpmLeadSize)
+	copy(lead, rpmLeadMagic)
+	buffer.Write(lead)
+	writeHeader := func(dataSize uint32) {
+		header := make([]byte, rpmHeaderSize)
+		copy(header, rpmHeaderMagic)
+		binary.BigEndian.PutUint32(header[12:16], dataSize)
+		buffer.Write(header)
+		buffer.Write(make([]byte, dataSize))
+	}
+	// The signature is padded to 8 bytes
+	writeHeader(5)
+	buffer.Write(make([]byte, 3))
+	writeHeader(7)
+	buffer.Write(payload)
+
+	return buffer.Bytes()
+}
+
+func writeArchive(t *testing.T, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, content, 0600))
+
+	return path
+}
+
+func fingerprintPaths(fingerprints []FileFingerprint) []string {
+	var paths []string
+	for _, fingerprint := range fingerprints {
+		paths = append(paths, filepath.ToSlash(fingerprint.path))
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func TestArchiveFormatOf(t *testing.T) {
+	cases := map[string]archiveFormat{
+		"app.war":           zipArchive,
+		"lib.aar":           zipArchive,
+		"app.apk":           zipArchive,
+		"lodash.tgz":        tarGZipArchive,
+		"serde-1.0.0.crate": tarGZipArchive,
+		"stuf.tar.bz2":      tarBZip2Archive,
+		"data.tar.xz":       tarXzArchive,
+		"data.tar.zst":      tarZstdArchive,
+		"rails-7.1.0.gem":   tarArchive,
+		"data.tar":          tarArchive,
+		"curl_8.5.0.deb":    debArchive,
+		"curl-8.5.0.rpm":    rpmArchive,
+		"metadata.gz":       notArchive,
+		"file.py":           notArchive,
+	}
+	for name, format := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, format, archiveFormatOf(name))
+		})
+	}
+}
+
+func TestArchiveFileEndings(t *testing.T) {
+	endings := ArchiveFileEndings()
+	for _, ending := range []string{".jar", ".tgz", ".tar.xz", ".tar.zst", ".gem", ".deb", ".rpm"} {
+		assert.Contains(t, endings, ending)
+	}
+}
+
+func TestComputeHashForArchiveNested(t *testing.T) {
+	jar := makeZip(t, testArchiveFile{"a/B.class", []byte(archiveTestContent)})
+	war := makeZip(t,
+		testArchiveFile{"WEB-INF/lib/x.jar", jar},
+		testArchiveFile{"WEB-INF/web.py", []byte(archiveTestContent)},
+	)
+	path := writeArchive(t, "app.war", war)
+
+	fingerprints, err := computeHashForArchive(path, DebrickedOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.ToSlash(path) + "!/WEB-INF/lib/x.jar",
+		filepath.ToSlash(path) + "!/WEB-INF/lib/x.jar!/a/B.class",
+		filepath.ToSlash(path) + "!/WEB-INF/web.py",
+	}, fingerprintPaths(fingerprints))
+
+	for _, fingerprint := range fingerprints {
+		if strings.HasSuffix(fingerprint.path, "x.jar") {
+			assert.Equal(t, int64(len(jar)), fingerprint.contentLength)
+		}
+	}
+	// The nested archive is fingerprinted after its contents
+	assert.True(t, strings.HasSuffix(fingerprints[1].path, "x.jar"))
+	assert.Contains(t, fingerprints[1].ToString(), "!/WEB-INF/lib/x.jar")
+}
+
+func TestComputeHashForArchiveDepthLimit(t *testing.T) {
+	jar := makeZip(t, testArchiveFile{"a/B.class", []byte(archiveTestContent)})
+	war := makeZip(t, testArchiveFile{"WEB-INF/lib/x.jar", jar})
+	path := writeArchive(t, "app.war", war)
+
+	fingerprints, err := computeHashForArchive(path, DebrickedOptions{MaxArchiveDepth: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.ToSlash(path) + "!/WEB-INF/lib/x.jar"}, fingerprintPaths(fingerprints))
+}
+
+func TestComputeHashForArchiveSizeLimit(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1000)
+	path := writeArchive(t, "lib.tar", makeTar(t,
+		testArchiveFile{"first.py", content},
+		testArchiveFile{"second.py", content},
+	))
+
+	fingerprints, err := computeHashForArchive(path, DebrickedOptions{MaxArchiveSize: 1500})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.ToSlash(path) + "!/first.py"}, fingerprintPaths(fingerprints))
+}
+
+func TestComputeHashForArchiveCompressionRatio(t *testing.T) {
+	bomb := bytes.Repeat([]byte{0}, 4<<20)
+	path := writeArchive(t, "bomb.zip", makeZip(t,
+		testArchiveFile{"bomb.py", bomb},
+		testArchiveFile{"file.py", []byte(archiveTestContent)},
+	))
+
+	fingerprints, err := computeHashForArchive(path, DebrickedOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.ToSlash(path) + "!/file.py"}, fingerprintPaths(fingerprints))
+}
+
+func TestComputeHashForArchiveCompressionRatioStreams(t *testing.T) {
+	bombTar := makeTar(t,
+		testArchiveFile{"file.py", []byte(archiveTestContent)},
+		testArchiveFile{"bomb.py", bytes.Repeat([]byte{0}, 4<<20)},
+	)
+	cases := []struct {
+		name     string
+		content  []byte
+		expected []string
+	}{
+		{
+			name:     "bomb.tar.gz",
+			content:  gzipBytes(t, bombTar),
+			expected: []string{"!/file.py"},
+		},
+		{
+			name:     "bomb.tar.xz",
+			content:  xzBytes(t, bombTar),
+			expected: []string{"!/file.py"},
+		},
+		{
+			name:     "bomb.tar.zst",
+			content:  zstdBytes(t, bombTar),
+			expected: []string{"!/file.py"},
+		},
+		{
+			name:     "bomb.rpm",
+			content:  makeRpm(gzipBytes(t, makeCpio(testArchiveFile{"./file.py", []byte(archiveTestContent)}, testArchiveFile{"./bomb.py", bytes.Repeat([]byte{0}, 4<<20)}))),
+			expected: []string{"!/file.py"},
+		},
+		{
+			name: "bomb.gem",
+			content: makeTar(t,
+				testArchiveFile{"data.tar.gz", gzipBytes(t, bombTar)},
+				testArchiveFile{"metadata.gz", gzipBytes(t, []byte("--- !ruby/object:Gem::Specification"))},
+			),
+			expected: []string{"!/data.tar.gz", "!/data.tar.gz!/file.py", "!/metadata.gz"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := writeArchive(t, c.name, c.content)
+			fingerprints, err := computeHashForArchive(path, DebrickedOptions{})
+			assert.NoError(t, err)
+			var expected []string
+			for _, suffix := range c.expected {
+				expected = append(expected, filepath.ToSlash(path)+suffix)
+			}
+			assert.Equal(t, expected, fingerprintPaths(fingerprints))
+		})
+	}
+}
+
+func TestComputeHashForArchiveSizeLimitSkippedEntries(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1000)
+	path := writeArchive(t, "lib.tar.gz", gzipBytes(t, makeTar(t,
+		testArchiveFile{"first.txt", content},
+		testArchiveFile{"second.txt", content},
+		testArchiveFile{"lib.py", []byte(archiveTestContent)},
+	)))
+
+	fingerprints, err := computeHashForArchive(path, DebrickedOptions{MaxArchiveSize: 1500, Exclusions: []string{"**/*.txt"}})
+	assert.NoError(t, err)
+	assert.Empty(t, fingerprints, "failed to assert that excluded entries counted towards the size limit")
+}
+
+func TestComputeHashForArchiveFormats(t *testing.T) {
+	dataTar := makeTar(t, testArchiveFile{"./usr/lib/lib.py", []byte(archiveTestContent)})
+	cases := []struct {
+		name     string
+		content  []byte
+		expected []string
+	}{
+		{
+			name:     "lib.tar.xz",
+			content:  xzBytes(t, dataTar),
+			expected: []string{"!/usr/lib/lib.py"},
+		},
+		{
+			name:     "lib.tar.zst",
+			content:  zstdBytes(t, dataTar),
+			expected: []string{"!/usr/lib/lib.py"},
+		},
+		{
+			name:     "serde-1.0.0.crate",
+			content:  gzipBytes(t, dataTar),
+			expected: []string{"!/usr/lib/lib.py"},
+		},
+		{
+			name:     "lib.aar",
+			content:  makeZip(t, testArchiveFile{"classes.py", []byte(archiveTestContent)}),
+			expected: []string{"!/classes.py"},
+		},
+		{
+			name: "rails-7.1.0.gem",
+			content: makeTar(t,
+				testArchiveFile{"data.tar.gz", gzipBytes(t, dataTar)},
+				testArchiveFile{"metadata.gz", gzipBytes(t, []byte("--- !ruby/object:Gem::Specification"))},
+			),
+			expected: []string{"!/data.tar.gz", "!/data.tar.gz!/usr/lib/lib.py", "!/metadata.gz"},
+		},
+		{
+			name: "curl_8.5.0.deb",
+			content: makeAr(
+				testArchiveFile{"debian-binary", []byte("2.0\n")},
+				testArchiveFile{"control.tar.xz", xzBytes(t, makeTar(t, testArchiveFile{"./control.sh", []byte("#!/bin/sh")}))},
+				testArchiveFile{"data.tar.xz", xzBytes(t, dataTar)},
+			),
+			expected: []string{"!/control.tar.xz", "!/control.tar.xz!/control.sh", "!/data.tar.xz", "!/data.tar.xz!/usr/lib/lib.py"},
+		},
+		{
+			name:     "curl-8.5.0.rpm",
+			content:  makeRpm(gzipBytes(t, makeCpio(testArchiveFile{"./usr/lib/lib.py", []byte(archiveTestContent)}))),
+			expected: []string{"!/usr/lib/lib.py"},
+		},
+		{
+			name:     "curl-8.5.0.x86_64.rpm",
+			content:  makeRpm(zstdBytes(t, makeCpio(testArchiveFile{"./usr/lib/lib.py", []byte(archiveTestContent)}))),
+			expected: []string{"!/usr/lib/lib.py"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := writeArchive(t, c.name, c.content)
+			fingerprints, err := computeHashForArchive(path, DebrickedOptions{})
+			assert.NoError(t, err)
+			var expected []string
+			for _, suffix := range c.expected {
+				expected = append(expected, filepath.ToSlash(path)+suffix)
+			}
+			assert.Equal(t, expected, fingerprintPaths(fingerprints))
+		})
+	}
+}
+
+func TestComputeHashForArchiveContentMatchesFile(t *testing.T) {
+	path := writeArchive(t, "lib.tar.xz", xzBytes(t, makeTar(t, testArchiveFile{"lib.py", []byte(archiveTestContent)})))
+	file := writeArchive(t, "lib.py", []byte(archiveTestContent))
+
+	fingerprints, err := computeHashForArchive(path, DebrickedOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, fingerprints, 1)
+	fileFingerprint, err := computeHashForFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, fileFingerprint.fingerprint, fingerprints[0].fingerprint)
+	assert.Equal(t, fileFingerprint.contentLength, fingerprints[0].contentLength)
+}
+
+func TestComputeHashForArchiveInvalid(t *testing.T) {
+	for _, name := range []string{"invalid.deb", "invalid.rpm", "invalid.tar.xz", "invalid.tar.zst"} {
+		t.Run(name, func(t *testing.T) {
+			path := writeArchive(t, name, []byte("not an archive"))
+			_, err := computeHashForArchive(path, DebrickedOptions{})
+			assert.Error(t, err)
+			assert.True(t, isArchiveFormatError(err), err.Error())
+		})
+	}
+}
+
+func TestComputeHashForArchiveInvalidNested(t *testing.T) {
+	path := writeArchive(t, "app.war", makeZip(t,
+		testArchiveFile{"WEB-INF/lib/x.jar", []byte("not a jar")},
+		testArchiveFile{"WEB-INF/web.py", []byte(archiveTestContent)},
+	))
+
+	fingerprints, err := computeHashForArchive(path, DebrickedOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.ToSlash(path) + "!/WEB-INF/lib/x.jar",
+		filepath.ToSlash(path) + "!/WEB-INF/web.py",
+	}, fingerprintPaths(fingerprints))
+}
@@ -0,0 +1,167 @@
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const hashCacheVersion = 1
+
+type cachedFingerprint struct {
+	// Name is the path of the fingerprinted file within an archive, or empty for the file itself
+	Name          string `json:"name,omitempty"`
+	ContentLength int64  `json:"contentLength"`
+	Fingerprint   string `json:"fingerprint"`
+}
+
+type hashCacheEntry struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"modTime"`
+	// ArchiveKey identifies the options archive contents were fingerprinted with, empty if they were not
+	ArchiveKey   string              `json:"archiveKey,omitempty"`
+	Fingerprints []cachedFingerprint `json:"fingerprints"`
+}
+
+type hashCacheFile struct {
+	Version int                       `json:"version"`
+	Entries map[string]hashCacheEntry `json:"entries"`
+}
+
+// hashCache holds the fingerprints of files between runs, keyed by path, size and modification time.
+// Only files seen during a run are saved, so deleted files are pruned from the cache
+type hashCache struct {
+	path    string
+	mutex   sync.Mutex
+	entries map[string]hashCacheEntry
+	seen    map[string]hashCacheEntry
+}
+
+// loadHashCache reads the cache at path. A missing or unreadable cache results in an empty cache
+func loadHashCache(path string) *hashCache {
+	cache := &hashCache{
+		path:    path,
+		entries: map[string]hashCacheEntry{},
+		seen:    map[string]hashCacheEntry{},
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	var cacheFile hashCacheFile
+	if json.Unmarshal(content, &cacheFile) == nil && cacheFile.Version == hashCacheVersion && cacheFile.Entries != nil {
+		cache.entries = cacheFile.Entries
+	}
+
+	return cache
+}
+
+// get returns the cached fingerprints of path, if its size, modification time and archive key are unchanged
+func (c *hashCache) get(path string, fileInfo os.FileInfo, archiveKey string) ([]FileFingerprint, bool) {
+	key := cacheKey(path)
+	c.mutex.Lock()
+	entry, ok := c.entries[key]
+	c.mutex.Unlock()
+	if !ok || entry.Size != fileInfo.Size() || entry.ModTime != fileInfo.ModTime().UnixNano() || entry.ArchiveKey != archiveKey {
+		return nil, false
+	}
+	fingerprints := make([]FileFingerprint, 0, len(entry.Fingerprints))
+	for _, cached := range entry.Fingerprints {
+		fingerprint, err := hex.DecodeString(cached.Fingerprint)
+		if err != nil {
+			return nil, false
+		}
+		longPath := path
+		if len(cached.Name) > 0 {
+			longPath = path + ArchivePathSeparator + cached.Name
+		}
+		fingerprints = append(fingerprints, FileFingerprint{
+			path:          longPath,
+			contentLength: cached.ContentLength,
+			fingerprint:   fingerprint,
+		})
+	}
+	c.put(path, entry)
+
+	return fingerprints, true
+}
+
+// set caches the fingerprints of path, which are the fingerprints of the file itself and of the files within it
+func (c *hashCache) set(path string, fileInfo os.FileInfo, archiveKey string, fingerprints []FileFingerprint) {
+	entry := hashCacheEntry{
+		Size:         fileInfo.Size(),
+		ModTime:      fileInfo.ModTime().UnixNano(),
+		ArchiveKey:   archiveKey,
+		Fingerprints: make([]cachedFingerprint, 0, len(fingerprints)),
+	}
+	for _, fingerprint := range fingerprints {
+		name := ""
+		if fingerprint.path != path {
+			name = strings.TrimPrefix(fingerprint.path, path+ArchivePathSeparator)
+		}
+		entry.Fingerprints = append(entry.Fingerprints, cachedFingerprint{
+			Name:          name,
+			ContentLength: fingerprint.contentLength,
+			Fingerprint:   hex.EncodeToString(fingerprint.fingerprint),
+		})
+	}
+	c.put(path, entry)
+}
+
+func (c *hashCache) put(path string, entry hashCacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.seen[cacheKey(path)] = entry
+}
+
+// save writes the entries seen during the run to the cache file
+func (c *hashCache) save() error {
+	c.mutex.Lock()
+	content, err := json.Marshal(hashCacheFile{Version: hashCacheVersion, Entries: c.seen})
+	c.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = ensureDirExists(filepath.Dir(c.path)); err != nil {
+		return err
+	}
+	// Write to a temporary file first, to not leave a truncated cache behind if the CLI is interrupted
+	tmpPath := c.path + ".tmp"
+	if err = os.WriteFile(tmpPath, content, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, c.path)
+}
+
+// cacheKey returns the absolute path of path, so that the cache can be shared between working directories
+func cacheKey(path string) string {
+	if absPath, err := filepath.Abs(path); err == nil {
+		return absPath
+	}
+
+	return path
+}
+
+// archiveKey returns a key identifying the options affecting which files within the archive at path are fingerprinted.
+// It is empty if path is not an archive, or if archive contents are not fingerprinted
+func archiveKey(path string, options DebrickedOptions) string {
+	if !options.FingerprintCompressedContent || !isArchive(path) {
+		return ""
+	}
+	key := fmt.Sprintf(
+		"%s\x00%s\x00%d\x00%d",
+		strings.Join(options.Exclusions, "\n"),
+		strings.Join(options.Inclusions, "\n"),
+		options.MaxArchiveDepth,
+		options.MaxArchiveSize,
+	)
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:8])
+}
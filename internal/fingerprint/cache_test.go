@@ -0,0 +1,85 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadHashCacheMissing(t *testing.T) {
+	cache := loadHashCache(filepath.Join(t.TempDir(), "cache.json"))
+	assert.Empty(t, cache.entries)
+}
+
+func TestLoadHashCacheInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+	assert.Empty(t, loadHashCache(path).entries)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"version": 0, "entries": {"a": {}}}`), 0600))
+	assert.Empty(t, loadHashCache(path).entries)
+}
+
+func TestHashCacheSetGetSave(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.py")
+	assert.NoError(t, os.WriteFile(file, []byte("print('hello world')"), 0600))
+	fileInfo, _ := os.Stat(file)
+	fingerprints := []FileFingerprint{
+		{path: file + ArchivePathSeparator + "inner/file.py", contentLength: 3, fingerprint: []byte{1, 2}},
+		{path: file, contentLength: 20, fingerprint: []byte{3, 4}},
+	}
+
+	cachePath := filepath.Join(dir, "cache", "cache.json")
+	cache := loadHashCache(cachePath)
+	_, ok := cache.get(file, fileInfo, "")
+	assert.False(t, ok)
+	cache.set(file, fileInfo, "", fingerprints)
+	assert.NoError(t, cache.save())
+
+	cache = loadHashCache(cachePath)
+	cached, ok := cache.get(file, fileInfo, "")
+	assert.True(t, ok)
+	assert.Equal(t, fingerprints, cached)
+
+	_, ok = cache.get(file, fileInfo, "archive-key")
+	assert.False(t, ok, "failed to assert that a changed archive key invalidated the entry")
+
+	modTime := fileInfo.ModTime().Add(time.Second)
+	assert.NoError(t, os.Chtimes(file, modTime, modTime))
+	fileInfo, _ = os.Stat(file)
+	_, ok = cache.get(file, fileInfo, "")
+	assert.False(t, ok, "failed to assert that a changed modification time invalidated the entry")
+}
+
+func TestHashCacheSavePrunesUnseen(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.py")
+	assert.NoError(t, os.WriteFile(file, []byte("content"), 0600))
+	fileInfo, _ := os.Stat(file)
+	cachePath := filepath.Join(dir, "cache.json")
+
+	cache := loadHashCache(cachePath)
+	cache.set(file, fileInfo, "", []FileFingerprint{{path: file, contentLength: 7, fingerprint: []byte{1}}})
+	assert.NoError(t, cache.save())
+	assert.Len(t, loadHashCache(cachePath).entries, 1)
+
+	assert.NoError(t, loadHashCache(cachePath).save())
+	assert.Empty(t, loadHashCache(cachePath).entries)
+}
+
+func TestArchiveKey(t *testing.T) {
+	options := DebrickedOptions{FingerprintCompressedContent: false}
+	assert.Empty(t, archiveKey("lib.jar", options))
+
+	options.FingerprintCompressedContent = true
+	assert.Empty(t, archiveKey("file.py", options))
+	key := archiveKey("lib.jar", options)
+	assert.NotEmpty(t, key)
+
+	options.Exclusions = []string{"**/*.class"}
+	assert.NotEqual(t, key, archiveKey("lib.jar", options))
+}
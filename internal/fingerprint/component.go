@@ -0,0 +1,143 @@
+package fingerprint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ComponentsFileName is the optional file of a component database listing the purls of its artifacts
+const ComponentsFileName = "components.yaml"
+
+var ComponentDatabaseNotFoundErr = errors.New("component database directory not found")
+
+// Component is a known artifact of a component database, such as a JAR, wheel or tgz file,
+// or a directory of unpacked source files
+type Component struct {
+	Purl     string `json:"purl"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Artifact string `json:"artifact"`
+	// Files is the number of fingerprinted files of the component
+	Files int `json:"files"`
+}
+
+type componentsFile struct {
+	Components []struct {
+		Path string `yaml:"path"`
+		Purl string `yaml:"purl"`
+	} `yaml:"components"`
+}
+
+// readComponentPurls reads the purls of components.yaml in dir, keyed by the absolute path of their artifacts.
+// A missing file results in no purls, which are then inferred from artifact names
+func readComponentPurls(dir string) (map[string]string, error) {
+	purls := map[string]string{}
+	content, err := os.ReadFile(filepath.Join(dir, ComponentsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return purls, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var components componentsFile
+	if err = yaml.Unmarshal(content, &components); err != nil {
+		return nil, err
+	}
+	for _, component := range components.Components {
+		purls[filepath.Join(dir, filepath.FromSlash(component.Path))] = component.Purl
+	}
+
+	return purls, nil
+}
+
+// newComponent returns the component of artifact, using purl if set, or else a purl inferred from the artifact name
+func newComponent(artifact string, purl string) *Component {
+	if len(purl) == 0 {
+		purl = inferPurl(filepath.Base(artifact))
+	}
+	name, version := purlNameVersion(purl)
+
+	return &Component{
+		Purl:     purl,
+		Name:     name,
+		Version:  version,
+		Artifact: filepath.ToSlash(artifact),
+	}
+}
+
+// purlEcosystems maps archive endings to purl types. Artifacts of other types are generic, such as JARs lacking a Maven group
+var purlEcosystems = map[string]string{
+	".whl":   "pypi",
+	".tgz":   "npm",
+	".gem":   "gem",
+	".crate": "cargo",
+	".nupkg": "nuget",
+}
+
+// inferPurl infers the purl of an artifact from names such as requests-2.31.0-py3-none-any.whl or lodash-4.17.21.tgz
+func inferPurl(artifactName string) string {
+	purlType := "generic"
+	base := artifactName
+	endings := ArchiveFileEndings()
+	sort.Slice(endings, func(i, j int) bool { return len(endings[i]) > len(endings[j]) })
+	for _, ending := range endings {
+		if strings.HasSuffix(artifactName, ending) {
+			base = strings.TrimSuffix(artifactName, ending)
+			if ecosystem, ok := purlEcosystems[ending]; ok {
+				purlType = ecosystem
+			}
+
+			break
+		}
+	}
+
+	var name, version string
+	switch purlType {
+	case "pypi":
+		// Wheel names are {name}-{version}-{tags}, with dashes in the name replaced by underscores
+		parts := strings.SplitN(base, "-", 3)
+		name = strings.ReplaceAll(strings.ToLower(parts[0]), "_", "-")
+		if len(parts) > 1 {
+			version = parts[1]
+		}
+	case "nuget":
+		name, version = splitNameVersion(base, '.')
+	default:
+		name, version = splitNameVersion(base, '-')
+	}
+
+	purl := "pkg:" + purlType + "/" + name
+	if len(version) > 0 {
+		purl += "@" + version
+	}
+
+	return purl
+}
+
+// splitNameVersion splits base at the first separator followed by a digit, such as in newtonsoft.json.13.0.3
+func splitNameVersion(base string, separator byte) (string, string) {
+	for i := 0; i < len(base)-1; i++ {
+		if base[i] == separator && base[i+1] >= '0' && base[i+1] <= '9' {
+			return base[:i], base[i+1:]
+		}
+	}
+
+	return base, ""
+}
+
+// purlNameVersion returns the name and version of purl, without namespace, qualifiers and subpath
+func purlNameVersion(purl string) (string, string) {
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	version := ""
+	if i := strings.LastIndex(purl, "@"); i > strings.LastIndex(purl, "/") {
+		purl, version = purl[:i], purl[i+1:]
+	}
+
+	return purl[strings.LastIndex(purl, "/")+1:], version
+}
@@ -0,0 +1,66 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferPurl(t *testing.T) {
+	cases := map[string]string{
+		"requests-2.31.0-py3-none-any.whl": "pkg:pypi/requests@2.31.0",
+		"typing_extensions-4.9.0.whl":      "pkg:pypi/typing-extensions@4.9.0",
+		"lodash-4.17.21.tgz":               "pkg:npm/lodash@4.17.21",
+		"rails-7.1.0.gem":                  "pkg:gem/rails@7.1.0",
+		"serde-1.0.197.crate":              "pkg:cargo/serde@1.0.197",
+		"newtonsoft.json.13.0.3.nupkg":     "pkg:nuget/newtonsoft.json@13.0.3",
+		"log4j-api-2.18.0.jar":             "pkg:generic/log4j-api@2.18.0",
+		"zlib-1.3":                         "pkg:generic/zlib@1.3",
+		"vendored.jar":                     "pkg:generic/vendored",
+	}
+	for artifact, purl := range cases {
+		t.Run(artifact, func(t *testing.T) {
+			assert.Equal(t, purl, inferPurl(artifact))
+		})
+	}
+}
+
+func TestPurlNameVersion(t *testing.T) {
+	name, version := purlNameVersion("pkg:maven/junit/junit@4.13.2?type=jar")
+	assert.Equal(t, "junit", name)
+	assert.Equal(t, "4.13.2", version)
+
+	name, version = purlNameVersion("pkg:npm/%40angular/core")
+	assert.Equal(t, "core", name)
+	assert.Empty(t, version)
+}
+
+func TestNewComponent(t *testing.T) {
+	component := newComponent(filepath.Join("db", "junit-4.13.2.jar"), "pkg:maven/junit/junit@4.13.2")
+	assert.Equal(t, "pkg:maven/junit/junit@4.13.2", component.Purl)
+	assert.Equal(t, "junit", component.Name)
+	assert.Equal(t, "4.13.2", component.Version)
+	assert.Equal(t, "db/junit-4.13.2.jar", component.Artifact)
+
+	component = newComponent(filepath.Join("db", "lodash-4.17.21.tgz"), "")
+	assert.Equal(t, "pkg:npm/lodash@4.17.21", component.Purl)
+}
+
+func TestReadComponentPurls(t *testing.T) {
+	dir := t.TempDir()
+	purls, err := readComponentPurls(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, purls)
+
+	content := "components:\n  - path: maven/junit-4.13.2.jar\n    purl: pkg:maven/junit/junit@4.13.2\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ComponentsFileName), []byte(content), 0600))
+	purls, err = readComponentPurls(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{filepath.Join(dir, "maven", "junit-4.13.2.jar"): "pkg:maven/junit/junit@4.13.2"}, purls)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ComponentsFileName), []byte("components: ["), 0600))
+	_, err = readComponentPurls(dir)
+	assert.Error(t, err)
+}
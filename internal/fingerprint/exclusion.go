@@ -60,7 +60,7 @@ var defaultFingerprintExclusions = DefaultFingerprintExclusionList{
 		".toml", ".transform", ".ttf", ".txt", ".utf-8", ".vim", ".wav", ".woff", ".woff2", ".xht",
 		".xhtml", ".xls", ".xlsx", ".xpm", ".xsd", ".xul", ".yaml", ".yml", ".wfp",
 		".editorconfig", ".dotcover", ".pid", ".lcov", ".egg", ".manifest", ".cache", ".coverage", ".cover",
-		".gem", ".lst", ".pickle", ".pdb", ".gml", ".pot", ".plt", ".pyi",
+		".lst", ".pickle", ".pdb", ".gml", ".pot", ".plt", ".pyi",
 	},
 }
 
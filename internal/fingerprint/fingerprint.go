@@ -1,17 +1,18 @@
 package fingerprint
 
 import (
-	"archive/tar"
-	"archive/zip"
 	"bufio"
-	"compress/bzip2"
-	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/tui"
@@ -26,10 +27,18 @@ type DebrickedOptions struct {
 	MinFingerprintContentLength  int
 	OutputPath                   string
 	Regenerate                   bool
+	// Workers is the number of files fingerprinted in parallel, defaults to the number of CPUs
+	Workers int
+	// MaxArchiveDepth is the number of nested archive levels unpacked, defaults to DefaultMaxArchiveDepth
+	MaxArchiveDepth int
+	// MaxArchiveSize is the number of uncompressed bytes read from each archive, defaults to DefaultMaxArchiveSize
+	MaxArchiveSize int64
+	// CachePath is the file fingerprints are cached in between runs, the cache is disabled if empty
+	CachePath string
 }
 
-var ZIP_FILE_ENDINGS = []string{".jar", ".nupkg", ".war", ".zip", ".ear", ".whl"}
-var TAR_GZIP_FILE_ENDINGS = []string{".tgz", ".tar.gz"}
+var ZIP_FILE_ENDINGS = []string{".jar", ".nupkg", ".war", ".zip", ".ear", ".whl", ".aar", ".apk"}
+var TAR_GZIP_FILE_ENDINGS = []string{".tgz", ".tar.gz", ".crate"}
 var TAR_BZIP2_FILE_ENDINGS = []string{".tar.bz2"}
 
 const HASH_SIZE = 16
@@ -79,6 +88,21 @@ func (f FileFingerprint) ToString() string {
 	return fmt.Sprintf("file=%x,%d,%s", f.fingerprint, f.contentLength, path)
 }
 
+// fingerprintTask is a file to fingerprint, index being its position in the walk order
+type fingerprintTask struct {
+	index    int
+	path     string
+	fileInfo os.FileInfo
+}
+
+type fingerprintResult struct {
+	index        int
+	fingerprints []FileFingerprint
+	cacheHit     bool
+	hashedBytes  int64
+	err          error
+}
+
 func (f *Fingerprinter) FingerprintFiles(options DebrickedOptions) (Fingerprints, error) {
 	if len(options.Path) == 0 {
 		options.Path = filepath.Base("")
@@ -95,34 +119,99 @@ func (f *Fingerprinter) FingerprintFiles(options DebrickedOptions) (Fingerprints
 		return fingerprints, &FingerprintFileExistsError{}
 	}
 
+	var cache *hashCache
+	if len(options.CachePath) > 0 {
+		cache = loadHashCache(options.CachePath)
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	// The workers share the patterns. Copies without spare capacity make appending to them allocate, instead of
+	// writing to the backing array of the caller concurrently
+	options.Exclusions = clip(options.Exclusions)
+	options.Inclusions = clip(options.Inclusions)
+
 	f.spinnerManager.Start()
 	spinnerMessage := "files processed"
 	spinner := f.spinnerManager.AddSpinner(spinnerMessage)
+	start := time.Now()
+
+	tasks := make(chan fingerprintTask, workers*4)
+	results := make(chan fingerprintResult, workers*4)
+	var workerGroup sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		workerGroup.Add(1)
+		go func() {
+			defer workerGroup.Done()
+			for task := range tasks {
+				results <- fingerprintTaskFile(task, options, cache)
+			}
+		}()
+	}
 
+	// The first error aborts the walk, remaining tasks are still drained by the workers
+	var failed atomic.Bool
+	walkDone := make(chan error, 1)
+	go func() {
+		index := 0
+		walkErr := filepath.Walk(options.Path, func(path string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if failed.Load() {
+				return filepath.SkipAll
+			}
+			tasks <- fingerprintTask{index: index, path: path, fileInfo: fileInfo}
+			index++
+
+			return nil
+		})
+		close(tasks)
+		workerGroup.Wait()
+		close(results)
+		walkDone <- walkErr
+	}()
+
+	fileFingerprints := map[int][]FileFingerprint{}
 	nbFiles := 0
 	lastLogNb := 0
+	var resultErr error
+	for result := range results {
+		if result.err != nil {
+			if resultErr == nil {
+				resultErr = result.err
+				failed.Store(true)
+			}
 
-	err = filepath.Walk(options.Path, func(path string, fileInfo os.FileInfo, err error) error {
-		if err != nil {
-			return err
+			continue
 		}
-		fileFingerprints, err := fingerprintFile(path, fileInfo, options)
-		if err != nil {
-			return err
+		if result.cacheHit {
+			fingerprints.Stats.CacheHits++
 		}
-		nbFiles += len(fileFingerprints)
+		fingerprints.Stats.HashedBytes += result.hashedBytes
+		nbFiles += len(result.fingerprints)
 		if nbFiles-lastLogNb >= 100 {
 			lastLogNb = nbFiles
 			f.spinnerManager.SetSpinnerMessage(spinner, spinnerMessage, fmt.Sprintf("%d", nbFiles))
 		}
-
-		if len(fileFingerprints) != 0 {
-			fingerprints.Entries = append(fingerprints.Entries, fileFingerprints...)
-
+		if len(result.fingerprints) != 0 {
+			fileFingerprints[result.index] = result.fingerprints
 		}
+	}
+	err = <-walkDone
+	if resultErr != nil {
+		err = resultErr
+	}
 
-		return nil
-	})
+	if err == nil {
+		fingerprints.Entries = orderedFingerprints(fileFingerprints)
+		if cache != nil {
+			err = cache.save()
+		}
+	}
+	fingerprints.Stats.Files = len(fingerprints.Entries)
+	fingerprints.Stats.Duration = time.Since(start)
 
 	f.spinnerManager.SetSpinnerMessage(spinner, spinnerMessage, fmt.Sprintf("%d", nbFiles))
 
@@ -137,49 +226,66 @@ func (f *Fingerprinter) FingerprintFiles(options DebrickedOptions) (Fingerprints
 	return fingerprints, err
 }
 
-func fingerprintFile(path string, fileInfo os.FileInfo, options DebrickedOptions) ([]FileFingerprint, error) {
-	fileFingerprints, err := computeHashForFileAndZip(fileInfo, path, options)
-	if err != nil {
-		return nil, err
+// orderedFingerprints returns the fingerprints of all files in walk order, regardless of the order they were computed in
+func orderedFingerprints(fileFingerprints map[int][]FileFingerprint) []FileFingerprint {
+	indexes := make([]int, 0, len(fileFingerprints))
+	for index := range fileFingerprints {
+		indexes = append(indexes, index)
 	}
-
-	var filteredFileFingerprints []FileFingerprint
-	for _, fileFingerprint := range fileFingerprints {
-		if fileFingerprint.contentLength >= int64(options.MinFingerprintContentLength) {
-			filteredFileFingerprints = append(filteredFileFingerprints, fileFingerprint)
-		}
+	sort.Ints(indexes)
+	var entries []FileFingerprint
+	for _, index := range indexes {
+		entries = append(entries, fileFingerprints[index]...)
 	}
 
-	return filteredFileFingerprints, nil
+	return entries
 }
 
-func computeHashForArchive(path string, exclusions []string, inclusions []string) ([]FileFingerprint, error) {
-	if isZipFile(path) {
-		return inMemFingerprintZipContent(path, exclusions, inclusions)
+func fingerprintTaskFile(task fingerprintTask, options DebrickedOptions, cache *hashCache) fingerprintResult {
+	result := fingerprintResult{index: task.index}
+	if !shouldProcessFile(task.fileInfo, options.Exclusions, options.Inclusions, task.path) {
+		return result
 	}
-	if isTarGZipFile(path) {
-		return inMemFingerprintTarGZipContent(path, exclusions, inclusions)
+
+	key := archiveKey(task.path, options)
+	var fileFingerprints []FileFingerprint
+	if cache != nil {
+		fileFingerprints, result.cacheHit = cache.get(task.path, task.fileInfo, key)
 	}
-	if isTarBZip2File(path) {
-		return inMemFingerprintTarBZip2Content(path, exclusions, inclusions)
+	if !result.cacheHit {
+		fileFingerprints, result.err = computeHashForFileAndZip(task.path, options)
+		if result.err != nil {
+			return result
+		}
+		result.hashedBytes = task.fileInfo.Size()
+		if cache != nil {
+			cache.set(task.path, task.fileInfo, key, fileFingerprints)
+		}
 	}
+	result.fingerprints = filterFingerprints(fileFingerprints, options)
 
-	return nil, nil
+	return result
 }
 
-func computeHashForFileAndZip(
-	fileInfo os.FileInfo, path string, options DebrickedOptions,
-) ([]FileFingerprint, error) {
-	if !shouldProcessFile(fileInfo, options.Exclusions, options.Inclusions, path) {
-		return nil, nil
+// filterFingerprints removes the fingerprints of files shorter than the minimum content length
+func filterFingerprints(fileFingerprints []FileFingerprint, options DebrickedOptions) []FileFingerprint {
+	var filteredFileFingerprints []FileFingerprint
+	for _, fileFingerprint := range fileFingerprints {
+		if fileFingerprint.contentLength >= int64(options.MinFingerprintContentLength) {
+			filteredFileFingerprints = append(filteredFileFingerprints, fileFingerprint)
+		}
 	}
 
+	return filteredFileFingerprints
+}
+
+func computeHashForFileAndZip(path string, options DebrickedOptions) ([]FileFingerprint, error) {
 	var fingerprints []FileFingerprint
 
 	if options.FingerprintCompressedContent {
-		fingerprintsArchive, err := computeHashForArchive(path, options.Exclusions, options.Inclusions)
+		fingerprintsArchive, err := computeHashForArchive(path, options)
 		if err != nil {
-			if errors.Is(err, zip.ErrFormat) {
+			if isArchiveFormatError(err) {
 				fmt.Printf("WARNING: Could not unpack and fingerprint contents of compressed file [%s]. Error: %v\n", path, err)
 			} else {
 				return nil, err
@@ -207,18 +313,24 @@ func isSymlink(filename string) (bool, error) {
 
 var isSymlinkFunc = isSymlink
 
+// clip returns a copy of patterns whose capacity equals its length
+func clip(patterns []string) []string {
+	if patterns == nil {
+		return nil
+	}
+	clipped := make([]string, len(patterns))
+	copy(clipped, patterns)
+
+	return clipped
+}
+
 func shouldProcessFile(fileInfo os.FileInfo, exclusions []string, inclusions []string, path string) bool {
-	inclusions = append(inclusions, DefaultInclusionsFingerprint()...)
-	exclusions = append(exclusions, DefaultExclusionsFingerprint()...)
 	if fileInfo.IsDir() {
 		return false
 	}
-	if file.Excluded(exclusions, inclusions, path) {
+	if !shouldProcessPath(exclusions, inclusions, path) {
 		return false
 	}
-	if !strings.Contains(filepath.Base(path), ".") {
-		return false
-	} // If no extension
 
 	isSymlink, err := isSymlinkFunc(path)
 	if err != nil {
@@ -234,20 +346,33 @@ func shouldProcessFile(fileInfo os.FileInfo, exclusions []string, inclusions []s
 	return !isSymlink
 }
 
+// computeHashForFile streams the content of filename into the hasher, to not load large files into memory
+// shouldProcessPath returns true if path is neither excluded nor lacks an extension
+func shouldProcessPath(exclusions []string, inclusions []string, path string) bool {
+	// Full slice expressions make append copy, as the slices are shared between fingerprinting workers
+	inclusions = append(inclusions[:len(inclusions):len(inclusions)], DefaultInclusionsFingerprint()...)
+	exclusions = append(exclusions[:len(exclusions):len(exclusions)], DefaultExclusionsFingerprint()...)
+	if file.Excluded(exclusions, inclusions, path) {
+		return false
+	}
+
+	return strings.Contains(filepath.Base(path), ".") // If no extension
+}
+
 func computeHashForFile(filename string) (FileFingerprint, error) {
-	data, err := os.ReadFile(filename)
+	file, err := os.Open(filename)
 	if err != nil {
 		return FileFingerprint{}, err
 	}
+	defer file.Close()
 
 	hasher := newHasher()
 
-	if _, err := hasher.Write(data); err != nil {
+	contentLength, err := io.Copy(hasher, file)
+	if err != nil {
 		return FileFingerprint{}, err
 	}
 
-	contentLength := int64(len(data))
-
 	return FileFingerprint{
 		path:          filename,
 		contentLength: contentLength,
@@ -257,6 +382,26 @@ func computeHashForFile(filename string) (FileFingerprint, error) {
 
 type Fingerprints struct {
 	Entries []FileFingerprint `json:"fingerprints"`
+	Stats   Stats             `json:"-"`
+}
+
+// Stats describes a fingerprinting run
+type Stats struct {
+	// Files is the number of fingerprints, including files within archives
+	Files     int
+	CacheHits int
+	// HashedBytes is the size of all files hashed during the run, files found in the cache are not included
+	HashedBytes int64
+	Duration    time.Duration
+}
+
+// Throughput returns the number of hashed bytes per second
+func (s Stats) Throughput() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+
+	return float64(s.HashedBytes) / s.Duration.Seconds()
 }
 
 func (f *Fingerprints) Len() int {
@@ -298,6 +443,7 @@ func (f *Fingerprints) writeToFile(file *os.File) error {
 
 	return writer.Flush()
 }
+
 func isZipFile(filename string) bool {
 	for _, file := range ZIP_FILE_ENDINGS {
 		if filepath.Ext(filename) == file {
@@ -327,139 +473,3 @@ func isTarBZip2File(filename string) bool {
 
 	return false
 }
-
-func shouldProcessTarHeader(header tar.Header, exclusions []string, inclusions []string, longPath string) bool {
-	if header.Typeflag != tar.TypeReg {
-		return false
-	}
-	if filepath.IsAbs(header.Name) || strings.HasPrefix(header.Name, "..") {
-		return false
-	}
-	if !shouldProcessFile(header.FileInfo(), exclusions, inclusions, longPath) {
-		return false
-	}
-
-	return true
-}
-
-func inMemFingerprintTarBZip2Content(filename string, exclusions []string, inclusions []string) ([]FileFingerprint, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-	bz2Reader := bzip2.NewReader(file)
-	tarReader := tar.NewReader(bz2Reader)
-	fingerprints := []FileFingerprint{}
-	for {
-		header, err := tarReader.Next()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		longPath := filepath.Join(filename, header.Name) // #nosec
-		if !shouldProcessTarHeader(*header, exclusions, inclusions, longPath) {
-			continue
-		}
-		hasher := newHasher()
-
-		_, err = io.Copy(hasher, tarReader) // #nosec
-		if err != nil {
-			return nil, err
-		}
-
-		fingerprints = append(fingerprints, FileFingerprint{
-			path:          longPath,
-			contentLength: header.Size,
-			fingerprint:   hasher.Sum(nil),
-		})
-	}
-
-	return fingerprints, nil
-}
-
-func inMemFingerprintTarGZipContent(filename string, exclusions []string, inclusions []string) ([]FileFingerprint, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-	gzReader, err := gzip.NewReader(file)
-	if err != nil {
-		return nil, err
-	}
-	tarReader := tar.NewReader(gzReader)
-	fingerprints := []FileFingerprint{}
-	for {
-		header, err := tarReader.Next()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		longPath := filepath.Join(filename, header.Name) // #nosec
-		if !shouldProcessTarHeader(*header, exclusions, inclusions, longPath) {
-			continue
-		}
-		hasher := newHasher()
-
-		_, err = io.Copy(hasher, tarReader) // #nosec
-		if err != nil {
-			return nil, err
-		}
-
-		fingerprints = append(fingerprints, FileFingerprint{
-			path:          longPath,
-			contentLength: header.Size,
-			fingerprint:   hasher.Sum(nil),
-		})
-	}
-
-	return fingerprints, nil
-}
-
-func inMemFingerprintZipContent(filename string, exclusions []string, inclusions []string) ([]FileFingerprint, error) {
-	r, err := zip.OpenReader(filename)
-
-	if err != nil {
-		return nil, err
-	}
-	defer r.Close()
-
-	fingerprints := []FileFingerprint{}
-
-	for _, f := range r.File {
-		if filepath.IsAbs(f.Name) || strings.HasPrefix(f.Name, "..") {
-			continue
-		}
-		longFileName := filepath.Join(filename, f.Name) // #nosec
-
-		if !shouldProcessFile(f.FileInfo(), exclusions, inclusions, longFileName) {
-			continue
-		}
-		rc, err := f.Open()
-		if err != nil {
-			return nil, err
-		}
-
-		hasher := newHasher()
-
-		_, err = io.Copy(hasher, rc) // #nosec
-		if err != nil {
-			rc.Close()
-
-			return nil, err
-		}
-
-		fingerprints = append(fingerprints, FileFingerprint{
-			path:          longFileName,
-			contentLength: int64(f.UncompressedSize64),
-			fingerprint:   hasher.Sum(nil),
-		})
-
-		rc.Close()
-	}
-
-	return fingerprints, nil
-}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -561,3 +562,69 @@ func TestComputeHashForFile(t *testing.T) {
 		})
 	}
 }
+
+func TestFingerprintFilesWorkersKeepWalkOrder(t *testing.T) {
+	options := DebrickedOptions{
+		Path:                        "testdata",
+		Exclusions:                  []string{},
+		Inclusions:                  []string{},
+		MinFingerprintContentLength: 0,
+		Workers:                     1,
+	}
+	expected, err := NewFingerprinter().FingerprintFiles(options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, expected.Entries)
+
+	options.Workers = 8
+	fingerprints, err := NewFingerprinter().FingerprintFiles(options)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Entries, fingerprints.Entries)
+	assert.Equal(t, len(fingerprints.Entries), fingerprints.Stats.Files)
+	assert.Positive(t, fingerprints.Stats.HashedBytes)
+}
+
+func TestFingerprintFilesWorkersSharePatterns(t *testing.T) {
+	// Spare capacity would let the workers append the default patterns to the same backing array
+	exclusions := make([]string, 0, 16)
+	inclusions := make([]string, 0, 16)
+	options := DebrickedOptions{
+		Path:       "testdata",
+		Exclusions: exclusions,
+		Inclusions: inclusions,
+		Workers:    8,
+	}
+
+	fingerprints, err := NewFingerprinter().FingerprintFiles(options)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, fingerprints.Entries)
+	assert.Empty(t, exclusions[:cap(exclusions)][0])
+	assert.Empty(t, inclusions[:cap(inclusions)][0])
+}
+
+func TestFingerprintFilesCache(t *testing.T) {
+	dir := t.TempDir()
+	for i, content := range []string{"first file content", "second file content"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d.py", i)), []byte(content), 0600))
+	}
+	options := DebrickedOptions{
+		Path:      dir,
+		CachePath: filepath.Join(t.TempDir(), "cache.json"),
+	}
+
+	fingerprints, err := NewFingerprinter().FingerprintFiles(options)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fingerprints.Stats.CacheHits)
+	assert.Equal(t, int64(37), fingerprints.Stats.HashedBytes)
+
+	cachedFingerprints, err := NewFingerprinter().FingerprintFiles(options)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cachedFingerprints.Stats.CacheHits)
+	assert.Equal(t, int64(0), cachedFingerprints.Stats.HashedBytes)
+	assert.Equal(t, fingerprints.Entries, cachedFingerprints.Entries)
+}
+
+func TestStatsThroughput(t *testing.T) {
+	assert.Equal(t, float64(0), Stats{HashedBytes: 10}.Throughput())
+	assert.Equal(t, float64(5), Stats{HashedBytes: 10, Duration: 2 * time.Second}.Throughput())
+}
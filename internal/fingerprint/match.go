@@ -0,0 +1,288 @@
+package fingerprint
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type MatchOptions struct {
+	Path string
+	// DatabasePath is the directory of known artifacts to match against
+	DatabasePath                 string
+	Exclusions                   []string
+	Inclusions                   []string
+	FingerprintCompressedContent bool
+	MinFingerprintContentLength  int
+	Workers                      int
+	CachePath                    string
+}
+
+type IMatcher interface {
+	Match(options MatchOptions) (MatchResult, error)
+}
+
+// Matcher matches the fingerprints of files against a local component database, without contacting Debricked
+type Matcher struct {
+	fingerprinter IFingerprint
+}
+
+func NewMatcher(fingerprinter IFingerprint) *Matcher {
+	return &Matcher{fingerprinter: fingerprinter}
+}
+
+// FileMatch is a file whose fingerprint matches a file of a component
+type FileMatch struct {
+	Path string `json:"path"`
+	// ComponentPath is the path of the matching file within the component, empty if the whole artifact matches
+	ComponentPath string `json:"componentPath"`
+}
+
+type ComponentMatch struct {
+	Component Component   `json:"component"`
+	Matches   []FileMatch `json:"matches"`
+}
+
+// MatchedFiles returns the number of distinct component files matched
+func (m ComponentMatch) MatchedFiles() int {
+	matched := map[string]bool{}
+	for _, match := range m.Matches {
+		matched[match.ComponentPath] = true
+	}
+
+	return len(matched)
+}
+
+type MatchResult struct {
+	Components []ComponentMatch `json:"components"`
+	// Files is the number of fingerprinted files
+	Files int `json:"files"`
+	// MatchedFiles is the number of fingerprinted files matching at least one component
+	MatchedFiles int `json:"matchedFiles"`
+}
+
+type componentFile struct {
+	component *Component
+	path      string
+}
+
+// ComponentDatabase indexes the fingerprints of all files of its components
+type ComponentDatabase struct {
+	path       string
+	components []*Component
+	index      map[string][]componentFile
+}
+
+func (m *Matcher) Match(options MatchOptions) (MatchResult, error) {
+	database, err := m.LoadDatabase(options)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	fingerprints, err := m.fingerprinter.FingerprintFiles(DebrickedOptions{
+		Path:                         options.Path,
+		Exclusions:                   options.Exclusions,
+		Inclusions:                   options.Inclusions,
+		FingerprintCompressedContent: options.FingerprintCompressedContent,
+		MinFingerprintContentLength:  options.MinFingerprintContentLength,
+		Regenerate:                   true,
+		Workers:                      options.Workers,
+		CachePath:                    options.CachePath,
+	})
+	if err != nil {
+		return MatchResult{}, err
+	}
+
+	return database.Match(fingerprints.Entries), nil
+}
+
+// LoadDatabase fingerprints all artifacts of the component database, including the contents of archives
+func (m *Matcher) LoadDatabase(options MatchOptions) (*ComponentDatabase, error) {
+	if info, err := os.Stat(options.DatabasePath); err != nil || !info.IsDir() {
+		return nil, ComponentDatabaseNotFoundErr
+	}
+	purls, err := readComponentPurls(options.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	fingerprints, err := m.fingerprinter.FingerprintFiles(DebrickedOptions{
+		Path:                         options.DatabasePath,
+		FingerprintCompressedContent: true,
+		MinFingerprintContentLength:  options.MinFingerprintContentLength,
+		Regenerate:                   true,
+		Workers:                      options.Workers,
+		CachePath:                    options.CachePath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newComponentDatabase(options.DatabasePath, purls, fingerprints.Entries), nil
+}
+
+func newComponentDatabase(path string, purls map[string]string, fingerprints []FileFingerprint) *ComponentDatabase {
+	database := &ComponentDatabase{
+		path:  filepath.Clean(path),
+		index: map[string][]componentFile{},
+	}
+	components := map[string]*Component{}
+	for _, fingerprint := range fingerprints {
+		artifact, componentPath := database.artifactOf(fingerprint.path, purls)
+		component, ok := components[artifact]
+		if !ok {
+			component = newComponent(artifact, purls[artifact])
+			components[artifact] = component
+			database.components = append(database.components, component)
+		}
+		component.Files++
+		key := hex.EncodeToString(fingerprint.fingerprint)
+		database.index[key] = append(database.index[key], componentFile{component: component, path: componentPath})
+	}
+
+	return database
+}
+
+// artifactOf returns the artifact path is part of, and the path of the file within the artifact.
+// The artifact is the closest ancestor listed in components.yaml, or else the top level file or directory of the database
+func (database *ComponentDatabase) artifactOf(path string, purls map[string]string) (string, string) {
+	file, archivePath, _ := strings.Cut(path, ArchivePathSeparator)
+	file = filepath.Clean(file)
+	artifact := ""
+	for candidate := file; candidate != database.path && candidate != filepath.Dir(candidate); candidate = filepath.Dir(candidate) {
+		if _, ok := purls[candidate]; ok {
+			artifact = candidate
+
+			break
+		}
+		if filepath.Dir(candidate) == database.path {
+			artifact = candidate
+		}
+	}
+	if len(artifact) == 0 {
+		artifact = file
+	}
+
+	componentPath := ""
+	if relPath, err := filepath.Rel(artifact, file); err == nil && relPath != "." {
+		componentPath = filepath.ToSlash(relPath)
+	}
+	if len(archivePath) > 0 {
+		if len(componentPath) > 0 {
+			componentPath += ArchivePathSeparator
+		}
+		componentPath += archivePath
+	}
+
+	return artifact, componentPath
+}
+
+// Match returns the components matching fingerprints. Files within the database itself are not matched
+func (database *ComponentDatabase) Match(fingerprints []FileFingerprint) MatchResult {
+	result := MatchResult{}
+	matches := map[*Component][]FileMatch{}
+	for _, fingerprint := range fingerprints {
+		if database.contains(fingerprint.path) {
+			continue
+		}
+		result.Files++
+		componentFiles := database.index[hex.EncodeToString(fingerprint.fingerprint)]
+		if len(componentFiles) > 0 {
+			result.MatchedFiles++
+		}
+		for _, componentFile := range componentFiles {
+			matches[componentFile.component] = append(matches[componentFile.component], FileMatch{
+				Path:          filepath.ToSlash(fingerprint.path),
+				ComponentPath: componentFile.path,
+			})
+		}
+	}
+	for component, componentMatches := range matches {
+		sort.Slice(componentMatches, func(i, j int) bool {
+			if componentMatches[i].Path != componentMatches[j].Path {
+				return componentMatches[i].Path < componentMatches[j].Path
+			}
+
+			return componentMatches[i].ComponentPath < componentMatches[j].ComponentPath
+		})
+		result.Components = append(result.Components, ComponentMatch{Component: *component, Matches: componentMatches})
+	}
+	sort.Slice(result.Components, func(i, j int) bool {
+		if result.Components[i].Component.Purl != result.Components[j].Component.Purl {
+			return result.Components[i].Component.Purl < result.Components[j].Component.Purl
+		}
+
+		return result.Components[i].Component.Artifact < result.Components[j].Component.Artifact
+	})
+
+	return result
+}
+
+// Components returns the components of the database
+func (database *ComponentDatabase) Components() []Component {
+	components := make([]Component, 0, len(database.components))
+	for _, component := range database.components {
+		components = append(components, *component)
+	}
+
+	return components
+}
+
+func (database *ComponentDatabase) contains(path string) bool {
+	file, _, _ := strings.Cut(path, ArchivePathSeparator)
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+	absDatabase, err := filepath.Abs(database.path)
+	if err != nil {
+		return false
+	}
+	relPath, err := filepath.Rel(absDatabase, absFile)
+
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
+// WriteMatches writes the matched components, with the files matching each component
+func WriteMatches(w io.Writer, result MatchResult) {
+	if len(result.Components) == 0 {
+		fmt.Fprintf(w, "None of %d files matched the component database\n", result.Files)
+
+		return
+	}
+	fmt.Fprintf(w, "%d of %d files matched %d components\n", result.MatchedFiles, result.Files, len(result.Components))
+	for _, componentMatch := range result.Components {
+		component := componentMatch.Component
+		fmt.Fprintf(
+			w,
+			"%s (%s): %d of %d files matched\n",
+			component.Purl,
+			component.Artifact,
+			componentMatch.MatchedFiles(),
+			component.Files,
+		)
+		for _, match := range componentMatch.Matches {
+			if len(match.ComponentPath) == 0 {
+				fmt.Fprintf(w, "  %s\n", match.Path)
+			} else {
+				fmt.Fprintf(w, "  %s = %s\n", match.Path, match.ComponentPath)
+			}
+		}
+	}
+}
+
+// ToFile writes result as JSON to outputFile
+func (result MatchResult) ToFile(outputFile string) error {
+	if err := ensureDirExists(filepath.Dir(outputFile)); err != nil {
+		return fmt.Errorf("failed to ensure directory exists: %w", err)
+	}
+	content, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outputFile, content, 0600)
+}
@@ -0,0 +1,130 @@
+package fingerprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	matchTestContentA = "module.exports = function lodash() { return 'a vendored lodash function'; };"
+	matchTestContentB = "module.exports = function chunk(array, size) { return [array.slice(0, size)]; };"
+)
+
+// makeComponentDatabase creates a database with a tgz artifact, a directory of unpacked sources and a listed JAR
+func makeComponentDatabase(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	tgz := gzipBytes(t, makeTar(t,
+		testArchiveFile{"package/lodash.js", []byte(matchTestContentA)},
+		testArchiveFile{"package/chunk.js", []byte(matchTestContentB)},
+	))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "lodash-4.17.21.tgz"), tgz, 0600))
+	sources := filepath.Join(dir, "chunk-1.0.0", "src")
+	assert.NoError(t, os.MkdirAll(sources, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(sources, "chunk.js"), []byte(matchTestContentB), 0600))
+	jar := makeZip(t, testArchiveFile{"org/junit/Test.java", []byte(archiveTestContent)})
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "maven"), 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "maven", "junit.jar"), jar, 0600))
+	components := "components:\n  - path: maven/junit.jar\n    purl: pkg:maven/junit/junit@4.13.2\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ComponentsFileName), []byte(components), 0600))
+
+	return dir
+}
+
+func TestMatch(t *testing.T) {
+	database := makeComponentDatabase(t)
+	tree := t.TempDir()
+	vendor := filepath.Join(tree, "third_party")
+	assert.NoError(t, os.MkdirAll(vendor, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(vendor, "lodash.js"), []byte(matchTestContentA), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(vendor, "chunk.js"), []byte(matchTestContentB), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(vendor, "Test.java"), []byte(archiveTestContent), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(tree, "own.js"), []byte("module.exports = function own() { return 'not part of any component'; };"), 0600))
+
+	matcher := NewMatcher(NewFingerprinter())
+	result, err := matcher.Match(MatchOptions{Path: tree, DatabasePath: database})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Files)
+	assert.Equal(t, 3, result.MatchedFiles)
+
+	var purls []string
+	for _, componentMatch := range result.Components {
+		purls = append(purls, componentMatch.Component.Purl)
+	}
+	assert.Equal(t, []string{"pkg:generic/chunk@1.0.0", "pkg:maven/junit/junit@4.13.2", "pkg:npm/lodash@4.17.21"}, purls)
+
+	chunk := result.Components[0]
+	assert.Equal(t, []FileMatch{{Path: filepath.ToSlash(filepath.Join(vendor, "chunk.js")), ComponentPath: "src/chunk.js"}}, chunk.Matches)
+	assert.Equal(t, 1, chunk.Component.Files)
+
+	junit := result.Components[1]
+	assert.Equal(t, "junit", junit.Component.Name)
+	assert.Equal(t, "4.13.2", junit.Component.Version)
+	assert.Equal(t, "Test.java", filepath.Base(junit.Matches[0].Path))
+	assert.Equal(t, "org/junit/Test.java", junit.Matches[0].ComponentPath)
+
+	lodash := result.Components[2]
+	assert.Len(t, lodash.Matches, 2)
+	assert.Equal(t, 2, lodash.MatchedFiles())
+	assert.Equal(t, 3, lodash.Component.Files, "failed to assert that the tgz itself was counted")
+}
+
+func TestMatchDatabaseWithinPath(t *testing.T) {
+	tree := t.TempDir()
+	database := filepath.Join(tree, "db")
+	assert.NoError(t, os.Mkdir(database, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(database, "lodash-4.17.21.js"), []byte(matchTestContentA), 0600))
+
+	result, err := NewMatcher(NewFingerprinter()).Match(MatchOptions{Path: tree, DatabasePath: database})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Files)
+	assert.Empty(t, result.Components)
+}
+
+func TestMatchDatabaseNotFound(t *testing.T) {
+	_, err := NewMatcher(NewFingerprinter()).Match(MatchOptions{DatabasePath: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorIs(t, err, ComponentDatabaseNotFoundErr)
+}
+
+func TestWriteMatches(t *testing.T) {
+	var buffer bytes.Buffer
+	WriteMatches(&buffer, MatchResult{Files: 3})
+	assert.Equal(t, "None of 3 files matched the component database\n", buffer.String())
+
+	buffer.Reset()
+	WriteMatches(&buffer, MatchResult{
+		Files:        3,
+		MatchedFiles: 2,
+		Components: []ComponentMatch{
+			{
+				Component: Component{Purl: "pkg:npm/lodash@4.17.21", Artifact: "db/lodash-4.17.21.tgz", Files: 3},
+				Matches: []FileMatch{
+					{Path: "vendor/lodash.js", ComponentPath: "package/lodash.js"},
+					{Path: "vendor/lodash-4.17.21.tgz"},
+				},
+			},
+		},
+	})
+	assert.Equal(t, `2 of 3 files matched 1 components
+pkg:npm/lodash@4.17.21 (db/lodash-4.17.21.tgz): 2 of 3 files matched
+  vendor/lodash.js = package/lodash.js
+  vendor/lodash-4.17.21.tgz
+`, buffer.String())
+}
+
+func TestMatchResultToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "matches.json")
+	result := MatchResult{Files: 1, MatchedFiles: 1, Components: []ComponentMatch{{Component: Component{Purl: "pkg:npm/lodash@4.17.21"}}}}
+	assert.NoError(t, result.ToFile(path))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var written MatchResult
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, result, written)
+}
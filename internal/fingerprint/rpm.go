@@ -0,0 +1,134 @@
+package fingerprint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	rpmLeadSize       = 96
+	rpmHeaderSize     = 16
+	rpmIndexEntrySize = 16
+	cpioHeaderSize    = 110
+	cpioTrailer       = "TRAILER!!!"
+)
+
+var (
+	rpmLeadMagic   = []byte{0xed, 0xab, 0xee, 0xdb}
+	rpmHeaderMagic = []byte{0x8e, 0xad, 0xe8, 0x01}
+)
+
+// rpmPayload skips the lead, signature and header of the RPM package read by r, and returns its decompressed cpio payload
+func rpmPayload(r io.Reader) (io.ReadCloser, error) {
+	reader := bufio.NewReader(r)
+	lead := make([]byte, rpmLeadSize)
+	if _, err := io.ReadFull(reader, lead); err != nil || !bytes.Equal(lead[:4], rpmLeadMagic) {
+		return nil, errInvalidArchive
+	}
+	// The signature is padded to a multiple of 8 bytes, the header that follows it is not
+	signatureSize, err := skipRpmHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = reader.Discard(int((8 - signatureSize%8) % 8)); err != nil {
+		return nil, errInvalidArchive
+	}
+	if _, err = skipRpmHeader(reader); err != nil {
+		return nil, err
+	}
+
+	return decompressPayload(reader)
+}
+
+// skipRpmHeader skips a header structure, returning its size
+func skipRpmHeader(reader *bufio.Reader) (int64, error) {
+	header := make([]byte, rpmHeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil || !bytes.Equal(header[:4], rpmHeaderMagic) {
+		return 0, errInvalidArchive
+	}
+	entries := int64(binary.BigEndian.Uint32(header[8:12]))
+	dataSize := int64(binary.BigEndian.Uint32(header[12:16]))
+	size := entries*rpmIndexEntrySize + dataSize
+	if _, err := io.CopyN(io.Discard, reader, size); err != nil {
+		return 0, errInvalidArchive
+	}
+
+	return rpmHeaderSize + size, nil
+}
+
+// decompressPayload detects the compression of the payload from its magic bytes
+func decompressPayload(reader *bufio.Reader) (io.ReadCloser, error) {
+	magic, _ := reader.Peek(6)
+	format := tarArchive
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		format = tarGZipArchive
+	case bytes.HasPrefix(magic, []byte("BZh")):
+		format = tarBZip2Archive
+	case bytes.HasPrefix(magic, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}):
+		format = tarXzArchive
+	case bytes.HasPrefix(magic, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		format = tarZstdArchive
+	}
+
+	return decompress(format, reader)
+}
+
+// walkCpio calls fn for each file of the cpio archive read by r, in the "new ASCII" format used by RPM payloads
+func walkCpio(r io.Reader, fn func(entry archiveEntry) error) error {
+	reader := bufio.NewReader(r)
+	header := make([]byte, cpioHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return errInvalidArchive
+		}
+		magic := string(header[0:6])
+		if magic != "070701" && magic != "070702" {
+			return errInvalidArchive
+		}
+		mode, modeErr := strconv.ParseUint(string(header[14:22]), 16, 32)
+		size, sizeErr := strconv.ParseInt(string(header[54:62]), 16, 64)
+		nameSize, nameSizeErr := strconv.ParseInt(string(header[94:102]), 16, 64)
+		if modeErr != nil || sizeErr != nil || nameSizeErr != nil || nameSize <= 0 {
+			return errInvalidArchive
+		}
+		name := make([]byte, nameSize)
+		if _, err := io.ReadFull(reader, name); err != nil {
+			return errInvalidArchive
+		}
+		// The name and the file data are both padded to multiples of 4 bytes
+		if _, err := reader.Discard(int(cpioPadding(cpioHeaderSize + nameSize))); err != nil {
+			return errInvalidArchive
+		}
+		entryName := strings.TrimRight(string(name), "\x00")
+		if entryName == cpioTrailer {
+			return nil
+		}
+		data := io.LimitReader(reader, size)
+		err := fn(archiveEntry{
+			name:    entryName,
+			size:    size,
+			regular: mode&0170000 == 0100000,
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(data), nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(io.Discard, data); err != nil {
+			return err
+		}
+		if _, err = reader.Discard(int(cpioPadding(size))); err != nil {
+			return errInvalidArchive
+		}
+	}
+}
+
+func cpioPadding(size int64) int64 {
+	return (4 - size%4) % 4
+}
@@ -25,3 +25,15 @@ func (f *FingerprintMock) FingerprintFiles(
 ) (fingerprint.Fingerprints, error) {
 	return fingerprint.Fingerprints{}, f.error
 }
+
+type MatcherMock struct {
+	Result  fingerprint.MatchResult
+	Error   error
+	Options fingerprint.MatchOptions
+}
+
+func (m *MatcherMock) Match(options fingerprint.MatchOptions) (fingerprint.MatchResult, error) {
+	m.Options = options
+
+	return m.Result, m.Error
+}
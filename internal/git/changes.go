@@ -0,0 +1,102 @@
+package git
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var MergeBaseError = errors.New("failed to find a merge base, the history of the repository may be shallow")
+
+// FindChangedFiles returns the files changed between the merge base of since and HEAD, and HEAD, in the repository
+// containing path. since is a revision, such as a branch, tag or commit hash. Branches are also looked up on the
+// origin remote, as CI pipelines often only fetch the branch being built. Paths are relative to path, and files
+// outside path are left out
+func FindChangedFiles(path string, since string) ([]string, error) {
+	repository, err := openRepository(path)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := FindCommit(repository.Repository)
+	if err != nil {
+		return nil, err
+	}
+	base, err := findMergeBase(repository.Repository, head, since)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := diffCommits(base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]bool{}
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if len(name) == 0 {
+				continue
+			}
+			if rel, ok := repository.relativePath(name); ok {
+				files[rel] = true
+			}
+		}
+	}
+	changedFiles := make([]string, 0, len(files))
+	for file := range files {
+		changedFiles = append(changedFiles, file)
+	}
+	sort.Strings(changedFiles)
+
+	return changedFiles, nil
+}
+
+// FindMergeBase returns the hash of the merge base of revision and HEAD in the repository containing path. Branches
+// are also looked up on the origin remote
+func FindMergeBase(path string, revision string) (string, error) {
+	repository, err := openRepository(path)
+	if err != nil {
+		return "", err
+	}
+	head, err := FindCommit(repository.Repository)
+	if err != nil {
+		return "", err
+	}
+	base, err := findMergeBase(repository.Repository, head, revision)
+	if err != nil {
+		return "", err
+	}
+
+	return base.Hash.String(), nil
+}
+
+func findMergeBase(repository *git.Repository, head *object.Commit, since string) (*object.Commit, error) {
+	sinceCommit, err := resolveCommit(repository, since)
+	if err != nil {
+		return nil, err
+	}
+	mergeBases, err := head.MergeBase(sinceCommit)
+	if err != nil {
+		return nil, err
+	}
+	if len(mergeBases) == 0 {
+		return nil, MergeBaseError
+	}
+
+	return mergeBases[0], nil
+}
+
+func diffCommits(from *object.Commit, to *object.Commit) (object.Changes, error) {
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	return object.DiffTree(fromTree, toTree)
+}
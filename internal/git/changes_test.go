@@ -0,0 +1,119 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitFiles(t *testing.T, repository *git.Repository, dir string, files map[string]string) plumbing.Hash {
+	t.Helper()
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		_, err = worktree.Add(name)
+		assert.NoError(t, err)
+	}
+	signature := &object.Signature{Name: "debricked", Email: "debricked@example.com", When: time.Now()}
+	hash, err := worktree.Commit("commit", &git.CommitOptions{Author: signature})
+	assert.NoError(t, err)
+
+	return hash
+}
+
+// setUpChangesRepository commits a base and a change on top of it, and returns the directory and the base commit
+func setUpChangesRepository(t *testing.T) (string, *git.Repository, plumbing.Hash) {
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	base := commitFiles(t, repository, dir, map[string]string{
+		"web/package.json": "{}",
+		"api/go.mod":       "module api",
+	})
+	commitFiles(t, repository, dir, map[string]string{
+		"web/package.json": `{"dependencies": {}}`,
+		"docs/README.md":   "docs",
+	})
+
+	return dir, repository, base
+}
+
+func TestFindChangedFiles(t *testing.T) {
+	dir, repository, base := setUpChangesRepository(t)
+	assert.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference("refs/heads/base", base)))
+
+	for _, since := range []string{"base", base.String(), "HEAD~1"} {
+		changedFiles, err := FindChangedFiles(dir, since)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("docs", "README.md"), filepath.Join("web", "package.json")}, changedFiles)
+	}
+}
+
+func TestFindChangedFilesSubdirectory(t *testing.T) {
+	dir, _, base := setUpChangesRepository(t)
+
+	changedFiles, err := FindChangedFiles(filepath.Join(dir, "web"), base.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"package.json"}, changedFiles)
+}
+
+func TestFindChangedFilesRemoteBranch(t *testing.T) {
+	dir, repository, base := setUpChangesRepository(t)
+	assert.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/main", base)))
+
+	changedFiles, err := FindChangedFiles(dir, "main")
+
+	assert.NoError(t, err)
+	assert.Len(t, changedFiles, 2)
+}
+
+func TestFindChangedFilesNoChanges(t *testing.T) {
+	dir, _, _ := setUpChangesRepository(t)
+
+	changedFiles, err := FindChangedFiles(dir, "HEAD")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, changedFiles)
+	assert.Empty(t, changedFiles)
+}
+
+func TestFindChangedFilesErrors(t *testing.T) {
+	dir, _, _ := setUpChangesRepository(t)
+
+	_, err := FindChangedFiles(dir, "missing")
+	assert.ErrorContains(t, err, "failed to resolve missing")
+
+	_, err = FindChangedFiles(t.TempDir(), "main")
+	assert.ErrorIs(t, err, git.ErrRepositoryNotExists)
+}
+
+func TestFindMergeBase(t *testing.T) {
+	dir, repository, base := setUpChangesRepository(t)
+	assert.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/main", base)))
+
+	mergeBase, err := FindMergeBase(dir, "main")
+
+	assert.NoError(t, err)
+	assert.Equal(t, base.String(), mergeBase)
+}
+
+func TestFindMergeBaseErrors(t *testing.T) {
+	dir, _, _ := setUpChangesRepository(t)
+
+	_, err := FindMergeBase(dir, "missing")
+	assert.ErrorContains(t, err, "failed to resolve missing")
+
+	_, err = FindMergeBase(t.TempDir(), "main")
+	assert.ErrorIs(t, err, git.ErrRepositoryNotExists)
+}
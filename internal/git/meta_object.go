@@ -14,6 +14,9 @@ type MetaObject struct {
 	BranchName        string
 	DefaultBranchName string
 	Author            string
+	PullRequest       string
+	TargetBranchName  string
+	BaseCommitName    string
 }
 
 // NewMetaObject returns MetaObject based on git repository existing on path. Otherwise, inputted arguments are used
@@ -44,6 +47,21 @@ func NewMetaObject(path string, repositoryName string, commit string, branchName
 	return obj, checkErrors(obj)
 }
 
+// SetPullRequest sets the pull request context of obj. A missing base commit is set to the merge base of HEAD and
+// targetBranch in the git repository existing on path
+func (obj *MetaObject) SetPullRequest(path string, pullRequest string, targetBranch string, baseCommit string) {
+	obj.PullRequest = pullRequest
+	obj.TargetBranchName = targetBranch
+	if !isSet(baseCommit) && isSet(targetBranch) {
+		var err error
+		baseCommit, err = FindMergeBase(path, targetBranch)
+		if err != nil {
+			log.Println(err.Error())
+		}
+	}
+	obj.BaseCommitName = baseCommit
+}
+
 func isSet(attribute string) bool {
 	return len(attribute) > 0
 }
@@ -47,3 +47,31 @@ func TestNewMetaObjectWithRepository(t *testing.T) {
 	assert.Greater(t, len(newMetaObj.Author), 0)
 
 }
+
+func TestSetPullRequest(t *testing.T) {
+	dir, _, base := setUpChangesRepository(t)
+	metaObj := &MetaObject{}
+
+	metaObj.SetPullRequest(dir, "12", "HEAD~1", "")
+
+	assert.Equal(t, "12", metaObj.PullRequest)
+	assert.Equal(t, "HEAD~1", metaObj.TargetBranchName)
+	assert.Equal(t, base.String(), metaObj.BaseCommitName)
+}
+
+func TestSetPullRequestWithBaseCommit(t *testing.T) {
+	metaObj := &MetaObject{}
+
+	metaObj.SetPullRequest(t.TempDir(), "12", "main", "base")
+
+	assert.Equal(t, "base", metaObj.BaseCommitName)
+}
+
+func TestSetPullRequestWithoutMergeBase(t *testing.T) {
+	metaObj := &MetaObject{}
+
+	metaObj.SetPullRequest(t.TempDir(), "12", "main", "")
+
+	assert.Equal(t, "main", metaObj.TargetBranchName)
+	assert.Empty(t, metaObj.BaseCommitName)
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// subdirectoryRepository is a repository opened from a directory which may be below the root of its worktree
+type subdirectoryRepository struct {
+	*git.Repository
+	root string
+	path string
+}
+
+func openRepository(path string) (*subdirectoryRepository, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := git.PlainOpenWithOptions(absPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, err
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	return &subdirectoryRepository{Repository: repository, root: worktree.Filesystem.Root(), path: absPath}, nil
+}
+
+// relativePath returns the path of the file with name, relative to the root of the worktree, relative to the opened
+// directory instead. False is returned for files outside the directory
+func (repository *subdirectoryRepository) relativePath(name string) (string, bool) {
+	rel, err := filepath.Rel(repository.path, filepath.Join(repository.root, filepath.FromSlash(name)))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return rel, true
+}
+
+// resolveCommit resolves revision to a commit. Branches missing locally are looked up on the origin remote
+func resolveCommit(repository *git.Repository, revision string) (*object.Commit, error) {
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		var remoteErr error
+		hash, remoteErr = repository.ResolveRevision(plumbing.Revision("origin/" + revision))
+		if remoteErr != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", revision, err)
+		}
+	}
+
+	return repository.CommitObject(*hash)
+}
+
+// WriteRevisionFiles writes the files of revision, in the repository containing path, to dir. Only files within path
+// for which include returns true are written, and their paths in dir are relative to path
+func WriteRevisionFiles(path string, revision string, dir string, include func(name string) bool) error {
+	repository, err := openRepository(path)
+	if err != nil {
+		return err
+	}
+	commit, err := resolveCommit(repository.Repository, revision)
+	if err != nil {
+		return err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return err
+	}
+
+	return files.ForEach(func(file *object.File) error {
+		rel, ok := repository.relativePath(file.Name)
+		// Symbolic links are left out, as their contents are the paths they point to
+		if !ok || !file.Mode.IsFile() || file.Mode == filemode.Symlink || !include(rel) {
+			return nil
+		}
+
+		return writeFile(file, filepath.Join(dir, rel))
+	})
+}
+
+func writeFile(file *object.File, path string) error {
+	reader, err := file.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	output, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+	_, err = io.Copy(output, reader)
+
+	return err
+}
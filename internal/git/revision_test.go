@@ -0,0 +1,56 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteRevisionFiles(t *testing.T) {
+	dir, _, base := setUpChangesRepository(t)
+
+	for revision, content := range map[string]string{base.String(): "{}", "HEAD": `{"dependencies": {}}`} {
+		output := t.TempDir()
+		err := WriteRevisionFiles(dir, revision, output, func(name string) bool {
+			return strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".mod")
+		})
+
+		assert.NoError(t, err)
+		data, err := os.ReadFile(filepath.Join(output, "web", "package.json"))
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(data))
+		assert.FileExists(t, filepath.Join(output, "api", "go.mod"))
+		assert.NoFileExists(t, filepath.Join(output, "docs", "README.md"))
+	}
+}
+
+func TestWriteRevisionFilesSubdirectory(t *testing.T) {
+	dir, _, _ := setUpChangesRepository(t)
+	output := t.TempDir()
+
+	var names []string
+	err := WriteRevisionFiles(filepath.Join(dir, "web"), "HEAD", output, func(name string) bool {
+		names = append(names, name)
+
+		return true
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"package.json"}, names)
+	assert.FileExists(t, filepath.Join(output, "package.json"))
+}
+
+func TestWriteRevisionFilesErrors(t *testing.T) {
+	dir, _, _ := setUpChangesRepository(t)
+	include := func(string) bool { return true }
+
+	err := WriteRevisionFiles(dir, "missing", t.TempDir(), include)
+	assert.ErrorContains(t, err, "failed to resolve missing")
+
+	err = WriteRevisionFiles(t.TempDir(), "HEAD", t.TempDir(), include)
+	assert.ErrorIs(t, err, git.ErrRepositoryNotExists)
+}
@@ -0,0 +1,82 @@
+package image
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// InventoryFileName is the CycloneDX SBOM of the packages of a scanned image, which is uploaded with its other files
+const InventoryFileName = "debricked-image.cdx.json"
+
+const (
+	cycloneDXSpecVersion = "1.5"
+	locationProperty     = "debricked:image:location"
+)
+
+type cycloneDXBom struct {
+	BomFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    cycloneDXMetadata    `json:"metadata"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXComponent struct {
+	BomRef     string              `json:"bom-ref,omitempty"`
+	Type       string              `json:"type"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	Purl       string              `json:"purl,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WriteCycloneDX writes the packages of inventory as a CycloneDX SBOM of image to outputFile.
+// Packages found in several locations are listed once, with a location property for each
+func WriteCycloneDX(outputFile string, image *Image, inventory *Inventory) error {
+	componentType := "container"
+	if image.Layers == 0 {
+		componentType = "application"
+	}
+	bom := cycloneDXBom{
+		BomFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata: cycloneDXMetadata{
+			Component: cycloneDXComponent{Type: componentType, Name: image.Name, Version: image.Digest},
+		},
+		Components: []cycloneDXComponent{},
+	}
+	indexes := map[string]int{}
+	for _, pkg := range inventory.Packages() {
+		property := cycloneDXProperty{Name: locationProperty, Value: pkg.Location}
+		if i, ok := indexes[pkg.Purl]; ok {
+			bom.Components[i].Properties = append(bom.Components[i].Properties, property)
+
+			continue
+		}
+		indexes[pkg.Purl] = len(bom.Components)
+		bom.Components = append(bom.Components, cycloneDXComponent{
+			BomRef:     pkg.Purl,
+			Type:       "library",
+			Name:       pkg.Name,
+			Version:    pkg.Version,
+			Purl:       pkg.Purl,
+			Properties: []cycloneDXProperty{property},
+		})
+	}
+	content, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outputFile, content, 0600)
+}
@@ -0,0 +1,46 @@
+package image
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCycloneDX(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), InventoryFileName)
+	inventory := &Inventory{
+		OSPackages: []Package{{Purl: "pkg:deb/debian/libc6@2.36", Name: "libc6", Version: "2.36", Location: "var/lib/dpkg/status"}},
+		LanguagePackages: []Package{
+			{Purl: "pkg:npm/lodash@4.17.21", Name: "lodash", Version: "4.17.21", Location: "a/node_modules/lodash/package.json"},
+			{Purl: "pkg:npm/lodash@4.17.21", Name: "lodash", Version: "4.17.21", Location: "b/node_modules/lodash/package.json"},
+		},
+	}
+	err := WriteCycloneDX(outputFile, &Image{Name: "app:1.2.0", Digest: "sha256:0123", Layers: 2}, inventory)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	var bom cycloneDXBom
+	assert.NoError(t, json.Unmarshal(content, &bom))
+	assert.Equal(t, "CycloneDX", bom.BomFormat)
+	assert.Equal(t, cycloneDXComponent{Type: "container", Name: "app:1.2.0", Version: "sha256:0123"}, bom.Metadata.Component)
+	assert.Len(t, bom.Components, 2)
+	assert.Equal(t, "pkg:deb/debian/libc6@2.36", bom.Components[0].BomRef)
+	lodash := bom.Components[1]
+	assert.Equal(t, "library", lodash.Type)
+	assert.Equal(t, "pkg:npm/lodash@4.17.21", lodash.Purl)
+	assert.Equal(t, []cycloneDXProperty{
+		{Name: locationProperty, Value: "a/node_modules/lodash/package.json"},
+		{Name: locationProperty, Value: "b/node_modules/lodash/package.json"},
+	}, lodash.Properties)
+
+	err = WriteCycloneDX(outputFile, &Image{Name: "app.jar"}, &Inventory{})
+	assert.NoError(t, err)
+	content, err = os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"type": "application"`)
+	assert.Contains(t, string(content), `"components": []`)
+}
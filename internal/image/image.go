@@ -0,0 +1,301 @@
+package image
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	dockerManifestFile = "manifest.json"
+	ociIndexFile       = "index.json"
+	ociIndexMediaType  = "application/vnd.oci.image.index.v1+json"
+	dockerListType     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	refNameAnnotation  = "org.opencontainers.image.ref.name"
+)
+
+var (
+	UnsupportedImageErr = errors.New("unsupported image, expected an OCI image layout directory, a docker-archive tar file or a JAR file")
+	NoManifestErr       = errors.New("image contains no manifest")
+)
+
+// Image is a container image, or binary artifact, unpacked to RootFS
+type Image struct {
+	Name string
+	// Digest is the digest of the image config, or of the artifact if it is not an image
+	Digest string
+	RootFS string
+	Layers int
+}
+
+// Extract unpacks the layers of the image at source to dest, in order and respecting whiteouts.
+// source is an OCI image layout directory, a tar file of a docker-archive or OCI layout, or a JAR file which is scanned as is
+func Extract(source string, dest string) (*Image, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	rootfs := filepath.Join(dest, "rootfs")
+	if err = os.MkdirAll(rootfs, 0750); err != nil {
+		return nil, err
+	}
+	if !info.IsDir() && isJar(source) {
+		return extractJar(source, rootfs)
+	}
+
+	var l layout = tarLayout{path: source}
+	if info.IsDir() {
+		l = dirLayout{path: source}
+	}
+	manifest, err := readManifest(l)
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, tar.ErrHeader) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, UnsupportedImageErr
+	} else if err != nil {
+		return nil, err
+	}
+	if len(manifest.name) == 0 {
+		manifest.name = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	}
+	for _, layer := range manifest.layers {
+		if err = extractLayer(l, layer, rootfs); err != nil {
+			return nil, fmt.Errorf("failed to extract layer %s: %w", layer, err)
+		}
+	}
+
+	return &Image{
+		Name:   manifest.name,
+		Digest: manifest.digest,
+		RootFS: rootfs,
+		Layers: len(manifest.layers),
+	}, nil
+}
+
+// Repository returns the name of the image without tag and digest, such as registry.example.com:5000/app for
+// registry.example.com:5000/app:1.2.0
+func (image *Image) Repository() string {
+	name, _, _ := strings.Cut(image.Name, "@")
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+
+	return name
+}
+
+func extractLayer(l layout, layer string, rootfs string) error {
+	reader, err := l.open(layer)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	decompressed, err := decompress(reader)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	return applyLayer(decompressed, rootfs)
+}
+
+// layout is the storage of the manifests and blobs of an image
+type layout interface {
+	open(name string) (io.ReadCloser, error)
+}
+
+type dirLayout struct {
+	path string
+}
+
+func (l dirLayout) open(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(l.path, filepath.FromSlash(path.Clean("/"+name))))
+}
+
+// tarLayout reads blobs from a tar file, such as written by "docker save". Blobs are looked up by scanning
+// the headers of the file, which skips the content of other entries since the file is seekable
+type tarLayout struct {
+	path string
+}
+
+func (l tarLayout) open(name string) (io.ReadCloser, error) {
+	file, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	name = path.Clean("/" + name)
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			_ = file.Close()
+
+			return nil, fmt.Errorf("%s: %w", name, os.ErrNotExist)
+		} else if err != nil {
+			_ = file.Close()
+
+			return nil, err
+		}
+		if path.Clean("/"+header.Name) == name && header.Typeflag == tar.TypeReg {
+			return struct {
+				io.Reader
+				io.Closer
+			}{reader, file}, nil
+		}
+	}
+}
+
+type imageManifest struct {
+	name   string
+	digest string
+	layers []string
+}
+
+// readManifest reads the manifest.json of docker-archives, or else the index.json of OCI image layouts
+func readManifest(l layout) (imageManifest, error) {
+	var dockerManifests []struct {
+		Config   string   `json:"Config"`
+		RepoTags []string `json:"RepoTags"`
+		Layers   []string `json:"Layers"`
+	}
+	err := readJson(l, dockerManifestFile, &dockerManifests)
+	if err == nil {
+		if len(dockerManifests) == 0 {
+			return imageManifest{}, NoManifestErr
+		}
+		dockerManifest := dockerManifests[0]
+		manifest := imageManifest{layers: dockerManifest.Layers, digest: configDigest(dockerManifest.Config)}
+		if len(dockerManifest.RepoTags) > 0 {
+			manifest.name = dockerManifest.RepoTags[0]
+		}
+
+		return manifest, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return imageManifest{}, err
+	}
+
+	return readOciManifest(l)
+}
+
+// configDigest returns the digest of a docker-archive config, named either blobs/sha256/<hex> or <hex>.json
+func configDigest(config string) string {
+	dir, file := path.Split(config)
+	if strings.HasPrefix(dir, "blobs/") {
+		return path.Base(dir) + ":" + file
+	}
+
+	return "sha256:" + strings.TrimSuffix(file, ".json")
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations"`
+	Platform    *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"platform"`
+}
+
+type ociIndex struct {
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+func readOciManifest(l layout) (imageManifest, error) {
+	var index ociIndex
+	if err := readJson(l, ociIndexFile, &index); err != nil {
+		return imageManifest{}, err
+	}
+	descriptor, err := selectManifest(index)
+	if err != nil {
+		return imageManifest{}, err
+	}
+	name := descriptor.Annotations[refNameAnnotation]
+	// Multi-platform images nest the index of platform manifests
+	for descriptor.MediaType == ociIndexMediaType || descriptor.MediaType == dockerListType {
+		index = ociIndex{}
+		if err = readJson(l, blobPath(descriptor.Digest), &index); err != nil {
+			return imageManifest{}, err
+		}
+		if descriptor, err = selectManifest(index); err != nil {
+			return imageManifest{}, err
+		}
+	}
+	var manifest struct {
+		Config ociDescriptor   `json:"config"`
+		Layers []ociDescriptor `json:"layers"`
+	}
+	if err = readJson(l, blobPath(descriptor.Digest), &manifest); err != nil {
+		return imageManifest{}, err
+	}
+	layers := make([]string, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		layers = append(layers, blobPath(layer.Digest))
+	}
+
+	return imageManifest{name: name, digest: manifest.Config.Digest, layers: layers}, nil
+}
+
+// selectManifest selects the manifest matching the current architecture, or else the first one.
+// Attestation manifests, which have an unknown platform, are skipped
+func selectManifest(index ociIndex) (ociDescriptor, error) {
+	var candidates []ociDescriptor
+	for _, descriptor := range index.Manifests {
+		if descriptor.Platform != nil && descriptor.Platform.OS == "unknown" {
+			continue
+		}
+		candidates = append(candidates, descriptor)
+	}
+	if len(candidates) == 0 {
+		return ociDescriptor{}, NoManifestErr
+	}
+	for _, descriptor := range candidates {
+		if descriptor.Platform != nil && descriptor.Platform.Architecture == runtime.GOARCH {
+			return descriptor, nil
+		}
+	}
+
+	return candidates[0], nil
+}
+
+// blobPath returns the path of the blob with digest, such as blobs/sha256/<hex> for sha256:<hex>
+func blobPath(digest string) string {
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+
+	return path.Join("blobs", algorithm, encoded)
+}
+
+func readJson(l layout, name string, v interface{}) error {
+	reader, err := l.open(name)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return json.NewDecoder(reader).Decode(v)
+}
+
+// extractJar copies the JAR at source to rootfs, to scan a binary artifact which is not an image
+func extractJar(source string, rootfs string) (*Image, error) {
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if err = writeFile(filepath.Join(rootfs, filepath.Base(source)), io.TeeReader(file, hash)); err != nil {
+		return nil, err
+	}
+
+	return &Image{
+		Name:   filepath.Base(source),
+		Digest: "sha256:" + hex.EncodeToString(hash.Sum(nil)),
+		RootFS: rootfs,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package image
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func zstdBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+	encoder, err := zstd.NewWriter(nil)
+	assert.NoError(t, err)
+
+	return encoder.EncodeAll(content, nil)
+}
+
+func jsonBytes(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	content, err := json.Marshal(v)
+	assert.NoError(t, err)
+
+	return content
+}
+
+// makeTarFile writes a tar file with files, in order, to path
+func makeTarFile(t *testing.T, path string, files ...[2]interface{}) {
+	t.Helper()
+	var entries []testLayerEntry
+	for _, f := range files {
+		entries = append(entries, testLayerEntry{name: f[0].(string), typeflag: tar.TypeReg, content: string(f[1].([]byte))})
+	}
+	assert.NoError(t, os.WriteFile(path, makeLayer(t, entries...), 0600))
+}
+
+func makeZipBytes(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range files {
+		entry, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = entry.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func TestExtractDockerArchive(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "app.tar")
+	lower := gzipBytes(t, makeLayer(t, file("etc/os-release", "ID=debian\n"), file("app/old.js", "old")))
+	upper := makeLayer(t, file("app/.wh.old.js", ""), file("app/new.js", "new"))
+	manifest := []map[string]interface{}{{
+		"Config":   "0123abcd.json",
+		"RepoTags": []string{"registry.example.com:5000/app:1.2.0"},
+		"Layers":   []string{"lower/layer.tar", "upper/layer.tar"},
+	}}
+	makeTarFile(t, source,
+		[2]interface{}{"upper/layer.tar", upper},
+		[2]interface{}{"0123abcd.json", []byte("{}")},
+		[2]interface{}{"lower/layer.tar", lower},
+		[2]interface{}{"manifest.json", jsonBytes(t, manifest)},
+	)
+
+	dest := t.TempDir()
+	img, err := Extract(source, dest)
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com:5000/app:1.2.0", img.Name)
+	assert.Equal(t, "registry.example.com:5000/app", img.Repository())
+	assert.Equal(t, "sha256:0123abcd", img.Digest)
+	assert.Equal(t, 2, img.Layers)
+	assert.Equal(t, filepath.Join(dest, "rootfs"), img.RootFS)
+	assertFile(t, img.RootFS, "etc/os-release", "ID=debian\n")
+	assertFile(t, img.RootFS, "app/new.js", "new")
+	assertNotExists(t, img.RootFS, "app/old.js")
+}
+
+func writeBlob(t *testing.T, layoutDir string, content []byte) string {
+	t.Helper()
+	sum := sha256.Sum256(content)
+	encoded := hex.EncodeToString(sum[:])
+	blobDir := filepath.Join(layoutDir, "blobs", "sha256")
+	assert.NoError(t, os.MkdirAll(blobDir, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(blobDir, encoded), content, 0600))
+
+	return "sha256:" + encoded
+}
+
+func TestExtractOciLayout(t *testing.T) {
+	layoutDir := t.TempDir()
+	layer := writeBlob(t, layoutDir, zstdBytes(t, makeLayer(t, file("usr/lib/os-release", "ID=fedora\n"))))
+	otherLayer := writeBlob(t, layoutDir, gzipBytes(t, makeLayer(t, file("other", "other"))))
+	config := writeBlob(t, layoutDir, []byte("{}"))
+	manifest := writeBlob(t, layoutDir, jsonBytes(t, map[string]interface{}{
+		"config": map[string]string{"digest": config},
+		"layers": []map[string]string{{"digest": layer}},
+	}))
+	otherManifest := writeBlob(t, layoutDir, jsonBytes(t, map[string]interface{}{
+		"layers": []map[string]string{{"digest": otherLayer}},
+	}))
+	platformIndex := writeBlob(t, layoutDir, jsonBytes(t, map[string]interface{}{
+		"manifests": []map[string]interface{}{
+			{"digest": otherManifest, "platform": map[string]string{"os": "unknown", "architecture": "unknown"}},
+			{"digest": otherManifest, "platform": map[string]string{"os": "linux", "architecture": "not-" + runtime.GOARCH}},
+			{"digest": manifest, "platform": map[string]string{"os": "linux", "architecture": runtime.GOARCH}},
+		},
+	}))
+	index := map[string]interface{}{
+		"manifests": []map[string]interface{}{{
+			"mediaType":   ociIndexMediaType,
+			"digest":      platformIndex,
+			"annotations": map[string]string{refNameAnnotation: "app:1.2.0"},
+		}},
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(layoutDir, "index.json"), jsonBytes(t, index), 0600))
+
+	img, err := Extract(layoutDir, t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, "app:1.2.0", img.Name)
+	assert.Equal(t, config, img.Digest)
+	assert.Equal(t, 1, img.Layers)
+	assertFile(t, img.RootFS, "usr/lib/os-release", "ID=fedora\n")
+	assertNotExists(t, img.RootFS, "other")
+}
+
+func TestExtractJar(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "app.jar")
+	content := makeZipBytes(t, map[string][]byte{"META-INF/MANIFEST.MF": []byte("Manifest-Version: 1.0\n")})
+	assert.NoError(t, os.WriteFile(source, content, 0600))
+
+	img, err := Extract(source, t.TempDir())
+	assert.NoError(t, err)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, "app.jar", img.Name)
+	assert.Equal(t, "sha256:"+hex.EncodeToString(sum[:]), img.Digest)
+	assert.Equal(t, 0, img.Layers)
+	assertFile(t, img.RootFS, "app.jar", string(content))
+}
+
+func TestExtractUnsupported(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "image.tar")
+	assert.NoError(t, os.WriteFile(source, bytes.Repeat([]byte("not a tar file "), 100), 0600))
+	_, err := Extract(source, t.TempDir())
+	assert.ErrorIs(t, err, UnsupportedImageErr)
+
+	_, err = Extract(t.TempDir(), t.TempDir())
+	assert.ErrorIs(t, err, UnsupportedImageErr)
+
+	_, err = Extract(filepath.Join(t.TempDir(), "missing.tar"), t.TempDir())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestExtractMissingLayer(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "app.tar")
+	manifest := []map[string]interface{}{{"Config": "abc.json", "Layers": []string{"missing/layer.tar"}}}
+	makeTarFile(t, source, [2]interface{}{"manifest.json", jsonBytes(t, manifest)})
+
+	_, err := Extract(source, t.TempDir())
+	assert.ErrorContains(t, err, "failed to extract layer missing/layer.tar")
+}
+
+func TestRepository(t *testing.T) {
+	cases := map[string]string{
+		"app":                                "app",
+		"app:1.2.0":                          "app",
+		"localhost:5000/team/app":            "localhost:5000/team/app",
+		"localhost:5000/team/app:latest":     "localhost:5000/team/app",
+		"app@sha256:0123abcd":                "app",
+		"app.jar":                            "app.jar",
+		"registry.example.com/app:1@sha256:": "registry.example.com/app",
+	}
+	for name, repository := range cases {
+		assert.Equal(t, repository, (&Image{Name: name}).Repository(), name)
+	}
+}
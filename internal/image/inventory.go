@@ -0,0 +1,122 @@
+package image
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Package is an installed package found in an image
+type Package struct {
+	Purl    string
+	Name    string
+	Version string
+	// Location is the path of the package database or artifact the package was found in, relative to the rootfs
+	Location string
+}
+
+type Inventory struct {
+	OS         OSRelease
+	OSPackages []Package
+	// LanguagePackages are the packages of language ecosystems, such as JARs, Python distributions and npm packages
+	LanguagePackages []Package
+	// Warnings are package databases which could not be read
+	Warnings []string
+}
+
+// NewInventory finds the OS package databases and language artifacts of rootfs
+func NewInventory(rootfs string) (*Inventory, error) {
+	inventory := &Inventory{OS: readOSRelease(rootfs)}
+	for _, database := range osPackageDatabases {
+		location := filepath.Join(rootfs, filepath.FromSlash(database.path))
+		if _, err := os.Stat(location); err != nil {
+			continue
+		}
+		packages, err := database.read(location, inventory.OS)
+		if err != nil {
+			inventory.Warnings = append(inventory.Warnings, fmt.Sprintf("failed to read %s: %s", database.path, err.Error()))
+
+			continue
+		}
+		for i := range packages {
+			packages[i].Location = database.path
+		}
+		inventory.OSPackages = append(inventory.OSPackages, packages...)
+	}
+
+	err := filepath.WalkDir(rootfs, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		relPath, _ := filepath.Rel(rootfs, path)
+		relPath = filepath.ToSlash(relPath)
+		packages, err := readLanguagePackages(path, relPath)
+		if err != nil {
+			inventory.Warnings = append(inventory.Warnings, fmt.Sprintf("failed to read %s: %s", relPath, err.Error()))
+
+			return nil
+		}
+		inventory.LanguagePackages = append(inventory.LanguagePackages, packages...)
+
+		return nil
+	})
+	sortPackages(inventory.OSPackages)
+	sortPackages(inventory.LanguagePackages)
+
+	return inventory, err
+}
+
+// Packages returns all packages of the inventory
+func (inventory *Inventory) Packages() []Package {
+	return append(append([]Package{}, inventory.OSPackages...), inventory.LanguagePackages...)
+}
+
+func sortPackages(packages []Package) {
+	sort.SliceStable(packages, func(i, j int) bool {
+		if packages[i].Purl != packages[j].Purl {
+			return packages[i].Purl < packages[j].Purl
+		}
+
+		return packages[i].Location < packages[j].Location
+	})
+}
+
+// newPurl formats a package URL, see https://github.com/package-url/purl-spec
+func newPurl(purlType string, namespace string, name string, version string, qualifiers map[string]string) string {
+	purl := "pkg:" + purlType + "/"
+	if len(namespace) > 0 {
+		var segments []string
+		for _, segment := range strings.Split(namespace, "/") {
+			segments = append(segments, escapePurlSegment(segment))
+		}
+		purl += strings.Join(segments, "/") + "/"
+	}
+	purl += escapePurlSegment(name)
+	if len(version) > 0 {
+		purl += "@" + escapePurlSegment(version)
+	}
+	var keys []string
+	for key, value := range qualifiers {
+		if len(value) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		separator := "&"
+		if i == 0 {
+			separator = "?"
+		}
+		purl += separator + key + "=" + url.QueryEscape(qualifiers[key])
+	}
+
+	return purl
+}
+
+func escapePurlSegment(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+}
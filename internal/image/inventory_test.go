@@ -0,0 +1,127 @@
+package image
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dpkgStatus = `Package: libc6
+Status: install ok installed
+Architecture: amd64
+Version: 2.36-9+deb12u4
+Description: GNU C Library
+ Contains the standard libraries.
+
+Package: removed
+Status: deinstall ok config-files
+Architecture: amd64
+Version: 1.0
+`
+
+const apkInstalled = `C:Q1abc=
+P:musl
+V:1.2.4-r2
+A:x86_64
+o:musl
+
+P:busybox
+V:1.36.1-r15
+A:x86_64
+`
+
+func writeRootfsFile(t *testing.T, rootfs string, name string, content []byte) {
+	t.Helper()
+	path := filepath.Join(rootfs, filepath.FromSlash(name))
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+	assert.NoError(t, os.WriteFile(path, content, 0600))
+}
+
+func TestNewInventory(t *testing.T) {
+	rootfs := t.TempDir()
+	writeRootfsFile(t, rootfs, "etc/os-release", []byte("NAME=\"Debian GNU/Linux\"\nID=debian\nVERSION_ID=\"12\"\n"))
+	writeRootfsFile(t, rootfs, "var/lib/dpkg/status", []byte(dpkgStatus))
+	writeRootfsFile(t, rootfs, "var/lib/dpkg/status.d/tzdata", []byte("Package: tzdata\nVersion: 2024a-0+deb12u1\nArchitecture: all\n"))
+	writeRootfsFile(t, rootfs, "var/lib/dpkg/status.d/tzdata.md5sums", []byte("0123 usr/share/zoneinfo/UTC\n"))
+	writeRootfsFile(t, rootfs, "var/lib/rpm/Packages", []byte("Berkeley DB"))
+	writeRootfsFile(
+		t,
+		rootfs,
+		"usr/lib/python3/site-packages/Typing_Extensions-4.9.0.dist-info/METADATA",
+		[]byte("Metadata-Version: 2.1\nName: Typing_Extensions\nVersion: 4.9.0\n\nName: not a header\n"),
+	)
+	writeRootfsFile(t, rootfs, "usr/lib/python3/site-packages/six-1.16.0.egg-info/PKG-INFO", []byte("Name: six\nVersion: 1.16.0\n"))
+	writeRootfsFile(t, rootfs, "app/node_modules/lodash/package.json", []byte(`{"name": "lodash", "version": "4.17.21"}`))
+	writeRootfsFile(t, rootfs, "app/node_modules/@types/node/package.json", []byte(`{"name": "@types/node", "version": "20.11.0"}`))
+	writeRootfsFile(t, rootfs, "app/node_modules/lodash/fp/package.json", []byte(`{"main": "../fp.js"}`))
+	writeRootfsFile(t, rootfs, "app/package.json", []byte(`{"name": "app", "version": "1.0.0"}`))
+	writeRootfsFile(t, rootfs, "app/node_modules/broken/package.json", []byte(`{`))
+	nested := makeZipBytes(t, map[string][]byte{
+		"META-INF/maven/org.slf4j/slf4j-api/pom.properties": []byte("#Generated\ngroupId=org.slf4j\nartifactId=slf4j-api\nversion=2.0.9\n"),
+	})
+	writeRootfsFile(t, rootfs, "opt/app.jar", makeZipBytes(t, map[string][]byte{
+		"META-INF/maven/com.example/app/pom.properties": []byte("groupId=com.example\nartifactId=app\nversion=1.2.0\n"),
+		"BOOT-INF/lib/slf4j-api-2.0.9.jar":              nested,
+	}))
+	writeRootfsFile(t, rootfs, "opt/not-a-zip.jar", []byte("not a zip"))
+
+	inventory, err := NewInventory(rootfs)
+	assert.NoError(t, err)
+	assert.Equal(t, OSRelease{ID: "debian", VersionID: "12"}, inventory.OS)
+	assert.Equal(t, []Package{
+		{
+			Purl:     "pkg:deb/debian/libc6@2.36-9+deb12u4?arch=amd64&distro=debian-12",
+			Name:     "libc6",
+			Version:  "2.36-9+deb12u4",
+			Location: "var/lib/dpkg/status",
+		},
+		{
+			Purl:     "pkg:deb/debian/tzdata@2024a-0+deb12u1?arch=all&distro=debian-12",
+			Name:     "tzdata",
+			Version:  "2024a-0+deb12u1",
+			Location: "var/lib/dpkg/status.d",
+		},
+	}, inventory.OSPackages)
+
+	var purls []string
+	for _, pkg := range inventory.LanguagePackages {
+		purls = append(purls, pkg.Purl)
+	}
+	assert.Equal(t, []string{
+		"pkg:maven/com.example/app@1.2.0",
+		"pkg:maven/org.slf4j/slf4j-api@2.0.9",
+		"pkg:npm/%40types/node@20.11.0",
+		"pkg:npm/lodash@4.17.21",
+		"pkg:pypi/six@1.16.0",
+		"pkg:pypi/typing-extensions@4.9.0",
+	}, purls)
+	assert.Equal(t, "opt/app.jar!/BOOT-INF/lib/slf4j-api-2.0.9.jar", inventory.LanguagePackages[1].Location)
+	assert.Equal(t, "@types/node", inventory.LanguagePackages[2].Name)
+
+	assert.Len(t, inventory.Warnings, 2)
+	assert.Contains(t, inventory.Warnings[0], "var/lib/rpm/Packages")
+	assert.Contains(t, inventory.Warnings[1], "app/node_modules/broken/package.json")
+	assert.Len(t, inventory.Packages(), 8)
+}
+
+func TestNewInventoryApk(t *testing.T) {
+	rootfs := t.TempDir()
+	writeRootfsFile(t, rootfs, "etc/os-release", []byte("ID=alpine\nVERSION_ID=3.19.1\n"))
+	writeRootfsFile(t, rootfs, "lib/apk/db/installed", []byte(apkInstalled))
+
+	inventory, err := NewInventory(rootfs)
+	assert.NoError(t, err)
+	assert.Len(t, inventory.OSPackages, 2)
+	assert.Equal(t, "pkg:apk/alpine/busybox@1.36.1-r15?arch=x86_64&distro=alpine-3.19.1", inventory.OSPackages[0].Purl)
+	assert.Equal(t, "pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.19.1", inventory.OSPackages[1].Purl)
+	assert.Equal(t, "lib/apk/db/installed", inventory.OSPackages[1].Location)
+}
+
+func TestNewPurl(t *testing.T) {
+	assert.Equal(t, "pkg:generic/name", newPurl("generic", "", "name", "", nil))
+	assert.Equal(t, "pkg:deb/debian/curl@1:7.88.1?arch=amd64", newPurl("deb", "debian", "curl", "1:7.88.1", map[string]string{"arch": "amd64", "distro": ""}))
+	assert.Equal(t, "pkg:npm/%40scope/a%20b@1.0.0", newPurl("npm", "@scope", "a b", "1.0.0", nil))
+	assert.Equal(t, "pkg:golang/github.com/debricked/cli@v1", newPurl("golang", "github.com/debricked", "cli", "v1", nil))
+}
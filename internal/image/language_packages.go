@@ -0,0 +1,212 @@
+package image
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/fingerprint"
+)
+
+const (
+	// maxNestedJarSize limits the size of JARs within JARs, which are read into memory
+	maxNestedJarSize = 256 * 1024 * 1024
+	maxJarDepth      = 2
+)
+
+var (
+	jarEndings         = []string{".jar", ".war", ".ear"}
+	pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+)
+
+// readLanguagePackages returns the packages of the file at path, which is located at relPath in the rootfs
+func readLanguagePackages(path string, relPath string) ([]Package, error) {
+	dir, base := pathDirBase(relPath)
+	switch {
+	case (base == "METADATA" && strings.HasSuffix(dir, ".dist-info")) || (base == "PKG-INFO" && strings.HasSuffix(dir, ".egg-info")):
+		return readPythonMetadata(path, relPath)
+	case base == "package.json" && isNodeModule(dir):
+		return readNpmPackage(path, relPath)
+	case isJar(path):
+		reader, err := zip.OpenReader(path)
+		if err != nil {
+			// Not all files with JAR endings are archives, which is left for fingerprinting to report
+			return nil, nil
+		}
+		defer reader.Close()
+
+		return readJarPackages(&reader.Reader, relPath, 1)
+	}
+
+	return nil, nil
+}
+
+func pathDirBase(relPath string) (string, string) {
+	dir, base := path.Split(relPath)
+
+	return strings.TrimSuffix(dir, "/"), base
+}
+
+// isNodeModule returns true if dir is a package installed in node_modules, such as node_modules/@types/node
+func isNodeModule(dir string) bool {
+	parent, _ := pathDirBase(dir)
+	if strings.HasPrefix(path.Base(parent), "@") {
+		parent, _ = pathDirBase(parent)
+	}
+
+	return path.Base(parent) == "node_modules"
+}
+
+func isJar(path string) bool {
+	for _, ending := range jarEndings {
+		if strings.HasSuffix(strings.ToLower(path), ending) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readPythonMetadata reads the name and version headers of the METADATA or PKG-INFO of a Python distribution
+func readPythonMetadata(path string, relPath string) ([]Package, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	headers := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() && len(scanner.Text()) > 0 {
+		if key, value, found := strings.Cut(scanner.Text(), ":"); found {
+			if _, ok := headers[key]; !ok {
+				headers[key] = strings.TrimSpace(value)
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	name, version := headers["Name"], headers["Version"]
+	if len(name) == 0 {
+		return nil, nil
+	}
+	name = pypiNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+
+	return []Package{{
+		Purl:     newPurl("pypi", "", name, version, nil),
+		Name:     name,
+		Version:  version,
+		Location: relPath,
+	}}, nil
+}
+
+func readNpmPackage(path string, relPath string) ([]Package, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var packageJson struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err = json.Unmarshal(content, &packageJson); err != nil {
+		return nil, err
+	}
+	if len(packageJson.Name) == 0 {
+		return nil, nil
+	}
+	namespace, name, found := strings.Cut(packageJson.Name, "/")
+	if !found {
+		namespace, name = "", packageJson.Name
+	}
+
+	return []Package{{
+		Purl:     newPurl("npm", namespace, name, packageJson.Version, nil),
+		Name:     packageJson.Name,
+		Version:  packageJson.Version,
+		Location: relPath,
+	}}, nil
+}
+
+// readJarPackages returns the Maven packages of the pom.properties of a JAR, and of the JARs it contains,
+// such as the dependencies in BOOT-INF/lib of Spring Boot fat JARs
+func readJarPackages(reader *zip.Reader, relPath string, depth int) ([]Package, error) {
+	var packages []Package
+	for _, file := range reader.File {
+		switch {
+		case strings.HasPrefix(file.Name, "META-INF/maven/") && strings.HasSuffix(file.Name, "/pom.properties"):
+			pkg, err := readPomProperties(file)
+			if err != nil {
+				return nil, err
+			}
+			if pkg != nil {
+				pkg.Location = relPath
+				packages = append(packages, *pkg)
+			}
+		case isJar(file.Name) && depth < maxJarDepth && file.UncompressedSize64 <= maxNestedJarSize:
+			nested, err := readNestedJar(file)
+			if err != nil {
+				continue
+			}
+			nestedPackages, err := readJarPackages(nested, relPath+fingerprint.ArchivePathSeparator+file.Name, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			packages = append(packages, nestedPackages...)
+		}
+	}
+
+	return packages, nil
+}
+
+func readNestedJar(file *zip.File) (*zip.Reader, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(io.LimitReader(reader, maxNestedJarSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(content), int64(len(content)))
+}
+
+func readPomProperties(file *zip.File) (*Package, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	properties := map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, found := strings.Cut(line, "="); found {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	groupId, artifactId, version := properties["groupId"], properties["artifactId"], properties["version"]
+	if len(groupId) == 0 || len(artifactId) == 0 {
+		return nil, nil
+	}
+
+	return &Package{
+		Purl:    newPurl("maven", groupId, artifactId, version, nil),
+		Name:    groupId + ":" + artifactId,
+		Version: version,
+	}, nil
+}
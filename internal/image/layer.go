@@ -0,0 +1,177 @@
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	// opaqueWhiteout marks that the contents of a directory in lower layers are hidden
+	opaqueWhiteout = whiteoutPrefix + whiteoutPrefix + ".opq"
+)
+
+// decompress detects the compression of a layer from its magic bytes, layers may be gzip, zstd or not compressed
+func decompress(r io.Reader) (io.ReadCloser, error) {
+	reader := bufio.NewReader(r)
+	magic, _ := reader.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		return gzip.NewReader(reader)
+	case bytes.HasPrefix(magic, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		decoder, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		return decoder.IOReadCloser(), nil
+	}
+
+	return io.NopCloser(reader), nil
+}
+
+// applyLayer applies the tar layer read by r on top of rootfs.
+// Only directories and regular files are written, symbolic links and special files are skipped
+// so that no path written can resolve outside rootfs
+func applyLayer(r io.Reader, rootfs string) error {
+	reader := tar.NewReader(r)
+	// Paths added by this layer, which are kept by opaque whiteouts regardless of the entry order
+	added := map[string]bool{}
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		name := path.Clean("/" + header.Name)
+		if name == "/" {
+			continue
+		}
+		dir, base := path.Split(name)
+		target := filepath.Join(rootfs, filepath.FromSlash(name))
+		switch {
+		case base == opaqueWhiteout:
+			if err = removeChildren(filepath.Join(rootfs, filepath.FromSlash(dir)), added); err != nil {
+				return err
+			}
+		case strings.HasPrefix(base, whiteoutPrefix):
+			whiteout := filepath.Join(rootfs, filepath.FromSlash(dir), strings.TrimPrefix(base, whiteoutPrefix))
+			if err = os.RemoveAll(whiteout); err != nil {
+				return err
+			}
+		case header.Typeflag == tar.TypeDir:
+			if err = makeParents(target); err != nil {
+				return err
+			}
+			added[target] = true
+		case header.Typeflag == tar.TypeReg:
+			if err = writeFile(target, reader); err != nil {
+				return err
+			}
+			added[target] = true
+		case header.Typeflag == tar.TypeLink:
+			source := filepath.Join(rootfs, filepath.FromSlash(path.Clean("/"+header.Linkname)))
+			if err = linkFile(source, target); err != nil {
+				return err
+			}
+			added[target] = true
+		}
+	}
+}
+
+// removeChildren removes the children of dir which were not added by the current layer
+func removeChildren(dir string, added map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		child := filepath.Join(dir, entry.Name())
+		if added[child] {
+			if entry.IsDir() {
+				if err = removeChildren(child, added); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+		if err = os.RemoveAll(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// makeParents creates dir and its parents. A layer may replace a file of a lower layer by a directory,
+// so the closest existing ancestor is removed if it is not a directory
+func makeParents(dir string) error {
+	for ancestor := dir; ancestor != filepath.Dir(ancestor); ancestor = filepath.Dir(ancestor) {
+		info, err := os.Lstat(ancestor)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			if err = os.Remove(ancestor); err != nil {
+				return err
+			}
+		}
+
+		break
+	}
+
+	return os.MkdirAll(dir, 0750)
+}
+
+// prepareFile creates the parent directories of target, and removes whatever is at target
+func prepareFile(target string) error {
+	if err := makeParents(filepath.Dir(target)); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(target)
+}
+
+func writeFile(target string, r io.Reader) error {
+	if err := prepareFile(target); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(file, r); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// linkFile hard links target to source. Links to files which were skipped, such as symbolic links, are skipped
+func linkFile(source string, target string) error {
+	info, err := os.Lstat(source)
+	if err != nil || !info.Mode().IsRegular() {
+		return nil
+	}
+	if err = prepareFile(target); err != nil {
+		return err
+	}
+
+	return os.Link(source, target)
+}
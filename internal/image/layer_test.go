@@ -0,0 +1,124 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLayerEntry struct {
+	name     string
+	typeflag byte
+	content  string
+	linkname string
+}
+
+func makeLayer(t *testing.T, entries ...testLayerEntry) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Typeflag: entry.typeflag, Linkname: entry.linkname, Mode: 0644}
+		if entry.typeflag == tar.TypeReg {
+			header.Size = int64(len(entry.content))
+		}
+		assert.NoError(t, writer.WriteHeader(header))
+		if entry.typeflag == tar.TypeReg {
+			_, err := writer.Write([]byte(entry.content))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func file(name string, content string) testLayerEntry {
+	return testLayerEntry{name: name, typeflag: tar.TypeReg, content: content}
+}
+
+func dir(name string) testLayerEntry {
+	return testLayerEntry{name: name, typeflag: tar.TypeDir}
+}
+
+func assertFile(t *testing.T, rootfs string, name string, content string) {
+	t.Helper()
+	actual, err := os.ReadFile(filepath.Join(rootfs, filepath.FromSlash(name)))
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(actual))
+}
+
+func assertNotExists(t *testing.T, rootfs string, name string) {
+	t.Helper()
+	_, err := os.Lstat(filepath.Join(rootfs, filepath.FromSlash(name)))
+	assert.ErrorIs(t, err, os.ErrNotExist, "failed to assert that %s does not exist", name)
+}
+
+func TestApplyLayer(t *testing.T) {
+	rootfs := t.TempDir()
+	err := applyLayer(bytes.NewReader(makeLayer(t,
+		dir("etc/"),
+		file("etc/hostname", "base"),
+		file("etc/removed", "removed"),
+		file("app/lib/old.js", "old"),
+		file("app/lib/kept/old.js", "old"),
+		file("app/file-then-dir", "file"),
+		testLayerEntry{name: "app/link", typeflag: tar.TypeSymlink, linkname: "/etc/hostname"},
+		testLayerEntry{name: "app/hardlink", typeflag: tar.TypeLink, linkname: "etc/hostname"},
+		testLayerEntry{name: "dev/null", typeflag: tar.TypeChar},
+	)), rootfs)
+	assert.NoError(t, err)
+	assertFile(t, rootfs, "etc/hostname", "base")
+	assertFile(t, rootfs, "app/hardlink", "base")
+	assertNotExists(t, rootfs, "app/link")
+	assertNotExists(t, rootfs, "dev/null")
+
+	err = applyLayer(bytes.NewReader(makeLayer(t,
+		file("etc/hostname", "upper"),
+		file("etc/.wh.removed", ""),
+		file("app/lib/new.js", "new"),
+		dir("app/lib/kept/"),
+		file("app/lib/kept/new.js", "new"),
+		file("app/lib/.wh..wh..opq", ""),
+		file("app/file-then-dir/file", "file"),
+		file("../../escaped", "escaped"),
+	)), rootfs)
+	assert.NoError(t, err)
+	assertFile(t, rootfs, "etc/hostname", "upper")
+	assertFile(t, rootfs, "app/hardlink", "base")
+	assertNotExists(t, rootfs, "etc/removed")
+	assertNotExists(t, rootfs, "app/lib/old.js")
+	assertNotExists(t, rootfs, "app/lib/kept/old.js")
+	assertFile(t, rootfs, "app/lib/new.js", "new")
+	assertFile(t, rootfs, "app/lib/kept/new.js", "new")
+	assertFile(t, rootfs, "app/file-then-dir/file", "file")
+	assertFile(t, rootfs, "escaped", "escaped")
+	assertNotExists(t, filepath.Dir(rootfs), "escaped")
+}
+
+func TestApplyLayerInvalid(t *testing.T) {
+	err := applyLayer(bytes.NewReader([]byte("not a tar file, but long enough to not be an empty archive")), t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestDecompress(t *testing.T) {
+	layer := makeLayer(t, file("a", "content"))
+	for name, content := range map[string][]byte{
+		"tar":  layer,
+		"gzip": gzipBytes(t, layer),
+		"zstd": zstdBytes(t, layer),
+	} {
+		t.Run(name, func(t *testing.T) {
+			reader, err := decompress(bytes.NewReader(content))
+			assert.NoError(t, err)
+			rootfs := t.TempDir()
+			assert.NoError(t, applyLayer(reader, rootfs))
+			assert.NoError(t, reader.Close())
+			assertFile(t, rootfs, "a", "content")
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package image
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OSRelease is the distribution of an image, read from os-release
+type OSRelease struct {
+	ID        string
+	VersionID string
+}
+
+// distro returns the distro qualifier of purls, such as debian-12
+func (release OSRelease) distro() string {
+	if len(release.VersionID) == 0 {
+		return release.ID
+	}
+
+	return release.ID + "-" + release.VersionID
+}
+
+func readOSRelease(rootfs string) OSRelease {
+	var release OSRelease
+	for _, path := range []string{"etc/os-release", "usr/lib/os-release"} {
+		file, err := os.Open(filepath.Join(rootfs, filepath.FromSlash(path)))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			key, value, _ := strings.Cut(scanner.Text(), "=")
+			value = strings.Trim(value, `"'`)
+			switch key {
+			case "ID":
+				release.ID = value
+			case "VERSION_ID":
+				release.VersionID = value
+			}
+		}
+		_ = file.Close()
+
+		return release
+	}
+
+	return release
+}
+
+type osPackageDatabase struct {
+	// path is relative to the rootfs
+	path string
+	read func(location string, release OSRelease) ([]Package, error)
+}
+
+var osPackageDatabases = []osPackageDatabase{
+	{path: "var/lib/dpkg/status", read: readDpkgStatus},
+	{path: "var/lib/dpkg/status.d", read: readDpkgStatusDir},
+	{path: "lib/apk/db/installed", read: readApkInstalled},
+	{path: "var/lib/rpm/rpmdb.sqlite", read: readRpmDatabase},
+	{path: "usr/lib/sysimage/rpm/rpmdb.sqlite", read: readRpmDatabase},
+	{path: "var/lib/rpm/Packages", read: readUnsupportedRpmDatabase},
+}
+
+// readStanzas reads the "Key: value" stanzas separated by empty lines of dpkg and apk databases.
+// Continuation lines of multi-line values are ignored
+func readStanzas(r io.Reader, fn func(stanza map[string]string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	stanza := map[string]string{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			if len(stanza) > 0 {
+				fn(stanza)
+				stanza = map[string]string{}
+			}
+
+			continue
+		}
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if key, value, found := strings.Cut(line, ":"); found {
+			stanza[key] = strings.TrimSpace(value)
+		}
+	}
+	if len(stanza) > 0 {
+		fn(stanza)
+	}
+
+	return scanner.Err()
+}
+
+func readDpkgStatus(location string, release OSRelease) ([]Package, error) {
+	file, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var packages []Package
+	err = readStanzas(file, func(stanza map[string]string) {
+		// Removed packages remain in the database with their configuration files
+		if status, ok := stanza["Status"]; ok && !strings.HasSuffix(status, " installed") {
+			return
+		}
+		name, version := stanza["Package"], stanza["Version"]
+		if len(name) == 0 {
+			return
+		}
+		qualifiers := map[string]string{"arch": stanza["Architecture"], "distro": release.distro()}
+		packages = append(packages, Package{
+			Purl:    newPurl("deb", osNamespace(release, "debian"), name, version, qualifiers),
+			Name:    name,
+			Version: version,
+		})
+	})
+
+	return packages, err
+}
+
+// readDpkgStatusDir reads the status files of distroless images, which have one file per package
+func readDpkgStatusDir(location string, release OSRelease) ([]Package, error) {
+	entries, err := os.ReadDir(location)
+	if err != nil {
+		return nil, err
+	}
+	var packages []Package
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".md5sums") {
+			continue
+		}
+		filePackages, err := readDpkgStatus(filepath.Join(location, entry.Name()), release)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, filePackages...)
+	}
+
+	return packages, nil
+}
+
+func readApkInstalled(location string, release OSRelease) ([]Package, error) {
+	file, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var packages []Package
+	err = readStanzas(file, func(stanza map[string]string) {
+		name, version := stanza["P"], stanza["V"]
+		if len(name) == 0 {
+			return
+		}
+		qualifiers := map[string]string{"arch": stanza["A"], "distro": release.distro()}
+		packages = append(packages, Package{
+			Purl:    newPurl("apk", osNamespace(release, "alpine"), name, version, qualifiers),
+			Name:    name,
+			Version: version,
+		})
+	})
+
+	return packages, err
+}
+
+// osNamespace returns the purl namespace of OS packages, which is the distribution id
+func osNamespace(release OSRelease, fallback string) string {
+	if len(release.ID) == 0 {
+		return fallback
+	}
+
+	return release.ID
+}
@@ -0,0 +1,111 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strconv"
+)
+
+const (
+	rpmTagName    = 1000
+	rpmTagVersion = 1001
+	rpmTagRelease = 1002
+	rpmTagEpoch   = 1003
+	rpmTagArch    = 1022
+	rpmTypeInt32  = 4
+	rpmTypeString = 6
+	// rpmIndexEntrySize is the size of an index entry: its tag, type, offset and count
+	rpmIndexEntrySize = 16
+	// rpmPubKeyName is the name of the pseudo packages of imported signing keys
+	rpmPubKeyName = "gpg-pubkey"
+)
+
+var (
+	errInvalidRpmHeader       = errors.New("invalid rpm header")
+	UnsupportedRpmDatabaseErr = errors.New("Berkeley DB rpm databases are not supported, only rpmdb.sqlite")
+)
+
+func readRpmDatabase(location string, release OSRelease) ([]Package, error) {
+	db, err := openSqlite(location)
+	if err != nil {
+		return nil, err
+	}
+	var packages []Package
+	err = db.tableRows("Packages", func(columns []interface{}) error {
+		if len(columns) < 2 {
+			return errInvalidRpmHeader
+		}
+		blob, ok := columns[1].([]byte)
+		if !ok {
+			return errInvalidRpmHeader
+		}
+		tags, err := readRpmHeaderBlob(blob)
+		if err != nil {
+			return err
+		}
+		name := tags[rpmTagName]
+		if len(name) == 0 || name == rpmPubKeyName {
+			return nil
+		}
+		version := tags[rpmTagVersion]
+		if len(tags[rpmTagRelease]) > 0 {
+			version += "-" + tags[rpmTagRelease]
+		}
+		qualifiers := map[string]string{
+			"arch":   tags[rpmTagArch],
+			"epoch":  tags[rpmTagEpoch],
+			"distro": release.distro(),
+		}
+		packages = append(packages, Package{
+			Purl:    newPurl("rpm", osNamespace(release, "redhat"), name, version, qualifiers),
+			Name:    name,
+			Version: version,
+		})
+
+		return nil
+	})
+
+	return packages, err
+}
+
+func readUnsupportedRpmDatabase(string, OSRelease) ([]Package, error) {
+	return nil, UnsupportedRpmDatabaseErr
+}
+
+// readRpmHeaderBlob reads the name, version, release, epoch and arch tags of a header blob, as stored by rpm
+// without the magic of the header structure: the number of index entries, the data size, the entries and the data
+func readRpmHeaderBlob(blob []byte) (map[int]string, error) {
+	if len(blob) < 8 {
+		return nil, errInvalidRpmHeader
+	}
+	entries := int(binary.BigEndian.Uint32(blob[0:4]))
+	dataSize := int(binary.BigEndian.Uint32(blob[4:8]))
+	dataStart := 8 + entries*rpmIndexEntrySize
+	if entries < 0 || dataSize < 0 || dataStart+dataSize > len(blob) {
+		return nil, errInvalidRpmHeader
+	}
+	data := blob[dataStart : dataStart+dataSize]
+	tags := map[int]string{}
+	for i := 0; i < entries; i++ {
+		entry := blob[8+i*rpmIndexEntrySize:]
+		tag := int(binary.BigEndian.Uint32(entry[0:4]))
+		tagType := binary.BigEndian.Uint32(entry[4:8])
+		offset := int(binary.BigEndian.Uint32(entry[8:12]))
+		if offset < 0 || offset >= len(data) {
+			continue
+		}
+		switch {
+		case tag == rpmTagEpoch && tagType == rpmTypeInt32 && offset+4 <= len(data):
+			tags[tag] = strconv.FormatUint(uint64(binary.BigEndian.Uint32(data[offset:])), 10)
+		case (tag == rpmTagName || tag == rpmTagVersion || tag == rpmTagRelease || tag == rpmTagArch) && tagType == rpmTypeString:
+			value := data[offset:]
+			if end := bytes.IndexByte(value, 0); end >= 0 {
+				value = value[:end]
+			}
+			tags[tag] = string(value)
+		}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,132 @@
+package image
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testdata/rpmdb.sqlite has 1 KiB pages and a Packages table of bash, a gpg-pubkey, openssl-libs with an epoch and
+// a blob overflowing its page, and 60 filler packages spanning several pages
+func TestReadRpmDatabase(t *testing.T) {
+	packages, err := readRpmDatabase(filepath.Join("testdata", "rpmdb.sqlite"), OSRelease{ID: "fedora", VersionID: "38"})
+	assert.NoError(t, err)
+	assert.Len(t, packages, 62)
+	assert.Equal(t, Package{
+		Purl:    "pkg:rpm/fedora/bash@5.2.15-3.fc38?arch=x86_64&distro=fedora-38",
+		Name:    "bash",
+		Version: "5.2.15-3.fc38",
+	}, packages[0])
+	assert.Equal(t, "pkg:rpm/fedora/openssl-libs@3.0.9-2.fc38?arch=x86_64&distro=fedora-38&epoch=1", packages[1].Purl)
+	assert.Equal(t, "filler59", packages[61].Name)
+	assert.Equal(t, "pkg:rpm/fedora/filler59@1.0-1?arch=noarch&distro=fedora-38", packages[61].Purl)
+}
+
+func TestReadRpmDatabaseInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rpmdb.sqlite")
+	assert.NoError(t, os.WriteFile(path, []byte("not a database"), 0600))
+	_, err := readRpmDatabase(path, OSRelease{})
+	assert.ErrorIs(t, err, errInvalidSqlite)
+
+	content, err := os.ReadFile(filepath.Join("testdata", "rpmdb.sqlite"))
+	assert.NoError(t, err)
+	// Point the first cell of the schema page past the end of the page
+	binary.BigEndian.PutUint16(content[sqliteHeaderSize+8:], 0xffff)
+	assert.NoError(t, os.WriteFile(path, content, 0600))
+	_, err = readRpmDatabase(path, OSRelease{})
+	assert.ErrorIs(t, err, errInvalidSqlite)
+
+	_, err = readUnsupportedRpmDatabase(path, OSRelease{})
+	assert.ErrorIs(t, err, UnsupportedRpmDatabaseErr)
+}
+
+func TestReadRpmHeaderBlob(t *testing.T) {
+	_, err := readRpmHeaderBlob([]byte{0, 0, 0, 1})
+	assert.ErrorIs(t, err, errInvalidRpmHeader)
+
+	_, err = readRpmHeaderBlob([]byte{0, 0, 0, 1, 0, 0, 0, 8})
+	assert.ErrorIs(t, err, errInvalidRpmHeader)
+}
+
+func TestReadVarint(t *testing.T) {
+	value, n := readVarint([]byte{0x81, 0x00})
+	assert.Equal(t, uint64(128), value)
+	assert.Equal(t, 2, n)
+
+	_, n = readVarint([]byte{0x81})
+	assert.Equal(t, 0, n)
+
+	value, n = readVarint([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	assert.Equal(t, ^uint64(0), value)
+	assert.Equal(t, 9, n)
+}
+
+func TestReadRecord(t *testing.T) {
+	// Header of 5 bytes with NULL, int8, int16, text of 2 and blob of 1 bytes
+	columns, err := readRecord([]byte{6, 0, 1, 2, 17, 14, 0xff, 0x01, 0x00, 'o', 'k', 0x2a})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, int64(-1), int64(256), "ok", []byte{0x2a}}, columns)
+
+	_, err = readRecord([]byte{2, 10})
+	assert.ErrorIs(t, err, errSqliteUnsupported)
+
+	_, err = readRecord([]byte{2, 17})
+	assert.ErrorIs(t, err, errInvalidSqlite)
+
+	// A header size of 2^64-1 is negative when converted to int
+	_, err = readRecord([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	assert.ErrorIs(t, err, errInvalidSqlite)
+}
+
+func TestSqliteCorrupt(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "rpmdb.sqlite"))
+	assert.NoError(t, err)
+	// Every byte of the database is set to values that are likely to be out of bounds when read as sizes and offsets
+	for i := range content {
+		for _, value := range []byte{0x00, 0x7f, 0xff} {
+			corrupt := append([]byte{}, content...)
+			corrupt[i] = value
+			assert.NotPanicsf(t, func() {
+				db, err := newSqliteDatabase(corrupt)
+				if err == nil {
+					_ = db.tableRows("Packages", func(columns []interface{}) error {
+						return nil
+					})
+				}
+			}, "failed to assert that setting byte %d to %d did not panic", i, value)
+		}
+	}
+}
+
+func TestReadLeafCellPayloadTooLarge(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "rpmdb.sqlite"))
+	assert.NoError(t, err)
+	db, err := newSqliteDatabase(content)
+	assert.NoError(t, err)
+
+	// A payload size of 2^63 is negative when converted to int
+	cell := []byte{0x81, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}
+	err = db.readLeafCell(cell, func(columns []interface{}) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, errInvalidSqlite)
+
+	// A payload size larger than the database
+	err = db.readLeafCell([]byte{0x84, 0x80, 0x80, 0x00, 0x01, 0x00}, func(columns []interface{}) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, errInvalidSqlite)
+}
+
+func TestNewSqliteDatabaseInvalidUsableSize(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "rpmdb.sqlite"))
+	assert.NoError(t, err)
+	// Pages of 512 bytes with 255 reserved bytes leave too little room for cells
+	binary.BigEndian.PutUint16(content[16:18], 512)
+	content[20] = 255
+	_, err = newSqliteDatabase(content)
+	assert.ErrorIs(t, err, errInvalidSqlite)
+}
@@ -0,0 +1,294 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+)
+
+// A minimal reader of SQLite database files, sufficient to read the blobs of rpmdb.sqlite without a SQLite driver.
+// See https://www.sqlite.org/fileformat.html
+
+const (
+	sqliteHeaderSize      = 100
+	sqliteInteriorTable   = 0x05
+	sqliteLeafTable       = 0x0d
+	sqliteSchemaRootPage  = 1
+	sqliteMaxTreeDepth    = 64
+	sqliteSchemaNameIndex = 1
+	sqliteSchemaRootIndex = 3
+)
+
+var (
+	sqliteMagic            = []byte("SQLite format 3\x00")
+	errInvalidSqlite       = errors.New("invalid SQLite database")
+	errSqliteTableNotFound = errors.New("SQLite table not found")
+	errSqliteUnsupported   = errors.New("unsupported SQLite record")
+)
+
+type sqliteDatabase struct {
+	data       []byte
+	pageSize   int
+	usableSize int
+}
+
+func openSqlite(path string) (*sqliteDatabase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSqliteDatabase(data)
+}
+
+func newSqliteDatabase(data []byte) (*sqliteDatabase, error) {
+	if len(data) < sqliteHeaderSize || !bytes.Equal(data[:len(sqliteMagic)], sqliteMagic) {
+		return nil, errInvalidSqlite
+	}
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	// The usable size of a page is at least 480 bytes, leaving room for the payload of a cell and its overflow page number
+	usableSize := pageSize - int(data[20])
+	if pageSize < 512 || len(data)%pageSize != 0 || usableSize < 480 {
+		return nil, errInvalidSqlite
+	}
+
+	return &sqliteDatabase{data: data, pageSize: pageSize, usableSize: usableSize}, nil
+}
+
+func (db *sqliteDatabase) page(number uint32) ([]byte, error) {
+	start := (int(number) - 1) * db.pageSize
+	if number == 0 || start+db.pageSize > len(db.data) {
+		return nil, errInvalidSqlite
+	}
+
+	return db.data[start : start+db.pageSize], nil
+}
+
+// tableRows calls fn with the columns of each row of table, in rowid order
+func (db *sqliteDatabase) tableRows(table string, fn func(columns []interface{}) error) error {
+	var rootPage uint32
+	err := db.walkTable(sqliteSchemaRootPage, 0, func(columns []interface{}) error {
+		if len(columns) > sqliteSchemaRootIndex && columns[0] == "table" && columns[sqliteSchemaNameIndex] == table {
+			if root, ok := columns[sqliteSchemaRootIndex].(int64); ok {
+				rootPage = uint32(root)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if rootPage == 0 {
+		return errSqliteTableNotFound
+	}
+
+	return db.walkTable(rootPage, 0, fn)
+}
+
+// walkTable walks the table b-tree rooted at number
+func (db *sqliteDatabase) walkTable(number uint32, depth int, fn func(columns []interface{}) error) error {
+	if depth > sqliteMaxTreeDepth {
+		return errInvalidSqlite
+	}
+	page, err := db.page(number)
+	if err != nil {
+		return err
+	}
+	headerOffset := 0
+	if number == 1 {
+		headerOffset = sqliteHeaderSize
+	}
+	header := page[headerOffset:]
+	pageType := header[0]
+	cells := int(binary.BigEndian.Uint16(header[3:5]))
+	pointerOffset := headerOffset + 8
+	if pageType == sqliteInteriorTable {
+		pointerOffset = headerOffset + 12
+	} else if pageType != sqliteLeafTable {
+		return errInvalidSqlite
+	}
+	if pointerOffset+cells*2 > len(page) {
+		return errInvalidSqlite
+	}
+	for i := 0; i < cells; i++ {
+		cellOffset := int(binary.BigEndian.Uint16(page[pointerOffset+i*2:]))
+		if cellOffset+4 > len(page) {
+			return errInvalidSqlite
+		}
+		cell := page[cellOffset:]
+		if pageType == sqliteInteriorTable {
+			err = db.walkTable(binary.BigEndian.Uint32(cell), depth+1, fn)
+		} else {
+			err = db.readLeafCell(cell, fn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if pageType == sqliteInteriorTable {
+		return db.walkTable(binary.BigEndian.Uint32(header[8:12]), depth+1, fn)
+	}
+
+	return nil
+}
+
+func (db *sqliteDatabase) readLeafCell(cell []byte, fn func(columns []interface{}) error) error {
+	payloadSize, n := readVarint(cell)
+	_, m := readVarint(cell[n:])
+	if n == 0 || m == 0 {
+		return errInvalidSqlite
+	}
+	// The payload can not be larger than the database, which also keeps the conversion to int from overflowing
+	if payloadSize > uint64(len(db.data)) {
+		return errInvalidSqlite
+	}
+	cell = cell[n+m:]
+	payload, err := db.payload(cell, int(payloadSize))
+	if err != nil {
+		return err
+	}
+	columns, err := readRecord(payload)
+	if err != nil {
+		return err
+	}
+
+	return fn(columns)
+}
+
+// payload returns the payload of a cell, following its overflow pages if it does not fit on the page
+func (db *sqliteDatabase) payload(cell []byte, size int) ([]byte, error) {
+	maxLocal := db.usableSize - 35
+	local := size
+	if size > maxLocal {
+		minLocal := (db.usableSize-12)*32/255 - 23
+		local = minLocal + (size-minLocal)%(db.usableSize-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if local > len(cell) || (local < size && local+4 > len(cell)) {
+		return nil, errInvalidSqlite
+	}
+	payload := make([]byte, 0, size)
+	payload = append(payload, cell[:local]...)
+	if local == size {
+		return payload, nil
+	}
+	next := binary.BigEndian.Uint32(cell[local:])
+	for len(payload) < size {
+		page, err := db.page(next)
+		if err != nil {
+			return nil, err
+		}
+		chunk := page[4:db.usableSize]
+		if remaining := size - len(payload); remaining < len(chunk) {
+			chunk = chunk[:remaining]
+		}
+		payload = append(payload, chunk...)
+		next = binary.BigEndian.Uint32(page)
+	}
+
+	return payload, nil
+}
+
+// readRecord decodes the columns of a record. Integers are returned as int64, texts as string and blobs as []byte
+func readRecord(record []byte) ([]interface{}, error) {
+	headerSize, n := readVarint(record)
+	if n == 0 || headerSize > uint64(len(record)) {
+		return nil, errInvalidSqlite
+	}
+	var serialTypes []int64
+	for offset := n; offset < int(headerSize); {
+		serialType, m := readVarint(record[offset:])
+		if m == 0 {
+			return nil, errInvalidSqlite
+		}
+		serialTypes = append(serialTypes, int64(serialType))
+		offset += m
+	}
+	body := record[headerSize:]
+	columns := make([]interface{}, 0, len(serialTypes))
+	for _, serialType := range serialTypes {
+		size := serialTypeSize(serialType)
+		if size < 0 {
+			return nil, errSqliteUnsupported
+		}
+		if size > len(body) {
+			return nil, errInvalidSqlite
+		}
+		value := body[:size]
+		body = body[size:]
+		switch {
+		case serialType == 0:
+			columns = append(columns, nil)
+		case serialType <= 6:
+			columns = append(columns, readInt(value))
+		case serialType == 7:
+			columns = append(columns, nil)
+		case serialType == 8 || serialType == 9:
+			columns = append(columns, serialType-8)
+		case serialType%2 == 0:
+			columns = append(columns, value)
+		default:
+			columns = append(columns, string(value))
+		}
+	}
+
+	return columns, nil
+}
+
+func serialTypeSize(serialType int64) int {
+	switch {
+	case serialType < 0:
+		return -1
+	case serialType <= 4:
+		return []int{0, 1, 2, 3, 4}[serialType]
+	case serialType == 5:
+		return 6
+	case serialType == 6 || serialType == 7:
+		return 8
+	case serialType == 8 || serialType == 9:
+		return 0
+	case serialType >= 12:
+		return int((serialType - 12) / 2)
+	}
+
+	return -1
+}
+
+// readInt reads a big-endian two's complement integer
+func readInt(value []byte) int64 {
+	var result int64
+	for i, b := range value {
+		if i == 0 && b&0x80 != 0 {
+			result = -1
+		}
+		result = result<<8 | int64(b)
+	}
+
+	return result
+}
+
+// readVarint reads a SQLite varint, returning it and the number of bytes read, or 0 bytes if data is too short
+func readVarint(data []byte) (uint64, int) {
+	var result uint64
+	for i := 0; i < 9; i++ {
+		if i >= len(data) {
+			return 0, 0
+		}
+		if i == 8 {
+			return result<<8 | uint64(data[i]), 9
+		}
+		result = result<<7 | uint64(data[i]&0x7f)
+		if data[i]&0x80 == 0 {
+			return result, i + 1
+		}
+	}
+
+	return result, 9
+}
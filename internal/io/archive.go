@@ -4,8 +4,13 @@ import (
 	"encoding/base64"
 	"fmt"
 	"path"
+	"time"
 )
 
+// zipModified is the modification time of zipped files, which is fixed so that zipping the same content always gives
+// the same archive. It is the earliest time of the zip format
+var zipModified = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
 type IArchive interface {
 	ZipFile(sourcePath string, targetPath string, zippedName string) error
 	UnzipFile(sourcePath string, targetPath string) error
@@ -64,6 +69,7 @@ func (arc *Archive) ZipFile(sourcePath string, targetPath string, zippedName str
 	}
 
 	header.Name = zippedName
+	header.Modified = zipModified
 	header.Method = zip.GetDeflate()
 
 	fileWriter, err := zip.CreateHeader(zipWriter, header)
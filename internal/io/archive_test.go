@@ -3,6 +3,8 @@ package io
 import (
 	"archive/zip"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	ioTestData "github.com/debricked/cli/internal/io/testdata"
@@ -272,3 +274,24 @@ func TestUnzipFileNotSingleFile(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "cannot unzip archive which does not contain exactly one file")
 }
+
+func TestZipFileDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source")
+	assert.NoError(t, os.WriteFile(source, []byte("content"), 0600))
+	a := NewArchive(dir)
+
+	first, second := filepath.Join(dir, "first.zip"), filepath.Join(dir, "second.zip")
+	assert.NoError(t, a.ZipFile(source, first, "zippedName"))
+	assert.NoError(t, a.ZipFile(source, second, "zippedName"))
+
+	firstContent, err := os.ReadFile(first)
+	assert.NoError(t, err)
+	secondContent, err := os.ReadFile(second)
+	assert.NoError(t, err)
+	assert.Equal(t, firstContent, secondContent)
+	reader, err := zip.OpenReader(first)
+	assert.NoError(t, err)
+	defer reader.Close()
+	assert.True(t, reader.File[0].Modified.Equal(zipModified))
+}
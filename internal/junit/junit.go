@@ -0,0 +1,198 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const (
+	ResolutionSuiteName = "debricked.resolution"
+	PolicySuiteName     = "debricked.policy"
+	ScanSuiteName       = "debricked.scan"
+	reportName          = "debricked"
+)
+
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+type TestSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func newTestSuite(name string, testCases []TestCase) TestSuite {
+	suite := TestSuite{Name: name, Tests: len(testCases), TestCases: testCases}
+	for _, testCase := range testCases {
+		if testCase.Failure != nil {
+			suite.Failures++
+		}
+	}
+
+	return suite
+}
+
+// NewResolutionSuite returns a test suite with one test case per resolution job.
+// Jobs with critical errors fail, while warnings are added as output of the test case
+func NewResolutionSuite(jobs []job.IJob) TestSuite {
+	testCases := make([]TestCase, 0, len(jobs))
+	for _, j := range jobs {
+		testCase := TestCase{Name: j.GetFile(), ClassName: ResolutionSuiteName}
+		var warnings []string
+		for _, warning := range j.Errors().GetWarningErrors() {
+			warnings = append(warnings, "Warning: "+describeJobError(warning))
+		}
+		testCase.SystemOut = strings.Join(warnings, "\n\n")
+
+		criticals := j.Errors().GetCriticalErrors()
+		if len(criticals) > 0 {
+			var descriptions []string
+			for _, critical := range criticals {
+				descriptions = append(descriptions, describeJobError(critical))
+			}
+			testCase.Failure = &Failure{
+				Message: jobErrorMessage(criticals[0]),
+				Type:    "ResolutionError",
+				Text:    strings.Join(descriptions, "\n\n"),
+			}
+		}
+		testCases = append(testCases, testCase)
+	}
+
+	return newTestSuite(ResolutionSuiteName, testCases)
+}
+
+func jobErrorMessage(err job.IError) string {
+	if len(err.Status()) == 0 {
+		return "resolution failed"
+	}
+
+	return fmt.Sprintf("%s failed", err.Status())
+}
+
+func describeJobError(err job.IError) string {
+	var builder strings.Builder
+	builder.WriteString(jobErrorMessage(err) + "\n")
+	if documentation := strings.TrimSpace(err.Documentation()); len(documentation) > 0 {
+		builder.WriteString(documentation + "\n")
+	}
+	if len(err.Command()) > 0 {
+		builder.WriteString(fmt.Sprintf("command: %s\n", err.Command()))
+	}
+	builder.WriteString(strings.TrimSpace(err.Error()))
+
+	return builder.String()
+}
+
+// NewPolicySuite returns a test suite with one test case per automation rule.
+// Triggered rules with the failPipeline action fail, while other triggered rules are added as output of the test case
+func NewPolicySuite(rules []automation.Rule) TestSuite {
+	testCases := make([]TestCase, 0, len(rules))
+	for _, rule := range rules {
+		testCase := TestCase{Name: ruleName(rule), ClassName: PolicySuiteName}
+		if rule.Triggered {
+			details := describeRule(rule)
+			if rule.FailPipeline() {
+				testCase.Failure = &Failure{
+					Message: "automation rule triggered and failed the pipeline",
+					Type:    "AutomationRule",
+					Text:    details,
+				}
+			} else {
+				testCase.SystemOut = details
+			}
+		}
+		testCases = append(testCases, testCase)
+	}
+
+	return newTestSuite(PolicySuiteName, testCases)
+}
+
+func ruleName(rule automation.Rule) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(rule.RuleDescription), "\n")
+	if len(name) == 0 {
+		return "Automation rule"
+	}
+
+	return name
+}
+
+func describeRule(rule automation.Rule) string {
+	var builder strings.Builder
+	builder.WriteString(strings.TrimSpace(rule.RuleDescription) + "\n")
+	if len(rule.RuleLink) > 0 {
+		builder.WriteString(fmt.Sprintf("Manage rule: %s\n", rule.RuleLink))
+	}
+	for _, event := range rule.TriggerEvents {
+		builder.WriteString(fmt.Sprintf("\n%s", event.Dependency))
+		if len(event.DependencyLink) > 0 {
+			builder.WriteString(fmt.Sprintf(" (%s)", event.DependencyLink))
+		}
+		if len(event.Cve) > 0 {
+			builder.WriteString(fmt.Sprintf("\n  %s CVSS2: %g CVSS3: %g", event.Cve, event.Cvss2, event.Cvss3))
+			if len(event.CveLink) > 0 {
+				builder.WriteString(fmt.Sprintf(" (%s)", event.CveLink))
+			}
+		}
+		if len(event.Licenses) > 0 {
+			builder.WriteString(fmt.Sprintf("\n  Licenses: %s", strings.Join(event.Licenses, ", ")))
+		}
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// NewScanSuite returns a test suite with a single test case, failed by the error that stopped the scan
+func NewScanSuite(err error) TestSuite {
+	testCase := TestCase{
+		Name:      "Scan",
+		ClassName: ScanSuiteName,
+		Failure: &Failure{
+			Message: "scan failed",
+			Type:    "ScanError",
+			Text:    strings.TrimSpace(err.Error()),
+		},
+	}
+
+	return newTestSuite(ScanSuiteName, []TestCase{testCase})
+}
+
+// Write writes suites as a JUnit XML report to path
+func Write(path string, suites ...TestSuite) error {
+	report := TestSuites{Name: reportName, Suites: suites}
+	for _, suite := range suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Clean(path), append([]byte(xml.Header), append(data, '\n')...), 0600)
+}
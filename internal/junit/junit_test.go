@@ -0,0 +1,177 @@
+package junit
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJobs() []job.IJob {
+	succeeded := testdata.NewJobMock("go.mod")
+
+	failed := testdata.NewJobMock("package.json")
+	critical := job.NewBaseJobError("npm ERR! code ERESOLVE")
+	critical.SetStatus("installing dependencies")
+	critical.SetCommand("npm install --ignore-scripts")
+	critical.SetDocumentation("Failed to resolve dependency tree")
+	failed.SetErr(critical)
+
+	warned := testdata.NewJobMock("pom.xml")
+	warning := job.NewBaseJobError("dependency not found")
+	warning.SetStatus("building dependency tree")
+	warning.SetIsCritical(false)
+	warned.Errors().Append(warning)
+
+	return []job.IJob{succeeded, failed, warned}
+}
+
+func newTestRules() []automation.Rule {
+	return []automation.Rule{
+		{
+			RuleDescription: "Fail on critical vulnerabilities\nAdded by security",
+			RuleActions:     []string{"failPipeline"},
+			RuleLink:        "https://debricked.com/rule/1",
+			Triggered:       true,
+			TriggerEvents: []automation.TriggerEvent{
+				{
+					Dependency:     "lodash (npm)",
+					DependencyLink: "https://debricked.com/dependency/1",
+					Licenses:       []string{"MIT"},
+					Cve:            "CVE-2021-23337",
+					Cvss2:          6.5,
+					Cvss3:          7.2,
+					CveLink:        "https://debricked.com/vulnerability/1",
+				},
+			},
+		},
+		{
+			RuleDescription: "Warn on copyleft licenses",
+			RuleActions:     []string{"warnPipeline"},
+			Triggered:       true,
+			TriggerEvents: []automation.TriggerEvent{
+				{Dependency: "readline (npm)", Licenses: []string{"GPL-3.0-only"}},
+			},
+		},
+		{
+			RuleDescription: "",
+			RuleActions:     []string{"failPipeline"},
+			Triggered:       false,
+		},
+	}
+}
+
+func TestNewResolutionSuite(t *testing.T) {
+	suite := NewResolutionSuite(newTestJobs())
+
+	assert.Equal(t, ResolutionSuiteName, suite.Name)
+	assert.Equal(t, 3, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+
+	succeeded := suite.TestCases[0]
+	assert.Equal(t, "go.mod", succeeded.Name)
+	assert.Equal(t, ResolutionSuiteName, succeeded.ClassName)
+	assert.Nil(t, succeeded.Failure)
+	assert.Empty(t, succeeded.SystemOut)
+
+	failed := suite.TestCases[1]
+	assert.Equal(t, "installing dependencies failed", failed.Failure.Message)
+	assert.Equal(t, "ResolutionError", failed.Failure.Type)
+	assert.Equal(t, "installing dependencies failed\nFailed to resolve dependency tree\ncommand: npm install --ignore-scripts\nnpm ERR! code ERESOLVE", failed.Failure.Text)
+
+	warned := suite.TestCases[2]
+	assert.Nil(t, warned.Failure)
+	assert.Equal(t, "Warning: building dependency tree failed\ndependency not found", warned.SystemOut)
+}
+
+func TestNewResolutionSuiteWithoutStatus(t *testing.T) {
+	failed := testdata.NewJobMock("requirements.txt")
+	failed.SetErr(job.NewBaseJobError("error"))
+
+	suite := NewResolutionSuite([]job.IJob{failed})
+
+	assert.Equal(t, "resolution failed", suite.TestCases[0].Failure.Message)
+}
+
+func TestNewPolicySuite(t *testing.T) {
+	suite := NewPolicySuite(newTestRules())
+
+	assert.Equal(t, PolicySuiteName, suite.Name)
+	assert.Equal(t, 3, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+
+	failed := suite.TestCases[0]
+	assert.Equal(t, "Fail on critical vulnerabilities", failed.Name)
+	assert.Equal(t, PolicySuiteName, failed.ClassName)
+	assert.Equal(t, "AutomationRule", failed.Failure.Type)
+	assert.Equal(t, strings.Join([]string{
+		"Fail on critical vulnerabilities",
+		"Added by security",
+		"Manage rule: https://debricked.com/rule/1",
+		"",
+		"lodash (npm) (https://debricked.com/dependency/1)",
+		"  CVE-2021-23337 CVSS2: 6.5 CVSS3: 7.2 (https://debricked.com/vulnerability/1)",
+		"  Licenses: MIT",
+	}, "\n"), failed.Failure.Text)
+
+	warned := suite.TestCases[1]
+	assert.Nil(t, warned.Failure)
+	assert.Equal(t, "Warn on copyleft licenses\n\nreadline (npm)\n  Licenses: GPL-3.0-only", warned.SystemOut)
+
+	untriggered := suite.TestCases[2]
+	assert.Equal(t, "Automation rule", untriggered.Name)
+	assert.Nil(t, untriggered.Failure)
+	assert.Empty(t, untriggered.SystemOut)
+}
+
+func TestNewScanSuite(t *testing.T) {
+	suite := NewScanSuite(errors.New("failed to upload file: 503 Service Unavailable\n"))
+
+	assert.Equal(t, ScanSuiteName, suite.Name)
+	assert.Equal(t, 1, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, ScanSuiteName, suite.TestCases[0].ClassName)
+	assert.Equal(t, "ScanError", suite.TestCases[0].Failure.Type)
+	assert.Equal(t, "failed to upload file: 503 Service Unavailable", suite.TestCases[0].Failure.Text)
+}
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+
+	err := Write(path, NewResolutionSuite(newTestJobs()), NewPolicySuite(newTestRules()))
+
+	assert.NoError(t, err)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+	var report TestSuites
+	assert.NoError(t, xml.Unmarshal(data, &report))
+	assert.Equal(t, "debricked", report.Name)
+	assert.Equal(t, 6, report.Tests)
+	assert.Equal(t, 2, report.Failures)
+	assert.Len(t, report.Suites, 2)
+	assert.Equal(t, "npm ERR! code ERESOLVE", report.Suites[0].TestCases[1].Failure.Text[strings.LastIndex(report.Suites[0].TestCases[1].Failure.Text, "\n")+1:])
+}
+
+func TestWriteEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+
+	err := Write(path, NewPolicySuite(nil))
+
+	assert.NoError(t, err)
+	data, _ := os.ReadFile(path)
+	assert.Contains(t, string(data), `<testsuites name="debricked" tests="0" failures="0">`)
+}
+
+func TestWriteError(t *testing.T) {
+	err := Write(filepath.Join(t.TempDir(), "missing", "junit.xml"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	bowerTreeIndentation = 2
+	bowerBranchLength    = 4
+)
+
+// parseBowerList parses the output of `bower list`:
+//
+//	app#1.0.0 /path/to/app
+//	├─┬ angular-mocks#1.8.2
+//	│ └── angular#1.8.2
+//	└── jquery#3.6.0 extraneous
+//
+// The first line is the project itself
+func parseBowerList(file string, data []byte) (*Graph, error) {
+	graph := NewGraph(EcosystemNpm, file)
+	var parents []string
+	projectFound := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		start := strings.IndexFunc(line, func(r rune) bool {
+			return !strings.ContainsRune(" │├└─┬", r)
+		})
+		if start < 0 || !strings.Contains(line[start:], "#") {
+			continue
+		}
+		if !projectFound {
+			projectFound = true
+
+			continue
+		}
+		fields := strings.Fields(line[start:])
+		name, version, _ := strings.Cut(fields[0], "#")
+		// Top level dependencies are prefixed by a branch such as `├── `, which is followed by two runes per level
+		depth := (utf8.RuneCountInString(line[:start]) - bowerBranchLength) / bowerTreeIndentation
+		if depth < 0 {
+			depth = 0
+		}
+		if depth > len(parents) {
+			depth = len(parents)
+		}
+		parents = parents[:depth]
+		id := graph.Add(name, version).Id()
+		if depth == 0 {
+			graph.AddRoot(id)
+		} else {
+			graph.AddEdge(parents[depth-1], id)
+		}
+		parents = append(parents, id)
+	}
+
+	return graph, scanner.Err()
+}
@@ -0,0 +1,19 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBowerList(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "bower", "bower.debricked.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemNpm, graph.Ecosystem)
+	assert.Equal(t, 3, graph.Size())
+	assert.Equal(t, []string{"angular-mocks@1.8.2", "jquery@3.6.0"}, graph.Roots)
+	mocks, _ := graph.Get("angular-mocks@1.8.2")
+	assert.Equal(t, []string{"angular@1.8.2"}, mocks.Dependencies)
+}
@@ -0,0 +1,124 @@
+package lockfile
+
+import (
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+type cargoLockFile struct {
+	Packages []cargoPackage `toml:"package"`
+}
+
+type cargoPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	// Source is empty for the packages of the workspace and other path dependencies
+	Source       string   `toml:"source"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+type cargoManifest struct {
+	Dependencies      map[string]interface{} `toml:"dependencies"`
+	DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+	BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+}
+
+// parseCargo parses Cargo.lock files. The dependencies of local packages, such as the members of a workspace, are the
+// roots. Dev dependencies are read from Cargo.toml, as the lock file does not record them
+func parseCargo(file string, data []byte) (*Graph, error) {
+	var lockFile cargoLockFile
+	if err := toml.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	graph := NewGraph(EcosystemCargo, file)
+	// Dependencies are referred to by name, and by name and version if several versions are locked
+	versions := map[string][]string{}
+	for _, pkg := range lockFile.Packages {
+		if len(pkg.Source) > 0 {
+			graph.Add(pkg.Name, pkg.Version)
+		}
+		versions[pkg.Name] = append(versions[pkg.Name], pkg.Version)
+	}
+	resolve := func(reference string) (string, bool) {
+		fields := strings.Fields(reference)
+		if len(fields) == 0 {
+			return "", false
+		}
+		name := fields[0]
+		version := ""
+		if len(fields) > 1 {
+			version = fields[1]
+		} else if len(versions[name]) == 1 {
+			version = versions[name][0]
+		}
+		id := Id(name, version)
+		_, ok := graph.Get(id)
+
+		return id, ok
+	}
+
+	// rootNames maps the roots to the names they are depended on by
+	rootNames := map[string]string{}
+	for _, pkg := range lockFile.Packages {
+		from := Id(pkg.Name, pkg.Version)
+		for _, reference := range pkg.Dependencies {
+			to, ok := resolve(reference)
+			if !ok {
+				continue
+			}
+			if len(pkg.Source) == 0 {
+				graph.AddRoot(to)
+				rootNames[to] = strings.Fields(reference)[0]
+			} else {
+				graph.AddEdge(from, to)
+			}
+		}
+	}
+
+	manifestData, ok, err := readSibling(file, "Cargo.toml")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return graph, nil
+	}
+	var manifest cargoManifest
+	if err = toml.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, err
+	}
+	devOnly := cargoDependencyNames(manifest.DevDependencies)
+	for name := range cargoDependencyNames(manifest.Dependencies) {
+		delete(devOnly, name)
+	}
+	for name := range cargoDependencyNames(manifest.BuildDependencies) {
+		delete(devOnly, name)
+	}
+	var prodRoots, devRoots []string
+	for id, name := range rootNames {
+		if devOnly[name] {
+			devRoots = append(devRoots, id)
+		} else {
+			prodRoots = append(prodRoots, id)
+		}
+	}
+	graph.markDev(prodRoots, devRoots)
+
+	return graph, nil
+}
+
+// cargoDependencyNames returns the package names of dependencies, which differ from their keys if they are renamed
+func cargoDependencyNames(dependencies map[string]interface{}) map[string]bool {
+	names := map[string]bool{}
+	for key, value := range dependencies {
+		name := key
+		if table, ok := value.(map[string]interface{}); ok {
+			if pkg, ok := table["package"].(string); ok {
+				name = pkg
+			}
+		}
+		names[name] = true
+	}
+
+	return names
+}
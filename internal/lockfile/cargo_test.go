@@ -0,0 +1,37 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCargo(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "cargo", "Cargo.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemCargo, graph.Ecosystem)
+	assert.Equal(t, 7, graph.Size())
+	assert.Equal(t, []string{"log@0.4.20", "pretty_assertions@1.4.0", "serde@1.0.193"}, graph.Roots)
+	_, local := graph.Get("app@0.1.0")
+	assert.False(t, local, "failed to assert that the local package was left out")
+	serdeDerive, _ := graph.Get("serde_derive@1.0.193")
+	assert.Equal(t, []string{"yansi@0.5.1"}, serdeDerive.Dependencies)
+	assert.False(t, serdeDerive.Dev)
+	prettyAssertions, _ := graph.Get("pretty_assertions@1.4.0")
+	assert.Equal(t, []string{"diff@0.1.13", "yansi@1.0.0"}, prettyAssertions.Dependencies)
+	assert.True(t, prettyAssertions.Dev)
+	yansi, _ := graph.Get("yansi@1.0.0")
+	assert.True(t, yansi.Dev)
+	yansi, _ = graph.Get("yansi@0.5.1")
+	assert.False(t, yansi.Dev)
+}
+
+func TestParseCargoWithoutManifest(t *testing.T) {
+	graph, err := parseCargo("Cargo.lock", []byte("version = 3\n\n[[package]]\nname = \"log\"\nversion = \"0.4.20\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, graph.Size())
+	assert.Empty(t, graph.Roots)
+}
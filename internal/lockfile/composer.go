@@ -0,0 +1,65 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type composerLockFile struct {
+	Packages    []composerPackage `json:"packages"`
+	PackagesDev []composerPackage `json:"packages-dev"`
+}
+
+type composerPackage struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+func parseComposer(file string, data []byte) (*Graph, error) {
+	var lockFile composerLockFile
+	if err := json.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	graph := NewGraph(EcosystemComposer, file)
+	ids := map[string]string{}
+	for _, pkg := range lockFile.Packages {
+		ids[strings.ToLower(pkg.Name)] = graph.Add(pkg.Name, pkg.Version).Id()
+	}
+	for _, pkg := range lockFile.PackagesDev {
+		dependency := graph.Add(pkg.Name, pkg.Version)
+		dependency.Dev = true
+		ids[strings.ToLower(pkg.Name)] = dependency.Id()
+	}
+	// Platform requirements such as php and ext-json are not packages, and are therefore never resolved
+	for _, pkg := range append(lockFile.Packages, lockFile.PackagesDev...) {
+		from := Id(pkg.Name, pkg.Version)
+		for name := range pkg.Require {
+			if to, ok := ids[strings.ToLower(name)]; ok {
+				graph.AddEdge(from, to)
+			}
+		}
+	}
+
+	// composer.lock does not record the direct dependencies, they are read from composer.json
+	manifestData, ok, err := readSibling(file, "composer.json")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		var manifest composerPackage
+		if err = json.Unmarshal(manifestData, &manifest); err != nil {
+			return nil, err
+		}
+		for _, requirements := range []map[string]string{manifest.Require, manifest.RequireDev} {
+			for name := range requirements {
+				if to, ok := ids[strings.ToLower(name)]; ok {
+					graph.AddRoot(to)
+				}
+			}
+		}
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,21 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseComposer(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "composer", "composer.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemComposer, graph.Ecosystem)
+	assert.Equal(t, 3, graph.Size())
+	assert.Equal(t, []string{"guzzlehttp/guzzle@7.8.1", "phpunit/php-timer@6.0.0"}, graph.Roots)
+	guzzle, _ := graph.Get("guzzlehttp/guzzle@7.8.1")
+	assert.Equal(t, []string{"guzzlehttp/promises@2.0.2"}, guzzle.Dependencies)
+	timer, _ := graph.Get("phpunit/php-timer@6.0.0")
+	assert.True(t, timer.Dev)
+}
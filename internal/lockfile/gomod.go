@@ -0,0 +1,131 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+var MissingGoModErr = errors.New("go.mod is missing next to go.sum")
+
+const goSumModSuffix = "/go.mod"
+
+// parseGoSum parses go.mod and go.sum. The build list is read from the require directives of go.mod,
+// which lists all modules needed to build the module since Go 1.17. Modules downloaded according to go.sum
+// but missing in go.mod, as in modules for older Go versions, are added as indirect dependencies using their highest version.
+// Neither file records which module requires which, so the graph has no edges
+func parseGoSum(file string, data []byte) (*Graph, error) {
+	modData, ok, err := readSibling(file, "go.mod")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, MissingGoModErr
+	}
+	mod, err := modfile.Parse("go.mod", modData, nil)
+	if err != nil {
+		return nil, err
+	}
+	replacements := map[string]modfile.Replace{}
+	for _, replace := range mod.Replace {
+		if len(replace.New.Version) > 0 {
+			replacements[replace.Old.Path] = *replace
+		}
+	}
+
+	graph := NewGraph(EcosystemGolang, file)
+	required := map[string]bool{}
+	for _, require := range mod.Require {
+		required[require.Mod.Path] = true
+		path, version := require.Mod.Path, require.Mod.Version
+		if replace, ok := replacements[path]; ok && (len(replace.Old.Version) == 0 || replace.Old.Version == version) {
+			path, version = replace.New.Path, replace.New.Version
+		}
+		dependency := graph.Add(path, version)
+		if !require.Indirect {
+			graph.AddRoot(dependency.Id())
+		}
+	}
+
+	for path, version := range downloadedModules(data) {
+		if !required[path] {
+			graph.Add(path, version)
+		}
+	}
+
+	return graph, nil
+}
+
+// downloadedModules returns the highest version of each module with a checksum of its content in go.sum.
+// Modules only having a go.mod checksum were needed for version selection, but are not part of the build
+func downloadedModules(data []byte) map[string]string {
+	modules := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], goSumModSuffix) {
+			continue
+		}
+		path, version := fields[0], fields[1]
+		if current, ok := modules[path]; !ok || semver.Compare(version, current) > 0 {
+			modules[path] = version
+		}
+	}
+
+	return modules
+}
+
+// parseGoModGraph parses gomod.debricked.lock files, consisting of the output of `go mod graph`,
+// followed by the selected versions of the production modules, and the selected versions of the development modules:
+//
+//	example.com/app github.com/spf13/cobra@v1.7.0
+//	github.com/spf13/cobra@v1.7.0 github.com/spf13/pflag@v1.0.5
+//
+//	example.com/app
+//	github.com/spf13/cobra v1.7.0
+//	github.com/spf13/pflag v1.0.5
+//
+// The module graph contains all versions considered by version selection, only edges between selected versions are kept
+func parseGoModGraph(file string, data []byte) (*Graph, error) {
+	sections := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n\n")
+	if len(sections) < 2 {
+		return nil, errors.New("module list is missing")
+	}
+
+	graph := NewGraph(EcosystemGolang, file)
+	selected := map[string]string{}
+	for i, section := range sections[1:] {
+		for _, line := range strings.Split(section, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			selected[fields[0]] = fields[1]
+			graph.Add(fields[0], fields[1]).Dev = i > 0
+		}
+	}
+
+	for _, line := range strings.Split(sections[0], "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		toPath, toVersion, _ := strings.Cut(fields[1], "@")
+		if selected[toPath] != toVersion {
+			continue
+		}
+		to := Id(toPath, toVersion)
+		fromPath, fromVersion, isDependency := strings.Cut(fields[0], "@")
+		if !isDependency {
+			graph.AddRoot(to)
+		} else if selected[fromPath] == fromVersion {
+			graph.AddEdge(Id(fromPath, fromVersion), to)
+		}
+	}
+
+	return graph, nil
+}
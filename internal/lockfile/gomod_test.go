@@ -0,0 +1,55 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGoSum(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "gomod", "go.sum"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemGolang, graph.Ecosystem)
+	assert.Equal(t, []string{"github.com/spf13/cobra@v1.7.0", "golang.org/x/mod@v0.17.0"}, graph.Roots)
+	var ids []string
+	for _, dependency := range graph.Sorted() {
+		ids = append(ids, dependency.Id())
+	}
+	assert.Equal(t, []string{
+		"github.com/inconshreveable/mousetrap@v1.1.0",
+		"github.com/spf13/cobra@v1.7.0",
+		"github.com/spf13/pflag@v1.0.5",
+		"golang.org/x/mod@v0.17.0",
+		"gopkg.in/yaml.v3@v3.0.1",
+	}, ids)
+}
+
+func TestParseGoSumWithoutGoMod(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "gomod", "missing", "go.sum"))
+
+	assert.Nil(t, graph)
+	assert.ErrorIs(t, err, MissingGoModErr)
+}
+
+func TestParseGoModGraph(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "gomod", "gomod.debricked.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, graph.Size())
+	assert.Equal(t, []string{"github.com/spf13/cobra@v1.7.0", "github.com/stretchr/testify@v1.9.0"}, graph.Roots)
+	cobra, _ := graph.Get("github.com/spf13/cobra@v1.7.0")
+	assert.Equal(t, []string{"github.com/spf13/pflag@v1.0.5"}, cobra.Dependencies)
+	assert.False(t, cobra.Dev)
+	testify, _ := graph.Get("github.com/stretchr/testify@v1.9.0")
+	assert.True(t, testify.Dev)
+	assert.Equal(t, []string{"github.com/davecgh/go-spew@v1.1.1"}, testify.Dependencies)
+}
+
+func TestParseGoModGraphWithoutModuleList(t *testing.T) {
+	graph, err := parseGoModGraph("gomod.debricked.lock", []byte("example.com/app github.com/spf13/cobra@v1.7.0\n"))
+
+	assert.Nil(t, graph)
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const gradleEmptyConfigurations = "empty="
+
+// parseGradle parses gradle.lockfile files written by Gradle dependency locking:
+//
+//	com.google.guava:guava:31.1-jre=compileClasspath,runtimeClasspath
+//
+// The lock file is flat, so the graph has neither roots nor edges.
+// Dependencies only locked for test configurations are flagged as dev dependencies
+func parseGradle(file string, data []byte) (*Graph, error) {
+	graph := NewGraph(EcosystemMaven, file)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, gradleEmptyConfigurations) {
+			continue
+		}
+		coordinates, configurations, _ := strings.Cut(line, "=")
+		parts := strings.Split(coordinates, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid dependency on line %d: %s", lineNumber, line)
+		}
+		dependency := graph.Add(parts[0]+":"+parts[1], parts[2])
+		dependency.Dev = isGradleTestOnly(configurations)
+	}
+
+	return graph, scanner.Err()
+}
+
+func isGradleTestOnly(configurations string) bool {
+	if len(configurations) == 0 {
+		return false
+	}
+	for _, configuration := range strings.Split(configurations, ",") {
+		if !strings.HasPrefix(configuration, "test") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+const gradleTreeIndentation = 5
+
+var (
+	gradleConfiguration = regexp.MustCompile(`^([A-Za-z][\w-]*)(\s+-\s+.*)?$`)
+	gradleTreeBranch    = regexp.MustCompile(`[+\\]--- `)
+	gradleTreeMarker    = regexp.MustCompile(`\s+\((\*|c|n)\)$`)
+)
+
+// parseGradleReport parses the dependency report written by the Gradle DependencyReportTask:
+//
+//	runtimeClasspath - Runtime classpath of source set 'main'.
+//	+--- com.google.guava:guava:31.1-jre
+//	|    \--- com.google.guava:failureaccess:1.0.1
+//	\--- org.slf4j:slf4j-api:1.7.36 -> 2.0.9 (*)
+//
+// The graph is the union of all configurations. Dependencies only found in test configurations are dev dependencies
+func parseGradleReport(file string, data []byte) (*Graph, error) {
+	graph := NewGraph(EcosystemMaven, file)
+	prod := map[string]bool{}
+	testConfiguration := false
+	// parents holds the closest ancestor dependency of each depth, project dependencies are skipped
+	var parents []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		location := gradleTreeBranch.FindStringIndex(line)
+		if location == nil {
+			if match := gradleConfiguration.FindStringSubmatch(line); match != nil {
+				testConfiguration = strings.HasPrefix(strings.ToLower(match[1]), "test")
+				parents = nil
+			}
+
+			continue
+		}
+		depth := location[0] / gradleTreeIndentation
+		if depth > len(parents) {
+			depth = len(parents)
+		}
+		parents = parents[:depth]
+		parent := ""
+		for i := depth - 1; i >= 0 && len(parent) == 0; i-- {
+			parent = parents[i]
+		}
+
+		name, version := parseGradleCoordinates(line[location[1]:])
+		if len(version) == 0 {
+			parents = append(parents, "")
+
+			continue
+		}
+		id := graph.Add(name, version).Id()
+		if !testConfiguration {
+			prod[id] = true
+		}
+		if len(parent) == 0 {
+			graph.AddRoot(id)
+		} else {
+			graph.AddEdge(parent, id)
+		}
+		parents = append(parents, id)
+	}
+	for id, dependency := range graph.Dependencies {
+		dependency.Dev = !prod[id]
+	}
+
+	return graph, scanner.Err()
+}
+
+// parseGradleCoordinates parses coordinates such as `group:artifact:1.0 -> 2.0 (*)`. Project dependencies,
+// as well as unresolved dependencies, have no version
+func parseGradleCoordinates(coordinates string) (string, string) {
+	coordinates = strings.TrimSuffix(strings.TrimSpace(coordinates), " FAILED")
+	coordinates = gradleTreeMarker.ReplaceAllString(coordinates, "")
+	if strings.HasPrefix(coordinates, "project ") {
+		return coordinates, ""
+	}
+	requested, selected, conflict := strings.Cut(coordinates, " -> ")
+	parts := strings.Split(requested, ":")
+	if len(parts) < 2 {
+		return coordinates, ""
+	}
+	name := parts[0] + ":" + parts[1]
+	version := ""
+	if len(parts) >= 3 {
+		version = parts[2]
+	}
+	if conflict {
+		version = strings.TrimSpace(selected)
+	}
+
+	return name, version
+}
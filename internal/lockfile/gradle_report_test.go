@@ -0,0 +1,44 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGradleReport(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "gradle", "gradle.debricked.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemMaven, graph.Ecosystem)
+	assert.Equal(t, 6, graph.Size())
+	assert.Equal(t, []string{
+		"com.google.guava:guava@32.1.2-jre",
+		"junit:junit@4.13.2",
+		"org.slf4j:slf4j-api@2.0.9",
+	}, graph.Roots)
+	guava, _ := graph.Get("com.google.guava:guava@32.1.2-jre")
+	assert.Equal(t, []string{"com.google.guava:failureaccess@1.0.1", "org.checkerframework:checker-qual@3.33.0"}, guava.Dependencies)
+	assert.False(t, guava.Dev)
+	junit, _ := graph.Get("junit:junit@4.13.2")
+	assert.True(t, junit.Dev)
+	assert.Equal(t, []string{"org.hamcrest:hamcrest-core@1.3"}, junit.Dependencies)
+}
+
+func TestParseGradleCoordinates(t *testing.T) {
+	cases := map[string][2]string{
+		"com.google.guava:guava:32.1.2-jre":             {"com.google.guava:guava", "32.1.2-jre"},
+		"org.slf4j:slf4j-api:1.7.36 -> 2.0.9 (*)":       {"org.slf4j:slf4j-api", "2.0.9"},
+		"org.slf4j:slf4j-api -> 2.0.9":                  {"org.slf4j:slf4j-api", "2.0.9"},
+		"org.slf4j:slf4j-api:{strictly 2.0.9} -> 2.0.9": {"org.slf4j:slf4j-api", "2.0.9"},
+		"com.google.guava:guava (n)":                    {"com.google.guava:guava", ""},
+		"project :core":                                 {"project :core", ""},
+		"com.example:missing:1.0.0 FAILED":              {"com.example:missing", "1.0.0"},
+	}
+	for coordinates, expected := range cases {
+		name, version := parseGradleCoordinates(coordinates)
+		assert.Equal(t, expected[0], name, coordinates)
+		assert.Equal(t, expected[1], version, coordinates)
+	}
+}
@@ -0,0 +1,21 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGradle(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "gradle", "gradle.lockfile"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemMaven, graph.Ecosystem)
+	assert.Equal(t, 3, graph.Size())
+	assert.Empty(t, graph.Roots)
+	guava, _ := graph.Get("com.google.guava:guava@32.1.2-jre")
+	assert.False(t, guava.Dev)
+	junit, _ := graph.Get("junit:junit@4.13.2")
+	assert.True(t, junit.Dev)
+}
@@ -0,0 +1,187 @@
+package lockfile
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	EcosystemNpm      = "npm"
+	EcosystemComposer = "composer"
+	EcosystemGolang   = "golang"
+	EcosystemPypi     = "pypi"
+	EcosystemNuget    = "nuget"
+	EcosystemMaven    = "maven"
+	EcosystemCargo    = "cargo"
+)
+
+type Dependency struct {
+	Name    string
+	Version string
+	// Dev is true if the dependency is only required for development
+	Dev          bool
+	Dependencies []string
+}
+
+// Id returns the identifier of the dependency within its graph
+func (dependency *Dependency) Id() string {
+	return Id(dependency.Name, dependency.Version)
+}
+
+func Id(name string, version string) string {
+	return name + "@" + version
+}
+
+// Graph is the dependency graph of a lock file. Roots are the direct dependencies of the project.
+// Lock files that do not record which dependencies are direct, such as gradle.lockfile, have no roots
+type Graph struct {
+	Ecosystem    string
+	File         string
+	Roots        []string
+	Dependencies map[string]*Dependency
+}
+
+func NewGraph(ecosystem string, file string) *Graph {
+	return &Graph{
+		Ecosystem:    ecosystem,
+		File:         file,
+		Roots:        []string{},
+		Dependencies: map[string]*Dependency{},
+	}
+}
+
+// Add adds the dependency unless it already exists, and returns it
+func (graph *Graph) Add(name string, version string) *Dependency {
+	id := Id(name, version)
+	if dependency, ok := graph.Dependencies[id]; ok {
+		return dependency
+	}
+	dependency := &Dependency{Name: name, Version: version, Dependencies: []string{}}
+	graph.Dependencies[id] = dependency
+
+	return dependency
+}
+
+// AddRoot marks the dependency as a direct dependency of the project
+func (graph *Graph) AddRoot(id string) {
+	graph.Roots = appendUnique(graph.Roots, id)
+}
+
+// AddEdge adds a dependency from one dependency to another
+func (graph *Graph) AddEdge(from string, to string) {
+	if dependency, ok := graph.Dependencies[from]; ok && from != to {
+		dependency.Dependencies = appendUnique(dependency.Dependencies, to)
+	}
+}
+
+func (graph *Graph) Get(id string) (*Dependency, bool) {
+	dependency, ok := graph.Dependencies[id]
+
+	return dependency, ok
+}
+
+// Find returns all versions of the dependency with name
+func (graph *Graph) Find(name string) []*Dependency {
+	var found []*Dependency
+	for _, dependency := range graph.Sorted() {
+		if dependency.Name == name {
+			found = append(found, dependency)
+		}
+	}
+
+	return found
+}
+
+func (graph *Graph) Size() int {
+	return len(graph.Dependencies)
+}
+
+// Sorted returns the dependencies sorted by name and version
+func (graph *Graph) Sorted() []*Dependency {
+	dependencies := make([]*Dependency, 0, len(graph.Dependencies))
+	for _, dependency := range graph.Dependencies {
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].Name != dependencies[j].Name {
+			return dependencies[i].Name < dependencies[j].Name
+		}
+
+		return dependencies[i].Version < dependencies[j].Version
+	})
+
+	return dependencies
+}
+
+// normalize sorts roots and edges, and removes edges to dependencies missing in the graph
+func (graph *Graph) normalize() *Graph {
+	roots := graph.Roots[:0]
+	for _, root := range graph.Roots {
+		if _, ok := graph.Dependencies[root]; ok {
+			roots = append(roots, root)
+		}
+	}
+	sort.Strings(roots)
+	graph.Roots = roots
+	for _, dependency := range graph.Dependencies {
+		edges := dependency.Dependencies[:0]
+		for _, edge := range dependency.Dependencies {
+			if _, ok := graph.Dependencies[edge]; ok {
+				edges = append(edges, edge)
+			}
+		}
+		sort.Strings(edges)
+		dependency.Dependencies = edges
+	}
+
+	return graph
+}
+
+// markDev flags all dependencies only reachable from dev roots as dev dependencies
+func (graph *Graph) markDev(prodRoots []string, devRoots []string) {
+	prod := graph.reachable(prodRoots)
+	for id := range graph.reachable(devRoots) {
+		if !prod[id] {
+			graph.Dependencies[id].Dev = true
+		}
+	}
+}
+
+func (graph *Graph) reachable(roots []string) map[string]bool {
+	visited := map[string]bool{}
+	queue := append([]string{}, roots...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		dependency, ok := graph.Dependencies[id]
+		if !ok || visited[id] {
+			continue
+		}
+		visited[id] = true
+		queue = append(queue, dependency.Dependencies...)
+	}
+
+	return visited
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+// normalizePythonName normalizes Python package names according to PEP 503
+func normalizePythonName(name string) string {
+	name = strings.ToLower(name)
+	replacer := strings.NewReplacer("_", "-", ".", "-")
+	name = replacer.Replace(name)
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+
+	return name
+}
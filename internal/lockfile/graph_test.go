@@ -0,0 +1,75 @@
+package lockfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdd(t *testing.T) {
+	graph := NewGraph(EcosystemNpm, "package-lock.json")
+	dependency := graph.Add("debug", "2.6.9")
+	dependency.Dev = true
+
+	assert.Equal(t, "debug@2.6.9", dependency.Id())
+	assert.Same(t, dependency, graph.Add("debug", "2.6.9"))
+	assert.Equal(t, 1, graph.Size())
+}
+
+func TestNormalize(t *testing.T) {
+	graph := NewGraph(EcosystemNpm, "package-lock.json")
+	graph.Add("b", "1.0.0")
+	graph.Add("a", "1.0.0")
+	graph.AddRoot("b@1.0.0")
+	graph.AddRoot("a@1.0.0")
+	graph.AddRoot("missing@1.0.0")
+	graph.AddEdge("b@1.0.0", "missing@1.0.0")
+	graph.AddEdge("b@1.0.0", "a@1.0.0")
+	graph.AddEdge("b@1.0.0", "a@1.0.0")
+	graph.AddEdge("a@1.0.0", "a@1.0.0")
+
+	graph.normalize()
+
+	assert.Equal(t, []string{"a@1.0.0", "b@1.0.0"}, graph.Roots)
+	b, _ := graph.Get("b@1.0.0")
+	assert.Equal(t, []string{"a@1.0.0"}, b.Dependencies)
+	a, _ := graph.Get("a@1.0.0")
+	assert.Empty(t, a.Dependencies)
+}
+
+func TestSortedAndFind(t *testing.T) {
+	graph := NewGraph(EcosystemNpm, "package-lock.json")
+	graph.Add("ms", "2.1.3")
+	graph.Add("debug", "2.6.9")
+	graph.Add("ms", "2.0.0")
+
+	sorted := graph.Sorted()
+	assert.Equal(t, "debug@2.6.9", sorted[0].Id())
+	assert.Equal(t, "ms@2.0.0", sorted[1].Id())
+	assert.Equal(t, "ms@2.1.3", sorted[2].Id())
+	assert.Len(t, graph.Find("ms"), 2)
+	assert.Empty(t, graph.Find("missing"))
+}
+
+func TestMarkDev(t *testing.T) {
+	graph := NewGraph(EcosystemNpm, "package-lock.json")
+	graph.Add("prod", "1.0.0")
+	graph.Add("dev", "1.0.0")
+	graph.Add("shared", "1.0.0")
+	graph.Add("dev-only", "1.0.0")
+	graph.AddEdge("prod@1.0.0", "shared@1.0.0")
+	graph.AddEdge("dev@1.0.0", "shared@1.0.0")
+	graph.AddEdge("dev@1.0.0", "dev-only@1.0.0")
+
+	graph.markDev([]string{"prod@1.0.0"}, []string{"dev@1.0.0"})
+
+	assert.False(t, graph.Dependencies["prod@1.0.0"].Dev)
+	assert.False(t, graph.Dependencies["shared@1.0.0"].Dev)
+	assert.True(t, graph.Dependencies["dev@1.0.0"].Dev)
+	assert.True(t, graph.Dependencies["dev-only@1.0.0"].Dev)
+}
+
+func TestNormalizePythonName(t *testing.T) {
+	assert.Equal(t, "typing-extensions", normalizePythonName("Typing_Extensions"))
+	assert.Equal(t, "zope-interface", normalizePythonName("zope.-interface"))
+}
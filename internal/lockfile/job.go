@@ -0,0 +1,47 @@
+package lockfile
+
+import (
+	"fmt"
+
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const parseStatus = "parsing lock file"
+
+// Job parses a lock file into a dependency graph, without invoking the package manager
+type Job struct {
+	job.BaseJob
+	graph *Graph
+}
+
+func NewJob(file string) *Job {
+	return &Job{BaseJob: job.NewBaseJob(file)}
+}
+
+func (j *Job) Run() {
+	j.SendStatus(parseStatus)
+	graph, err := Parse(j.GetFile())
+	if err != nil {
+		jobError := job.NewBaseJobError(err.Error())
+		jobError.SetStatus(parseStatus)
+		jobError.SetDocumentation("The lock file could not be parsed. Please make sure it is valid and up to date, or resolve it without --no-exec")
+		j.Errors().Critical(jobError)
+
+		return
+	}
+	j.graph = graph
+}
+
+// Graph returns the parsed dependency graph, or nil if the job has not run successfully
+func (j *Job) Graph() *Graph {
+	return j.graph
+}
+
+// Summary describes the number of dependencies in graph
+func Summary(graph *Graph) string {
+	if len(graph.Roots) == 0 {
+		return fmt.Sprintf("%d dependencies", graph.Size())
+	}
+
+	return fmt.Sprintf("%d dependencies (%d direct)", graph.Size(), len(graph.Roots))
+}
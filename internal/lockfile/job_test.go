@@ -0,0 +1,41 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	j := NewJob(filepath.Join("testdata", "nuget", "packages.lock.json"))
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, 3, j.Graph().Size())
+}
+
+func TestRunInvalidLockFile(t *testing.T) {
+	j := NewJob(filepath.Join("testdata", "invalid", "package-lock.json"))
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Nil(t, j.Graph())
+	errs := j.Errors().GetCriticalErrors()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, parseStatus, errs[0].Status())
+	assert.Contains(t, errs[0].Documentation(), "--no-exec")
+}
+
+func TestSummary(t *testing.T) {
+	graph := NewGraph(EcosystemMaven, "gradle.lockfile")
+	graph.Add("junit:junit", "4.13.2")
+	assert.Equal(t, "1 dependencies", Summary(graph))
+
+	graph.AddRoot("junit:junit@4.13.2")
+	assert.Equal(t, "1 dependencies (1 direct)", Summary(graph))
+}
@@ -0,0 +1,91 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	tgfEdgesDelimiter = "#"
+	mavenTestScope    = "test"
+)
+
+var mavenScopes = map[string]bool{
+	"compile":  true,
+	"provided": true,
+	"runtime":  true,
+	"test":     true,
+	"system":   true,
+	"import":   true,
+}
+
+// parseMavenTgf parses the Trivial Graph Format written by `mvn dependency:tree -DoutputType=tgf`:
+//
+//	1 com.example:app:jar:1.0.0
+//	2 com.google.guava:guava:jar:32.1.2-jre:compile
+//	#
+//	1 2 compile
+//
+// The first node is the project itself, and its dependencies are the direct dependencies
+func parseMavenTgf(file string, data []byte) (*Graph, error) {
+	graph := NewGraph(EcosystemMaven, file)
+	ids := map[string]string{}
+	projectNode := ""
+	inEdges := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		if line == tgfEdgesDelimiter {
+			inEdges = true
+
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid line: %s", line)
+		}
+		if inEdges {
+			from, fromOk := ids[fields[0]]
+			to, toOk := ids[fields[1]]
+			if fields[0] == projectNode && toOk {
+				graph.AddRoot(to)
+			} else if fromOk && toOk {
+				graph.AddEdge(from, to)
+			}
+
+			continue
+		}
+		if len(projectNode) == 0 {
+			projectNode = fields[0]
+
+			continue
+		}
+		name, version, scope := parseMavenCoordinates(fields[1])
+		dependency := graph.Add(name, version)
+		dependency.Dev = scope == mavenTestScope
+		ids[fields[0]] = dependency.Id()
+	}
+
+	return graph, scanner.Err()
+}
+
+// parseMavenCoordinates parses coordinates such as `group:artifact:type[:classifier]:version[:scope]`
+func parseMavenCoordinates(coordinates string) (string, string, string) {
+	parts := strings.Split(coordinates, ":")
+	if len(parts) < 3 {
+		return coordinates, "", ""
+	}
+	name := parts[0] + ":" + parts[1]
+	scope := ""
+	if last := parts[len(parts)-1]; len(parts) >= 5 && mavenScopes[last] {
+		scope = last
+		parts = parts[:len(parts)-1]
+	}
+
+	return name, parts[len(parts)-1], scope
+}
@@ -0,0 +1,45 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMavenTgf(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "maven", "maven.debricked.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemMaven, graph.Ecosystem)
+	assert.Equal(t, 5, graph.Size())
+	assert.Equal(t, []string{
+		"com.google.guava:guava@32.1.2-jre",
+		"io.netty:netty-transport-native-epoll@4.1.100.Final",
+		"junit:junit@4.13.2",
+	}, graph.Roots)
+	guava, _ := graph.Get("com.google.guava:guava@32.1.2-jre")
+	assert.Equal(t, []string{"com.google.guava:failureaccess@1.0.1"}, guava.Dependencies)
+	assert.False(t, guava.Dev)
+	hamcrest, _ := graph.Get("org.hamcrest:hamcrest-core@1.3")
+	assert.True(t, hamcrest.Dev)
+}
+
+func TestParseMavenTgfInvalid(t *testing.T) {
+	graph, err := parseMavenTgf("maven.debricked.lock", []byte("1 com.example:app:jar:1.0.0\n2\n"))
+
+	assert.Nil(t, graph)
+	assert.ErrorContains(t, err, "invalid line")
+}
+
+func TestParseMavenCoordinates(t *testing.T) {
+	name, version, scope := parseMavenCoordinates("com.example:app:jar:1.0.0")
+	assert.Equal(t, "com.example:app", name)
+	assert.Equal(t, "1.0.0", version)
+	assert.Empty(t, scope)
+
+	name, version, scope = parseMavenCoordinates("io.netty:netty:jar:linux-x86_64:4.1.100.Final:runtime")
+	assert.Equal(t, "io.netty:netty", name)
+	assert.Equal(t, "4.1.100.Final", version)
+	assert.Equal(t, "runtime", scope)
+}
@@ -0,0 +1,191 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const nodeModules = "node_modules/"
+
+type npmLockFile struct {
+	LockfileVersion int                         `json:"lockfileVersion"`
+	Packages        map[string]npmPackage       `json:"packages"`
+	Dependencies    map[string]npmLegacyPackage `json:"dependencies"`
+}
+
+// npmPackage is an entry of the packages object used by lock file version 2 and 3
+type npmPackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dev                  bool              `json:"dev"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// npmLegacyPackage is an entry of the nested dependencies object used by lock file version 1
+type npmLegacyPackage struct {
+	Version      string                      `json:"version"`
+	Dev          bool                        `json:"dev"`
+	Requires     map[string]string           `json:"requires"`
+	Dependencies map[string]npmLegacyPackage `json:"dependencies"`
+}
+
+// parseNpm parses package-lock.json and npm-shrinkwrap.json files of all lock file versions
+func parseNpm(file string, data []byte) (*Graph, error) {
+	var lockFile npmLockFile
+	if err := json.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	graph := NewGraph(EcosystemNpm, file)
+	if len(lockFile.Packages) > 0 {
+		parseNpmPackages(graph, lockFile.Packages)
+	} else {
+		manifest, err := readNpmManifest(file)
+		if err != nil {
+			return nil, err
+		}
+		parseNpmLegacy(graph, lockFile.Dependencies, manifest)
+	}
+
+	return graph, nil
+}
+
+func parseNpmPackages(graph *Graph, packages map[string]npmPackage) {
+	ids := map[string]string{}
+	for location, pkg := range packages {
+		if !strings.Contains(location, nodeModules) || pkg.Link {
+			continue
+		}
+		name := pkg.Name
+		if len(name) == 0 {
+			name = location[strings.LastIndex(location, nodeModules)+len(nodeModules):]
+		}
+		dependency := graph.Add(name, pkg.Version)
+		dependency.Dev = pkg.Dev
+		ids[location] = dependency.Id()
+	}
+
+	// Dependencies are resolved like Node.js does, from the closest node_modules folder up to the root
+	resolve := func(location string, name string) (string, bool) {
+		for {
+			prefix := location
+			if len(prefix) > 0 {
+				prefix += "/"
+			}
+			if id, ok := ids[prefix+nodeModules+name]; ok {
+				return id, true
+			}
+			if len(location) == 0 {
+				return "", false
+			}
+			i := strings.LastIndex(location, nodeModules)
+			if i < 0 {
+				location = ""
+			} else {
+				location = strings.TrimSuffix(location[:i], "/")
+			}
+		}
+	}
+
+	for location, pkg := range packages {
+		id, isDependency := ids[location]
+		if !isDependency && len(location) > 0 {
+			continue
+		}
+		names := append(mapKeys(pkg.Dependencies), mapKeys(pkg.OptionalDependencies)...)
+		names = append(names, mapKeys(pkg.PeerDependencies)...)
+		if !isDependency {
+			names = append(names, mapKeys(pkg.DevDependencies)...)
+		}
+		for _, name := range names {
+			to, ok := resolve(location, name)
+			if !ok {
+				continue
+			}
+			if isDependency {
+				graph.AddEdge(id, to)
+			} else {
+				graph.AddRoot(to)
+			}
+		}
+	}
+}
+
+func parseNpmLegacy(graph *Graph, dependencies map[string]npmLegacyPackage, manifest *npmPackage) {
+	type scope struct {
+		parent       *scope
+		dependencies map[string]npmLegacyPackage
+	}
+	resolve := func(s *scope, name string) (string, bool) {
+		for ; s != nil; s = s.parent {
+			if pkg, ok := s.dependencies[name]; ok {
+				return Id(name, pkg.Version), true
+			}
+		}
+
+		return "", false
+	}
+	required := map[string]bool{}
+
+	var walk func(s *scope)
+	walk = func(s *scope) {
+		for name, pkg := range s.dependencies {
+			dependency := graph.Add(name, pkg.Version)
+			dependency.Dev = pkg.Dev
+			nested := &scope{parent: s, dependencies: pkg.Dependencies}
+			for requiredName := range pkg.Requires {
+				if to, ok := resolve(nested, requiredName); ok {
+					graph.AddEdge(dependency.Id(), to)
+					required[to] = true
+				}
+			}
+			walk(nested)
+		}
+	}
+	root := &scope{dependencies: dependencies}
+	walk(root)
+
+	// Lock file version 1 does not record the direct dependencies. They are read from package.json,
+	// or if it is missing, assumed to be the top level dependencies no other dependency requires
+	if manifest != nil {
+		names := append(mapKeys(manifest.Dependencies), mapKeys(manifest.DevDependencies)...)
+		for _, name := range append(names, mapKeys(manifest.OptionalDependencies)...) {
+			if to, ok := resolve(root, name); ok {
+				graph.AddRoot(to)
+			}
+		}
+
+		return
+	}
+	for name, pkg := range dependencies {
+		id := Id(name, pkg.Version)
+		if !required[id] {
+			graph.AddRoot(id)
+		}
+	}
+}
+
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func readNpmManifest(file string) (*npmPackage, error) {
+	data, ok, err := readSibling(file, "package.json")
+	if !ok {
+		return nil, err
+	}
+	var manifest npmPackage
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
@@ -0,0 +1,36 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNpmV1(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "npm", "v1", "package-lock.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemNpm, graph.Ecosystem)
+	assert.Equal(t, 3, graph.Size())
+	assert.Equal(t, []string{"debug@2.6.9", "ms@2.1.3"}, graph.Roots)
+	debug, _ := graph.Get("debug@2.6.9")
+	assert.Equal(t, []string{"ms@2.0.0"}, debug.Dependencies)
+	assert.False(t, debug.Dev)
+	ms, _ := graph.Get("ms@2.1.3")
+	assert.True(t, ms.Dev)
+}
+
+func TestParseNpmV3(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "npm", "v3", "package-lock.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, graph.Size())
+	assert.Equal(t, []string{"@babel/highlight@7.22.20", "debug@2.6.9", "ms@2.1.3"}, graph.Roots)
+	highlight, _ := graph.Get("@babel/highlight@7.22.20")
+	assert.Equal(t, []string{"js-tokens@4.0.0"}, highlight.Dependencies)
+	debug, _ := graph.Get("debug@2.6.9")
+	assert.Equal(t, []string{"ms@2.0.0"}, debug.Dependencies)
+	ms, _ := graph.Get("ms@2.1.3")
+	assert.True(t, ms.Dev)
+}
@@ -0,0 +1,58 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	nugetDirectDependency  = "Direct"
+	nugetProjectDependency = "Project"
+)
+
+type nugetLockFile struct {
+	Dependencies map[string]map[string]nugetPackage `json:"dependencies"`
+}
+
+type nugetPackage struct {
+	Type         string            `json:"type"`
+	Resolved     string            `json:"resolved"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// parseNuget parses packages.lock.json files. Dependencies are locked per target framework,
+// and the graph is the union of all target frameworks
+func parseNuget(file string, data []byte) (*Graph, error) {
+	var lockFile nugetLockFile
+	if err := json.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	graph := NewGraph(EcosystemNuget, file)
+	for _, packages := range lockFile.Dependencies {
+		ids := map[string]string{}
+		for name, pkg := range packages {
+			// Project references are part of the solution and not dependencies
+			if pkg.Type == nugetProjectDependency {
+				continue
+			}
+			id := graph.Add(name, pkg.Resolved).Id()
+			ids[strings.ToLower(name)] = id
+			if pkg.Type == nugetDirectDependency {
+				graph.AddRoot(id)
+			}
+		}
+		for name, pkg := range packages {
+			from, ok := ids[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+			for dependencyName := range pkg.Dependencies {
+				if to, ok := ids[strings.ToLower(dependencyName)]; ok {
+					graph.AddEdge(from, to)
+				}
+			}
+		}
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,20 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNuget(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "nuget", "packages.lock.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemNuget, graph.Ecosystem)
+	assert.Equal(t, 3, graph.Size())
+	assert.Equal(t, []string{"Newtonsoft.Json@13.0.3", "Serilog@3.0.1"}, graph.Roots)
+	serilog, _ := graph.Get("Serilog@3.0.1")
+	assert.Equal(t, []string{"System.Memory@4.5.5"}, serilog.Dependencies)
+	assert.Empty(t, graph.Find("core"))
+}
@@ -0,0 +1,151 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+)
+
+var UnsupportedLockFileErr = errors.New("unsupported lock file")
+
+type parseFunc func(path string, data []byte) (*Graph, error)
+
+type parser struct {
+	// pattern is matched against the base name of lock files
+	pattern string
+	parse   parseFunc
+}
+
+// parsers maps lock file names to their parsers. Package manager native lock files are followed by
+// the Debricked lock files written when resolving manifest files
+var parsers = []parser{
+	{"package-lock.json", parseNpm},
+	{"npm-shrinkwrap.json", parseNpm},
+	{"yarn.lock", parseYarn},
+	{"pnpm-lock.yaml", parsePnpm},
+	{"composer.lock", parseComposer},
+	{"go.sum", parseGoSum},
+	{"poetry.lock", parsePoetry},
+	{"Pipfile.lock", parsePipfile},
+	{"pdm.lock", parsePdm},
+	{"packages.lock.json", parseNuget},
+	{"gradle.lockfile", parseGradle},
+	{"Cargo.lock", parseCargo},
+	{"maven.debricked.lock", parseMavenTgf},
+	{"gradle.debricked.lock", parseGradleReport},
+	{"gomod.debricked.lock", parseGoModGraph},
+	{"*.pip.debricked.lock", parsePipLock},
+	{"bower.debricked.lock", parseBowerList},
+	{"packages.config.nuget.debricked.lock", parseNuget},
+}
+
+func findParser(path string) (parseFunc, bool) {
+	base := filepath.Base(path)
+	for _, p := range parsers {
+		if matched, _ := filepath.Match(p.pattern, base); matched {
+			return p.parse, true
+		}
+	}
+
+	return nil, false
+}
+
+// Supported returns true if the lock file at path can be parsed without invoking a package manager
+func Supported(path string) bool {
+	_, ok := findParser(path)
+
+	return ok
+}
+
+// siblingFileNames are the manifest files read next to lock files when parsing them
+var siblingFileNames = []string{"package.json", "composer.json", "go.mod", "pyproject.toml", "Pipfile", "Cargo.toml"}
+
+// UsedByParsers returns true if the file at path is a parsable lock file, or a manifest file read when parsing one
+func UsedByParsers(path string) bool {
+	base := filepath.Base(path)
+	for _, name := range siblingFileNames {
+		if base == name {
+			return true
+		}
+	}
+
+	return Supported(path)
+}
+
+// SupportedFileNames returns the names, or name patterns, of all lock files that can be parsed
+func SupportedFileNames() []string {
+	names := make([]string, 0, len(parsers))
+	for _, p := range parsers {
+		names = append(names, p.pattern)
+	}
+
+	return names
+}
+
+// Parse reads the lock file at path into a dependency graph
+func Parse(path string) (*Graph, error) {
+	parse, ok := findParser(path)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", UnsupportedLockFileErr, path)
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	graph, err := parse(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return graph.normalize(), nil
+}
+
+// readSibling reads a file in the same directory as path. A missing file is not an error
+func readSibling(path string, name string) ([]byte, bool, error) {
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(path), name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+
+	return data, err == nil, err
+}
+
+// FindInGroup returns the parsable lock files of fileGroup. Lock files next to the manifest file are included,
+// as lock files such as poetry.lock are not necessarily part of the supported formats
+func FindInGroup(fileGroup file.Group) []string {
+	var found []string
+	for _, lockFile := range fileGroup.LockFiles {
+		if Supported(lockFile) {
+			found = append(found, lockFile)
+		}
+	}
+	if !fileGroup.HasFile() {
+		return found
+	}
+	for _, name := range SupportedFileNames() {
+		// Lock files named after their manifest file are found by the finder
+		if strings.Contains(name, "*") {
+			continue
+		}
+		lockFile := filepath.Join(filepath.Dir(fileGroup.ManifestFile), name)
+		if _, err := os.Stat(lockFile); err == nil && !contains(found, lockFile) {
+			found = append(found, lockFile)
+		}
+	}
+
+	return found
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,75 @@
+package lockfile
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported(filepath.Join("dir", "yarn.lock")))
+	assert.True(t, Supported("gradle.lockfile"))
+	assert.False(t, Supported("package.json"))
+	assert.True(t, Supported("requirements-dev.txt.pip.debricked.lock"))
+	assert.False(t, Supported(".debricked-fingerprints.txt"))
+}
+
+func TestUsedByParsers(t *testing.T) {
+	assert.True(t, UsedByParsers(filepath.Join("dir", "yarn.lock")))
+	assert.True(t, UsedByParsers(filepath.Join("dir", "package.json")))
+	assert.True(t, UsedByParsers("Pipfile"))
+	assert.False(t, UsedByParsers("pom.xml"))
+	assert.False(t, UsedByParsers(filepath.Join("package.json", "README.md")))
+}
+
+func TestParseUnsupported(t *testing.T) {
+	graph, err := Parse("package.json")
+
+	assert.Nil(t, graph)
+	assert.ErrorIs(t, err, UnsupportedLockFileErr)
+}
+
+func TestParseMissingFile(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "missing", "yarn.lock"))
+
+	assert.Nil(t, graph)
+	assert.Error(t, err)
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, name := range []string{"package-lock.json", "gradle.lockfile"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join("testdata", "invalid", name)
+			graph, err := Parse(path)
+
+			assert.Nil(t, graph)
+			assert.ErrorContains(t, err, "failed to parse "+path)
+			assert.False(t, errors.Is(err, UnsupportedLockFileErr))
+		})
+	}
+}
+
+func TestFindInGroup(t *testing.T) {
+	dir := filepath.Join("testdata", "poetry")
+	group := file.NewGroup(filepath.Join(dir, "pyproject.toml"), nil, []string{})
+
+	assert.Equal(t, []string{filepath.Join(dir, "poetry.lock")}, FindInGroup(*group))
+}
+
+func TestFindInGroupWithoutManifest(t *testing.T) {
+	yarnLock := filepath.Join("testdata", "yarn", "classic", "yarn.lock")
+	group := file.NewGroup("", nil, []string{yarnLock, ".debricked-fingerprints.txt"})
+
+	assert.Equal(t, []string{yarnLock}, FindInGroup(*group))
+}
+
+func TestFindInGroupDeduplicates(t *testing.T) {
+	dir := filepath.Join("testdata", "yarn", "classic")
+	yarnLock := filepath.Join(dir, "yarn.lock")
+	group := file.NewGroup(filepath.Join(dir, "package.json"), nil, []string{yarnLock})
+
+	assert.Equal(t, []string{yarnLock}, FindInGroup(*group))
+}
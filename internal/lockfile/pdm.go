@@ -0,0 +1,82 @@
+package lockfile
+
+import (
+	"github.com/pelletier/go-toml/v2"
+)
+
+const pdmDefaultGroup = "default"
+
+type pdmLockFile struct {
+	Packages []pdmPackage `toml:"package"`
+}
+
+type pdmPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Groups       []string `toml:"groups"`
+	Sections     []string `toml:"sections"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+// parsePdm parses pdm.lock files. Dependencies are PEP 508 requirements, and the direct dependencies are read from pyproject.toml
+func parsePdm(file string, data []byte) (*Graph, error) {
+	var lockFile pdmLockFile
+	if err := toml.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	graph := NewGraph(EcosystemPypi, file)
+	ids := map[string]string{}
+	for _, pkg := range lockFile.Packages {
+		name := normalizePythonName(pkg.Name)
+		dependency := graph.Add(name, pkg.Version)
+		// Lock files written before PDM 2.0 use sections instead of groups
+		groups := append(pkg.Groups, pkg.Sections...)
+		dependency.Dev = len(groups) > 0 && !contains(groups, pdmDefaultGroup)
+		ids[name] = dependency.Id()
+	}
+	for _, pkg := range lockFile.Packages {
+		from := ids[normalizePythonName(pkg.Name)]
+		for _, requirement := range pkg.Dependencies {
+			match := pythonRequirementName.FindStringSubmatch(requirement)
+			if match == nil {
+				continue
+			}
+			if to, ok := ids[normalizePythonName(match[1])]; ok {
+				graph.AddEdge(from, to)
+			}
+		}
+	}
+
+	manifestData, ok, err := readSibling(file, "pyproject.toml")
+	if !ok {
+		return graph, err
+	}
+	var manifest pyproject
+	if err = toml.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, err
+	}
+	requirements := manifest.Project.Dependencies
+	for _, optional := range manifest.Project.OptionalDependencies {
+		requirements = append(requirements, optional...)
+	}
+	for _, dev := range manifest.Tool.Pdm.DevDependencies {
+		requirements = append(requirements, dev...)
+	}
+	for _, group := range manifest.DependencyGroups {
+		for _, requirement := range group {
+			// Groups may include other groups, which are tables rather than requirements
+			if requirement, isString := requirement.(string); isString {
+				requirements = append(requirements, requirement)
+			}
+		}
+	}
+	for _, requirement := range requirements {
+		if match := pythonRequirementName.FindStringSubmatch(requirement); match != nil {
+			if id, ok := ids[normalizePythonName(match[1])]; ok {
+				graph.AddRoot(id)
+			}
+		}
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,38 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePdm(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "pdm", "pdm.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemPypi, graph.Ecosystem)
+	assert.Equal(t, 5, graph.Size())
+	assert.Equal(t, []string{"pytest@8.0.2", "requests@2.31.0"}, graph.Roots)
+	requests, _ := graph.Get("requests@2.31.0")
+	assert.Equal(t, []string{"certifi@2024.2.2", "pysocks@1.7.1"}, requests.Dependencies)
+	assert.False(t, requests.Dev)
+	pytest, _ := graph.Get("pytest@8.0.2")
+	assert.Equal(t, []string{"iniconfig@2.0.0"}, pytest.Dependencies)
+	assert.True(t, pytest.Dev)
+}
+
+func TestParsePdmWithoutPyproject(t *testing.T) {
+	graph, err := parsePdm(filepath.Join("testdata", "invalid", "pdm.lock"), []byte(`
+[[package]]
+name = "certifi"
+version = "2024.2.2"
+sections = ["default"]
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, graph.Size())
+	assert.Empty(t, graph.Roots)
+	certifi, _ := graph.Get("certifi@2024.2.2")
+	assert.False(t, certifi.Dev)
+}
@@ -0,0 +1,63 @@
+package lockfile
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	pipLockFileDelimiter = "***"
+	pipShowDelimiter     = "---"
+)
+
+// parsePipLock parses *.pip.debricked.lock files, consisting of the requirements file, the output of `pip list`
+// and the output of `pip show` for all installed packages, delimited by `***`
+func parsePipLock(file string, data []byte) (*Graph, error) {
+	sections := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"+pipLockFileDelimiter+"\n")
+	if len(sections) < 3 {
+		return nil, errors.New("pip show output is missing")
+	}
+
+	graph := NewGraph(EcosystemPypi, file)
+	ids := map[string]string{}
+	requires := map[string][]string{}
+	for _, block := range strings.Split(sections[2], "\n"+pipShowDelimiter+"\n") {
+		fields := map[string]string{}
+		for _, line := range strings.Split(block, "\n") {
+			if key, value, ok := strings.Cut(line, ":"); ok {
+				fields[key] = strings.TrimSpace(value)
+			}
+		}
+		name := normalizePythonName(fields["Name"])
+		if len(name) == 0 {
+			continue
+		}
+		ids[name] = graph.Add(name, fields["Version"]).Id()
+		for _, required := range strings.Split(fields["Requires"], ",") {
+			if required = strings.TrimSpace(required); len(required) > 0 {
+				requires[name] = append(requires[name], normalizePythonName(required))
+			}
+		}
+	}
+	for name, requiredNames := range requires {
+		for _, required := range requiredNames {
+			if to, ok := ids[required]; ok {
+				graph.AddEdge(ids[name], to)
+			}
+		}
+	}
+
+	for _, line := range strings.Split(sections[0], "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "-") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if match := pythonRequirementName.FindStringSubmatch(line); match != nil {
+			if id, ok := ids[normalizePythonName(match[1])]; ok {
+				graph.AddRoot(id)
+			}
+		}
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,28 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePipLock(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "pip", "requirements.txt.pip.debricked.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemPypi, graph.Ecosystem)
+	assert.Equal(t, 4, graph.Size())
+	assert.Equal(t, []string{"flask@2.1.2", "requests@2.31.0"}, graph.Roots)
+	flask, _ := graph.Get("flask@2.1.2")
+	assert.Equal(t, []string{"werkzeug@2.1.2"}, flask.Dependencies)
+	requests, _ := graph.Get("requests@2.31.0")
+	assert.Equal(t, []string{"certifi@2023.7.22"}, requests.Dependencies)
+}
+
+func TestParsePipLockWithoutShowOutput(t *testing.T) {
+	graph, err := parsePipLock("requirements.txt.pip.debricked.lock", []byte("Flask==2.1.2\n"))
+
+	assert.Nil(t, graph)
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+type pipfileLock struct {
+	Default map[string]pipfileLockPackage `json:"default"`
+	Develop map[string]pipfileLockPackage `json:"develop"`
+}
+
+type pipfileLockPackage struct {
+	Version string `json:"version"`
+	Ref     string `json:"ref"`
+}
+
+type pipfile struct {
+	Packages    map[string]interface{} `toml:"packages"`
+	DevPackages map[string]interface{} `toml:"dev-packages"`
+}
+
+// parsePipfile parses Pipfile.lock files. The lock file is flat, so the graph has no edges,
+// while the direct dependencies are read from Pipfile
+func parsePipfile(file string, data []byte) (*Graph, error) {
+	var lockFile pipfileLock
+	if err := json.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	graph := NewGraph(EcosystemPypi, file)
+	ids := map[string]string{}
+	add := func(packages map[string]pipfileLockPackage, dev bool) {
+		for name, pkg := range packages {
+			version := strings.TrimPrefix(pkg.Version, "==")
+			if len(version) == 0 {
+				// Dependencies installed from version control are locked to a ref instead of a version
+				version = pkg.Ref
+			}
+			name = normalizePythonName(name)
+			dependency := graph.Add(name, version)
+			dependency.Dev = dev
+			ids[name] = dependency.Id()
+		}
+	}
+	add(lockFile.Develop, true)
+	add(lockFile.Default, false)
+
+	manifestData, ok, err := readSibling(file, "Pipfile")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		var manifest pipfile
+		if err = toml.Unmarshal(manifestData, &manifest); err != nil {
+			return nil, err
+		}
+		for _, packages := range []map[string]interface{}{manifest.Packages, manifest.DevPackages} {
+			for name := range packages {
+				if id, ok := ids[normalizePythonName(name)]; ok {
+					graph.AddRoot(id)
+				}
+			}
+		}
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,22 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePipfile(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "pipfile", "Pipfile.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemPypi, graph.Ecosystem)
+	assert.Equal(t, 4, graph.Size())
+	assert.Equal(t, []string{"pytest@7.4.2", "requests@2.31.0"}, graph.Roots)
+	pytest, _ := graph.Get("pytest@7.4.2")
+	assert.True(t, pytest.Dev)
+	typingExtensions := graph.Find("typing-extensions")
+	assert.Len(t, typingExtensions, 1)
+	assert.Equal(t, "7fa4a3b2c8b4bc5a7e24a4f0b8ad1f4d1e9b3c2a", typingExtensions[0].Version)
+}
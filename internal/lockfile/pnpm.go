@@ -0,0 +1,163 @@
+package lockfile
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const pnpmLinkPrefix = "link:"
+
+type pnpmLockFile struct {
+	LockfileVersion string                  `yaml:"lockfileVersion"`
+	Importers       map[string]pnpmImporter `yaml:"importers"`
+	// Lock files before version 9 of projects without workspaces have the dependencies of the project at the top level
+	pnpmImporter `yaml:",inline"`
+	Packages     map[string]pnpmPackage `yaml:"packages"`
+	// Lock files of version 9 record the dependencies of packages in snapshots, and only metadata in packages
+	Snapshots map[string]pnpmPackage `yaml:"snapshots"`
+}
+
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmReference `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmReference `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmReference `yaml:"optionalDependencies"`
+}
+
+type pnpmPackage struct {
+	Dev                  bool              `yaml:"dev"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// pnpmReference is the resolved version of a dependency of an importer. Lock files before version 6 write the version,
+// later versions a mapping of the specifier and the version
+type pnpmReference struct {
+	Version string
+}
+
+func (reference *pnpmReference) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		reference.Version = value.Value
+
+		return nil
+	}
+	var mapping struct {
+		Version string `yaml:"version"`
+	}
+	err := value.Decode(&mapping)
+	reference.Version = mapping.Version
+
+	return err
+}
+
+// parsePnpm parses pnpm-lock.yaml files of lock file version 5 and later. The dependencies of all workspace projects are roots
+func parsePnpm(file string, data []byte) (*Graph, error) {
+	var lockFile pnpmLockFile
+	if err := yaml.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	legacy := isPnpmLegacy(lockFile.LockfileVersion)
+	packages := lockFile.Snapshots
+	if len(packages) == 0 {
+		packages = lockFile.Packages
+	}
+
+	graph := NewGraph(EcosystemNpm, file)
+	hasDevFlags := false
+	for key, pkg := range packages {
+		name, version := parsePnpmKey(key, legacy)
+		dependency := graph.Add(name, version)
+		dependency.Dev = pkg.Dev
+		hasDevFlags = hasDevFlags || pkg.Dev
+	}
+	for key, pkg := range packages {
+		from := Id(parsePnpmKey(key, legacy))
+		for _, dependencies := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies} {
+			for name, reference := range dependencies {
+				if to, ok := resolvePnpmReference(name, reference, legacy); ok {
+					graph.AddEdge(from, to)
+				}
+			}
+		}
+	}
+
+	importers := lockFile.Importers
+	if len(importers) == 0 {
+		importers = map[string]pnpmImporter{".": lockFile.pnpmImporter}
+	}
+	var prodRoots, devRoots []string
+	addRoots := func(dependencies map[string]pnpmReference, roots *[]string) {
+		for name, reference := range dependencies {
+			id, ok := resolvePnpmReference(name, reference.Version, legacy)
+			if _, exists := graph.Get(id); ok && exists {
+				graph.AddRoot(id)
+				*roots = append(*roots, id)
+			}
+		}
+	}
+	for _, importer := range importers {
+		addRoots(importer.Dependencies, &prodRoots)
+		addRoots(importer.OptionalDependencies, &prodRoots)
+		addRoots(importer.DevDependencies, &devRoots)
+	}
+	// Lock files of version 9 no longer flag dev dependencies
+	if !hasDevFlags {
+		graph.markDev(prodRoots, devRoots)
+	}
+
+	return graph, nil
+}
+
+// isPnpmLegacy returns true for lock files before version 6, which separate names from versions by slashes
+func isPnpmLegacy(lockfileVersion string) bool {
+	major, _, _ := strings.Cut(lockfileVersion, ".")
+	version, err := strconv.Atoi(major)
+
+	return err == nil && version < 6
+}
+
+// parsePnpmKey returns the name and version of a package key, such as /@babel/core@7.23.0(supports-color@8.1.1) or
+// /@babel/core/7.23.0_supports-color@8.1.1 before lock file version 6
+func parsePnpmKey(key string, legacy bool) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if legacy {
+		separator := strings.LastIndex(key, "/")
+		if separator < 0 {
+			return key, ""
+		}
+		version, _, _ := strings.Cut(key[separator+1:], "_")
+
+		return key[:separator], version
+	}
+	key, _, _ = strings.Cut(key, "(")
+	separator := strings.LastIndex(key, "@")
+	if separator <= 0 {
+		return key, ""
+	}
+
+	return key[:separator], key[separator+1:]
+}
+
+// resolvePnpmReference returns the id of the package a dependency named name refers to. The reference is either a
+// version, or the key of another package for aliased dependencies. Links to workspace projects are not packages
+func resolvePnpmReference(name string, reference string, legacy bool) (string, bool) {
+	if len(reference) == 0 || strings.HasPrefix(reference, pnpmLinkPrefix) {
+		return "", false
+	}
+	if strings.HasPrefix(reference, "/") {
+		return Id(parsePnpmKey(reference, legacy)), true
+	}
+	if legacy {
+		version, _, _ := strings.Cut(reference, "_")
+
+		return Id(name, version), true
+	}
+	version, _, _ := strings.Cut(reference, "(")
+	if strings.LastIndex(version, "@") > 0 {
+		return Id(parsePnpmKey(reference, legacy)), true
+	}
+
+	return Id(name, version), true
+}
@@ -0,0 +1,65 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePnpm(t *testing.T) {
+	for _, version := range []string{"v5", "v6", "v9"} {
+		t.Run(version, func(t *testing.T) {
+			graph, err := Parse(filepath.Join("testdata", "pnpm", version, "pnpm-lock.yaml"))
+
+			assert.NoError(t, err)
+			assert.Equal(t, EcosystemNpm, graph.Ecosystem)
+			assert.Equal(t, 5, graph.Size())
+			assert.Equal(t, []string{"@babel/highlight@7.22.20", "debug@2.6.9", "ms@2.1.3"}, graph.Roots)
+			highlight, _ := graph.Get("@babel/highlight@7.22.20")
+			assert.Equal(t, []string{"js-tokens@4.0.0"}, highlight.Dependencies)
+			debug, _ := graph.Get("debug@2.6.9")
+			assert.Equal(t, []string{"ms@2.0.0"}, debug.Dependencies)
+			assert.False(t, debug.Dev)
+			ms, _ := graph.Get("ms@2.1.3")
+			assert.True(t, ms.Dev)
+		})
+	}
+}
+
+func TestParsePnpmKey(t *testing.T) {
+	cases := []struct {
+		key     string
+		legacy  bool
+		name    string
+		version string
+	}{
+		{"/@babel/core@7.23.0(supports-color@8.1.1)", false, "@babel/core", "7.23.0"},
+		{"@babel/core@7.23.0", false, "@babel/core", "7.23.0"},
+		{"/string_decoder@1.3.0", false, "string_decoder", "1.3.0"},
+		{"/@babel/core/7.23.0_supports-color@8.1.1", true, "@babel/core", "7.23.0"},
+		{"/string_decoder/1.3.0", true, "string_decoder", "1.3.0"},
+	}
+	for _, c := range cases {
+		name, version := parsePnpmKey(c.key, c.legacy)
+		assert.Equal(t, c.name, name, c.key)
+		assert.Equal(t, c.version, version, c.key)
+	}
+}
+
+func TestResolvePnpmReference(t *testing.T) {
+	id, ok := resolvePnpmReference("string-width-cjs", "/string-width@4.2.3", false)
+	assert.True(t, ok)
+	assert.Equal(t, "string-width@4.2.3", id)
+
+	id, ok = resolvePnpmReference("string-width-cjs", "string-width@4.2.3", false)
+	assert.True(t, ok)
+	assert.Equal(t, "string-width@4.2.3", id)
+
+	id, ok = resolvePnpmReference("debug", "2.6.9(supports-color@8.1.1)", false)
+	assert.True(t, ok)
+	assert.Equal(t, "debug@2.6.9", id)
+
+	_, ok = resolvePnpmReference("shared", "link:../shared", false)
+	assert.False(t, ok)
+}
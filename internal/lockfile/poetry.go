@@ -0,0 +1,122 @@
+package lockfile
+
+import (
+	"regexp"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+const poetryDevCategory = "dev"
+
+// pythonRequirementName matches the name of a PEP 508 requirement, such as `requests[socks]>=2.0`
+var pythonRequirementName = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+type poetryLockFile struct {
+	Packages []poetryPackage `toml:"package"`
+}
+
+type poetryPackage struct {
+	Name         string                 `toml:"name"`
+	Version      string                 `toml:"version"`
+	Category     string                 `toml:"category"`
+	Dependencies map[string]interface{} `toml:"dependencies"`
+}
+
+type pyproject struct {
+	Project struct {
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Dependencies    map[string]interface{} `toml:"dependencies"`
+			DevDependencies map[string]interface{} `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]interface{} `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+		Pdm struct {
+			DevDependencies map[string][]string `toml:"dev-dependencies"`
+		} `toml:"pdm"`
+	} `toml:"tool"`
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+}
+
+// parsePoetry parses poetry.lock files. The direct dependencies are read from pyproject.toml
+func parsePoetry(file string, data []byte) (*Graph, error) {
+	var lockFile poetryLockFile
+	if err := toml.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	graph := NewGraph(EcosystemPypi, file)
+	ids := map[string]string{}
+	hasCategories := false
+	for _, pkg := range lockFile.Packages {
+		name := normalizePythonName(pkg.Name)
+		dependency := graph.Add(name, pkg.Version)
+		// Poetry 1.5 and later no longer writes categories, dev dependencies are then derived from pyproject.toml
+		dependency.Dev = pkg.Category == poetryDevCategory
+		hasCategories = hasCategories || len(pkg.Category) > 0
+		ids[name] = dependency.Id()
+	}
+	for _, pkg := range lockFile.Packages {
+		from := ids[normalizePythonName(pkg.Name)]
+		for name := range pkg.Dependencies {
+			if to, ok := ids[normalizePythonName(name)]; ok {
+				graph.AddEdge(from, to)
+			}
+		}
+	}
+
+	manifestData, ok, err := readSibling(file, "pyproject.toml")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return graph, nil
+	}
+	var manifest pyproject
+	if err = toml.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, err
+	}
+	var prodRoots, devRoots []string
+	addRoot := func(name string, roots *[]string) {
+		if id, ok := ids[normalizePythonName(name)]; ok {
+			graph.AddRoot(id)
+			*roots = append(*roots, id)
+		}
+	}
+	poetry := manifest.Tool.Poetry
+	for name := range poetry.Dependencies {
+		addRoot(name, &prodRoots)
+	}
+	for _, requirement := range manifest.Project.Dependencies {
+		if match := pythonRequirementName.FindStringSubmatch(requirement); match != nil {
+			addRoot(match[1], &prodRoots)
+		}
+	}
+	for _, requirements := range manifest.Project.OptionalDependencies {
+		for _, requirement := range requirements {
+			if match := pythonRequirementName.FindStringSubmatch(requirement); match != nil {
+				addRoot(match[1], &prodRoots)
+			}
+		}
+	}
+	for name := range poetry.DevDependencies {
+		addRoot(name, &devRoots)
+	}
+	for group, dependencies := range poetry.Group {
+		roots := &devRoots
+		if group == "main" {
+			roots = &prodRoots
+		}
+		for name := range dependencies.Dependencies {
+			addRoot(name, roots)
+		}
+	}
+	if !hasCategories {
+		graph.markDev(prodRoots, devRoots)
+	}
+
+	return graph, nil
+}
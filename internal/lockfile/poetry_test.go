@@ -0,0 +1,22 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePoetry(t *testing.T) {
+	graph, err := Parse(filepath.Join("testdata", "poetry", "poetry.lock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EcosystemPypi, graph.Ecosystem)
+	assert.Equal(t, 4, graph.Size())
+	assert.Equal(t, []string{"pytest@7.4.2", "requests@2.31.0"}, graph.Roots)
+	requests, _ := graph.Get("requests@2.31.0")
+	assert.Equal(t, []string{"certifi@2023.7.22"}, requests.Dependencies)
+	assert.False(t, requests.Dev)
+	iniconfig, _ := graph.Get("iniconfig@2.0.0")
+	assert.True(t, iniconfig.Dev)
+}
@@ -0,0 +1,15 @@
+module example.com/app
+
+go 1.20
+
+require (
+	github.com/spf13/cobra v1.7.0
+	golang.org/x/mod v0.16.0
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+)
+
+replace golang.org/x/mod => golang.org/x/mod v0.17.0
@@ -0,0 +1,218 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const yarnBerryMetadataKey = "__metadata"
+
+type yarnEntry struct {
+	descriptors  []string
+	name         string
+	version      string
+	dependencies map[string]string
+	workspace    bool
+}
+
+type yarnBerryEntry struct {
+	Version              string            `yaml:"version"`
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// parseYarn parses yarn.lock files of both Yarn classic (v1) and Yarn Berry (v2+)
+func parseYarn(file string, data []byte) (*Graph, error) {
+	var entries []yarnEntry
+	var err error
+	berry := isYarnBerry(data)
+	if berry {
+		entries, err = parseYarnBerryEntries(data)
+	} else {
+		entries, err = parseYarnClassicEntries(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	graph := NewGraph(EcosystemNpm, file)
+	descriptors := map[string]string{}
+	for _, entry := range entries {
+		if entry.workspace {
+			continue
+		}
+		id := graph.Add(entry.name, entry.version).Id()
+		for _, descriptor := range entry.descriptors {
+			descriptors[descriptor] = id
+		}
+	}
+	resolve := func(name string, versionRange string) (string, bool) {
+		id, ok := descriptors[name+"@"+versionRange]
+		if !ok && berry {
+			// Yarn Berry omits the default npm protocol in dependency ranges
+			id, ok = descriptors[name+"@npm:"+versionRange]
+		}
+
+		return id, ok
+	}
+	for _, entry := range entries {
+		if entry.workspace {
+			continue
+		}
+		from := Id(entry.name, entry.version)
+		for name, versionRange := range entry.dependencies {
+			if to, ok := resolve(name, versionRange); ok {
+				graph.AddEdge(from, to)
+			}
+		}
+	}
+
+	// yarn.lock does not record the direct dependencies, they are read from package.json
+	manifest, err := readNpmManifest(file)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		var prodRoots, devRoots []string
+		addRoots := func(dependencies map[string]string, roots *[]string) {
+			for name, versionRange := range dependencies {
+				if to, ok := resolve(name, versionRange); ok {
+					graph.AddRoot(to)
+					*roots = append(*roots, to)
+				}
+			}
+		}
+		addRoots(manifest.Dependencies, &prodRoots)
+		addRoots(manifest.OptionalDependencies, &prodRoots)
+		addRoots(manifest.DevDependencies, &devRoots)
+		graph.markDev(prodRoots, devRoots)
+	}
+
+	return graph, nil
+}
+
+func isYarnBerry(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), yarnBerryMetadataKey+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseYarnBerryEntries(data []byte) ([]yarnEntry, error) {
+	var lockFile map[string]yarnBerryEntry
+	if err := yaml.Unmarshal(data, &lockFile); err != nil {
+		return nil, err
+	}
+	entries := make([]yarnEntry, 0, len(lockFile))
+	for key, berryEntry := range lockFile {
+		if key == yarnBerryMetadataKey {
+			continue
+		}
+		entry := yarnEntry{
+			version:      berryEntry.Version,
+			dependencies: map[string]string{},
+			workspace:    strings.Contains(berryEntry.Resolution, "@workspace:"),
+		}
+		for _, descriptor := range strings.Split(key, ",") {
+			entry.descriptors = append(entry.descriptors, strings.TrimSpace(descriptor))
+		}
+		entry.name, _ = splitYarnDescriptor(entry.descriptors[0])
+		for _, dependencies := range []map[string]string{berryEntry.Dependencies, berryEntry.OptionalDependencies} {
+			for name, versionRange := range dependencies {
+				entry.dependencies[name] = versionRange
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseYarnClassicEntries parses the custom format of Yarn classic lock files:
+//
+//	"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+//	  version "7.12.13"
+//	  dependencies:
+//	    "@babel/highlight" "^7.12.13"
+func parseYarnClassicEntries(data []byte) ([]yarnEntry, error) {
+	var entries []yarnEntry
+	var entry *yarnEntry
+	inDependencies := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indentation := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indentation == 0:
+			if entry != nil {
+				entries = append(entries, *entry)
+			}
+			entry = &yarnEntry{dependencies: map[string]string{}}
+			for _, descriptor := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				entry.descriptors = append(entry.descriptors, unquote(strings.TrimSpace(descriptor)))
+			}
+			entry.name, _ = splitYarnDescriptor(entry.descriptors[0])
+			inDependencies = false
+		case entry == nil:
+			continue
+		case indentation == 2:
+			key, value := splitYarnField(trimmed)
+			inDependencies = key == "dependencies:" || key == "optionalDependencies:"
+			if key == "version" {
+				entry.version = value
+			}
+		case inDependencies:
+			name, versionRange := splitYarnField(trimmed)
+			entry.dependencies[name] = versionRange
+		}
+	}
+	if entry != nil {
+		entries = append(entries, *entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// splitYarnDescriptor splits a descriptor such as `@babel/core@^7.0.0` into name and range
+func splitYarnDescriptor(descriptor string) (string, string) {
+	i := strings.Index(strings.TrimPrefix(descriptor, "@"), "@")
+	if i < 0 {
+		return descriptor, ""
+	}
+	if strings.HasPrefix(descriptor, "@") {
+		i++
+	}
+
+	return descriptor[:i], descriptor[i+1:]
+}
+
+func splitYarnField(field string) (string, string) {
+	key, value, _ := strings.Cut(field, " ")
+
+	return unquote(key), unquote(strings.TrimSpace(value))
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		var unquoted string
+		if err := json.Unmarshal([]byte(value), &unquoted); err == nil {
+			return unquoted
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,41 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseYarn(t *testing.T) {
+	for _, version := range []string{"classic", "berry"} {
+		t.Run(version, func(t *testing.T) {
+			graph, err := Parse(filepath.Join("testdata", "yarn", version, "yarn.lock"))
+
+			assert.NoError(t, err)
+			assert.Equal(t, EcosystemNpm, graph.Ecosystem)
+			assert.Equal(t, 5, graph.Size())
+			assert.Equal(t, []string{"@babel/highlight@7.22.20", "debug@2.6.9", "ms@2.1.3"}, graph.Roots)
+			highlight, _ := graph.Get("@babel/highlight@7.22.20")
+			assert.Equal(t, []string{"js-tokens@4.0.0"}, highlight.Dependencies)
+			debug, _ := graph.Get("debug@2.6.9")
+			assert.Equal(t, []string{"ms@2.0.0"}, debug.Dependencies)
+			assert.False(t, debug.Dev)
+			ms, _ := graph.Get("ms@2.1.3")
+			assert.True(t, ms.Dev)
+		})
+	}
+}
+
+func TestSplitYarnDescriptor(t *testing.T) {
+	cases := map[string][2]string{
+		"debug@^2.6.9":                 {"debug", "^2.6.9"},
+		"@babel/highlight@npm:^7.22.0": {"@babel/highlight", "npm:^7.22.0"},
+		"debug":                        {"debug", ""},
+	}
+	for descriptor, expected := range cases {
+		name, versionRange := splitYarnDescriptor(descriptor)
+		assert.Equal(t, expected[0], name)
+		assert.Equal(t, expected[1], versionRange)
+	}
+}
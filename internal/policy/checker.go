@@ -0,0 +1,121 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/debricked/cli/internal/automation"
+)
+
+type IChecker interface {
+	Check(policyPath string, inputPath string) ([]automation.Rule, error)
+}
+
+type Checker struct{}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Check evaluates the policy at policyPath against the scan result or SBOM at inputPath
+func (checker *Checker) Check(policyPath string, inputPath string) ([]automation.Rule, error) {
+	policy, err := Load(policyPath)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := LoadDependencies(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.Evaluate(dependencies), nil
+}
+
+// Evaluate returns the policy rules as automation rules, triggered by the given dependencies
+func (policy *Policy) Evaluate(dependencies []Dependency) []automation.Rule {
+	rules := make([]automation.Rule, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		link := rule.Link
+		if len(link) == 0 {
+			link = policy.path
+		}
+		evaluated := automation.Rule{
+			RuleDescription: rule.Description,
+			RuleActions:     rule.Actions,
+			RuleLink:        link,
+			HasCves:         rule.Cvss3 != nil,
+			TriggerEvents:   []automation.TriggerEvent{},
+		}
+		for _, dependency := range dependencies {
+			evaluated.TriggerEvents = append(evaluated.TriggerEvents, rule.evaluate(dependency)...)
+		}
+		evaluated.Triggered = len(evaluated.TriggerEvents) > 0
+		rules = append(rules, evaluated)
+	}
+
+	return rules
+}
+
+// evaluate returns the trigger events caused by dependency
+func (rule *Rule) evaluate(dependency Dependency) []automation.TriggerEvent {
+	var events []automation.TriggerEvent
+	newEvent := func() automation.TriggerEvent {
+		return automation.TriggerEvent{Dependency: dependency.Name, DependencyLink: dependency.Link}
+	}
+
+	if rule.Cvss3 != nil {
+		for _, vulnerability := range dependency.Vulnerabilities {
+			if vulnerability.Cvss3 >= *rule.Cvss3 {
+				event := newEvent()
+				event.Cve = vulnerability.Cve
+				event.CveLink = vulnerability.Link
+				event.Cvss2 = vulnerability.Cvss2
+				event.Cvss3 = vulnerability.Cvss3
+				events = append(events, event)
+			}
+		}
+	}
+
+	var deniedLicenses []string
+	for _, license := range dependency.Licenses {
+		if licenseExpressionDenied(rule.licenseDeny, license) {
+			deniedLicenses = append(deniedLicenses, license)
+		}
+	}
+	if len(deniedLicenses) > 0 {
+		event := newEvent()
+		event.Licenses = deniedLicenses
+		events = append(events, event)
+	}
+
+	identifiers := []string{dependency.Purl, dependency.purlWithoutVersion(), dependency.Name}
+	denied := matchesAny(rule.dependencyDeny, identifiers...)
+	notAllowed := len(rule.dependencyAllow) > 0 && !matchesAny(rule.dependencyAllow, identifiers...)
+	if (denied || notAllowed) && len(events) == 0 {
+		events = append(events, newEvent())
+	}
+
+	return events
+}
+
+// FailPipeline returns true if any triggered rule has the failPipeline action
+func FailPipeline(rules []automation.Rule) bool {
+	for _, rule := range rules {
+		if rule.Triggered && rule.FailPipeline() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Summary returns a summary of the number of triggered rules
+func Summary(rules []automation.Rule) string {
+	triggered := 0
+	for _, rule := range rules {
+		if rule.Triggered {
+			triggered++
+		}
+	}
+
+	return fmt.Sprintf("%d of %d policy rules triggered", triggered, len(rules))
+}
@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCycloneDX(t *testing.T) {
+	checker := NewChecker()
+	rules, err := checker.Check(filepath.Join("testdata", "policy.yaml"), filepath.Join("testdata", "cyclonedx.json"))
+
+	assert.NoError(t, err)
+	assert.Len(t, rules, 3)
+
+	cvss := rules[0]
+	assert.True(t, cvss.Triggered)
+	assert.True(t, cvss.HasCves)
+	assert.Equal(t, filepath.Join("testdata", "policy.yaml"), cvss.RuleLink)
+	assert.Equal(t, []automation.TriggerEvent{{
+		Dependency: "event-stream 3.3.6",
+		Cve:        "CVE-2018-1002204",
+		Cvss3:      9.8,
+		CveLink:    "https://nvd.nist.gov/vuln/detail/CVE-2018-1002204",
+	}}, cvss.TriggerEvents)
+
+	licenses := rules[1]
+	assert.True(t, licenses.Triggered)
+	assert.Len(t, licenses.TriggerEvents, 1)
+	assert.Equal(t, []string{"MIT AND GPL-2.0-only"}, licenses.TriggerEvents[0].Licenses)
+
+	dependencies := rules[2]
+	assert.True(t, dependencies.Triggered)
+	assert.Equal(t, "https://wiki.example.com/approved-packages", dependencies.RuleLink)
+	var triggered []string
+	for _, event := range dependencies.TriggerEvents {
+		triggered = append(triggered, event.Dependency)
+	}
+	assert.Equal(t, []string{"event-stream 3.3.6", "requests 2.31.0"}, triggered)
+
+	assert.True(t, FailPipeline(rules))
+	assert.Equal(t, "3 of 3 policy rules triggered", Summary(rules))
+}
+
+func TestCheckUploadResult(t *testing.T) {
+	checker := NewChecker()
+	rules, err := checker.Check(filepath.Join("testdata", "policy.yaml"), filepath.Join("testdata", "result.json"))
+
+	assert.NoError(t, err)
+	assert.Len(t, rules, 3)
+	assert.Len(t, rules[0].TriggerEvents, 1)
+	assert.Equal(t, "CVE-2021-23337", rules[0].TriggerEvents[0].Cve)
+	assert.Len(t, rules[1].TriggerEvents, 1)
+	assert.Equal(t, "readline (npm)", rules[1].TriggerEvents[0].Dependency)
+	// The scan result has no purls, and the names do not match the allow list
+	assert.Len(t, rules[2].TriggerEvents, 2)
+}
+
+func TestCheckErrors(t *testing.T) {
+	checker := NewChecker()
+	rules, err := checker.Check(filepath.Join("testdata", "no-such-policy.yaml"), filepath.Join("testdata", "result.json"))
+	assert.Nil(t, rules)
+	assert.Error(t, err)
+
+	rules, err = checker.Check(filepath.Join("testdata", "policy.yaml"), filepath.Join("testdata", "unsupported.json"))
+	assert.Nil(t, rules)
+	assert.ErrorIs(t, err, UnsupportedInputErr)
+}
+
+func TestEvaluateUntriggered(t *testing.T) {
+	policy, err := Load(filepath.Join("testdata", "policy.yaml"))
+	assert.NoError(t, err)
+
+	rules := policy.Evaluate([]Dependency{{
+		Name:     "lodash 4.17.21",
+		Purl:     "pkg:npm/lodash@4.17.21",
+		Licenses: []string{"MIT"},
+		Vulnerabilities: []Vulnerability{
+			{Cve: "CVE-2020-28500", Cvss3: 5.3},
+		},
+	}})
+
+	for _, rule := range rules {
+		assert.False(t, rule.Triggered)
+		assert.Empty(t, rule.TriggerEvents)
+	}
+	assert.False(t, FailPipeline(rules))
+	assert.Equal(t, "0 of 3 policy rules triggered", Summary(rules))
+}
+
+func TestEvaluateDependencyDenyWithoutVersion(t *testing.T) {
+	rule := Rule{DependencyDeny: []string{"pkg:npm/lodash"}}
+	assert.NoError(t, rule.compile())
+
+	events := rule.evaluate(Dependency{Name: "lodash", Purl: "pkg:npm/lodash@4.17.21"})
+	assert.Len(t, events, 1)
+
+	events = rule.evaluate(Dependency{Name: "lodash-es", Purl: "pkg:npm/lodash-es@4.17.21"})
+	assert.Empty(t, events)
+}
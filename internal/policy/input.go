@@ -0,0 +1,271 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/upload"
+)
+
+var UnsupportedInputErr = errors.New("unsupported input, expected a scan result JSON file or a CycloneDX or SPDX SBOM")
+
+type Vulnerability struct {
+	Cve   string
+	Link  string
+	Cvss2 float32
+	Cvss3 float32
+}
+
+// Dependency is the data policy rules are evaluated against
+type Dependency struct {
+	Name            string
+	Purl            string
+	Link            string
+	Licenses        []string
+	Vulnerabilities []Vulnerability
+}
+
+// purlWithoutVersion returns the purl without version, qualifiers and subpath
+func (dependency Dependency) purlWithoutVersion() string {
+	purl := dependency.Purl
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	if i := strings.LastIndex(purl, "@"); i > strings.LastIndex(purl, "/") {
+		purl = purl[:i]
+	}
+
+	return purl
+}
+
+type inputFormat struct {
+	BomFormat       string          `json:"bomFormat"`
+	SpdxVersion     string          `json:"spdxVersion"`
+	AutomationRules json.RawMessage `json:"automationRules"`
+}
+
+// LoadDependencies reads dependencies from either the JSON written by `debricked scan --json-path`,
+// or a CycloneDX or SPDX SBOM in JSON format
+func LoadDependencies(path string) ([]Dependency, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var format inputFormat
+	if err = json.Unmarshal(data, &format); err != nil {
+		return nil, fmt.Errorf("%w: %s", UnsupportedInputErr, err.Error())
+	}
+
+	var dependencies []Dependency
+	switch {
+	case strings.EqualFold(format.BomFormat, "CycloneDX"):
+		dependencies, err = parseCycloneDX(data)
+	case len(format.SpdxVersion) > 0:
+		dependencies, err = parseSPDX(data)
+	case format.AutomationRules != nil:
+		dependencies, err = parseUploadResult(data)
+	default:
+		err = UnsupportedInputErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		return dependencies[i].Name < dependencies[j].Name
+	})
+
+	return dependencies, nil
+}
+
+// parseUploadResult collects the dependencies of the trigger events of a scan result.
+// The scan result only includes dependencies that triggered a server-side rule
+func parseUploadResult(data []byte) ([]Dependency, error) {
+	var result upload.UploadResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	var dependencies []Dependency
+	indexes := map[string]int{}
+	seenCves := map[string]bool{}
+	for _, rule := range result.AutomationRules {
+		for _, event := range rule.TriggerEvents {
+			i, ok := indexes[event.Dependency]
+			if !ok {
+				i = len(dependencies)
+				indexes[event.Dependency] = i
+				dependencies = append(dependencies, Dependency{Name: event.Dependency, Link: event.DependencyLink})
+			}
+			dependency := &dependencies[i]
+			for _, license := range event.Licenses {
+				dependency.Licenses = appendUnique(dependency.Licenses, license)
+			}
+			cveKey := event.Dependency + "\n" + event.Cve
+			if len(event.Cve) > 0 && !seenCves[cveKey] {
+				seenCves[cveKey] = true
+				dependency.Vulnerabilities = append(dependency.Vulnerabilities, Vulnerability{
+					Cve:   event.Cve,
+					Link:  event.CveLink,
+					Cvss2: event.Cvss2,
+					Cvss3: event.Cvss3,
+				})
+			}
+		}
+	}
+
+	return dependencies, nil
+}
+
+type cycloneDX struct {
+	Components []struct {
+		BomRef   string `json:"bom-ref"`
+		Name     string `json:"name"`
+		Group    string `json:"group"`
+		Version  string `json:"version"`
+		Purl     string `json:"purl"`
+		Licenses []struct {
+			License struct {
+				Id   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"license"`
+			Expression string `json:"expression"`
+		} `json:"licenses"`
+		ExternalReferences []struct {
+			Url  string `json:"url"`
+			Type string `json:"type"`
+		} `json:"externalReferences"`
+	} `json:"components"`
+	Vulnerabilities []struct {
+		Id     string `json:"id"`
+		Source struct {
+			Url string `json:"url"`
+		} `json:"source"`
+		Ratings []struct {
+			Score  float32 `json:"score"`
+			Method string  `json:"method"`
+		} `json:"ratings"`
+		Affects []struct {
+			Ref string `json:"ref"`
+		} `json:"affects"`
+	} `json:"vulnerabilities"`
+}
+
+func parseCycloneDX(data []byte) ([]Dependency, error) {
+	var bom cycloneDX
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]Dependency, 0, len(bom.Components))
+	indexes := map[string]int{}
+	for _, component := range bom.Components {
+		name := component.Name
+		if len(component.Group) > 0 {
+			name = component.Group + "/" + name
+		}
+		if len(component.Version) > 0 {
+			name = name + " " + component.Version
+		}
+		dependency := Dependency{Name: name, Purl: component.Purl}
+		for _, reference := range component.ExternalReferences {
+			if reference.Type == "website" || len(dependency.Link) == 0 {
+				dependency.Link = reference.Url
+			}
+		}
+		for _, license := range component.Licenses {
+			for _, l := range []string{license.License.Id, license.License.Name, license.Expression} {
+				if len(l) > 0 {
+					dependency.Licenses = appendUnique(dependency.Licenses, l)
+				}
+			}
+		}
+		ref := component.BomRef
+		if len(ref) == 0 {
+			ref = component.Purl
+		}
+		indexes[ref] = len(dependencies)
+		dependencies = append(dependencies, dependency)
+	}
+
+	for _, vulnerability := range bom.Vulnerabilities {
+		vuln := Vulnerability{Cve: vulnerability.Id, Link: vulnerability.Source.Url}
+		for _, rating := range vulnerability.Ratings {
+			method := strings.ToUpper(rating.Method)
+			if strings.HasPrefix(method, "CVSSV3") && rating.Score > vuln.Cvss3 {
+				vuln.Cvss3 = rating.Score
+			} else if method == "CVSSV2" && rating.Score > vuln.Cvss2 {
+				vuln.Cvss2 = rating.Score
+			}
+		}
+		for _, affected := range vulnerability.Affects {
+			if i, ok := indexes[affected.Ref]; ok {
+				dependencies[i].Vulnerabilities = append(dependencies[i].Vulnerabilities, vuln)
+			}
+		}
+	}
+
+	return dependencies, nil
+}
+
+type spdx struct {
+	Packages []struct {
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		DownloadLocation string `json:"downloadLocation"`
+		ExternalRefs     []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// parseSPDX reads dependencies from an SPDX SBOM. SPDX does not contain vulnerabilities,
+// so only license and dependency conditions apply
+func parseSPDX(data []byte) ([]Dependency, error) {
+	var bom spdx
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]Dependency, 0, len(bom.Packages))
+	for _, pkg := range bom.Packages {
+		dependency := Dependency{Name: strings.TrimSpace(pkg.Name + " " + pkg.VersionInfo)}
+		if isSpdxValue(pkg.DownloadLocation) {
+			dependency.Link = pkg.DownloadLocation
+		}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				dependency.Purl = ref.ReferenceLocator
+			}
+		}
+		for _, license := range []string{pkg.LicenseConcluded, pkg.LicenseDeclared} {
+			if isSpdxValue(license) {
+				dependency.Licenses = appendUnique(dependency.Licenses, license)
+			}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	return dependencies, nil
+}
+
+func isSpdxValue(value string) bool {
+	return len(value) > 0 && value != "NOASSERTION" && value != "NONE"
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDependenciesUploadResult(t *testing.T) {
+	dependencies, err := LoadDependencies(filepath.Join("testdata", "result.json"))
+
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 2)
+	lodash := dependencies[0]
+	assert.Equal(t, "lodash (npm)", lodash.Name)
+	assert.Equal(t, "https://debricked.com/app/en/dependency/1", lodash.Link)
+	assert.Equal(t, []string{"MIT"}, lodash.Licenses)
+	assert.Len(t, lodash.Vulnerabilities, 2)
+	assert.Equal(t, Vulnerability{
+		Cve:   "CVE-2021-23337",
+		Link:  "https://debricked.com/app/en/vulnerability/1",
+		Cvss2: 6.5,
+		Cvss3: 9.8,
+	}, lodash.Vulnerabilities[0])
+	readline := dependencies[1]
+	assert.Equal(t, []string{"GPL-3.0-only"}, readline.Licenses)
+	assert.Empty(t, readline.Vulnerabilities)
+}
+
+func TestLoadDependenciesCycloneDX(t *testing.T) {
+	dependencies, err := LoadDependencies(filepath.Join("testdata", "cyclonedx.json"))
+
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 3)
+	eventStream := dependencies[0]
+	assert.Equal(t, "event-stream 3.3.6", eventStream.Name)
+	assert.Equal(t, "pkg:npm/event-stream@3.3.6", eventStream.Purl)
+	assert.Equal(t, []string{"MIT AND GPL-2.0-only"}, eventStream.Licenses)
+	assert.Equal(t, float32(9.8), eventStream.Vulnerabilities[0].Cvss3)
+	lodash := dependencies[1]
+	assert.Equal(t, "https://lodash.com/", lodash.Link)
+	assert.Equal(t, Vulnerability{
+		Cve:   "CVE-2021-23337",
+		Link:  "https://nvd.nist.gov/vuln/detail/CVE-2021-23337",
+		Cvss2: 6.5,
+		Cvss3: 7.2,
+	}, lodash.Vulnerabilities[0])
+	requests := dependencies[2]
+	assert.Equal(t, []string{"Apache-2.0"}, requests.Licenses)
+	assert.Empty(t, requests.Vulnerabilities)
+}
+
+func TestLoadDependenciesSPDX(t *testing.T) {
+	dependencies, err := LoadDependencies(filepath.Join("testdata", "spdx.json"))
+
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 2)
+	assert.Equal(t, Dependency{
+		Name:     "lodash 4.17.20",
+		Purl:     "pkg:npm/lodash@4.17.20",
+		Link:     "https://registry.npmjs.org/lodash/-/lodash-4.17.20.tgz",
+		Licenses: []string{"MIT"},
+	}, dependencies[0])
+	assert.Equal(t, []string{"GPL-2.0-only"}, dependencies[1].Licenses)
+	assert.Empty(t, dependencies[1].Link)
+}
+
+func TestLoadDependenciesUnsupported(t *testing.T) {
+	for _, fileName := range []string{"unsupported.json", "policy.yaml"} {
+		dependencies, err := LoadDependencies(filepath.Join("testdata", fileName))
+
+		assert.Nil(t, dependencies)
+		assert.ErrorIs(t, err, UnsupportedInputErr)
+	}
+}
+
+func TestLoadDependenciesNoFile(t *testing.T) {
+	dependencies, err := LoadDependencies(filepath.Join("testdata", "no-such-file.json"))
+
+	assert.Nil(t, dependencies)
+	assert.Error(t, err)
+}
+
+func TestPurlWithoutVersion(t *testing.T) {
+	cases := map[string]string{
+		"pkg:npm/lodash@4.17.20":                   "pkg:npm/lodash",
+		"pkg:npm/%40acme/utils@1.0.0?arch=x86#lib": "pkg:npm/%40acme/utils",
+		"pkg:maven/org.slf4j/slf4j-api":            "pkg:maven/org.slf4j/slf4j-api",
+		"pkg:golang/github.com/pkg/errors@v0.9.1":  "pkg:golang/github.com/pkg/errors",
+		"": "",
+	}
+	for purl, expected := range cases {
+		assert.Equal(t, expected, Dependency{Purl: purl}.purlWithoutVersion())
+	}
+}
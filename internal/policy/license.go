@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"regexp"
+	"strings"
+)
+
+// licenseExpressionDenied returns true if an SPDX license expression, such as `MIT OR Apache-2.0`, matches patterns.
+// Licensees can choose between the licenses of OR, so it is only denied if all of them are. It is denied if any license
+// of AND is. Licenses that are not SPDX identifiers, such as `GNU General Public License v3`, are matched as a whole
+func licenseExpressionDenied(patterns []*regexp.Regexp, expression string) bool {
+	parser := licenseExpressionParser{tokens: tokenizeLicenseExpression(expression), patterns: patterns}
+
+	return parser.or()
+}
+
+func tokenizeLicenseExpression(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+
+	return strings.Fields(expression)
+}
+
+func isLicenseOperator(token string) bool {
+	switch strings.ToUpper(token) {
+	case "AND", "OR", "WITH", "(", ")":
+		return true
+	default:
+		return false
+	}
+}
+
+// licenseExpressionParser evaluates expressions by precedence, where WITH binds tighter than AND, and AND than OR
+type licenseExpressionParser struct {
+	tokens   []string
+	position int
+	patterns []*regexp.Regexp
+}
+
+func (parser *licenseExpressionParser) peek() string {
+	if parser.position < len(parser.tokens) {
+		return parser.tokens[parser.position]
+	}
+
+	return ""
+}
+
+func (parser *licenseExpressionParser) or() bool {
+	denied := parser.and()
+	for strings.EqualFold(parser.peek(), "OR") {
+		parser.position++
+		alternativeDenied := parser.and()
+		denied = denied && alternativeDenied
+	}
+
+	return denied
+}
+
+func (parser *licenseExpressionParser) and() bool {
+	denied := parser.license()
+	for strings.EqualFold(parser.peek(), "AND") {
+		parser.position++
+		conjunctDenied := parser.license()
+		denied = denied || conjunctDenied
+	}
+
+	return denied
+}
+
+// license evaluates a parenthesised expression, or a license with an optional exception
+func (parser *licenseExpressionParser) license() bool {
+	if parser.peek() == "(" {
+		parser.position++
+		denied := parser.or()
+		if parser.peek() == ")" {
+			parser.position++
+		}
+
+		return denied
+	}
+	denied := matchesAny(parser.patterns, parser.name())
+	if strings.EqualFold(parser.peek(), "WITH") {
+		parser.position++
+		denied = matchesAny(parser.patterns, parser.name()) || denied
+	}
+
+	return denied
+}
+
+// name returns the license at the current position, joining the words of licenses that are not SPDX identifiers
+func (parser *licenseExpressionParser) name() string {
+	var words []string
+	for token := parser.peek(); len(token) > 0 && !isLicenseOperator(token); token = parser.peek() {
+		words = append(words, token)
+		parser.position++
+	}
+	if len(words) == 0 && parser.peek() == ")" {
+		// Skip unbalanced parentheses
+		parser.position++
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,39 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLicenseExpressionDenied(t *testing.T) {
+	patterns := compilePatterns([]string{"GPL-*", "GNU General Public License*", "Classpath-exception-*"})
+	cases := []struct {
+		expression string
+		denied     bool
+	}{
+		{"MIT", false},
+		{"GPL-3.0-only", true},
+		{"MIT OR GPL-3.0-only", false},
+		{"GPL-2.0-only OR GPL-3.0-only", true},
+		{"MIT AND GPL-3.0-only", true},
+		{"MIT AND Apache-2.0", false},
+		{"mit or gpl-3.0-only", false},
+		{"(MIT OR GPL-2.0-only) AND GPL-3.0-only", true},
+		{"(MIT AND GPL-2.0-only) OR Apache-2.0", false},
+		{"MIT AND GPL-2.0-only OR Apache-2.0", false},
+		{"Apache-2.0 OR MIT AND GPL-2.0-only", false},
+		{"GPL-2.0-only OR MIT AND GPL-3.0-only", true},
+		{"GPL-2.0-only WITH Classpath-exception-2.0 OR MIT", false},
+		{"Apache-2.0 WITH Classpath-exception-2.0", true},
+		{"GNU General Public License v3", true},
+		{"GNU General Public License v3 OR MIT", false},
+		{"MIT OR GPL-3.0-only)", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		t.Run(c.expression, func(t *testing.T) {
+			assert.Equal(t, c.denied, licenseExpressionDenied(patterns, c.expression))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultPolicyFileName = "debricked-policy.yaml"
+
+const (
+	FailPipelineAction = "failPipeline"
+	WarnPipelineAction = "warnPipeline"
+)
+
+var (
+	NoRulesErr         = errors.New("policy does not contain any rules")
+	InvalidRuleErr     = errors.New("invalid policy rule")
+	InvalidPolicyErr   = errors.New("failed to parse policy")
+	supportedActions   = []string{FailPipelineAction, WarnPipelineAction}
+	defaultRuleActions = []string{WarnPipelineAction}
+)
+
+// Policy is a set of rules evaluated locally against scan results
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+	path  string
+}
+
+// Rule triggers for every dependency matching any of its conditions
+type Rule struct {
+	Description string   `yaml:"description"`
+	Actions     []string `yaml:"actions"`
+	Link        string   `yaml:"link"`
+	// Cvss3 triggers the rule for vulnerabilities with a CVSS3 score greater than or equal to the threshold
+	Cvss3 *float32 `yaml:"cvss3"`
+	// LicenseDeny triggers the rule for dependencies with a license matching any of the patterns
+	LicenseDeny []string `yaml:"licenseDeny"`
+	// DependencyAllow triggers the rule for dependencies not matching any of the patterns
+	DependencyAllow []string `yaml:"dependencyAllow"`
+	// DependencyDeny triggers the rule for dependencies matching any of the patterns
+	DependencyDeny []string `yaml:"dependencyDeny"`
+
+	licenseDeny     []*regexp.Regexp
+	dependencyAllow []*regexp.Regexp
+	dependencyDeny  []*regexp.Regexp
+}
+
+// Load reads and validates the policy file at path
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Policy
+	if err = yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("%w %s: %s", InvalidPolicyErr, path, err.Error())
+	}
+	policy.path = path
+	if len(policy.Rules) == 0 {
+		return nil, fmt.Errorf("%w: %s", NoRulesErr, path)
+	}
+	for i := range policy.Rules {
+		if err = policy.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("%w %d in %s: %s", InvalidRuleErr, i+1, path, err.Error())
+		}
+	}
+
+	return &policy, nil
+}
+
+func (rule *Rule) compile() error {
+	if rule.Cvss3 == nil && len(rule.LicenseDeny) == 0 && len(rule.DependencyAllow) == 0 && len(rule.DependencyDeny) == 0 {
+		return errors.New("rule has no conditions")
+	}
+	if rule.Cvss3 != nil && (*rule.Cvss3 < 0 || *rule.Cvss3 > 10) {
+		return fmt.Errorf("cvss3 threshold %g is not within 0 and 10", *rule.Cvss3)
+	}
+	if len(rule.Actions) == 0 {
+		rule.Actions = defaultRuleActions
+	}
+	for _, action := range rule.Actions {
+		if !isSupportedAction(action) {
+			return fmt.Errorf("unsupported action %q, supported actions are: %s", action, strings.Join(supportedActions, ", "))
+		}
+	}
+	if len(rule.Description) == 0 {
+		rule.Description = rule.describe()
+	}
+	rule.licenseDeny = compilePatterns(rule.LicenseDeny)
+	rule.dependencyAllow = compilePatterns(rule.DependencyAllow)
+	rule.dependencyDeny = compilePatterns(rule.DependencyDeny)
+
+	return nil
+}
+
+// describe returns a description of the rule conditions, used when the policy lacks one
+func (rule *Rule) describe() string {
+	var conditions []string
+	if rule.Cvss3 != nil {
+		conditions = append(conditions, fmt.Sprintf("a vulnerability has a CVSS3 score of %g or higher", *rule.Cvss3))
+	}
+	if len(rule.LicenseDeny) > 0 {
+		conditions = append(conditions, fmt.Sprintf("a license matches %s", strings.Join(rule.LicenseDeny, ", ")))
+	}
+	if len(rule.DependencyAllow) > 0 {
+		conditions = append(conditions, fmt.Sprintf("a dependency does not match %s", strings.Join(rule.DependencyAllow, ", ")))
+	}
+	if len(rule.DependencyDeny) > 0 {
+		conditions = append(conditions, fmt.Sprintf("a dependency matches %s", strings.Join(rule.DependencyDeny, ", ")))
+	}
+
+	return "Triggered if " + strings.Join(conditions, " or ")
+}
+
+func isSupportedAction(action string) bool {
+	for _, supported := range supportedActions {
+		if action == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compilePatterns converts glob patterns, where `*` matches any sequence of characters, to case-insensitive regexes
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+		compiled = append(compiled, regexp.MustCompile("(?i)^"+expr+"$"))
+	}
+
+	return compiled
+}
+
+func matchesAny(patterns []*regexp.Regexp, values ...string) bool {
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if len(value) > 0 && pattern.MatchString(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
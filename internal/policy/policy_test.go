@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	policy, err := Load(filepath.Join("testdata", "policy.yaml"))
+
+	assert.NoError(t, err)
+	assert.Len(t, policy.Rules, 3)
+	assert.Equal(t, float32(9), *policy.Rules[0].Cvss3)
+	assert.Equal(t, []string{FailPipelineAction}, policy.Rules[0].Actions)
+	assert.Equal(t, []string{"GPL-*", "AGPL-*"}, policy.Rules[1].LicenseDeny)
+	assert.Equal(t, []string{"pkg:npm/*"}, policy.Rules[2].DependencyAllow)
+	assert.Equal(t, []string{"pkg:npm/event-stream"}, policy.Rules[2].DependencyDeny)
+}
+
+func TestLoadNoFile(t *testing.T) {
+	policy, err := Load(filepath.Join("testdata", "no-such-policy.yaml"))
+
+	assert.Nil(t, policy)
+	assert.Error(t, err)
+}
+
+func TestLoadErrors(t *testing.T) {
+	cases := map[string]error{
+		"policy-invalid.yaml":        InvalidPolicyErr,
+		"policy-no-rules.yaml":       NoRulesErr,
+		"policy-no-conditions.yaml":  InvalidRuleErr,
+		"policy-invalid-action.yaml": InvalidRuleErr,
+	}
+	for fileName, expectedErr := range cases {
+		t.Run(fileName, func(t *testing.T) {
+			policy, err := Load(filepath.Join("testdata", fileName))
+
+			assert.Nil(t, policy)
+			assert.ErrorIs(t, err, expectedErr)
+		})
+	}
+}
+
+func TestCompileDefaults(t *testing.T) {
+	threshold := float32(7)
+	rule := Rule{Cvss3: &threshold, DependencyDeny: []string{"pkg:npm/left-pad"}}
+
+	assert.NoError(t, rule.compile())
+	assert.Equal(t, []string{WarnPipelineAction}, rule.Actions)
+	assert.Equal(t, "Triggered if a vulnerability has a CVSS3 score of 7 or higher or a dependency matches pkg:npm/left-pad", rule.Description)
+}
+
+func TestCompileInvalidThreshold(t *testing.T) {
+	threshold := float32(11)
+	rule := Rule{Cvss3: &threshold}
+
+	assert.ErrorContains(t, rule.compile(), "not within 0 and 10")
+}
+
+func TestMatchesAny(t *testing.T) {
+	patterns := compilePatterns([]string{"pkg:npm/@acme/*", "GPL-2.0"})
+
+	assert.True(t, matchesAny(patterns, "pkg:npm/@acme/utils@1.0.0"))
+	assert.True(t, matchesAny(patterns, "", "gpl-2.0"))
+	assert.False(t, matchesAny(patterns, "GPL-2.0-only"))
+	assert.False(t, matchesAny(patterns, "pkg:npm/acme"))
+	assert.False(t, matchesAny(patterns, ""))
+}
@@ -0,0 +1,17 @@
+package testdata
+
+import "github.com/debricked/cli/internal/automation"
+
+type CheckerMock struct {
+	PolicyPath string
+	InputPath  string
+	Rules      []automation.Rule
+	Error      error
+}
+
+func (mock *CheckerMock) Check(policyPath string, inputPath string) ([]automation.Rule, error) {
+	mock.PolicyPath = policyPath
+	mock.InputPath = inputPath
+
+	return mock.Rules, mock.Error
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/debricked/cli/internal/resolution/pm"
 	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
 )
 
@@ -33,7 +34,7 @@ func (bf *BatchFactory) Make(files []string) []IBatch {
 	batchMap := make(map[string]IBatch)
 	for _, file := range files {
 		for _, p := range bf.pms {
-			if bf.skipPackageManager(p) {
+			if bf.skipPackageManager(p, file) {
 				continue
 			}
 
@@ -60,10 +61,14 @@ func (bf *BatchFactory) Make(files []string) []IBatch {
 	return batches
 }
 
-func (bf *BatchFactory) skipPackageManager(p pm.IPm) bool {
+func (bf *BatchFactory) skipPackageManager(p pm.IPm, file string) bool {
 	name := p.Name()
 
 	switch true {
+	case name == pnpm.Name:
+		return !pnpm.IsProject(file)
+	case (name == npm.Name || name == yarn.Name) && pnpm.IsProject(file):
+		return true
 	case name == npm.Name && !bf.npmPreferred:
 		return true
 	case name == yarn.Name && bf.npmPreferred:
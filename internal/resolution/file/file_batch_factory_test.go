@@ -1,10 +1,14 @@
 package file
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/resolution/pm"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
 	"github.com/debricked/cli/internal/resolution/pm/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,3 +108,25 @@ func TestMakeMultipleBatchesMultipleFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestMakePnpmProject(t *testing.T) {
+	pnpmDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(pnpmDir, "pnpm-lock.yaml"), []byte{}, 0600))
+	pnpmManifest := filepath.Join(pnpmDir, "package.json")
+	yarnManifest := filepath.Join(t.TempDir(), "package.json")
+	bf := NewBatchFactory()
+
+	batches := bf.Make([]string{pnpmManifest, yarnManifest})
+
+	assert.Len(t, batches, 2)
+	for _, batch := range batches {
+		switch batch.Pm().Name() {
+		case pnpm.Name:
+			assert.Equal(t, []string{pnpmManifest}, batch.Files())
+		case yarn.Name:
+			assert.Equal(t, []string{yarnManifest}, batch.Files())
+		default:
+			t.Errorf("failed to assert that %s was not batched", batch.Pm().Name())
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package cargo
+
+import (
+	"os/exec"
+	"path/filepath"
+)
+
+type ICmdFactory interface {
+	MakeGenerateLockfileCmd(command string, file string, offline bool) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct {
+}
+
+func (ExecPath) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+func (cmdf CmdFactory) MakeGenerateLockfileCmd(command string, file string, offline bool) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(command)
+
+	args := []string{command, "generate-lockfile"}
+	if offline {
+		// Vendored dependencies are resolved without contacting the registry
+		args = append(args, "--offline")
+	}
+
+	return &exec.Cmd{
+		Path: path,
+		Args: args,
+		Dir:  filepath.Dir(file),
+	}, err
+}
@@ -0,0 +1,32 @@
+package cargo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func TestMakeGenerateLockfileCmd(t *testing.T) {
+	cmd, err := CmdFactory{
+		execPath: execPathMock{},
+	}.MakeGenerateLockfileCmd("cargo", filepath.Join("app", "Cargo.toml"), false)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"cargo", "generate-lockfile"}, cmd.Args)
+	assert.Equal(t, "app", cmd.Dir)
+}
+
+func TestMakeGenerateLockfileCmdOffline(t *testing.T) {
+	cmd, err := CmdFactory{
+		execPath: execPathMock{},
+	}.MakeGenerateLockfileCmd("cargo", filepath.Join("app", "Cargo.toml"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cargo", "generate-lockfile", "--offline"}, cmd.Args)
+}
@@ -0,0 +1,169 @@
+package cargo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	cargo                       = "cargo"
+	executableNotFoundErrRegex  = "executable file not found"
+	versionNotFoundErrRegex     = "failed to select a version for the requirement `([^\\s`]+) = \"([^\"`]+)\"`"
+	offlineErrRegex             = `you're using offline mode \(--offline\)`
+	dependencyNotFoundErrRegex  = "no matching package named `([^\\s`]+)` found"
+	registryUnavailableErrRegex = `Could not resolve host: ([\w\.-]+)`
+)
+
+type Job struct {
+	job.BaseJob
+	offline    bool
+	cmdFactory ICmdFactory
+}
+
+func NewJob(
+	file string,
+	offline bool,
+	cmdFactory ICmdFactory,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		offline:    offline,
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Offline() bool {
+	return j.offline
+}
+
+func (j *Job) Run() {
+	status := "generating lock file"
+	j.SendStatus(status)
+
+	generateCmd, err := j.cmdFactory.MakeGenerateLockfileCmd(cargo, j.GetFile(), j.offline)
+	if err != nil {
+		j.handleError(j.createError(err.Error(), generateCmd.String(), status))
+
+		return
+	}
+
+	if output, err := generateCmd.Output(); err != nil {
+		error := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
+		j.handleError(j.createError(error, generateCmd.String(), status))
+
+		return
+	}
+}
+
+func (j *Job) createError(error string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(error)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		versionNotFoundErrRegex,
+		offlineErrRegex,
+		dependencyNotFoundErrRegex,
+		registryUnavailableErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Cargo")
+	case versionNotFoundErrRegex:
+		documentation = j.getVersionNotFoundErrorDocumentation(matches)
+	case offlineErrRegex:
+		documentation = j.getOfflineErrorDocumentation()
+	case dependencyNotFoundErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case registryUnavailableErrRegex:
+		documentation = j.getRegistryUnavailableErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getVersionNotFoundErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	version := ""
+	if len(matches) > 0 && len(matches[0]) > 2 {
+		dependency = matches[0][1]
+		version = matches[0][2]
+	}
+
+	return strings.Join(
+		[]string{
+			"Couldn't find any versions of",
+			"\"" + dependency + "\"",
+			"matching",
+			"\"" + version + "\".",
+			"Please check that dependency versions are correct in your Cargo.toml file.",
+		}, " ")
+}
+
+func (j *Job) getOfflineErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"Failed to resolve dependencies offline, which is done since a vendor directory was found next to Cargo.toml.",
+			"Please make sure that all dependencies are vendored by running `cargo vendor`.",
+		}, " ")
+}
+
+func (j *Job) getDependencyNotFoundErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependency = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find package",
+			"\"" + dependency + "\"",
+			"that satisfies the requirement from Cargo dependencies.",
+			"Please check that dependencies are correct in your Cargo.toml file.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getRegistryUnavailableErrorDocumentation(matches [][]string) string {
+	registry := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		registry = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Package registry",
+			"\"" + registry + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+		}, " ")
+}
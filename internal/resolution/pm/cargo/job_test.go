@@ -0,0 +1,114 @@
+package cargo
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/cargo/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	badName = "bad-name"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", false, CmdFactory{
+		execPath: ExecPath{},
+	})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestOffline(t *testing.T) {
+	j := Job{offline: true}
+	assert.Equal(t, true, j.Offline())
+
+	j = Job{offline: false}
+	assert.Equal(t, false, j.Offline())
+}
+
+func TestRun(t *testing.T) {
+	j := NewJob("file", false, testdata.NewEchoCmdFactory())
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunGenerateLockfileCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Cargo not found",
+			error: "        |exec: \"cargo\": executable file not found in $PATH",
+			doc:   "Cargo wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Invalid package version",
+			error: "    Updating crates.io index\nerror: failed to select a version for the requirement `serde = \"^300.0\"`\ncandidate versions found which didn't match: 1.0.197, 1.0.196\nlocation searched: crates.io index\nrequired by package `app v0.1.0 (/app)`",
+			doc:   "Couldn't find any versions of \"serde\" matching \"^300.0\". Please check that dependency versions are correct in your Cargo.toml file.",
+		},
+		{
+			name:  "Invalid package name",
+			error: "    Updating crates.io index\nerror: no matching package named `serdee` found\nlocation searched: registry `crates-io`\nrequired by package `app v0.1.0 (/app)`",
+			doc:   "Failed to find package \"serdee\" that satisfies the requirement from Cargo dependencies. Please check that dependencies are correct in your Cargo.toml file. \nIf this is a private dependency, please make sure that the debricked CLI has access to install it or pre-install it before running the debricked CLI.",
+		},
+		{
+			name:  "Dependency not vendored",
+			error: "error: no matching package named `rand` found\nlocation searched: registry `crates-io`\nrequired by package `app v0.1.0 (/app)`\nAs a reminder, you're using offline mode (--offline) which can sometimes cause surprising resolution failures",
+			doc:   "Failed to resolve dependencies offline, which is done since a vendor directory was found next to Cargo.toml. Please make sure that all dependencies are vendored by running `cargo vendor`.",
+		},
+		{
+			name:  "No internet connection",
+			error: "    Updating crates.io index\nerror: failed to get `serde` as a dependency of package `app v0.1.0 (/app)`\n\nCaused by:\n  [6] Couldn't resolve host name (Could not resolve host: index.crates.io)",
+			doc:   "Package registry \"index.crates.io\" is not available at the moment. There might be a trouble with your network connection.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdErr := errors.New(c.error)
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeGenerateLockfileErr = cmdErr
+			cmd, _ := cmdFactoryMock.MakeGenerateLockfileCmd("echo", "Cargo.toml", false)
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("generating lock file")
+			expectedError.SetCommand(cmd.String())
+
+			j := NewJob("file", false, cmdFactoryMock)
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+
+			assert.Len(t, j.Errors().GetAll(), 1)
+			assert.Contains(t, allErrors, expectedError)
+		})
+	}
+}
+
+func TestRunGenerateLockfileCmdOutputErr(t *testing.T) {
+	cmdMock := testdata.NewEchoCmdFactory()
+	cmdMock.GenerateLockfileCmdName = badName
+	j := NewJob("file", false, cmdMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
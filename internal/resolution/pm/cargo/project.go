@@ -0,0 +1,73 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	manifestFile = "Cargo.toml"
+	lockFile     = "Cargo.lock"
+	vendorDir    = "vendor"
+)
+
+type manifest struct {
+	Workspace *struct {
+		Members []string `toml:"members"`
+	} `toml:"workspace"`
+}
+
+// WorkspaceRoot returns the Cargo.toml file of the closest workspace containing file, or file if it is not part of a workspace.
+// Cargo resolves all members of a workspace into a single Cargo.lock file at the root
+func WorkspaceRoot(file string) string {
+	if isWorkspace(file) {
+		return file
+	}
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return file
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return file
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return file
+		}
+		dir = parent
+		root := filepath.Join(dir, manifestFile)
+		if isWorkspace(root) {
+			return root
+		}
+	}
+}
+
+// IsVendored returns true if the dependencies of the project are vendored next to file, by `cargo vendor`
+func IsVendored(file string) bool {
+	info, err := os.Stat(filepath.Join(filepath.Dir(file), vendorDir))
+
+	return err == nil && info.IsDir()
+}
+
+// HasLockFile returns true if there is a Cargo.lock file next to file
+func HasLockFile(file string) bool {
+	_, err := os.Stat(filepath.Join(filepath.Dir(file), lockFile))
+
+	return err == nil
+}
+
+func isWorkspace(file string) bool {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	var m manifest
+	if err = toml.Unmarshal(content, &m); err != nil {
+		return false
+	}
+
+	return m.Workspace != nil
+}
@@ -0,0 +1,55 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeWorkspace creates a Cargo workspace with one member, and returns the root and member manifest files
+func makeWorkspace(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	member := filepath.Join(root, "crates", "app")
+	assert.NoError(t, os.MkdirAll(member, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, manifestFile), []byte("[workspace]\nmembers = [\"crates/*\"]\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(member, manifestFile), []byte("[package]\nname = \"app\"\nversion = \"0.1.0\"\n"), 0600))
+
+	return filepath.Join(root, manifestFile), filepath.Join(member, manifestFile)
+}
+
+func TestWorkspaceRoot(t *testing.T) {
+	rootManifest, memberManifest := makeWorkspace(t)
+	assert.Equal(t, rootManifest, WorkspaceRoot(memberManifest))
+	assert.Equal(t, rootManifest, WorkspaceRoot(rootManifest))
+
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0750))
+	manifest := filepath.Join(dir, manifestFile)
+	assert.NoError(t, os.WriteFile(manifest, []byte("[package]\nname = \"lib\"\n"), 0600))
+	assert.Equal(t, manifest, WorkspaceRoot(manifest))
+}
+
+func TestIsVendored(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, manifestFile)
+	assert.False(t, IsVendored(manifest))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, vendorDir), []byte{}, 0600))
+	assert.False(t, IsVendored(manifest))
+
+	assert.NoError(t, os.Remove(filepath.Join(dir, vendorDir)))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, vendorDir), 0750))
+	assert.True(t, IsVendored(manifest))
+}
+
+func TestHasLockFile(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, manifestFile)
+	assert.False(t, HasLockFile(manifest))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, lockFile), []byte{}, 0600))
+	assert.True(t, HasLockFile(manifest))
+}
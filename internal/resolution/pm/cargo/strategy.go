@@ -0,0 +1,53 @@
+package cargo
+
+import (
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+type Strategy struct {
+	files []string
+}
+
+// Invoke makes one job per project. Members of a Cargo workspace are resolved once, through the workspace root.
+// Members are not resolved if the root has a Cargo.lock file, unless the root itself is to be resolved
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	// The files to resolve are mapped to whether a job has been made for them
+	invoked := map[string]bool{}
+	for _, file := range s.files {
+		invoked[absPath(file)] = false
+	}
+	for _, file := range s.files {
+		root := WorkspaceRoot(file)
+		alreadyInvoked, selected := invoked[absPath(root)]
+		if alreadyInvoked || (!selected && HasLockFile(root)) {
+			continue
+		}
+		invoked[absPath(root)] = true
+		jobs = append(jobs, NewJob(
+			root,
+			IsVendored(root),
+			CmdFactory{
+				execPath: ExecPath{},
+			},
+		),
+		)
+	}
+
+	return jobs, nil
+}
+
+func absPath(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+
+	return abs
+}
+
+func NewStrategy(files []string) Strategy {
+	return Strategy{files}
+}
@@ -0,0 +1,76 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{})
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"})
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 1)
+
+	s = NewStrategy([]string{"file-1", "file-2"})
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 2)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{})
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeOneFile(t *testing.T) {
+	s := NewStrategy([]string{"file"})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+}
+
+func TestInvokeManyFiles(t *testing.T) {
+	s := NewStrategy([]string{"file-1", "file-2"})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+}
+
+func TestInvokeWorkspace(t *testing.T) {
+	rootManifest, memberManifest := makeWorkspace(t)
+	s := NewStrategy([]string{memberManifest, rootManifest})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, rootManifest, jobs[0].GetFile())
+}
+
+func TestInvokeWorkspaceWithLockFile(t *testing.T) {
+	rootManifest, memberManifest := makeWorkspace(t)
+	assert.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(rootManifest), lockFile), []byte("version = 3\n"), 0600))
+
+	s := NewStrategy([]string{memberManifest})
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs, "failed to assert that the committed Cargo.lock of the workspace was kept")
+
+	s = NewStrategy([]string{memberManifest, rootManifest})
+	jobs, _ = s.Invoke()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, rootManifest, jobs[0].GetFile())
+}
+
+func TestInvokeVendored(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, vendorDir), 0750))
+	s := NewStrategy([]string{filepath.Join(dir, manifestFile)})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	assert.True(t, jobs[0].(*Job).Offline())
+}
@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"os/exec"
+)
+
+type CmdFactoryMock struct {
+	GenerateLockfileCmdName string
+	MakeGenerateLockfileErr error
+}
+
+func NewEchoCmdFactory() CmdFactoryMock {
+	return CmdFactoryMock{
+		GenerateLockfileCmdName: "echo",
+	}
+}
+
+func (f CmdFactoryMock) MakeGenerateLockfileCmd(_ string, _ string, _ bool) (*exec.Cmd, error) {
+	return exec.Command(f.GenerateLockfileCmdName), f.MakeGenerateLockfileErr
+}
@@ -1,7 +1,9 @@
 package pip
 
 import (
+	goOs "os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -97,3 +99,19 @@ func (cmdf CmdFactory) MakeShowCmd(command string, list []string) (*exec.Cmd, er
 		Args: args,
 	}, err
 }
+
+type IProjectCmdFactory interface {
+	MakeLockCmd(command string, file string) (*exec.Cmd, error)
+}
+
+// MakeLockCmd makes a command locking the dependencies of the Poetry, Pipenv or PDM project of file
+func (cmdf CmdFactory) MakeLockCmd(command string, file string) (*exec.Cmd, error) {
+	path, err := cmdf.execPath.LookPath(command)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: []string{command, "lock"},
+		Dir:  filepath.Dir(file),
+		Env:  append(goOs.Environ(), "PIPENV_YES=1", "POETRY_NO_INTERACTION=1"),
+	}, err
+}
@@ -2,6 +2,7 @@ package pip
 
 import (
 	"errors"
+	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -123,3 +124,15 @@ func TestMakeShowCmd(t *testing.T) {
 	assert.Contains(t, args, "package1")
 	assert.Contains(t, args, "package2")
 }
+
+func TestMakeLockCmd(t *testing.T) {
+	file := filepath.Join("dir", "pyproject.toml")
+	cmd, err := CmdFactory{
+		execPath: ExecPathMock{},
+	}.MakeLockCmd("poetry", file)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"poetry", "lock"}, cmd.Args)
+	assert.Equal(t, "dir", cmd.Dir)
+	assert.Contains(t, cmd.Env, "POETRY_NO_INTERACTION=1")
+}
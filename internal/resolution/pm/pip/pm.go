@@ -19,5 +19,7 @@ func (pm Pm) Name() string {
 func (_ Pm) Manifests() []string {
 	return []string{
 		`requirements.*\.txt$`,
+		`^pyproject\.toml$`,
+		`^Pipfile$`,
 	}
 }
@@ -20,7 +20,7 @@ func TestName(t *testing.T) {
 func TestManifests(t *testing.T) {
 	pm := Pm{}
 	manifests := pm.Manifests()
-	assert.Len(t, manifests, 1)
+	assert.Len(t, manifests, 3)
 	manifest := manifests[0]
 	assert.Equal(t, `requirements.*\.txt$`, manifest)
 	_, err := regexp.Compile(manifest)
@@ -46,3 +46,29 @@ func TestManifests(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectManifests(t *testing.T) {
+	manifests := Pm{}.Manifests()[1:]
+
+	cases := map[string]bool{
+		"pyproject.toml":                    true,
+		"Pipfile":                           true,
+		"Pipfile.lock":                      false,
+		"poetry.lock":                       false,
+		"pyproject.toml.pip.debricked.lock": false,
+		"Pipfile.pip.debricked.lock":        false,
+		"requirements.txt":                  false,
+		"pyproject.toml.bak":                false,
+	}
+	for file, isMatch := range cases {
+		t.Run(file, func(t *testing.T) {
+			matched := false
+			for _, manifest := range manifests {
+				if regexp.MustCompile(manifest).MatchString(file) {
+					matched = true
+				}
+			}
+			assert.Equal(t, isMatch, matched)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package pip
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/lockfile"
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+const executableNotFoundErrRegex = `executable file not found`
+
+// ProjectJob resolves pyproject.toml and Pipfile manifests through Poetry, Pipenv or PDM. The lock file of the tool
+// is converted to the pip lock file format, so Poetry, Pipenv and PDM projects are uploaded like requirements files
+type ProjectJob struct {
+	job.BaseJob
+	cmdFactory IProjectCmdFactory
+	fileWriter writer.IFileWriter
+}
+
+func NewProjectJob(file string, cmdFactory IProjectCmdFactory, fileWriter writer.IFileWriter) *ProjectJob {
+	return &ProjectJob{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+		fileWriter: fileWriter,
+	}
+}
+
+func (j *ProjectJob) Run() {
+	status := "detecting project tool"
+	j.SendStatus(status)
+	tool, err := DetectTool(j.GetFile())
+	if errors.Is(err, UnsupportedPyprojectErr) {
+		// Only Poetry and PDM projects are resolved from pyproject.toml
+		return
+	}
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		j.Errors().Critical(cmdErr)
+
+		return
+	}
+
+	lockFile := util.MakePathFromManifestFile(j.GetFile(), tool.LockFile)
+	if _, err = os.Stat(lockFile); err != nil {
+		status = "locking dependencies"
+		j.SendStatus(status)
+		if cmdErr := j.runLockCmd(tool); cmdErr != nil {
+			cmdErr.SetStatus(status)
+			j.Errors().Critical(cmdErr)
+
+			return
+		}
+	}
+
+	status = "parsing " + tool.LockFile
+	j.SendStatus(status)
+	graph, err := lockfile.Parse(lockFile)
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+		j.Errors().Critical(cmdErr)
+
+		return
+	}
+
+	if cmdErr := j.writeLockContent(graph); cmdErr != nil {
+		j.Errors().Critical(cmdErr)
+	}
+}
+
+func (j *ProjectJob) runLockCmd(tool Tool) job.IError {
+	lockCmd, err := j.cmdFactory.MakeLockCmd(tool.Name, j.GetFile())
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		if lockCmd != nil {
+			cmdErr.SetCommand(lockCmd.String())
+		}
+		if regexp.MustCompile(executableNotFoundErrRegex).MatchString(err.Error()) {
+			cmdErr.SetDocumentation(j.GetExecutableNotFoundErrorDocumentation(tool.DisplayName))
+		}
+
+		return cmdErr
+	}
+
+	if _, err = lockCmd.Output(); err != nil {
+		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
+		cmdErr.SetCommand(lockCmd.String())
+		cmdErr.SetDocumentation(fmt.Sprintf("Failed to lock dependencies with %s. Run `%s lock` to see the full output.", tool.DisplayName, tool.Name))
+
+		return cmdErr
+	}
+
+	return nil
+}
+
+func (j *ProjectJob) writeLockContent(graph *lockfile.Graph) job.IError {
+	status := "generating lock file"
+	j.SendStatus(status)
+	lockFileName := fmt.Sprintf("%s%s", filepath.Base(j.GetFile()), lockFileExtension)
+	lockFile, err := j.fileWriter.Create(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+
+		return cmdErr
+	}
+	defer util.CloseFile(j, j.fileWriter, lockFile)
+
+	status = "writing lock file"
+	j.SendStatus(status)
+	if err = j.fileWriter.Write(lockFile, FormatLockContent(graph)); err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus(status)
+
+		return cmdErr
+	}
+
+	return nil
+}
+
+// FormatLockContent formats graph like the lock files written for requirements files. The direct dependencies
+// replace the requirements file, followed by the output `pip list` and `pip show` would give once installed
+func FormatLockContent(graph *lockfile.Graph) []byte {
+	dependencies := graph.Sorted()
+
+	requirements := make([]string, 0, len(graph.Roots))
+	for _, id := range graph.Roots {
+		if dependency, ok := graph.Get(id); ok {
+			requirements = append(requirements, dependency.Name+"=="+dependency.Version)
+		}
+	}
+	sort.Strings(requirements)
+
+	nameWidth, versionWidth := len("Package"), len("Version")
+	for _, dependency := range dependencies {
+		if len(dependency.Name) > nameWidth {
+			nameWidth = len(dependency.Name)
+		}
+		if len(dependency.Version) > versionWidth {
+			versionWidth = len(dependency.Version)
+		}
+	}
+	list := []string{
+		fmt.Sprintf("%-*s %s", nameWidth, "Package", "Version"),
+		strings.Repeat("-", nameWidth) + " " + strings.Repeat("-", versionWidth),
+	}
+	for _, dependency := range dependencies {
+		list = append(list, fmt.Sprintf("%-*s %s", nameWidth, dependency.Name, dependency.Version))
+	}
+
+	requiredBy := map[string][]string{}
+	for _, dependency := range dependencies {
+		for _, id := range dependency.Dependencies {
+			requiredBy[id] = append(requiredBy[id], dependency.Name)
+		}
+	}
+	show := make([]string, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		var requires []string
+		for _, id := range dependency.Dependencies {
+			if required, ok := graph.Get(id); ok {
+				requires = append(requires, required.Name)
+			}
+		}
+		sort.Strings(requires)
+		sort.Strings(requiredBy[dependency.Id()])
+		show = append(show, strings.Join([]string{
+			"Name: " + dependency.Name,
+			"Version: " + dependency.Version,
+			"Summary: ",
+			"Home-page: ",
+			"Author: ",
+			"Author-email: ",
+			"License: ",
+			"Location: ",
+			"Requires: " + strings.Join(requires, ", "),
+			"Required-by: " + strings.Join(requiredBy[dependency.Id()], ", "),
+		}, "\n"))
+	}
+
+	return []byte(strings.Join([]string{
+		strings.Join(requirements, "\n"),
+		lockFileDelimiter,
+		strings.Join(list, "\n"),
+		lockFileDelimiter,
+		strings.Join(show, "\n---\n"),
+	}, "\n") + "\n")
+}
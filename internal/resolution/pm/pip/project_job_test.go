@@ -0,0 +1,139 @@
+package pip
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/debricked/cli/internal/lockfile"
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/pip/testdata"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProjectJob(t *testing.T) {
+	j := NewProjectJob("pyproject.toml", CmdFactory{execPath: ExecPath{}}, &writerTestdata.FileWriterMock{})
+
+	assert.Equal(t, "pyproject.toml", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestProjectJobRunWithLockFile(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoProjectCmdFactory()
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewProjectJob(filepath.Join("testdata", "poetry", "pyproject.toml"), cmdFactoryMock, fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.False(t, *cmdFactoryMock.LockCmdCalled, "failed to assert that the existing lock file was used")
+	contents := string(fileWriterMock.Contents)
+	assert.True(t, strings.HasPrefix(contents, "pytest==7.4.2\nrequests==2.31.0\n***\n"))
+	assert.Contains(t, contents, "Name: requests\nVersion: 2.31.0\n")
+	assert.Contains(t, contents, "Requires: certifi\nRequired-by: \n")
+}
+
+func TestProjectJobRunLockCmd(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoProjectCmdFactory()
+	j := NewProjectJob(filepath.Join("testdata", "pdm", "pyproject.toml"), cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.True(t, *cmdFactoryMock.LockCmdCalled)
+	// echo does not write pdm.lock, so parsing it fails
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "parsing pdm.lock", errs[0].Status())
+}
+
+func TestProjectJobRunLockCmdExecutableNotFoundErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoProjectCmdFactory()
+	cmdFactoryMock.MakeLockErr = errors.New(`exec: "pdm": executable file not found in $PATH`)
+	j := NewProjectJob(filepath.Join("testdata", "pdm", "pyproject.toml"), cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "locking dependencies", errs[0].Status())
+	assert.Contains(t, errs[0].Documentation(), "PDM wasn't found. Please check if it is installed and accessible by the CLI.")
+}
+
+func TestProjectJobRunLockCmdOutputErr(t *testing.T) {
+	cmdFactoryMock := testdata.NewEchoProjectCmdFactory()
+	cmdFactoryMock.LockCmdName = "false"
+	j := NewProjectJob(filepath.Join("testdata", "pdm", "pyproject.toml"), cmdFactoryMock, &writerTestdata.FileWriterMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Documentation(), "Failed to lock dependencies with PDM")
+}
+
+func TestProjectJobRunUnsupportedPyproject(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewProjectJob(filepath.Join("testdata", "setuptools", "pyproject.toml"), testdata.NewEchoProjectCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Empty(t, fileWriterMock.Contents)
+}
+
+func TestProjectJobRunCreateErr(t *testing.T) {
+	createErr := errors.New("create-error")
+	fileWriterMock := &writerTestdata.FileWriterMock{CreateErr: createErr}
+	j := NewProjectJob(filepath.Join("testdata", "pipenv", "Pipfile"), testdata.NewEchoProjectCmdFactory(), fileWriterMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], createErr.Error())
+	assert.Equal(t, "generating lock file", errs[0].Status())
+}
+
+func TestFormatLockContentIsParsable(t *testing.T) {
+	graph, err := lockfile.Parse(filepath.Join("testdata", "poetry", "poetry.lock"))
+	assert.NoError(t, err)
+	lockFile := filepath.Join(t.TempDir(), "pyproject.toml.pip.debricked.lock")
+	assert.NoError(t, os.WriteFile(lockFile, FormatLockContent(graph), 0600))
+
+	parsed, err := lockfile.Parse(lockFile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, graph.Roots, parsed.Roots)
+	assert.Equal(t, graph.Size(), parsed.Size())
+	for id, dependency := range graph.Dependencies {
+		parsedDependency, ok := parsed.Get(id)
+		assert.True(t, ok, "failed to assert that %s was parsed", id)
+		assert.Equal(t, dependency.Dependencies, parsedDependency.Dependencies)
+	}
+}
+
+func TestFormatLockContentList(t *testing.T) {
+	graph := lockfile.NewGraph(lockfile.EcosystemPypi, "Pipfile.lock")
+	graph.Add("charset-normalizer", "3.3.2")
+	graph.Add("idna", "3.6")
+
+	content := string(FormatLockContent(graph))
+
+	assert.Contains(t, content, `***
+Package            Version
+------------------ -------
+charset-normalizer 3.3.2
+idna               3.6
+***
+`)
+	assert.True(t, strings.HasPrefix(content, "\n***\n"), "failed to assert that the requirements section was empty")
+}
@@ -1,6 +1,8 @@
 package pip
 
 import (
+	"errors"
+
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
 )
@@ -12,6 +14,15 @@ type Strategy struct {
 func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
 	for _, file := range s.files {
+		if IsProjectManifest(file) {
+			if _, err := DetectTool(file); errors.Is(err, UnsupportedPyprojectErr) {
+				// Other build backends are resolved from their requirements files, if there are any
+				continue
+			}
+			jobs = append(jobs, NewProjectJob(file, CmdFactory{execPath: ExecPath{}}, writer.FileWriter{}))
+
+			continue
+		}
 		jobs = append(jobs, NewJob(
 			file,
 			true,
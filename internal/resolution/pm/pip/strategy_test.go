@@ -1,6 +1,7 @@
 package pip
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,3 +42,20 @@ func TestInvokeManyFiles(t *testing.T) {
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 2)
 }
+
+func TestInvokeProjectManifests(t *testing.T) {
+	s := NewStrategy([]string{"requirements.txt", "pyproject.toml", "Pipfile"})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 3)
+	assert.IsType(t, &Job{}, jobs[0])
+	assert.IsType(t, &ProjectJob{}, jobs[1])
+	assert.IsType(t, &ProjectJob{}, jobs[2])
+}
+
+func TestInvokeUnsupportedPyproject(t *testing.T) {
+	s := NewStrategy([]string{"requirements.txt", filepath.Join("testdata", "setuptools", "pyproject.toml")})
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.IsType(t, &Job{}, jobs[0])
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"os/exec"
+)
+
+type ProjectCmdFactoryMock struct {
+	LockCmdName string
+	MakeLockErr error
+	// LockCmdCalled is set when a lock command is made
+	LockCmdCalled *bool
+}
+
+func NewEchoProjectCmdFactory() ProjectCmdFactoryMock {
+	called := false
+
+	return ProjectCmdFactoryMock{
+		LockCmdName:   "echo",
+		LockCmdCalled: &called,
+	}
+}
+
+func (f ProjectCmdFactoryMock) MakeLockCmd(command string, file string) (*exec.Cmd, error) {
+	*f.LockCmdCalled = true
+
+	return exec.Command(f.LockCmdName, command, "lock"), f.MakeLockErr
+}
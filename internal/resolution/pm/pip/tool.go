@@ -0,0 +1,78 @@
+package pip
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	pyprojectFile = "pyproject.toml"
+	pipfileFile   = "Pipfile"
+)
+
+var UnsupportedPyprojectErr = errors.New("pyproject.toml is neither a Poetry nor a PDM project")
+
+// Tool is a Python project manager writing a lock file next to the manifest file
+type Tool struct {
+	Name        string
+	DisplayName string
+	LockFile    string
+}
+
+var (
+	Poetry = Tool{Name: "poetry", DisplayName: "Poetry", LockFile: "poetry.lock"}
+	Pipenv = Tool{Name: "pipenv", DisplayName: "Pipenv", LockFile: "Pipfile.lock"}
+	Pdm    = Tool{Name: "pdm", DisplayName: "PDM", LockFile: "pdm.lock"}
+)
+
+type pyprojectTools struct {
+	Tool struct {
+		Poetry interface{} `toml:"poetry"`
+		Pdm    interface{} `toml:"pdm"`
+	} `toml:"tool"`
+	BuildSystem struct {
+		BuildBackend string `toml:"build-backend"`
+	} `toml:"build-system"`
+}
+
+// IsProjectManifest returns true if file is a Poetry, Pipenv or PDM manifest rather than a requirements file
+func IsProjectManifest(file string) bool {
+	base := filepath.Base(file)
+
+	return base == pyprojectFile || base == pipfileFile
+}
+
+// DetectTool returns the tool managing the project of file. An existing lock file takes precedence,
+// otherwise pyproject.toml is checked for Poetry and PDM configuration
+func DetectTool(file string) (Tool, error) {
+	if filepath.Base(file) == pipfileFile {
+		return Pipenv, nil
+	}
+	dir := filepath.Dir(file)
+	for _, tool := range []Tool{Poetry, Pdm} {
+		if _, err := os.Stat(filepath.Join(dir, tool.LockFile)); err == nil {
+			return tool, nil
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return Tool{}, err
+	}
+	var project pyprojectTools
+	if err = toml.Unmarshal(data, &project); err != nil {
+		return Tool{}, err
+	}
+	switch {
+	case project.Tool.Poetry != nil || strings.HasPrefix(project.BuildSystem.BuildBackend, "poetry"):
+		return Poetry, nil
+	case project.Tool.Pdm != nil || strings.HasPrefix(project.BuildSystem.BuildBackend, "pdm"):
+		return Pdm, nil
+	}
+
+	return Tool{}, UnsupportedPyprojectErr
+}
@@ -0,0 +1,51 @@
+package pip
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsProjectManifest(t *testing.T) {
+	cases := map[string]bool{
+		"pyproject.toml":                    true,
+		filepath.Join("dir", "Pipfile"):     true,
+		"requirements.txt":                  false,
+		"Pipfile.lock":                      false,
+		"pyproject.toml.pip.debricked.lock": false,
+	}
+	for file, isProjectManifest := range cases {
+		t.Run(file, func(t *testing.T) {
+			assert.Equal(t, isProjectManifest, IsProjectManifest(file))
+		})
+	}
+}
+
+func TestDetectTool(t *testing.T) {
+	cases := map[string]Tool{
+		filepath.Join("testdata", "poetry", "pyproject.toml"): Poetry,
+		filepath.Join("testdata", "pdm", "pyproject.toml"):    Pdm,
+		filepath.Join("testdata", "pipenv", "Pipfile"):        Pipenv,
+	}
+	for file, expected := range cases {
+		t.Run(file, func(t *testing.T) {
+			tool, err := DetectTool(file)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, tool)
+		})
+	}
+}
+
+func TestDetectToolUnsupportedPyproject(t *testing.T) {
+	_, err := DetectTool(filepath.Join("testdata", "setuptools", "pyproject.toml"))
+
+	assert.ErrorIs(t, err, UnsupportedPyprojectErr)
+}
+
+func TestDetectToolMissingFile(t *testing.T) {
+	_, err := DetectTool(filepath.Join("testdata", "missing", "pyproject.toml"))
+
+	assert.Error(t, err)
+}
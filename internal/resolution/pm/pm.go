@@ -2,6 +2,7 @@ package pm
 
 import (
 	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
@@ -9,6 +10,7 @@ import (
 	"github.com/debricked/cli/internal/resolution/pm/npm"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
 	"github.com/debricked/cli/internal/resolution/pm/sbt"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
 )
@@ -26,9 +28,11 @@ func Pms() []IPm {
 		pip.NewPm(),
 		yarn.NewPm(),
 		npm.NewPm(),
+		pnpm.NewPm(),
 		bower.NewPm(),
 		nuget.NewPm(),
 		composer.NewPm(),
 		sbt.NewPm(),
+		cargo.NewPm(),
 	}
 }
@@ -13,6 +13,8 @@ func TestPms(t *testing.T) {
 		"go",
 		"gradle",
 		"composer",
+		"pnpm",
+		"cargo",
 	}
 
 	for _, pmName := range pmNames {
@@ -0,0 +1,158 @@
+package pnpm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	pnpm                        = "pnpm"
+	executableNotFoundErrRegex  = `executable file not found`
+	versionNotFoundErrRegex     = `ERR_PNPM_NO_MATCHING_VERSION\s+No matching version found for (\S+)`
+	dependencyNotFoundErrRegex  = `ERR_PNPM_FETCH_404\s+GET (\S+): Not Found`
+	registryUnavailableErrRegex = `getaddrinfo (?:ENOTFOUND|EAI_AGAIN) ([\w\.]+)`
+)
+
+type Job struct {
+	job.BaseJob
+	install     bool
+	pnpmCommand string
+	cmdFactory  ICmdFactory
+}
+
+func NewJob(
+	file string,
+	install bool,
+	cmdFactory ICmdFactory,
+) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		install:    install,
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Install() bool {
+	return j.install
+}
+
+func (j *Job) Run() {
+	if j.install {
+		status := "installing dependencies"
+		j.SendStatus(status)
+		j.pnpmCommand = pnpm
+
+		installCmd, err := j.cmdFactory.MakeInstallCmd(j.pnpmCommand, j.GetFile())
+
+		if err != nil {
+			j.handleError(j.createError(err.Error(), installCmd.String(), status))
+
+			return
+		}
+
+		if output, err := installCmd.Output(); err != nil {
+			error := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
+			j.handleError(j.createError(error, installCmd.String(), status))
+
+			return
+		}
+	}
+}
+
+func (j *Job) createError(error string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(error)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		versionNotFoundErrRegex,
+		dependencyNotFoundErrRegex,
+		registryUnavailableErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("pnpm")
+	case versionNotFoundErrRegex:
+		documentation = j.getVersionNotFoundErrorDocumentation(matches)
+	case dependencyNotFoundErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case registryUnavailableErrRegex:
+		documentation = j.getRegistryUnavailableErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getVersionNotFoundErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependency = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Couldn't find any versions of",
+			"\"" + dependency + "\".",
+			"Please check that dependencies are correct in your package.json file.",
+		}, " ")
+}
+
+func (j *Job) getDependencyNotFoundErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependency = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find package",
+			"\"" + dependency + "\"",
+			"that satisfies the requirement from pnpm dependencies.",
+			"Please check that dependencies are correct in your package.json file.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getRegistryUnavailableErrorDocumentation(matches [][]string) string {
+	registry := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		registry = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Package registry",
+			"\"" + registry + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+		}, " ")
+}
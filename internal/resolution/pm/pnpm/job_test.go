@@ -0,0 +1,109 @@
+package pnpm
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	badName = "bad-name"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", false, CmdFactory{
+		execPath: ExecPath{},
+	})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestInstall(t *testing.T) {
+	j := Job{install: true}
+	assert.Equal(t, true, j.Install())
+
+	j = Job{install: false}
+	assert.Equal(t, false, j.Install())
+}
+
+func TestRunInstall(t *testing.T) {
+	j := NewJob("file", true, testdata.NewEchoCmdFactory())
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunInstallCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "pnpm not found",
+			error: "        |exec: \"pnpm\": executable file not found in $PATH",
+			doc:   "pnpm wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Invalid package version",
+			error: " ERR_PNPM_NO_MATCHING_VERSION  No matching version found for chalk@^300.0.0\n\nThis error happened while installing a direct dependency of /app",
+			doc:   "Couldn't find any versions of \"chalk@^300.0.0\". Please check that dependencies are correct in your package.json file.",
+		},
+		{
+			name:  "Invalid package name",
+			error: " ERR_PNPM_FETCH_404  GET https://registry.npmjs.org/chalke: Not Found - 404",
+			doc:   "Failed to find package \"https://registry.npmjs.org/chalke\" that satisfies the requirement from pnpm dependencies. Please check that dependencies are correct in your package.json file. \nIf this is a private dependency, please make sure that the debricked CLI has access to install it or pre-install it before running the debricked CLI.",
+		},
+		{
+			name:  "No internet connection",
+			error: " WARN  GET https://registry.npmjs.org/chalk error (ENOTFOUND). Will retry in 10 seconds. 2 retries left.\n ERR_PNPM_META_FETCH_FAIL  GET https://registry.npmjs.org/chalk: request to https://registry.npmjs.org/chalk failed, reason: getaddrinfo ENOTFOUND registry.npmjs.org",
+			doc:   "Package registry \"registry.npmjs.org\" is not available at the moment. There might be a trouble with your network connection.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmdErr := errors.New(c.error)
+			cmdFactoryMock := testdata.NewEchoCmdFactory()
+			cmdFactoryMock.MakeInstallErr = cmdErr
+			cmd, _ := cmdFactoryMock.MakeInstallCmd("echo", "package.json")
+
+			expectedError := util.NewPMJobError(c.error)
+			expectedError.SetDocumentation(c.doc)
+			expectedError.SetStatus("installing dependencies")
+			expectedError.SetCommand(cmd.String())
+
+			j := NewJob("file", true, cmdFactoryMock)
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			allErrors := j.Errors().GetAll()
+
+			assert.Len(t, j.Errors().GetAll(), 1)
+			assert.Contains(t, allErrors, expectedError)
+		})
+	}
+}
+
+func TestRunInstallCmdOutputErr(t *testing.T) {
+	cmdMock := testdata.NewEchoCmdFactory()
+	cmdMock.InstallCmdName = badName
+	j := NewJob("file", true, cmdMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	jobTestdata.AssertPathErr(t, j.Errors())
+}
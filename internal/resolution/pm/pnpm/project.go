@@ -0,0 +1,39 @@
+package pnpm
+
+import (
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	lockFile      = "pnpm-lock.yaml"
+	workspaceFile = "pnpm-workspace.yaml"
+	manifestFile  = "package.json"
+)
+
+// IsProject returns true if the package.json file belongs to a pnpm project, which is either next to or within
+// the workspace of a pnpm lock file, or declares pnpm as its package manager
+func IsProject(file string) bool {
+	if name, _ := util.PackageManager(file); name == Name {
+		return true
+	}
+	_, ok := util.FindUp(file, lockFile, workspaceFile)
+
+	return ok
+}
+
+// WorkspaceRoot returns the package.json file of the workspace root containing file, or file if it is not part of a workspace.
+// pnpm resolves all packages of a workspace into a single lock file at the root
+func WorkspaceRoot(file string) string {
+	dir, ok := util.FindUp(file, workspaceFile)
+	if !ok {
+		return file
+	}
+	root := filepath.Join(dir, manifestFile)
+	if absFile, err := filepath.Abs(file); err == nil && absFile == root {
+		return file
+	}
+
+	return root
+}
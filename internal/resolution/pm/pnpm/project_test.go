@@ -0,0 +1,49 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeWorkspace creates a pnpm workspace with one package, and returns the root and package manifest files
+func makeWorkspace(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	pkg := filepath.Join(root, "packages", "app")
+	assert.NoError(t, os.MkdirAll(pkg, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, workspaceFile), []byte("packages:\n  - \"packages/*\"\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, manifestFile), []byte("{}"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(pkg, manifestFile), []byte("{}"), 0600))
+
+	return filepath.Join(root, manifestFile), filepath.Join(pkg, manifestFile)
+}
+
+func TestIsProject(t *testing.T) {
+	rootManifest, packageManifest := makeWorkspace(t)
+	assert.True(t, IsProject(rootManifest))
+	assert.True(t, IsProject(packageManifest))
+
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, manifestFile)
+	assert.NoError(t, os.WriteFile(manifest, []byte(`{"packageManager": "yarn@4.1.0"}`), 0600))
+	assert.False(t, IsProject(manifest))
+
+	assert.NoError(t, os.WriteFile(manifest, []byte(`{"packageManager": "pnpm@8.15.4"}`), 0600))
+	assert.True(t, IsProject(manifest))
+
+	assert.NoError(t, os.WriteFile(manifest, []byte(`{}`), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, lockFile), []byte{}, 0600))
+	assert.True(t, IsProject(manifest))
+}
+
+func TestWorkspaceRoot(t *testing.T) {
+	rootManifest, packageManifest := makeWorkspace(t)
+	assert.Equal(t, rootManifest, WorkspaceRoot(packageManifest))
+	assert.Equal(t, rootManifest, WorkspaceRoot(rootManifest))
+
+	manifest := filepath.Join(t.TempDir(), manifestFile)
+	assert.Equal(t, manifest, WorkspaceRoot(manifest))
+}
@@ -0,0 +1,49 @@
+package pnpm
+
+import (
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+type Strategy struct {
+	files []string
+}
+
+// Invoke makes one job per project. Packages of a pnpm workspace are resolved once, through the workspace root
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	invoked := map[string]bool{}
+	for _, file := range s.files {
+		root := WorkspaceRoot(file)
+		// Members give the root as an absolute path, while the root itself can be given as a relative path
+		key := absPath(root)
+		if invoked[key] {
+			continue
+		}
+		invoked[key] = true
+		jobs = append(jobs, NewJob(
+			root,
+			true,
+			CmdFactory{
+				execPath: ExecPath{},
+			},
+		),
+		)
+	}
+
+	return jobs, nil
+}
+
+func absPath(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+
+	return abs
+}
+
+func NewStrategy(files []string) Strategy {
+	return Strategy{files}
+}
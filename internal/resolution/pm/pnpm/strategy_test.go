@@ -0,0 +1,68 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{})
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"})
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 1)
+
+	s = NewStrategy([]string{"file-1", "file-2"})
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 2)
+}
+
+func TestInvokeNoFiles(t *testing.T) {
+	s := NewStrategy([]string{})
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeOneFile(t *testing.T) {
+	s := NewStrategy([]string{"file"})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+}
+
+func TestInvokeManyFiles(t *testing.T) {
+	s := NewStrategy([]string{"file-1", "file-2"})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+}
+
+func TestInvokeWorkspace(t *testing.T) {
+	rootManifest, packageManifest := makeWorkspace(t)
+	s := NewStrategy([]string{packageManifest, rootManifest})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, rootManifest, jobs[0].GetFile())
+}
+
+func TestInvokeWorkspaceRelativePaths(t *testing.T) {
+	rootManifest, _ := makeWorkspace(t)
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(filepath.Dir(rootManifest)))
+	defer func() {
+		assert.NoError(t, os.Chdir(cwd))
+	}()
+
+	s := NewStrategy([]string{manifestFile, filepath.Join("packages", "app", manifestFile)})
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, manifestFile, jobs[0].GetFile())
+}
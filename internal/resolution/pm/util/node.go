@@ -0,0 +1,52 @@
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FindUp searches the directory of file and its parents for any of names, and returns the directory containing it.
+// The search stops at the root of the git repository
+func FindUp(file string, names ...string) (string, bool) {
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return "", false
+	}
+	for {
+		for _, name := range names {
+			if _, err = os.Stat(filepath.Join(dir, name)); err == nil {
+				return dir, true
+			}
+		}
+		if _, err = os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// PackageManager returns the name and version of the `packageManager` field of a package.json file,
+// such as `pnpm` and `8.15.4`. Empty strings are returned if the field is missing
+func PackageManager(manifestFile string) (string, string) {
+	data, err := os.ReadFile(filepath.Clean(manifestFile))
+	if err != nil {
+		return "", ""
+	}
+	var manifest struct {
+		PackageManager string `json:"packageManager"`
+	}
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return "", ""
+	}
+	name, version, _ := strings.Cut(manifest.PackageManager, "@")
+	// The version may be followed by a hash, such as `yarn@3.2.3+sha224.953c8233f7a92884eee2de69a1b92d1f2ec1655e66d08071ba9a02fa`
+	version, _, _ = strings.Cut(version, "+")
+
+	return name, version
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUp(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "packages", "app")
+	assert.NoError(t, os.MkdirAll(nested, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte{}, 0600))
+
+	dir, ok := FindUp(filepath.Join(nested, "package.json"), "pnpm-lock.yaml", "pnpm-workspace.yaml")
+	assert.True(t, ok)
+	assert.Equal(t, root, dir)
+
+	_, ok = FindUp(filepath.Join(nested, "package.json"), "missing.yaml")
+	assert.False(t, ok)
+}
+
+func TestFindUpStopsAtGitRoot(t *testing.T) {
+	root := t.TempDir()
+	repository := filepath.Join(root, "repository")
+	assert.NoError(t, os.MkdirAll(filepath.Join(repository, ".git"), 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ".yarnrc.yml"), []byte{}, 0600))
+
+	_, ok := FindUp(filepath.Join(repository, "package.json"), ".yarnrc.yml")
+	assert.False(t, ok)
+}
+
+func TestPackageManager(t *testing.T) {
+	cases := map[string][]string{
+		`{"packageManager": "pnpm@8.15.4"}`: {"pnpm", "8.15.4"},
+		`{"packageManager": "yarn@3.2.3+sha224.953c8233f7a92884eee2de69a1b92d1f2ec1655e66d08071ba9a02fa"}`: {"yarn", "3.2.3"},
+		`{"name": "app"}`: {"", ""},
+		`invalid`:         {"", ""},
+	}
+	for content, expected := range cases {
+		t.Run(content, func(t *testing.T) {
+			manifestFile := filepath.Join(t.TempDir(), "package.json")
+			assert.NoError(t, os.WriteFile(manifestFile, []byte(content), 0600))
+
+			name, version := PackageManager(manifestFile)
+			assert.Equal(t, expected, []string{name, version})
+		})
+	}
+
+	name, version := PackageManager(filepath.Join(t.TempDir(), "package.json"))
+	assert.Empty(t, name)
+	assert.Empty(t, version)
+}
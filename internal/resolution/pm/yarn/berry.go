@@ -0,0 +1,23 @@
+package yarn
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const berryConfigFile = ".yarnrc.yml"
+
+// IsBerry returns true if the project of the package.json file uses Yarn 2 or later, known as Yarn Berry.
+// The `packageManager` field takes precedence over a .yarnrc.yml file, which Yarn classic does not read
+func IsBerry(file string) bool {
+	if name, version := util.PackageManager(file); name == Name {
+		major, err := strconv.Atoi(strings.Split(version, ".")[0])
+
+		return err == nil && major >= 2
+	}
+	_, ok := util.FindUp(file, berryConfigFile)
+
+	return ok
+}
@@ -0,0 +1,45 @@
+package yarn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBerry(t *testing.T) {
+	cases := map[string]bool{
+		`{"packageManager": "yarn@4.1.0"}`:   true,
+		`{"packageManager": "yarn@2.4.3"}`:   true,
+		`{"packageManager": "yarn@1.22.19"}`: false,
+		`{"packageManager": "pnpm@8.15.4"}`:  false,
+		`{}`:                                 false,
+	}
+	for content, isBerry := range cases {
+		t.Run(content, func(t *testing.T) {
+			manifestFile := filepath.Join(t.TempDir(), "package.json")
+			assert.NoError(t, os.WriteFile(manifestFile, []byte(content), 0600))
+
+			assert.Equal(t, isBerry, IsBerry(manifestFile))
+		})
+	}
+}
+
+func TestIsBerryYarnrc(t *testing.T) {
+	root := t.TempDir()
+	pkg := filepath.Join(root, "packages", "app")
+	assert.NoError(t, os.MkdirAll(pkg, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, berryConfigFile), []byte("nodeLinker: node-modules\n"), 0600))
+
+	assert.True(t, IsBerry(filepath.Join(pkg, "package.json")))
+}
+
+func TestIsBerryPackageManagerTakesPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "package.json")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(`{"packageManager": "yarn@1.22.19"}`), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, berryConfigFile), []byte{}, 0600))
+
+	assert.False(t, IsBerry(manifestFile))
+}
@@ -1,6 +1,7 @@
 package yarn
 
 import (
+	"os"
 	"os/exec"
 	"path/filepath"
 )
@@ -29,6 +30,19 @@ func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, e
 
 	fileDir := filepath.Dir(file)
 
+	if IsBerry(file) {
+		return &exec.Cmd{
+			Path: path,
+			// Yarn Berry rejects the flags of Yarn classic
+			Args: []string{command, "install",
+				"--mode=update-lockfile", // Only update the lock file, no packages are linked and no scripts are run
+			},
+			Dir: fileDir,
+			// Immutable installs are enabled by default in CI environments
+			Env: append(os.Environ(), "YARN_ENABLE_IMMUTABLE_INSTALLS=false"),
+		}, err
+	}
+
 	return &exec.Cmd{
 		Path: path,
 		Args: []string{command, "install",
@@ -1,6 +1,8 @@
 package yarn
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,3 +19,35 @@ func TestMakeInstallCmd(t *testing.T) {
 	assert.Contains(t, args, "yarn")
 	assert.Contains(t, args, "install")
 }
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func TestMakeInstallCmdClassic(t *testing.T) {
+	manifestFile := filepath.Join(t.TempDir(), "package.json")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(`{"packageManager": "yarn@1.22.19"}`), 0600))
+
+	cmd, err := CmdFactory{
+		execPath: execPathMock{},
+	}.MakeInstallCmd("yarn", manifestFile)
+	assert.NoError(t, err)
+	assert.Contains(t, cmd.Args, "--non-interactive")
+	assert.NotContains(t, cmd.Args, "--mode=update-lockfile")
+	assert.Nil(t, cmd.Env)
+}
+
+func TestMakeInstallCmdBerry(t *testing.T) {
+	manifestFile := filepath.Join(t.TempDir(), "package.json")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(`{"packageManager": "yarn@4.1.0"}`), 0600))
+
+	cmd, err := CmdFactory{
+		execPath: execPathMock{},
+	}.MakeInstallCmd("yarn", manifestFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"yarn", "install", "--mode=update-lockfile"}, cmd.Args)
+	assert.Equal(t, filepath.Dir(manifestFile), cmd.Dir)
+	assert.Contains(t, cmd.Env, "YARN_ENABLE_IMMUTABLE_INSTALLS=false")
+}
@@ -6,13 +6,16 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"sort"
 
 	"github.com/debricked/cli/internal/cmd/cmderror"
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/lockfile"
 	resolutionFile "github.com/debricked/cli/internal/resolution/file"
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/strategy"
 	"github.com/debricked/cli/internal/tui"
+	"github.com/fatih/color"
 )
 
 var (
@@ -65,6 +68,11 @@ type DebrickedOptions struct {
 	Regenerate           int
 	NpmPreferred         bool
 	ResolutionStrictness StrictnessLevel
+	Offline              bool
+	// NoExec parses existing lock files instead of invoking package managers
+	NoExec bool
+	// ChangedFiles limits resolution to groups with a changed manifest or lock file. Nil resolves all groups
+	ChangedFiles []string
 }
 
 func NewResolver(
@@ -147,24 +155,16 @@ func (r Resolver) Resolve(paths []string, options IOptions) (IResolution, error)
 	if !ok {
 		return nil, ErrBadOpts
 	}
-	files, err := r.refinePaths(paths, dOptions)
+	var jobs []job.IJob
+	var err error
+	if dOptions.NoExec {
+		jobs, err = r.makeLockFileJobs(paths, dOptions)
+	} else {
+		jobs, err = r.makeJobs(paths, dOptions)
+	}
 	if err != nil {
 		return nil, err
 	}
-	r.setNpmPreferred(dOptions.NpmPreferred)
-	pmBatches := r.batchFactory.Make(files)
-
-	var jobs []job.IJob
-	for _, pmBatch := range pmBatches {
-		s, strategyErr := r.strategyFactory.Make(pmBatch, paths)
-		if strategyErr == nil {
-			newJobs, err := s.Invoke()
-			if err != nil {
-				return nil, err
-			}
-			jobs = append(jobs, newJobs...)
-		}
-	}
 
 	resolution, err := r.scheduler.Schedule(jobs)
 
@@ -192,6 +192,86 @@ func (r Resolver) Resolve(paths []string, options IOptions) (IResolution, error)
 	return resolution, err
 }
 
+func (r Resolver) makeJobs(paths []string, dOptions DebrickedOptions) ([]job.IJob, error) {
+	files, err := r.refinePaths(paths, dOptions)
+	if err != nil {
+		return nil, err
+	}
+	r.setNpmPreferred(dOptions.NpmPreferred)
+	pmBatches := r.batchFactory.Make(files)
+
+	var jobs []job.IJob
+	for _, pmBatch := range pmBatches {
+		s, strategyErr := r.strategyFactory.Make(pmBatch, paths)
+		if strategyErr == nil {
+			newJobs, err := s.Invoke()
+			if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, newJobs...)
+		}
+	}
+
+	return jobs, nil
+}
+
+// makeLockFileJobs returns jobs parsing the lock files of paths. Manifest files without a parsable lock file are skipped,
+// as they cannot be resolved without invoking their package manager
+func (r Resolver) makeLockFileJobs(paths []string, dOptions DebrickedOptions) ([]job.IJob, error) {
+	lockFiles := map[string]bool{}
+	for _, arg := range paths {
+		cleanArg := path.Clean(arg)
+		fileInfo, err := os.Stat(cleanArg)
+		if err != nil {
+			return nil, err
+		}
+		if !fileInfo.IsDir() {
+			if lockfile.Supported(cleanArg) {
+				lockFiles[cleanArg] = true
+			} else {
+				fmt.Printf("%s %s is not a supported lock file, skipping\n", color.YellowString("⚠️"), cleanArg)
+			}
+
+			continue
+		}
+		fileGroups, err := r.finder.GetGroups(
+			file.DebrickedOptions{
+				RootPath:     cleanArg,
+				Exclusions:   dOptions.Exclusions,
+				Inclusions:   dOptions.Inclusions,
+				LockFileOnly: false,
+				Strictness:   file.StrictAll,
+				Offline:      dOptions.Offline,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		filterGroupsByChanges(&fileGroups, dOptions)
+		for _, fileGroup := range fileGroups.ToSlice() {
+			found := lockfile.FindInGroup(fileGroup)
+			if len(found) == 0 && fileGroup.HasFile() {
+				fmt.Printf("%s No parsable lock file found for %s, skipping\n", color.YellowString("⚠️"), fileGroup.ManifestFile)
+			}
+			for _, lockFile := range found {
+				lockFiles[lockFile] = true
+			}
+		}
+	}
+
+	files := make([]string, 0, len(lockFiles))
+	for lockFile := range lockFiles {
+		files = append(files, lockFile)
+	}
+	sort.Strings(files)
+	jobs := make([]job.IJob, 0, len(files))
+	for _, lockFile := range files {
+		jobs = append(jobs, lockfile.NewJob(lockFile))
+	}
+
+	return jobs, nil
+}
+
 func (r Resolver) refinePaths(paths []string, options DebrickedOptions) ([]string, error) {
 	var fileSet = map[string]bool{}
 	var dirs []string
@@ -247,16 +327,24 @@ func (r Resolver) processDir(fileSet map[string]bool, dir string, options Debric
 			Inclusions:   options.Inclusions,
 			LockFileOnly: false,
 			Strictness:   file.StrictAll,
+			Offline:      options.Offline,
 		},
 	)
 	if err != nil {
 		return err
 	}
+	filterGroupsByChanges(&fileGroups, options)
 	r.processFileGroups(fileSet, fileGroups, options.Regenerate)
 
 	return nil
 }
 
+func filterGroupsByChanges(fileGroups *file.Groups, options DebrickedOptions) {
+	if options.ChangedFiles != nil {
+		fileGroups.FilterGroupsByChanges(options.ChangedFiles)
+	}
+}
+
 func (r Resolver) processFileGroups(fileSet map[string]bool, fileGroups file.Groups, regenerate int) {
 	for _, fileGroup := range fileGroups.ToSlice() {
 		if shouldGenerateLock(fileGroup, regenerate) {
@@ -3,10 +3,12 @@ package resolution
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/file/testdata"
+	"github.com/debricked/cli/internal/lockfile"
 	resolutionFile "github.com/debricked/cli/internal/resolution/file"
 	fileTestdata "github.com/debricked/cli/internal/resolution/file/testdata"
 	"github.com/debricked/cli/internal/resolution/job"
@@ -224,6 +226,30 @@ func TestResolveDirWithExclusions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestResolveDirWithChangedFiles(t *testing.T) {
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: goModFile})
+	groups.Add(file.Group{ManifestFile: filepath.Join("api", "go.mod")})
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	r := NewResolver(
+		f,
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		SchedulerMock{},
+	)
+
+	options := DebrickedOptions{
+		ChangedFiles: []string{goModFile, "README.md"},
+	}
+	res, err := r.Resolve([]string{"."}, options)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	assert.Equal(t, goModFile, res.Jobs()[0].GetFile())
+}
+
 func TestResolveHasResolutionErrs(t *testing.T) {
 	f := testdata.NewFinderMock()
 	groups := file.Groups{}
@@ -259,6 +285,98 @@ func TestResolveHasResolutionErrs(t *testing.T) {
 	assert.ErrorIs(t, jobErr, errs[0])
 }
 
+func TestResolveNoExec(t *testing.T) {
+	lockFileDir := filepath.Join("..", "lockfile", "testdata")
+	yarnLock := filepath.Join(lockFileDir, "yarn", "classic", "yarn.lock")
+	poetryLock := filepath.Join(lockFileDir, "poetry", "poetry.lock")
+	gradleLock := filepath.Join(lockFileDir, "gradle", "gradle.lockfile")
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: filepath.Join(lockFileDir, "yarn", "classic", "package.json"), LockFiles: []string{yarnLock}})
+	groups.Add(file.Group{ManifestFile: filepath.Join(lockFileDir, "poetry", "pyproject.toml")})
+	groups.Add(file.Group{ManifestFile: goModFile})
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	r := NewResolver(
+		f,
+		fileTestdata.NewBatchFactoryMock(),
+		strategyTestdata.NewStrategyFactoryErrorMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose: true,
+		NoExec:  true,
+	}
+	res, err := r.Resolve([]string{".", gradleLock}, options)
+
+	assert.NoError(t, err)
+	var files []string
+	for _, j := range res.Jobs() {
+		assert.False(t, j.Errors().HasError())
+		assert.NotNil(t, j.(*lockfile.Job).Graph())
+		files = append(files, j.GetFile())
+	}
+	assert.ElementsMatch(t, []string{yarnLock, poetryLock, gradleLock}, files)
+}
+
+func TestResolveNoExecWithChangedFiles(t *testing.T) {
+	lockFileDir := filepath.Join("..", "lockfile", "testdata")
+	yarnLock := filepath.Join(lockFileDir, "yarn", "classic", "yarn.lock")
+	f := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{ManifestFile: filepath.Join(lockFileDir, "yarn", "classic", "package.json"), LockFiles: []string{yarnLock}})
+	groups.Add(file.Group{ManifestFile: filepath.Join(lockFileDir, "poetry", "pyproject.toml")})
+	f.SetGetGroupsReturnMock(groups, nil)
+
+	r := NewResolver(
+		f,
+		fileTestdata.NewBatchFactoryMock(),
+		strategyTestdata.NewStrategyFactoryErrorMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		NoExec:       true,
+		ChangedFiles: []string{yarnLock},
+	}
+	res, err := r.Resolve([]string{"."}, options)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	assert.Equal(t, yarnLock, res.Jobs()[0].GetFile())
+}
+
+func TestResolveNoExecInvalidLockFile(t *testing.T) {
+	r := NewResolver(
+		testdata.NewFinderMock(),
+		fileTestdata.NewBatchFactoryMock(),
+		strategyTestdata.NewStrategyFactoryErrorMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose:              true,
+		NoExec:               true,
+		ResolutionStrictness: FailIfAnyFail,
+	}
+	res, err := r.Resolve([]string{filepath.Join("..", "lockfile", "testdata", "invalid", "package-lock.json")}, options)
+
+	assert.ErrorContains(t, err, "resolution failed")
+	assert.Len(t, res.Jobs(), 1)
+	assert.True(t, res.HasErr())
+}
+
+func TestResolveNoExecUnsupportedFile(t *testing.T) {
+	r := NewResolver(
+		testdata.NewFinderMock(),
+		fileTestdata.NewBatchFactoryMock(),
+		strategyTestdata.NewStrategyFactoryErrorMock(),
+		NewScheduler(workers),
+	)
+	res, err := r.Resolve([]string{"../../go.mod"}, DebrickedOptions{NoExec: true})
+
+	assert.NoError(t, err)
+	assert.Empty(t, res.Jobs())
+}
+
 func TestGetExitCode(t *testing.T) {
 	f := testdata.NewFinderMock()
 	groups := file.Groups{}
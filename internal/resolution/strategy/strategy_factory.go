@@ -5,6 +5,7 @@ import (
 
 	"github.com/debricked/cli/internal/resolution/file"
 	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
@@ -12,6 +13,7 @@ import (
 	"github.com/debricked/cli/internal/resolution/pm/npm"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
 	"github.com/debricked/cli/internal/resolution/pm/sbt"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
 )
@@ -42,6 +44,8 @@ func (sf Factory) Make(pmFileBatch file.IBatch, paths []string) (IStrategy, erro
 		return yarn.NewStrategy(pmFileBatch.Files()), nil
 	case npm.Name:
 		return npm.NewStrategy(pmFileBatch.Files()), nil
+	case pnpm.Name:
+		return pnpm.NewStrategy(pmFileBatch.Files()), nil
 	case bower.Name:
 		return bower.NewStrategy(pmFileBatch.Files()), nil
 	case nuget.Name:
@@ -50,6 +54,8 @@ func (sf Factory) Make(pmFileBatch file.IBatch, paths []string) (IStrategy, erro
 		return composer.NewStrategy(pmFileBatch.Files()), nil
 	case sbt.Name:
 		return sbt.NewStrategy(pmFileBatch.Files()), nil
+	case cargo.Name:
+		return cargo.NewStrategy(pmFileBatch.Files()), nil
 	default:
 		return nil, fmt.Errorf("failed to make strategy from %s", name)
 	}
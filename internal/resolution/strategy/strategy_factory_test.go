@@ -4,12 +4,14 @@ import (
 	"testing"
 
 	"github.com/debricked/cli/internal/resolution/file"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
 	"github.com/debricked/cli/internal/resolution/pm/maven"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pnpm"
 	"github.com/debricked/cli/internal/resolution/pm/sbt"
 	"github.com/debricked/cli/internal/resolution/pm/testdata"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
@@ -36,9 +38,11 @@ func TestMake(t *testing.T) {
 		gomod.Name:    gomod.NewStrategy(nil),
 		pip.Name:      pip.NewStrategy(nil),
 		yarn.Name:     yarn.NewStrategy(nil),
+		pnpm.Name:     pnpm.NewStrategy(nil),
 		nuget.Name:    nuget.NewStrategy(nil),
 		composer.Name: composer.NewStrategy(nil),
 		sbt.Name:      sbt.NewStrategy(nil),
+		cargo.Name:    cargo.NewStrategy(nil),
 	}
 	f := NewStrategyFactory()
 	var batch file.IBatch
@@ -10,6 +10,7 @@ import (
 
 type ResolverMock struct {
 	Err   error
+	Jobs  []job.IJob
 	files []string
 }
 
@@ -29,7 +30,12 @@ func (r *ResolverMock) Resolve(_ []string, _ resolution.IOptions) (resolution.IR
 		}
 	}
 
-	return resolution.NewResolution([]job.IJob{}), r.Err
+	jobs := r.Jobs
+	if jobs == nil {
+		jobs = []job.IJob{}
+	}
+
+	return resolution.NewResolution(jobs), r.Err
 }
 
 func (r *ResolverMock) SetFiles(files []string) {
@@ -0,0 +1,122 @@
+package sarif
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+)
+
+// maxLocatorFileSize limits the size of files searched for dependencies, as generated lock files can be huge
+const maxLocatorFileSize = 10 * 1024 * 1024
+
+const (
+	callGraphFileSuffix  = "debricked-call-graph"
+	fingerprintsFileName = "debricked.fingerprints.txt"
+)
+
+// dependencyTypeSuffix matches the package manager suffix of dependency names, such as ` (npm)`
+var dependencyTypeSuffix = regexp.MustCompile(`\s+\([^)]*\)$`)
+
+type locator struct {
+	files []string
+	lines map[string][]string
+}
+
+// newLocator returns a locator searching manifest files before lock files,
+// as manifest files point out the direct dependency to update
+func newLocator(fileGroups file.Groups) *locator {
+	var manifestFiles, lockFiles []string
+	for _, group := range fileGroups.ToSlice() {
+		if group.HasFile() {
+			manifestFiles = append(manifestFiles, group.ManifestFile)
+		}
+		for _, lockFile := range group.LockFiles {
+			if isDependencyFile(lockFile) {
+				lockFiles = append(lockFiles, lockFile)
+			}
+		}
+	}
+
+	return &locator{files: append(manifestFiles, lockFiles...), lines: map[string][]string{}}
+}
+
+// isDependencyFile returns false for files generated by the CLI that never mention dependency names
+func isDependencyFile(path string) bool {
+	base := filepath.Base(path)
+
+	return !strings.HasSuffix(base, callGraphFileSuffix) && base != fingerprintsFileName
+}
+
+// locate returns the location of the first line mentioning dependency. If no file mentions it,
+// the first dependency file is used, as code-scanning dashboards require findings to have a location
+func (l *locator) locate(dependency string) *Location {
+	if len(l.files) == 0 {
+		return nil
+	}
+	pattern := dependencyPattern(dependency)
+	if pattern != nil {
+		for _, path := range l.files {
+			for i, line := range l.readLines(path) {
+				if pattern.MatchString(line) {
+					return newLocation(path, i+1)
+				}
+			}
+		}
+	}
+
+	return newLocation(l.files[0], 0)
+}
+
+func (l *locator) readLines(path string) []string {
+	if lines, ok := l.lines[path]; ok {
+		return lines
+	}
+	var lines []string
+	if info, err := os.Stat(path); err == nil && info.Size() <= maxLocatorFileSize {
+		if f, err := os.Open(filepath.Clean(path)); err == nil {
+			scanner := bufio.NewScanner(f)
+			scanner.Buffer(make([]byte, 0, 64*1024), maxLocatorFileSize)
+			for scanner.Scan() {
+				lines = append(lines, scanner.Text())
+			}
+			_ = f.Close()
+		}
+	}
+	l.lines[path] = lines
+
+	return lines
+}
+
+// dependencyPattern matches the dependency name as a whole word. Maven style `group:artifact` names
+// are matched on the artifact, as the group and artifact are usually on separate lines
+func dependencyPattern(dependency string) *regexp.Regexp {
+	name := strings.TrimSpace(dependencyTypeSuffix.ReplaceAllString(dependency, ""))
+	if i := strings.LastIndex(name, ":"); i >= 0 && i < len(name)-1 {
+		name = name[i+1:]
+	}
+	if len(name) == 0 {
+		return nil
+	}
+
+	return regexp.MustCompile(`(^|[^\w.\-/@])` + regexp.QuoteMeta(name) + `($|[^\w.\-/])`)
+}
+
+func newLocation(path string, line int) *Location {
+	location := &Location{
+		PhysicalLocation: PhysicalLocation{
+			ArtifactLocation: ArtifactLocation{
+				Uri:       filepath.ToSlash(filepath.Clean(path)),
+				UriBaseId: srcRoot,
+			},
+		},
+	}
+	if line > 0 {
+		location.PhysicalLocation.Region = &Region{StartLine: line}
+	}
+
+	return location
+}
@@ -0,0 +1,281 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/upload"
+)
+
+const (
+	Version   = "2.1.0"
+	SchemaUri = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName  = "debricked"
+	toolUri   = "https://debricked.com"
+	srcRoot   = "%SRCROOT%"
+)
+
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	InformationUri string `json:"informationUri"`
+	Version        string `json:"version,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+type Rule struct {
+	Id               string                 `json:"id"`
+	Name             string                 `json:"name,omitempty"`
+	ShortDescription Message                `json:"shortDescription"`
+	FullDescription  *Message               `json:"fullDescription,omitempty"`
+	HelpUri          string                 `json:"helpUri,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Result struct {
+	RuleId     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    Message                `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	Uri       string `json:"uri"`
+	UriBaseId string `json:"uriBaseId"`
+}
+
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// NewLog converts the triggered automation rules of result to SARIF results.
+// Findings are located on the first file of fileGroups mentioning the dependency
+func NewLog(result *upload.UploadResult, fileGroups file.Groups, cliVersion string) Log {
+	locator := newLocator(fileGroups)
+	rules := map[string]Rule{}
+	var results []Result
+	seen := map[string]int{}
+
+	for _, automationRule := range result.AutomationRules {
+		if !automationRule.Triggered {
+			continue
+		}
+		level := LevelWarning
+		if automationRule.FailPipeline() {
+			level = LevelError
+		}
+		for _, event := range automationRule.TriggerEvents {
+			rule := newRule(automationRule, event)
+			if _, ok := rules[rule.Id]; !ok {
+				rules[rule.Id] = rule
+			}
+			key := rule.Id + "\n" + event.Dependency
+			if i, ok := seen[key]; ok {
+				// Several automation rules can be triggered by the same finding, only the most severe is kept
+				if level == LevelError {
+					results[i].Level = level
+				}
+
+				continue
+			}
+			seen[key] = len(results)
+			results = append(results, newResult(rule.Id, level, automationRule, event, locator.locate(event.Dependency)))
+		}
+	}
+
+	return Log{
+		Schema:  SchemaUri,
+		Version: Version,
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name:           toolName,
+						InformationUri: toolUri,
+						Version:        cliVersion,
+						Rules:          sortedRules(rules),
+					},
+				},
+				Results: nonNil(results),
+			},
+		},
+	}
+}
+
+// Write writes the SARIF log of result to path
+func Write(path string, result *upload.UploadResult, fileGroups file.Groups, cliVersion string) error {
+	data, err := json.MarshalIndent(NewLog(result, fileGroups, cliVersion), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Clean(path), data, 0600)
+}
+
+func hasCve(automationRule automation.Rule, event automation.TriggerEvent) bool {
+	return len(event.Cve) > 0 && (automationRule.HasCves || len(event.Licenses) == 0)
+}
+
+func newRule(automationRule automation.Rule, event automation.TriggerEvent) Rule {
+	if hasCve(automationRule, event) {
+		return Rule{
+			Id:               event.Cve,
+			Name:             event.Cve,
+			ShortDescription: Message{Text: event.Cve},
+			HelpUri:          event.CveLink,
+			Properties: map[string]interface{}{
+				"security-severity": fmt.Sprintf("%.1f", severity(event)),
+				"tags":              []string{"security", "vulnerability"},
+			},
+		}
+	}
+
+	description := strings.TrimSpace(automationRule.RuleDescription)
+
+	return Rule{
+		Id:               "automation-rule/" + slug(description),
+		Name:             "AutomationRule",
+		ShortDescription: Message{Text: firstLine(description)},
+		FullDescription:  &Message{Text: description},
+		HelpUri:          automationRule.RuleLink,
+		Properties: map[string]interface{}{
+			"tags": []string{"license", "dependency"},
+		},
+	}
+}
+
+func newResult(ruleId string, level string, automationRule automation.Rule, event automation.TriggerEvent, location *Location) Result {
+	var message string
+	if hasCve(automationRule, event) {
+		message = fmt.Sprintf("%s is affected by %s (CVSS3: %g, CVSS2: %g)", event.Dependency, event.Cve, event.Cvss3, event.Cvss2)
+	} else {
+		message = fmt.Sprintf("%s triggered the automation rule: %s", event.Dependency, firstLine(automationRule.RuleDescription))
+	}
+	if len(event.Licenses) > 0 {
+		message = fmt.Sprintf("%s. Licenses: %s", message, strings.Join(event.Licenses, ", "))
+	}
+
+	properties := map[string]interface{}{
+		"dependency":     event.Dependency,
+		"dependencyLink": event.DependencyLink,
+		"ruleLink":       automationRule.RuleLink,
+	}
+	if len(event.Licenses) > 0 {
+		properties["licenses"] = event.Licenses
+	}
+	if len(event.Cve) > 0 {
+		properties["cve"] = event.Cve
+		properties["cvss2"] = event.Cvss2
+		properties["cvss3"] = event.Cvss3
+	}
+
+	result := Result{
+		RuleId:     ruleId,
+		Level:      level,
+		Message:    Message{Text: message},
+		Properties: properties,
+	}
+	if location != nil {
+		result.Locations = []Location{*location}
+	}
+
+	return result
+}
+
+// severity returns the CVSS3 score, falling back to CVSS2 for vulnerabilities lacking one
+func severity(event automation.TriggerEvent) float32 {
+	if event.Cvss3 > 0 {
+		return event.Cvss3
+	}
+
+	return event.Cvss2
+}
+
+func sortedRules(rules map[string]Rule) []Rule {
+	sorted := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		sorted = append(sorted, rule)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Id < sorted[j].Id
+	})
+
+	return sorted
+}
+
+func nonNil(results []Result) []Result {
+	if results == nil {
+		return []Result{}
+	}
+
+	return results
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+
+	return line
+}
+
+// slug returns a lower-case identifier only containing letters, digits and dashes
+func slug(text string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+			dash = false
+		} else if !dash && builder.Len() > 0 {
+			builder.WriteRune('-')
+			dash = true
+		}
+		if builder.Len() >= 64 {
+			break
+		}
+	}
+	id := strings.TrimSuffix(builder.String(), "-")
+	if len(id) == 0 {
+		return "unnamed"
+	}
+
+	return id
+}
@@ -0,0 +1,195 @@
+package sarif
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGroups() file.Groups {
+	var groups file.Groups
+	groups.Add(*file.NewGroup("testdata/package.json", nil, []string{"testdata/yarn.lock"}))
+	groups.Add(*file.NewGroup("testdata/pom.xml", nil, []string{"testdata/debricked.fingerprints.txt"}))
+
+	return groups
+}
+
+func newTestResult() *upload.UploadResult {
+	return &upload.UploadResult{
+		AutomationRules: []automation.Rule{
+			{
+				RuleDescription: "Fail on critical vulnerabilities",
+				RuleActions:     []string{"failPipeline"},
+				RuleLink:        "https://debricked.com/rule/1",
+				HasCves:         true,
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{
+						Dependency:     "lodash (npm)",
+						DependencyLink: "https://debricked.com/dependency/1",
+						Licenses:       []string{"MIT"},
+						Cve:            "CVE-2021-23337",
+						Cvss2:          6.5,
+						Cvss3:          7.2,
+						CveLink:        "https://debricked.com/vulnerability/1",
+					},
+					{
+						Dependency: "org.apache.logging.log4j:log4j-core (Maven)",
+						Cve:        "CVE-2021-44228",
+						Cvss2:      9.3,
+						Cvss3:      10,
+						CveLink:    "https://debricked.com/vulnerability/2",
+					},
+				},
+			},
+			{
+				RuleDescription: "Warn on vulnerabilities",
+				RuleActions:     []string{"warnPipeline"},
+				HasCves:         true,
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{Dependency: "lodash (npm)", Cve: "CVE-2021-23337", Cvss3: 7.2},
+					{Dependency: "minimist (npm)", Cve: "CVE-2021-44906", Cvss2: 7.5},
+				},
+			},
+			{
+				RuleDescription: "Copyleft licenses are not allowed\nContact legal for exceptions",
+				RuleActions:     []string{"warnPipeline"},
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{Dependency: "unknown-package (npm)", Licenses: []string{"GPL-3.0-only"}},
+				},
+			},
+			{
+				RuleDescription: "Untriggered rule",
+				RuleActions:     []string{"failPipeline"},
+				Triggered:       false,
+			},
+		},
+	}
+}
+
+func TestNewLog(t *testing.T) {
+	log := NewLog(newTestResult(), newTestGroups(), "v1.0.0")
+
+	assert.Equal(t, Version, log.Version)
+	assert.Equal(t, SchemaUri, log.Schema)
+	assert.Len(t, log.Runs, 1)
+	driver := log.Runs[0].Tool.Driver
+	assert.Equal(t, "debricked", driver.Name)
+	assert.Equal(t, "v1.0.0", driver.Version)
+
+	var ruleIds []string
+	for _, rule := range driver.Rules {
+		ruleIds = append(ruleIds, rule.Id)
+	}
+	assert.Equal(t, []string{
+		"CVE-2021-23337",
+		"CVE-2021-44228",
+		"CVE-2021-44906",
+		"automation-rule/copyleft-licenses-are-not-allowed-contact-legal-for-exceptions",
+	}, ruleIds)
+	assert.Equal(t, "7.2", driver.Rules[0].Properties["security-severity"])
+	assert.Equal(t, "7.5", driver.Rules[2].Properties["security-severity"])
+	assert.Equal(t, "Copyleft licenses are not allowed", driver.Rules[3].ShortDescription.Text)
+
+	results := log.Runs[0].Results
+	assert.Len(t, results, 4)
+
+	lodash := results[0]
+	assert.Equal(t, "CVE-2021-23337", lodash.RuleId)
+	assert.Equal(t, LevelError, lodash.Level, "failed to assert that the most severe level was kept")
+	assert.Equal(t, "lodash (npm) is affected by CVE-2021-23337 (CVSS3: 7.2, CVSS2: 6.5). Licenses: MIT", lodash.Message.Text)
+	assert.Equal(t, "testdata/package.json", lodash.Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+	assert.Equal(t, srcRoot, lodash.Locations[0].PhysicalLocation.ArtifactLocation.UriBaseId)
+	assert.Equal(t, 6, lodash.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "https://debricked.com/dependency/1", lodash.Properties["dependencyLink"])
+
+	log4j := results[1]
+	assert.Equal(t, LevelError, log4j.Level)
+	assert.Equal(t, "testdata/pom.xml", log4j.Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+	assert.Equal(t, 5, log4j.Locations[0].PhysicalLocation.Region.StartLine)
+
+	minimist := results[2]
+	assert.Equal(t, LevelWarning, minimist.Level)
+	assert.Equal(t, "testdata/yarn.lock", minimist.Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+	assert.Equal(t, 14, minimist.Locations[0].PhysicalLocation.Region.StartLine)
+
+	license := results[3]
+	assert.Equal(t, LevelWarning, license.Level)
+	assert.Equal(t, "unknown-package (npm) triggered the automation rule: Copyleft licenses are not allowed. Licenses: GPL-3.0-only", license.Message.Text)
+	assert.Equal(t, "testdata/package.json", license.Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+	assert.Nil(t, license.Locations[0].PhysicalLocation.Region)
+}
+
+func TestNewLogWithoutTriggeredRules(t *testing.T) {
+	log := NewLog(&upload.UploadResult{}, file.Groups{}, "")
+
+	data, err := json.Marshal(log)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"results":[]`)
+	assert.Contains(t, string(data), `"rules":[]`)
+	assert.NotContains(t, string(data), `"version":""`)
+}
+
+func TestNewLogWithoutFiles(t *testing.T) {
+	log := NewLog(newTestResult(), file.Groups{}, "")
+
+	for _, result := range log.Runs[0].Results {
+		assert.Empty(t, result.Locations)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.sarif")
+
+	err := Write(path, newTestResult(), newTestGroups(), "v1.0.0")
+
+	assert.NoError(t, err)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var log Log
+	assert.NoError(t, json.Unmarshal(data, &log))
+	assert.Len(t, log.Runs[0].Results, 4)
+}
+
+func TestWriteError(t *testing.T) {
+	err := Write(filepath.Join(t.TempDir(), "missing", "result.sarif"), newTestResult(), newTestGroups(), "")
+
+	assert.Error(t, err)
+}
+
+func TestDependencyPattern(t *testing.T) {
+	cases := []struct {
+		dependency string
+		line       string
+		match      bool
+	}{
+		{"lodash (npm)", `    "lodash": "^4.17.20",`, true},
+		{"lodash (npm)", `    "lodash.get": "^4.4.2"`, false},
+		{"lodash (npm)", `lodash@^4.17.20:`, true},
+		{"@babel/core (npm)", `"@babel/core@^7.22.0":`, true},
+		{"core (npm)", `"@babel/core@^7.22.0":`, false},
+		{"org.slf4j:slf4j-api (Maven)", `<artifactId>slf4j-api</artifactId>`, true},
+		{"requests (PyPI)", `requests==2.31.0`, true},
+		{"requests (PyPI)", `requests-oauthlib==1.3.1`, false},
+	}
+	for _, c := range cases {
+		pattern := dependencyPattern(c.dependency)
+		assert.Equalf(t, c.match, pattern.MatchString(c.line), "%s in %s", c.dependency, c.line)
+	}
+
+	assert.Nil(t, dependencyPattern(" (npm)"))
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "no-gpl-licenses", slug("No GPL licenses!"))
+	assert.Equal(t, "unnamed", slug("!!!"))
+	assert.Len(t, slug("a very long description which goes on and on and on and on and on and on"), 64)
+}
@@ -0,0 +1,76 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/debug"
+	"github.com/debricked/cli/internal/image"
+	"github.com/fatih/color"
+)
+
+// prepareImage extracts options.Image to a temporary directory, along with a CycloneDX SBOM of its packages,
+// which is then scanned instead of options.Path. The returned function removes the directory
+func prepareImage(options *DebrickedOptions) (func(), error) {
+	// Reports are written relative to where the CLI was invoked, not the temporary directory
+	for _, outputPath := range []*string{&options.JsonFilePath, &options.SarifPath, &options.JUnitPath, &options.SBOMOutput} {
+		if len(*outputPath) == 0 {
+			continue
+		}
+		absPath, err := filepath.Abs(*outputPath)
+		if err != nil {
+			return nil, err
+		}
+		*outputPath = absPath
+	}
+	dir, err := os.MkdirTemp("", "debricked-image-")
+	if err != nil {
+		return nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	fmt.Printf("Extracting image: %s\n", options.Image)
+	img, err := image.Extract(options.Image, dir)
+	if err != nil {
+		cleanup()
+
+		return nil, err
+	}
+	debug.Log(fmt.Sprintf("Extracted %d layers of %s to %s", img.Layers, img.Name, img.RootFS), options.Debug)
+	inventory, err := image.NewInventory(img.RootFS)
+	if err != nil {
+		cleanup()
+
+		return nil, err
+	}
+	for _, warning := range inventory.Warnings {
+		fmt.Printf("%s %s\n", color.YellowString("⚠️"), warning)
+	}
+	if err = image.WriteCycloneDX(filepath.Join(dir, image.InventoryFileName), img, inventory); err != nil {
+		cleanup()
+
+		return nil, err
+	}
+	fmt.Printf(
+		"Found %d OS packages and %d language packages in %s\n",
+		len(inventory.OSPackages),
+		len(inventory.LanguagePackages),
+		img.Name,
+	)
+
+	options.Path = dir
+	// Package managers are not run on the contents of images, and call graphs require source code
+	options.Resolve = false
+	options.CallGraph = false
+	if len(options.RepositoryName) == 0 {
+		options.RepositoryName = img.Repository()
+	}
+	if len(options.CommitName) == 0 {
+		options.CommitName = img.Digest
+	}
+
+	return cleanup, nil
+}
@@ -8,8 +8,10 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/debricked/cli/internal/bundle"
 	"github.com/debricked/cli/internal/callgraph"
 	"github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/language"
 	"github.com/debricked/cli/internal/ci"
 	"github.com/debricked/cli/internal/ci/env"
 	"github.com/debricked/cli/internal/client"
@@ -18,16 +20,22 @@ import (
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/git"
 	"github.com/debricked/cli/internal/io"
+	"github.com/debricked/cli/internal/junit"
 	"github.com/debricked/cli/internal/report/sbom"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/sarif"
 	"github.com/debricked/cli/internal/tui"
 	"github.com/debricked/cli/internal/upload"
 	"github.com/fatih/color"
 )
 
+// SinceAuto is the since option that selects the target branch of the pull request the CI pipeline runs for
+const SinceAuto = "auto"
+
 var (
 	BadOptsErr      = errors.New("failed to type case IOptions")
 	FailPipelineErr = errors.New("")
+	NoChangesErr    = errors.New("no dependency files changed")
 )
 
 type IScanner interface {
@@ -48,6 +56,7 @@ type DebrickedScanner struct {
 
 type DebrickedOptions struct {
 	Path                        string
+	Image                       string
 	Resolve                     bool
 	Fingerprint                 bool
 	CallGraph                   bool
@@ -63,18 +72,33 @@ type DebrickedOptions struct {
 	CommitName                  string
 	GenerateCommitName          bool
 	BranchName                  string
+	PullRequest                 string
+	TargetBranchName            string
+	BaseCommitName              string
 	CommitAuthor                string
 	RepositoryUrl               string
 	IntegrationName             string
 	JsonFilePath                string
+	SarifPath                   string
+	JUnitPath                   string
 	NpmPreferred                bool
 	PassOnTimeOut               bool
 	CallGraphUploadTimeout      int
 	CallGraphGenerateTimeout    int
 	MinFingerprintContentLength int
+	FingerprintCachePath        string
 	TagCommitAsRelease          bool
 	Experimental                bool
 	Version                     string
+	Offline                     bool
+	BundleOutput                string
+	UploadWorkers               int
+	// UploadJournalPath is the file the progress of uploads is kept in, so that an interrupted upload can be resumed
+	UploadJournalPath string
+	// Since is the revision whose merge base with HEAD the changed files are computed against
+	Since string
+	// ChangedFiles limits the scan to groups with a changed manifest or lock file. Nil scans all groups
+	ChangedFiles []string
 }
 
 func NewDebrickedScanner(
@@ -110,10 +134,28 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 	MapEnvToOptions(&dOptions, e)
 	UpdatedEmptyCommitName(&dOptions)
 
+	if err := SetOutputPaths(&dOptions); err != nil {
+		return err
+	}
+
+	if len(dOptions.Image) > 0 {
+		debug.Log("Extracting image...", dOptions.Debug)
+		cleanup, err := prepareImage(&dOptions)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
+	SetUploadJournalPath(&dOptions)
 	if err := SetWorkingDirectory(&dOptions); err != nil {
 		return err
 	}
 
+	if err := SetChangedFiles(&dOptions); err != nil {
+		return err
+	}
+
 	debug.Log("Setting up git objects...", dOptions.Debug)
 	gitMetaObject, err := git.NewMetaObject(
 		dOptions.Path,
@@ -126,11 +168,25 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 	if err != nil {
 		return err
 	}
+	gitMetaObject.SetPullRequest(dOptions.Path, dOptions.PullRequest, dOptions.TargetBranchName, dOptions.BaseCommitName)
+
+	if dOptions.Offline {
+		debug.Log("Running offline scan with initialized scanner...", dOptions.Debug)
+
+		return dScanner.handleScanError(dScanner.scanOffline(dOptions, *gitMetaObject), dOptions)
+	}
 
 	debug.Log("Running scan with initialized scanner...", dOptions.Debug)
-	result, err := dScanner.scan(dOptions, *gitMetaObject)
+	result, prepared, err := dScanner.scan(dOptions, *gitMetaObject)
 	if err != nil {
-		return dScanner.handleScanError(err, dOptions.PassOnTimeOut)
+		err = dScanner.handleScanError(err, dOptions)
+		if err != nil {
+			if junitErr := WriteJUnitFile(dOptions, nil, prepared.resolution, err); junitErr != nil {
+				fmt.Printf("%s Failed to write JUnit report: %s\n", color.YellowString("⚠️"), junitErr)
+			}
+		}
+
+		return err
 	}
 
 	if result.LongQueue {
@@ -141,7 +197,18 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 	}
 
 	WriteApiReplyToJsonFile(dOptions, result)
+	if err = WriteSarifFile(dOptions, result, prepared.uploadOptions.FileGroups); err != nil {
+		return err
+	}
+	if err = WriteJUnitFile(dOptions, result, prepared.resolution, nil); err != nil {
+		return err
+	}
+
+	return ReportResult(result)
+}
 
+// ReportResult prints the result of a finished scan. FailPipelineErr is returned if a triggered automation rule fails the pipeline
+func ReportResult(result *upload.UploadResult) error {
 	fmt.Printf("\n%d vulnerabilities found\n", result.VulnerabilitiesFound)
 	fmt.Println("")
 	failPipeline := false
@@ -179,7 +246,7 @@ func (dScanner *DebrickedScanner) scanReportSBOM(options DebrickedOptions, detai
 	})
 }
 
-func (dScanner *DebrickedScanner) scanResolve(options DebrickedOptions) error {
+func (dScanner *DebrickedScanner) scanResolve(options DebrickedOptions) (resolution.IResolution, error) {
 	resolveOptions := resolution.DebrickedOptions{
 		Path:         options.Path,
 		Verbose:      options.Verbose,
@@ -187,20 +254,19 @@ func (dScanner *DebrickedScanner) scanResolve(options DebrickedOptions) error {
 		Exclusions:   options.Exclusions,
 		Inclusions:   options.Inclusions,
 		NpmPreferred: options.NpmPreferred,
+		Offline:      options.Offline,
+		ChangedFiles: options.ChangedFiles,
 	}
 	if options.Resolve {
-		_, resErr := dScanner.resolver.Resolve([]string{options.Path}, resolveOptions)
-		if resErr != nil {
-			return resErr
-		}
+		return dScanner.resolver.Resolve([]string{options.Path}, resolveOptions)
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (dScanner *DebrickedScanner) scanFingerprint(options DebrickedOptions) error {
 	if options.Fingerprint {
-		if !(*dScanner.client).IsEnterpriseCustomer(false) {
+		if !options.Offline && !(*dScanner.client).IsEnterpriseCustomer(false) {
 
 			return nil
 		}
@@ -210,8 +276,9 @@ func (dScanner *DebrickedScanner) scanFingerprint(options DebrickedOptions) erro
 				Exclusions:                   append(options.Exclusions, fingerprint.DefaultExclusionsFingerprint()...),
 				Inclusions:                   append(options.Inclusions, fingerprint.DefaultInclusionsFingerprint()...),
 				MinFingerprintContentLength:  options.MinFingerprintContentLength,
-				FingerprintCompressedContent: false,
+				FingerprintCompressedContent: len(options.Image) > 0,
 				Regenerate:                   options.Regenerate > 0,
+				CachePath:                    options.FingerprintCachePath,
 			},
 		)
 		if err != nil {
@@ -225,25 +292,35 @@ func (dScanner *DebrickedScanner) scanFingerprint(options DebrickedOptions) erro
 	return nil
 }
 
-func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject git.MetaObject) (*upload.UploadResult, error) {
+// preparation holds the upload options for all matched files, and the resolution if it was run
+type preparation struct {
+	uploadOptions *upload.DebrickedOptions
+	resolution    resolution.IResolution
+}
+
+// prepare runs resolution, fingerprinting and call graph generation. The returned preparation holds the resolution, if
+// it was run, even if a later step fails
+func (dScanner *DebrickedScanner) prepare(options DebrickedOptions, gitMetaObject git.MetaObject) (*preparation, error) {
 
 	debug.Log("Running scanResolve...", options.Debug)
-	err := dScanner.scanResolve(options)
+	res, err := dScanner.scanResolve(options)
+	prepared := &preparation{resolution: res}
 	if err != nil {
-		return nil, err
+		return prepared, err
 	}
 
 	debug.Log("Running scanFingerprint...", options.Debug)
 	err = dScanner.scanFingerprint(options)
 	if err != nil {
-		return nil, err
+		return prepared, err
 	}
 
 	if options.CallGraph {
 		debug.Log("Running scanFingerprint...", options.Debug)
-		configs := []config.IConfig{
-			config.NewConfig("java", []string{}, map[string]string{"pm": "maven"}, true, "maven", options.Version),
-			config.NewConfig("golang", []string{}, map[string]string{"pm": "go"}, true, "go", options.Version),
+		var configs []config.IConfig
+		for _, lang := range language.Languages() {
+			pm := lang.PackageManager()
+			configs = append(configs, config.NewConfig(lang.Name(), []string{}, map[string]string{"pm": pm}, true, pm, options.Version))
 		}
 		timeout := options.CallGraphGenerateTimeout
 		path := options.Path
@@ -260,7 +337,7 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 			},
 		)
 		if resErr != nil {
-			return nil, resErr
+			return prepared, resErr
 		}
 	}
 
@@ -272,14 +349,20 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 			Inclusions:   options.Inclusions,
 			LockFileOnly: false,
 			Strictness:   file.StrictAll,
+			Offline:      options.Offline,
 		},
 	)
 	if err != nil {
-		return nil, err
+		return prepared, err
+	}
+	if options.ChangedFiles != nil {
+		reportSkippedGroups(fileGroups.FilterGroupsByChanges(options.ChangedFiles), options.Since)
+		if fileGroups.Size() == 0 {
+			return prepared, NoChangesErr
+		}
 	}
 
-	debug.Log("Starting upload...", options.Debug)
-	uploaderOptions := upload.DebrickedOptions{
+	uploadOptions := &upload.DebrickedOptions{
 		FileGroups:             fileGroups,
 		GitMetaObject:          gitMetaObject,
 		IntegrationsName:       options.IntegrationName,
@@ -288,20 +371,56 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 		DebrickedConfig:        dScanner.getDebrickedConfig(options.Path, options.Exclusions, options.Inclusions),
 		TagCommitAsRelease:     options.TagCommitAsRelease,
 		Experimental:           options.Experimental,
+		UploadWorkers:          options.UploadWorkers,
+		JournalPath:            options.UploadJournalPath,
+	}
+
+	prepared.uploadOptions = uploadOptions
+
+	return prepared, nil
+}
+
+func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject git.MetaObject) (*upload.UploadResult, *preparation, error) {
+	prepared, err := dScanner.prepare(options, gitMetaObject)
+	if err != nil {
+		return nil, prepared, err
 	}
-	result, err := (*dScanner.uploader).Upload(uploaderOptions)
+
+	debug.Log("Starting upload...", options.Debug)
+	result, err := (*dScanner.uploader).Upload(*prepared.uploadOptions)
 	if err != nil {
-		return nil, err
+		return nil, prepared, err
 	}
 	err = dScanner.scanReportSBOM(
 		options,
 		result.DetailsUrl,
 	)
 	if err != nil {
-		return nil, err
+		return nil, prepared, err
 	}
 
-	return result, nil
+	return result, prepared, nil
+}
+
+func (dScanner *DebrickedScanner) scanOffline(options DebrickedOptions, gitMetaObject git.MetaObject) error {
+	prepared, err := dScanner.prepare(options, gitMetaObject)
+	if err != nil {
+		return err
+	}
+	uploaderOptions := prepared.uploadOptions
+	if uploaderOptions.FileGroups.Size() == 0 {
+		return upload.NoFilesErr
+	}
+
+	debug.Log("Writing bundle...", options.Debug)
+	err = bundle.Write(options.BundleOutput, bundle.NewManifest(*uploaderOptions))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Offline scan bundle written to: %s\n", color.YellowString(options.BundleOutput))
+	fmt.Println("Upload it from a connected machine with \"debricked upload-bundle <file>\"")
+
+	return nil
 }
 
 func (dScanner *DebrickedScanner) getDebrickedConfig(path string, exclusions []string, inclusions []string) *upload.DebrickedConfig {
@@ -313,12 +432,28 @@ func (dScanner *DebrickedScanner) getDebrickedConfig(path string, exclusions []s
 	return upload.GetDebrickedConfig(configPath)
 }
 
-func (dScanner *DebrickedScanner) handleScanError(err error, passOnTimeOut bool) error {
-	if err == client.NoResErr && passOnTimeOut {
+// reportSkippedGroups prints the groups left out of a scan of the files changed since the since revision
+func reportSkippedGroups(skipped []file.Group, since string) {
+	for _, group := range skipped {
+		name := group.ManifestFile
+		if len(name) == 0 && len(group.LockFiles) > 0 {
+			name = group.LockFiles[0]
+		}
+		fmt.Printf("Skipping %s, unchanged since %s\n", color.YellowString(name), since)
+	}
+}
+
+func (dScanner *DebrickedScanner) handleScanError(err error, options DebrickedOptions) error {
+	if err == client.NoResErr && options.PassOnTimeOut {
 		fmt.Println(err)
 
 		return nil
 	}
+	if errors.Is(err, NoChangesErr) {
+		fmt.Printf("No dependency files changed since %s, nothing to scan\n", options.Since)
+
+		return nil
+	}
 
 	return err
 }
@@ -336,6 +471,64 @@ func SetWorkingDirectory(d *DebrickedOptions) error {
 	return nil
 }
 
+// SetUploadJournalPath keeps the upload journal of the scanned directory in the user cache directory, unless a path is
+// set. It is called before SetWorkingDirectory clears the path. Uploads are not resumable without a cache directory
+func SetUploadJournalPath(d *DebrickedOptions) {
+	if len(d.UploadJournalPath) > 0 {
+		return
+	}
+	journalPath, err := upload.JournalPath(d.Path)
+	if err != nil {
+		debug.Log("Failed to find upload journal path: "+err.Error(), d.Debug)
+
+		return
+	}
+	d.UploadJournalPath = journalPath
+}
+
+// SetOutputPaths makes the paths of the bundle, SARIF and JUnit reports and the fingerprint cache absolute. They are
+// relative to where the CLI was invoked, while the JSON result and SBOM paths are relative to the scanned directory
+func SetOutputPaths(d *DebrickedOptions) error {
+	for _, outputPath := range []*string{&d.BundleOutput, &d.SarifPath, &d.JUnitPath, &d.FingerprintCachePath} {
+		if len(*outputPath) == 0 {
+			continue
+		}
+		absPath, err := filepath.Abs(*outputPath)
+		if err != nil {
+			return err
+		}
+		*outputPath = absPath
+	}
+
+	return nil
+}
+
+// SetChangedFiles limits the scan to the files changed since the since option. All files are scanned if it is not set,
+// or if it is SinceAuto outside of pull request pipelines
+func SetChangedFiles(d *DebrickedOptions) error {
+	if d.Since == SinceAuto {
+		if len(d.TargetBranchName) == 0 {
+			fmt.Println("No pull request target branch found, scanning all files")
+			d.Since = ""
+
+			return nil
+		}
+		d.Since = d.TargetBranchName
+	}
+	if len(d.Since) == 0 {
+		return nil
+	}
+
+	changedFiles, err := git.FindChangedFiles(d.Path, d.Since)
+	if err != nil {
+		return fmt.Errorf("failed to find files changed since %s: %w", d.Since, err)
+	}
+	d.ChangedFiles = changedFiles
+	fmt.Printf("Scanning dependency files changed since %s\n", color.YellowString(d.Since))
+
+	return nil
+}
+
 func UpdatedEmptyCommitName(o *DebrickedOptions) {
 	if o.GenerateCommitName && o.CommitName == "" {
 		debug.Log("No commit name set, generating commit name", o.Debug)
@@ -357,6 +550,15 @@ func MapEnvToOptions(o *DebrickedOptions, env env.Env) {
 	if len(o.BranchName) == 0 {
 		o.BranchName = env.Branch
 	}
+	if len(o.PullRequest) == 0 {
+		o.PullRequest = env.PullRequest
+	}
+	if len(o.TargetBranchName) == 0 {
+		o.TargetBranchName = env.TargetBranch
+	}
+	if len(o.BaseCommitName) == 0 {
+		o.BaseCommitName = env.BaseCommit
+	}
 	if len(o.CommitAuthor) == 0 {
 		o.CommitAuthor = env.Author
 	}
@@ -379,3 +581,39 @@ func WriteApiReplyToJsonFile(options DebrickedOptions, result *upload.UploadResu
 		_ = os.WriteFile(options.JsonFilePath, file, 0600)
 	}
 }
+
+// WriteSarifFile writes the triggered automation rules as SARIF, locating findings on the files of fileGroups
+func WriteSarifFile(options DebrickedOptions, result *upload.UploadResult, fileGroups file.Groups) error {
+	if options.SarifPath == "" {
+		return nil
+	}
+	if err := sarif.Write(options.SarifPath, result, fileGroups, options.Version); err != nil {
+		return err
+	}
+	fmt.Printf("SARIF report written to: %s\n", color.YellowString(options.SarifPath))
+
+	return nil
+}
+
+// WriteJUnitFile writes a JUnit XML report with a test suite for the resolution, if it was run, and the automation rules
+// of the result. If the scan failed, scanErr is reported as a failing test case instead of the automation rules
+func WriteJUnitFile(options DebrickedOptions, result *upload.UploadResult, res resolution.IResolution, scanErr error) error {
+	if options.JUnitPath == "" {
+		return nil
+	}
+	var suites []junit.TestSuite
+	if res != nil {
+		suites = append(suites, junit.NewResolutionSuite(res.Jobs()))
+	}
+	if scanErr != nil {
+		suites = append(suites, junit.NewScanSuite(scanErr))
+	} else {
+		suites = append(suites, junit.NewPolicySuite(result.AutomationRules))
+	}
+	if err := junit.Write(options.JUnitPath, suites...); err != nil {
+		return err
+	}
+	fmt.Printf("JUnit report written to: %s\n", color.YellowString(options.JUnitPath))
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package scan
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -12,7 +13,10 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/bundle"
 	"github.com/debricked/cli/internal/callgraph"
 	callgraphTestdata "github.com/debricked/cli/internal/callgraph/testdata"
 	"github.com/debricked/cli/internal/ci"
@@ -30,10 +34,17 @@ import (
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/image"
 	ioFs "github.com/debricked/cli/internal/io"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/job"
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
 	resolveTestdata "github.com/debricked/cli/internal/resolution/testdata"
+	"github.com/debricked/cli/internal/sarif"
 	"github.com/debricked/cli/internal/upload"
+	uploadTestdata "github.com/debricked/cli/internal/upload/testdata"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -195,6 +206,52 @@ func TestScanWithJsonPath(t *testing.T) {
 	assert.FileExists(t, filepath.Join(cwd, path, "result.json"))
 }
 
+func TestScanWithSarifPath(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skipf("TestScan is skipped due to Windows env")
+	}
+	clientMock := testdata.NewDebClientMock()
+	addMockedFormatsResponse(clientMock, "package\\.json")
+	addMockedFileUploadResponse(clientMock)
+	addMockedFinishResponse(clientMock, http.StatusNoContent)
+	addMockedStatusResponse(clientMock, http.StatusOK, 100)
+	scanner := makeScanner(clientMock, nil, nil)
+
+	cwd, _ := os.Getwd()
+	// reset working directory that has been manipulated in scanner.Scan
+	defer resetWd(t, cwd)
+	sarifPath := filepath.Join(t.TempDir(), "result.sarif")
+	opts := DebrickedOptions{
+		Path:                     testdataNpm,
+		RepositoryName:           testdataNpm,
+		CommitName:               "commit",
+		CallGraphUploadTimeout:   10 * 60,
+		CallGraphGenerateTimeout: 10 * 60,
+		SarifPath:                sarifPath,
+		Version:                  "v1.0.0",
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := scanner.Scan(opts)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "SARIF report written to:")
+	data, err := os.ReadFile(sarifPath)
+	assert.NoError(t, err)
+	var log sarif.Log
+	assert.NoError(t, json.Unmarshal(data, &log))
+	assert.Equal(t, sarif.Version, log.Version)
+	assert.Equal(t, "v1.0.0", log.Runs[0].Tool.Driver.Version)
+	assert.Empty(t, log.Runs[0].Results)
+}
+
 func TestScanFailingMetaObject(t *testing.T) {
 	var debClient client.IDebClient = testdata.NewDebClientMock()
 	scanner := NewDebrickedScanner(&debClient, nil, nil, ciService, nil, nil, nil)
@@ -367,6 +424,83 @@ func TestScanWithResolve(t *testing.T) {
 	assert.Contains(t, cwd, path)
 }
 
+func TestScanWithJUnitPath(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	addMockedFormatsResponse(clientMock, "yarn\\.lock")
+	addMockedFileUploadResponse(clientMock)
+	addMockedFinishResponse(clientMock, http.StatusNoContent)
+	addMockedStatusResponse(clientMock, http.StatusOK, 100)
+
+	resolverMock := resolveTestdata.ResolverMock{}
+	resolverMock.SetFiles([]string{"yarn.lock"})
+
+	scanner := makeScanner(clientMock, &resolverMock, nil)
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	// Clean up resolution must be done before wd reset, otherwise files cannot be deleted
+	defer cleanUpResolution(t, resolverMock)
+
+	junitPath := filepath.Join(t.TempDir(), "junit.xml")
+	opts := DebrickedOptions{
+		Path:           testdataNpm,
+		Resolve:        true,
+		RepositoryName: testdataNpm,
+		CommitName:     "testdata/npm-commit",
+		JUnitPath:      junitPath,
+	}
+	err := scanner.Scan(opts)
+
+	assert.NoError(t, err)
+	data, err := os.ReadFile(junitPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `<testsuite name="debricked.resolution" tests="0" failures="0">`)
+	assert.Contains(t, string(data), `<testsuite name="debricked.policy" tests="0" failures="0">`)
+}
+
+func TestWriteJUnitFileWithoutResolution(t *testing.T) {
+	junitPath := filepath.Join(t.TempDir(), "junit.xml")
+	result := &upload.UploadResult{AutomationRules: []automation.Rule{{RuleDescription: "rule", Triggered: true}}}
+
+	err := WriteJUnitFile(DebrickedOptions{JUnitPath: junitPath}, result, nil, nil)
+
+	assert.NoError(t, err)
+	data, err := os.ReadFile(junitPath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "debricked.resolution")
+	assert.Contains(t, string(data), `<testsuite name="debricked.policy" tests="1" failures="0">`)
+}
+
+func TestScanWithJUnitPathResolveErr(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	failedJob := jobTestdata.NewJobMock("package.json")
+	failedJob.SetErr(job.NewBaseJobError("npm ERR! code ERESOLVE"))
+	resolutionErr := errors.New("resolution failed")
+	resolverMock := resolveTestdata.ResolverMock{Err: resolutionErr, Jobs: []job.IJob{failedJob}}
+	scanner := makeScanner(clientMock, &resolverMock, nil)
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+
+	junitPath := filepath.Join(t.TempDir(), "junit.xml")
+	opts := DebrickedOptions{
+		Path:           testdataNpm,
+		Resolve:        true,
+		RepositoryName: testdataNpm,
+		CommitName:     "testdata/npm-commit",
+		JUnitPath:      junitPath,
+	}
+	err := scanner.Scan(opts)
+
+	assert.ErrorIs(t, err, resolutionErr)
+	data, err := os.ReadFile(junitPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `<testsuite name="debricked.resolution" tests="1" failures="1">`)
+	assert.Contains(t, string(data), "npm ERR! code ERESOLVE")
+	assert.Contains(t, string(data), `<testsuite name="debricked.scan" tests="1" failures="1">`)
+	assert.NotContains(t, string(data), "debricked.policy")
+}
+
 func TestScanWithResolveErr(t *testing.T) {
 	clientMock := testdata.NewDebClientMock()
 	resolutionErr := errors.New("resolution-error")
@@ -517,6 +651,39 @@ var cases = []struct {
 			Filepath:      "env-path",
 		},
 	},
+	{
+		name: "CI env set with pull request",
+		template: DebrickedOptions{
+			Path:             "input-path",
+			Exclusions:       nil,
+			RepositoryName:   "env-repository",
+			CommitName:       "env-commit",
+			BranchName:       "env-branch",
+			PullRequest:      "12",
+			TargetBranchName: "env-target-branch",
+			BaseCommitName:   "env-base-commit",
+			CommitAuthor:     "author",
+			RepositoryUrl:    "env-url",
+			IntegrationName:  github.Integration,
+		},
+		opts: DebrickedOptions{
+			Path:            "input-path",
+			Exclusions:      nil,
+			CommitAuthor:    "author",
+			IntegrationName: "CLI",
+		},
+		env: env.Env{
+			Repository:    "env-repository",
+			Commit:        "env-commit",
+			Branch:        "env-branch",
+			TargetBranch:  "env-target-branch",
+			PullRequest:   "12",
+			BaseCommit:    "env-base-commit",
+			Author:        "env-author",
+			RepositoryUrl: "env-url",
+			Integration:   github.Integration,
+		},
+	},
 }
 
 func TestUpdateEmptyCommitName(t *testing.T) {
@@ -565,6 +732,9 @@ func TestMapEnvToOptions(t *testing.T) {
 			assert.Equal(t, c.template.RepositoryName, c.opts.RepositoryName)
 			assert.Equal(t, c.template.CommitName, c.opts.CommitName)
 			assert.Equal(t, c.template.BranchName, c.opts.BranchName)
+			assert.Equal(t, c.template.PullRequest, c.opts.PullRequest)
+			assert.Equal(t, c.template.TargetBranchName, c.opts.TargetBranchName)
+			assert.Equal(t, c.template.BaseCommitName, c.opts.BaseCommitName)
 			assert.Equal(t, c.template.CommitAuthor, c.opts.CommitAuthor)
 			assert.Equal(t, c.template.RepositoryUrl, c.opts.RepositoryUrl)
 			assert.Equal(t, c.template.IntegrationName, c.opts.IntegrationName)
@@ -710,6 +880,43 @@ func TestScanWithFingerprint(t *testing.T) {
 	assert.Contains(t, cwd, path)
 }
 
+func TestScanWithFingerprintCache(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skipf("TestScan is skipped due to Windows env")
+	}
+	clientMock := testdata.NewDebClientMock()
+	clientMock.SetEnterpriseCustomer(true)
+	addMockedFormatsResponse(clientMock, "yarn\\.lock")
+	addMockedFileUploadResponse(clientMock)
+	addMockedFinishResponse(clientMock, http.StatusNoContent)
+	addMockedStatusResponse(clientMock, http.StatusOK, 100)
+
+	resolverMock := resolveTestdata.ResolverMock{}
+	resolverMock.SetFiles([]string{"yarn.lock"})
+
+	scanner := makeScanner(clientMock, &resolverMock, nil)
+	scanner.fingerprint = fingerprint.NewFingerprinter()
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	// Clean up resolution must be done before wd reset, otherwise files cannot be deleted
+	defer cleanUpResolution(t, resolverMock)
+
+	cachePath := filepath.Join(t.TempDir(), "fingerprint-cache.json")
+	opts := DebrickedOptions{
+		Path:                 testdataNpm,
+		Resolve:              true,
+		Fingerprint:          true,
+		RepositoryName:       testdataNpm,
+		CommitName:           "testdata/npm-commit-fingerprint",
+		FingerprintCachePath: cachePath,
+	}
+	err := scanner.Scan(opts)
+
+	assert.NoError(t, err)
+	assert.FileExists(t, cachePath)
+}
+
 func TestScanWithFingerprintNoEnterprise(t *testing.T) {
 	if runtime.GOOS == windowsOS {
 		t.Skipf("TestScan is skipped due to Windows env")
@@ -752,6 +959,320 @@ func TestScanWithFingerprintNoEnterprise(t *testing.T) {
 	assert.Contains(t, cwd, path)
 }
 
+func TestScanOffline(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skipf("TestScan is skipped due to Windows env")
+	}
+	clientMock := testdata.NewDebClientMock()
+	clientMock.SetEnterpriseCustomer(false)
+	uploaderMock := uploadTestdata.NewUploaderMock()
+	scanner := makeScanner(clientMock, nil, nil)
+	var uploader upload.IUploader = uploaderMock
+	scanner.uploader = &uploader
+	scanner.fingerprint = fingerprint.NewFingerprinter()
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	defer os.Remove(filepath.Join(cwd, testdataNpm, fingerprint.OutputFileNameFingerprints))
+	bundlePath := filepath.Join(t.TempDir(), bundle.DefaultOutputFileName)
+	opts := DebrickedOptions{
+		Path:           testdataNpm,
+		Fingerprint:    true,
+		RepositoryName: testdataNpm,
+		CommitName:     "testdata/npm-commit-offline",
+		Offline:        true,
+		BundleOutput:   bundlePath,
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := scanner.Scan(opts)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "Offline scan bundle written to:")
+	assert.Empty(t, uploaderMock.Options.FileGroups.GetFiles(), "failed to assert that nothing was uploaded")
+	assert.FileExists(t, fingerprint.OutputFileNameFingerprints, "failed to assert that fingerprinting ran without enterprise check")
+
+	manifest, err := bundle.Read(bundlePath, t.TempDir())
+	assert.NoError(t, err)
+	bundleOptions := manifest.UploadOptions()
+	assert.Contains(t, bundleOptions.FileGroups.GetFiles(), "package.json")
+	assert.Equal(t, testdataNpm, manifest.GitMetaObject.RepositoryName)
+	assert.Equal(t, "testdata/npm-commit-offline", manifest.GitMetaObject.CommitName)
+}
+
+func TestScanOfflineNoFiles(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skipf("TestScan is skipped due to Windows env")
+	}
+	clientMock := testdata.NewDebClientMock()
+	scanner := makeScanner(clientMock, nil, nil)
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	bundlePath := filepath.Join(t.TempDir(), bundle.DefaultOutputFileName)
+	opts := DebrickedOptions{
+		Path:           testdataNpm,
+		Exclusions:     []string{"**"},
+		RepositoryName: testdataNpm,
+		CommitName:     "testdata/npm-commit-offline",
+		Offline:        true,
+		BundleOutput:   bundlePath,
+	}
+
+	err := scanner.Scan(opts)
+
+	assert.ErrorIs(t, err, upload.NoFilesErr)
+	assert.NoFileExists(t, bundlePath)
+}
+
+// setUpChangedRepository commits two npm projects, and then a change to the web project
+func setUpChangedRepository(t *testing.T) string {
+	dir := t.TempDir()
+	repository, err := gogit.PlainInit(dir, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	signature := &object.Signature{Name: "debricked", Email: "debricked@example.com", When: time.Now()}
+	for _, files := range []map[string]string{
+		{"web/package.json": "{}", "api/package.json": "{}"},
+		{"web/package.json": `{"name": "web"}`},
+	} {
+		for name, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+			assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			_, err = worktree.Add(name)
+			assert.NoError(t, err)
+		}
+		_, err = worktree.Commit("commit", &gogit.CommitOptions{Author: signature})
+		assert.NoError(t, err)
+	}
+
+	return dir
+}
+
+func TestScanOfflineSince(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	scanner := makeScanner(clientMock, nil, nil)
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	bundlePath := filepath.Join(t.TempDir(), bundle.DefaultOutputFileName)
+	opts := DebrickedOptions{
+		Path:           setUpChangedRepository(t),
+		RepositoryName: "repository",
+		CommitName:     "commit",
+		Offline:        true,
+		BundleOutput:   bundlePath,
+		Since:          "HEAD~1",
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := scanner.Scan(opts)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "Scanning dependency files changed since")
+	assert.Contains(t, string(output), filepath.Join("api", "package.json"))
+	assert.Contains(t, string(output), "unchanged since HEAD~1")
+	manifest, err := bundle.Read(bundlePath, t.TempDir())
+	assert.NoError(t, err)
+	bundleOptions := manifest.UploadOptions()
+	assert.Equal(t, []string{filepath.Join("web", "package.json")}, bundleOptions.FileGroups.GetFiles())
+}
+
+func TestScanOfflineSinceNoChanges(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	scanner := makeScanner(clientMock, nil, nil)
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	bundlePath := filepath.Join(t.TempDir(), bundle.DefaultOutputFileName)
+	opts := DebrickedOptions{
+		Path:           setUpChangedRepository(t),
+		RepositoryName: "repository",
+		CommitName:     "commit",
+		Offline:        true,
+		BundleOutput:   bundlePath,
+		Since:          "HEAD",
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := scanner.Scan(opts)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "No dependency files changed since HEAD, nothing to scan")
+	assert.NoFileExists(t, bundlePath)
+}
+
+func TestSetOutputPaths(t *testing.T) {
+	cwd, _ := os.Getwd()
+	absSarifPath := filepath.Join(t.TempDir(), "report.sarif")
+	opts := DebrickedOptions{
+		BundleOutput: "bundle.tar.gz",
+		SBOMOutput:   filepath.Join("reports", "sbom.json"),
+		JsonFilePath: "result.json",
+		SarifPath:    absSarifPath,
+	}
+
+	err := SetOutputPaths(&opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cwd, "bundle.tar.gz"), opts.BundleOutput)
+	assert.Equal(t, filepath.Join("reports", "sbom.json"), opts.SBOMOutput)
+	assert.Equal(t, "result.json", opts.JsonFilePath)
+	assert.Equal(t, absSarifPath, opts.SarifPath)
+	assert.Empty(t, opts.JUnitPath)
+}
+
+func TestSetUploadJournalPath(t *testing.T) {
+	dir := t.TempDir()
+	expected, err := upload.JournalPath(dir)
+	if err != nil {
+		t.Skip("no user cache directory:", err)
+	}
+	opts := DebrickedOptions{Path: dir}
+
+	SetUploadJournalPath(&opts)
+
+	assert.Equal(t, expected, opts.UploadJournalPath)
+	assert.True(t, filepath.IsAbs(opts.UploadJournalPath))
+	assert.False(t, strings.HasPrefix(opts.UploadJournalPath, dir))
+
+	opts = DebrickedOptions{Path: dir, UploadJournalPath: "journal.json"}
+	SetUploadJournalPath(&opts)
+	assert.Equal(t, "journal.json", opts.UploadJournalPath)
+}
+
+func TestSetChangedFiles(t *testing.T) {
+	dir := setUpChangedRepository(t)
+
+	opts := DebrickedOptions{Path: dir, Since: "HEAD~1"}
+	err := SetChangedFiles(&opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "HEAD~1", opts.Since)
+	assert.Equal(t, []string{filepath.Join("web", "package.json")}, opts.ChangedFiles)
+}
+
+func TestSetChangedFilesWithoutSince(t *testing.T) {
+	opts := DebrickedOptions{Path: t.TempDir()}
+	err := SetChangedFiles(&opts)
+
+	assert.NoError(t, err)
+	assert.Nil(t, opts.ChangedFiles)
+}
+
+func TestSetChangedFilesAuto(t *testing.T) {
+	dir := setUpChangedRepository(t)
+
+	opts := DebrickedOptions{Path: dir, Since: SinceAuto, TargetBranchName: "HEAD~1"}
+	err := SetChangedFiles(&opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "HEAD~1", opts.Since)
+	assert.Equal(t, []string{filepath.Join("web", "package.json")}, opts.ChangedFiles)
+}
+
+func TestSetChangedFilesAutoWithoutTargetBranch(t *testing.T) {
+	opts := DebrickedOptions{Path: t.TempDir(), Since: SinceAuto}
+	err := SetChangedFiles(&opts)
+
+	assert.NoError(t, err)
+	assert.Empty(t, opts.Since)
+	assert.Nil(t, opts.ChangedFiles)
+}
+
+func TestSetChangedFilesErr(t *testing.T) {
+	dir := setUpChangedRepository(t)
+
+	opts := DebrickedOptions{Path: dir, Since: "unknown"}
+	err := SetChangedFiles(&opts)
+
+	assert.ErrorContains(t, err, "failed to find files changed since unknown")
+	assert.Nil(t, opts.ChangedFiles)
+}
+
+func TestScanOfflineImage(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skipf("TestScan is skipped due to Windows env")
+	}
+	clientMock := testdata.NewDebClientMock()
+	scanner := makeScanner(clientMock, nil, nil)
+	scanner.fingerprint = fingerprint.NewFingerprinter()
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	dir := t.TempDir()
+	jarPath := filepath.Join(dir, "app.jar")
+	var jar bytes.Buffer
+	writer := zip.NewWriter(&jar)
+	entry, _ := writer.Create("META-INF/maven/com.example/app/pom.properties")
+	_, _ = entry.Write([]byte("groupId=com.example\nartifactId=app\nversion=1.2.0\n"))
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, os.WriteFile(jarPath, jar.Bytes(), 0600))
+	bundlePath := filepath.Join(dir, bundle.DefaultOutputFileName)
+	opts := DebrickedOptions{
+		Image:        jarPath,
+		Resolve:      true,
+		Fingerprint:  true,
+		Offline:      true,
+		BundleOutput: bundlePath,
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := scanner.Scan(opts)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "Found 0 OS packages and 1 language packages in app.jar")
+
+	manifest, err := bundle.Read(bundlePath, t.TempDir())
+	assert.NoError(t, err)
+	bundleOptions := manifest.UploadOptions()
+	files := bundleOptions.FileGroups.GetFiles()
+	assert.Contains(t, files, image.InventoryFileName)
+	assert.Equal(t, "app.jar", manifest.GitMetaObject.RepositoryName)
+	assert.True(t, strings.HasPrefix(manifest.GitMetaObject.CommitName, "sha256:"))
+}
+
+func TestScanImageNotFound(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	scanner := makeScanner(clientMock, nil, nil)
+
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	err := scanner.Scan(DebrickedOptions{Image: filepath.Join(t.TempDir(), "missing.tar")})
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestScanWithGeneratedCommitName(t *testing.T) {
 	clientMock := testdata.NewDebClientMock()
 	addMockedFormatsResponse(clientMock, "package\\.json")
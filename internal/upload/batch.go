@@ -11,8 +11,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/debricked/cli/internal/client"
@@ -28,10 +30,14 @@ var (
 	PollingTerminatedErr = errors.New("progress polling terminated due to long queue times")
 	EmptyFileErr         = errors.New("tried to upload empty file")
 	InitScanErr          = errors.New("failed to initialize a scan")
+	FailedUploadsErr     = errors.New("failed to upload required files")
+	NonEnterpriseErr     = errors.New("non-enterprise customer trying to upload fingerprints")
 )
 
 const callgraphName = "debricked-call-graph"
 
+const DefaultUploadWorkers = 20
+
 type uploadBatch struct {
 	client             *client.IDebClient
 	fileGroups         file.Groups
@@ -43,6 +49,8 @@ type uploadBatch struct {
 	debrickedConfig    *DebrickedConfig // JSON Config
 	tagCommitAsRelease bool
 	experimental       bool
+	workers            int
+	journal            *Journal
 }
 
 func newUploadBatch(
@@ -61,108 +69,148 @@ func newUploadBatch(
 		debrickedConfig:    debrickedConfig,
 		tagCommitAsRelease: tagCommitAsRelease,
 		experimental:       experimental,
+		workers:            DefaultUploadWorkers,
+		journal:            newMemoryJournal(),
 	}
 }
 
-// upload concurrently posts all file groups to Debricked
+// upload concurrently posts all file groups to Debricked. Requests are retried with backoff by the client, and an
+// error is returned if any required file could not be delivered
 func (uploadBatch *uploadBatch) upload() error {
-	uploadWorker := func(fileQueue <-chan string, fileResults chan<- int) {
-		const ok = 0
-		const fail = 1
-
-		for f := range fileQueue {
-			fileName := filepath.Base(f)
-			var err error
-			timeout := 0
-			if strings.HasSuffix(fileName, callgraphName) {
-				timeout = uploadBatch.callGraphTimeout
-			}
-			err = uploadBatch.uploadFile(f, timeout)
-
-			if err != nil {
-				log.Println("Failed to upload:", f)
-				if err != nil {
-					log.Println(err.Error())
-					fileResults <- fail
-				}
-			} else {
-				printSuccessfulUpload(f)
-				fileResults <- ok
-			}
-		}
-	}
-
 	files, err := uploadBatch.initUpload()
 	if err != nil {
 
 		return err
 	}
 
+	workers := uploadBatch.workers
+	if workers > len(files) {
+		workers = len(files)
+	}
 	fileQueue := make(chan string, len(files))
-	fileResults := make(chan int, len(files))
-
-	// Spawn workers
-	for w := 1; w <= 20; w++ {
-		go uploadWorker(fileQueue, fileResults)
+	failedFiles := make(chan string, len(files))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range fileQueue {
+				if !uploadBatch.uploadJournaledFile(f) {
+					failedFiles <- f
+				}
+			}
+		}()
 	}
 
-	// Append file jobs on queue
 	for _, f := range files {
 		fileQueue <- f
 	}
+	close(fileQueue)
+	wg.Wait()
+	close(failedFiles)
 
-	// Await completion
-	for range files {
-		<-fileResults
+	var failed []string
+	for f := range failedFiles {
+		failed = append(failed, f)
 	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
 
-	close(fileQueue)
+		return fmt.Errorf("%w: %s. Re-run the scan to retry the failed files", FailedUploadsErr, strings.Join(failed, ", "))
+	}
 
 	return nil
 }
 
-// uploadFile Reads file content from filepath and uploads it to Debricked. Returns HTTP status code or 0 if other error occur
-func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
-	if strings.HasSuffix(filePath, "debricked.fingerprints.txt") && !(*uploadBatch.client).IsEnterpriseCustomer(true) {
-		return errors.New("non-enterprise customer trying to upload fingerprints")
+// uploadJournaledFile uploads the file unless the journal shows it is already uploaded.
+// Returns false if the file is required but could not be uploaded
+func (uploadBatch *uploadBatch) uploadJournaledFile(filePath string) bool {
+	sum, err := checksum(filePath)
+	if err != nil {
+		log.Println("Failed to upload:", filePath)
+		log.Println(err.Error())
+		uploadBatch.updateJournal(filePath, sum, FileFailed, err)
+
+		return false
+	}
+	if uploadBatch.journal.Uploaded(filePath, sum) {
+		fmt.Printf("Already uploaded: %s\n", color.YellowString(filePath))
+
+		return true
 	}
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	defer writer.Close()
+	err = uploadBatch.uploadFile(filePath, uploadBatch.fileTimeout(filePath))
+	if errors.Is(err, NonEnterpriseErr) {
+		log.Println("Skipped upload:", filePath)
+		log.Println(err.Error())
+		uploadBatch.updateJournal(filePath, sum, FileSkipped, err)
 
-	fileData, _ := writer.CreateFormFile("fileData", filepath.Base(filePath))
-	f, err := os.Open(filepath.Clean(filePath))
+		return true
+	}
 	if err != nil {
-		return err
+		log.Println("Failed to upload:", filePath)
+		log.Println(err.Error())
+		uploadBatch.updateJournal(filePath, sum, FileFailed, err)
+
+		return false
 	}
+	printSuccessfulUpload(filePath)
+	uploadBatch.updateJournal(filePath, sum, FileUploaded, nil)
 
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
+	return true
+}
 
-	_, _ = io.Copy(fileData, f)
+func (uploadBatch *uploadBatch) updateJournal(filePath string, checksum string, state FileState, err error) {
+	if journalErr := uploadBatch.journal.Update(filePath, checksum, state, err); journalErr != nil {
+		log.Println("Failed to update upload journal:", journalErr.Error())
+	}
+}
 
-	_ = writer.WriteField("fileRelativePath", getRelativeFilePath(filePath))
-	_ = writer.WriteField("repositoryName", uploadBatch.gitMetaObject.RepositoryName)
-	_ = writer.WriteField("commitName", uploadBatch.gitMetaObject.CommitName)
-	_ = writer.WriteField("repositoryUrl", uploadBatch.gitMetaObject.RepositoryUrl)
-	_ = writer.WriteField("branchName", uploadBatch.gitMetaObject.BranchName)
+func (uploadBatch *uploadBatch) fileTimeout(filePath string) int {
+	if strings.HasSuffix(filepath.Base(filePath), callgraphName) {
+		return uploadBatch.callGraphTimeout
+	}
+
+	return 0
+}
+
+// uploadFile streams file content from filepath to Debricked
+func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
+	if strings.HasSuffix(filePath, "debricked.fingerprints.txt") && !(*uploadBatch.client).IsEnterpriseCustomer(true) {
+		return NonEnterpriseErr
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		return err
+	}
+
+	fields := [][2]string{
+		{"fileRelativePath", getRelativeFilePath(filePath)},
+		{"repositoryName", uploadBatch.gitMetaObject.RepositoryName},
+		{"commitName", uploadBatch.gitMetaObject.CommitName},
+		{"repositoryUrl", uploadBatch.gitMetaObject.RepositoryUrl},
+		{"branchName", uploadBatch.gitMetaObject.BranchName},
+	}
 	if uploadBatch.initialized() {
-		_ = writer.WriteField("ciUploadId", strconv.Itoa(uploadBatch.ciUploadId))
+		fields = append(fields, [2]string{"ciUploadId", strconv.Itoa(uploadBatch.ciUploadId)})
 	}
-	response, err := (*uploadBatch.client).Post(
+	contentType, body := newMultipartFileBody(filePath, fields)
+	response, err := (*uploadBatch.client).PostStream(
 		"/api/1.0/open/uploads/dependencies/files",
-		writer.FormDataContentType(),
+		contentType,
 		body,
 		timeout,
 	)
 	if err != nil {
 		return err
 	}
+	if response.Body != nil {
+		defer response.Body.Close()
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to upload file due to status code %d", response.StatusCode)
+	}
 	if !uploadBatch.initialized() {
 		data, _ := io.ReadAll(response.Body)
-		defer response.Body.Close()
 		uFile := uploadedFile{}
 		_ = json.Unmarshal(data, &uFile)
 		if uFile.CiUploadId == 0 {
@@ -174,24 +222,73 @@ func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
 	return nil
 }
 
-// initAnalysis send the finish request that starts the analysis
-func (uploadBatch *uploadBatch) initAnalysis() error {
-	if uploadBatch.ciUploadId == 0 {
-		return NoFilesErr
+// newMultipartFileBody returns a function creating a multipart body which streams the file content,
+// instead of reading the whole file into memory. Each invocation creates a new body, allowing retries
+func newMultipartFileBody(filePath string, fields [][2]string) (string, func() (io.Reader, error)) {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	body := func() (io.Reader, error) {
+		f, err := os.Open(filepath.Clean(filePath))
+		if err != nil {
+			return nil, err
+		}
+		reader, writer := io.Pipe()
+		go func() {
+			defer f.Close()
+			writer.CloseWithError(writeMultipartFile(writer, boundary, f, filePath, fields))
+		}()
+
+		return reader, nil
+	}
+
+	return "multipart/form-data; boundary=" + boundary, body
+}
+
+func writeMultipartFile(w io.Writer, boundary string, f io.Reader, filePath string, fields [][2]string) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return err
+	}
+	fileData, err := writer.CreateFormFile("fileData", filepath.Base(filePath))
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(fileData, f); err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err = writer.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
 	}
-	body, err := json.Marshal(uploadFinish{
+
+	return writer.Close()
+}
+
+// finish returns the body of the finish request
+func (uploadBatch *uploadBatch) finish() uploadFinish {
+	return uploadFinish{
 		CiUploadId:           strconv.Itoa(uploadBatch.ciUploadId),
 		RepositoryName:       uploadBatch.gitMetaObject.RepositoryName,
 		IntegrationName:      uploadBatch.integrationName,
 		CommitName:           uploadBatch.gitMetaObject.CommitName,
 		Author:               uploadBatch.gitMetaObject.Author,
+		PullRequest:          uploadBatch.gitMetaObject.PullRequest,
+		TargetBranchName:     uploadBatch.gitMetaObject.TargetBranchName,
+		BaseCommitName:       uploadBatch.gitMetaObject.BaseCommitName,
 		VersionHint:          uploadBatch.versionHint,
 		DebrickedConfig:      uploadBatch.debrickedConfig,
 		DebrickedIntegration: "cli",
 		TagCommitAsRelease:   uploadBatch.tagCommitAsRelease,
 		Experimental:         uploadBatch.experimental,
-	})
+	}
+}
 
+// initAnalysis send the finish request that starts the analysis
+func (uploadBatch *uploadBatch) initAnalysis() error {
+	if uploadBatch.ciUploadId == 0 {
+		return NoFilesErr
+	}
+	body, err := json.Marshal(uploadBatch.finish())
 	if err != nil {
 		return err
 	}
@@ -212,7 +309,7 @@ func (uploadBatch *uploadBatch) initAnalysis() error {
 		fmt.Println("Successfully initialized scan")
 	}
 
-	return nil
+	return uploadBatch.journal.Remove()
 }
 
 func (uploadBatch *uploadBatch) initialized() bool {
@@ -270,6 +367,12 @@ func (uploadBatch *uploadBatch) initUpload() ([]string, error) {
 	if len(files) == 0 {
 		return files, nil
 	}
+	if uploadBatch.journal.Resumed() {
+		uploadBatch.ciUploadId = uploadBatch.journal.CiUploadId
+		fmt.Printf("Resuming interrupted upload with ID %d\n", uploadBatch.ciUploadId)
+
+		return files, nil
+	}
 
 	var entryFile string
 	var err error
@@ -283,6 +386,11 @@ func (uploadBatch *uploadBatch) initUpload() ([]string, error) {
 		err = uploadBatch.uploadFile(entryFile, timeout)
 		if err == nil {
 			printSuccessfulUpload(entryFile)
+			sum, _ := checksum(entryFile)
+			uploadBatch.updateJournal(entryFile, sum, FileUploaded, nil)
+			if journalErr := uploadBatch.journal.SetCiUploadId(uploadBatch.ciUploadId); journalErr != nil {
+				log.Println("Failed to update upload journal:", journalErr.Error())
+			}
 
 			return files, nil
 		}
@@ -315,6 +423,20 @@ func (boolOrString *boolOrString) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&boolOrString.Version)
 }
 
+func (boolOrString *boolOrString) UnmarshalJSON(data []byte) error {
+	var version string
+	if err := json.Unmarshal(data, &version); err == nil {
+		boolOrString.Version = version
+		boolOrString.HasVersion = true
+
+		return nil
+	}
+	boolOrString.Version = ""
+	boolOrString.HasVersion = false
+
+	return json.Unmarshal(data, new(bool))
+}
+
 type purlConfig struct {
 	PackageURL  string       `json:"pURL" yaml:"pURL"`
 	Version     boolOrString `json:"version" yaml:"version"` // Either false or version string
@@ -331,6 +453,9 @@ type uploadFinish struct {
 	IntegrationName      string           `json:"integrationName"`
 	CommitName           string           `json:"commitName"`
 	Author               string           `json:"author"`
+	PullRequest          string           `json:"pullRequest,omitempty"`
+	TargetBranchName     string           `json:"targetBranchName,omitempty"`
+	BaseCommitName       string           `json:"baseCommitName,omitempty"`
 	DebrickedIntegration string           `json:"debrickedIntegration"`
 	VersionHint          bool             `json:"versionHint"`
 	DebrickedConfig      *DebrickedConfig `json:"debrickedConfig"`
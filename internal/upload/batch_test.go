@@ -6,6 +6,8 @@ import (
 	"errors"
 	"io"
 	"log"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -56,6 +58,40 @@ func TestInitAnalysisWithoutAnyFiles(t *testing.T) {
 	assert.ErrorContains(t, err, "failed to find dependency files")
 }
 
+func TestFinish(t *testing.T) {
+	metaObj := &git.MetaObject{
+		RepositoryName:   "repository-name",
+		CommitName:       "commit-name",
+		PullRequest:      "12",
+		TargetBranchName: "main",
+		BaseCommitName:   "base-commit",
+	}
+	batch := newUploadBatch(nil, file.Groups{}, metaObj, "CLI", 10*60, true, &DebrickedConfig{}, true, false)
+	batch.ciUploadId = 1
+
+	body, err := json.Marshal(batch.finish())
+
+	assert.NoError(t, err)
+	var finish map[string]any
+	assert.NoError(t, json.Unmarshal(body, &finish))
+	assert.Equal(t, "1", finish["ciUploadId"])
+	assert.Equal(t, "12", finish["pullRequest"])
+	assert.Equal(t, "main", finish["targetBranchName"])
+	assert.Equal(t, "base-commit", finish["baseCommitName"])
+}
+
+func TestFinishWithoutPullRequest(t *testing.T) {
+	metaObj := &git.MetaObject{RepositoryName: "repository-name", CommitName: "commit-name"}
+	batch := newUploadBatch(nil, file.Groups{}, metaObj, "CLI", 10*60, true, &DebrickedConfig{}, true, false)
+
+	body, err := json.Marshal(batch.finish())
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "pullRequest")
+	assert.NotContains(t, string(body), "targetBranchName")
+	assert.NotContains(t, string(body), "baseCommitName")
+}
+
 func TestWaitWithPollingTerminatedError(t *testing.T) {
 	group := file.NewGroup("package.json", nil, []string{"yarn.lock"})
 	var groups file.Groups
@@ -213,3 +249,134 @@ func TestMarshalJSONDebrickedConfig(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, []byte(expectedJSON), config)
 }
+
+func TestUnmarshalJSONDebrickedConfig(t *testing.T) {
+	expectedConfig := DebrickedConfig{
+		Overrides: []purlConfig{
+			{
+				PackageURL:  "pkg:npm/lodash",
+				Version:     boolOrString{Version: "1.0.0", HasVersion: true},
+				FileRegexes: []string{".*/lodash/.*"},
+			},
+			{
+				PackageURL:  "pkg:maven/org.openjfx/javafx-base",
+				Version:     boolOrString{Version: "", HasVersion: false},
+				FileRegexes: []string{"subpath/org.openjfx/.*"},
+			},
+		},
+	}
+	configJSON := "{\"overrides\":[{\"pURL\":\"pkg:npm/lodash\",\"version\":\"1.0.0\",\"fileRegexes\":[\".*/lodash/.*\"]},{\"pURL\":\"pkg:maven/org.openjfx/javafx-base\",\"version\":false,\"fileRegexes\":[\"subpath/org.openjfx/.*\"]}]}"
+	var config DebrickedConfig
+	err := json.Unmarshal([]byte(configJSON), &config)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedConfig, config)
+}
+
+func newTestUploadBatch(t *testing.T, clientMock *testdata.DebClientMock) *uploadBatch {
+	t.Helper()
+	group := file.NewGroup("testdata/yarn/package.json", nil, []string{"testdata/yarn/yarn.lock"})
+	var groups file.Groups
+	groups.Add(*group)
+	metaObj, err := git.NewMetaObject("", "repository-name", "commit-name", "", "", "")
+	if err != nil {
+		t.Fatal("failed to create new MetaObject")
+	}
+	var c client.IDebClient = clientMock
+	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60, true, &DebrickedConfig{}, true, false)
+	batch.workers = 1
+
+	return batch
+}
+
+func addUploadMockResponse(clientMock *testdata.DebClientMock, statusCode int) {
+	clientMock.AddMockUriResponse("/api/1.0/open/uploads/dependencies/files", testdata.MockResponse{
+		StatusCode:   statusCode,
+		ResponseBody: io.NopCloser(strings.NewReader(`{"ciUploadId": 1}`)),
+	})
+}
+
+func TestUploadFailsWhenFileCannotBeDelivered(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	addUploadMockResponse(clientMock, http.StatusOK)
+	addUploadMockResponse(clientMock, http.StatusInternalServerError)
+	addUploadMockResponse(clientMock, http.StatusOK)
+	batch := newTestUploadBatch(t, clientMock)
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	err := batch.upload()
+	log.SetOutput(os.Stderr)
+
+	assert.ErrorIs(t, err, FailedUploadsErr)
+	assert.Contains(t, buf.String(), "Failed to upload")
+	failed := 0
+	for _, entry := range batch.journal.Files {
+		assert.Equal(t, 1, entry.Attempts, "failed to assert that the client error was not retried again")
+		if entry.State == FileFailed {
+			failed++
+			assert.Contains(t, entry.Error, "500")
+		}
+	}
+	assert.Equal(t, 1, failed)
+}
+
+func TestUploadResumesFromJournal(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	addUploadMockResponse(clientMock, http.StatusOK)
+	batch := newTestUploadBatch(t, clientMock)
+	sum, err := checksum("testdata/yarn/yarn.lock")
+	assert.NoError(t, err)
+	batch.journal = LoadJournal(filepath.Join(t.TempDir(), "upload-journal.json"), *batch.gitMetaObject)
+	assert.NoError(t, batch.journal.Update("testdata/yarn/yarn.lock", sum, FileUploaded, nil))
+	assert.NoError(t, batch.journal.SetCiUploadId(5))
+
+	err = batch.upload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, batch.ciUploadId)
+	assert.Equal(t, 1, batch.journal.Files["testdata/yarn/yarn.lock"].Attempts)
+	assert.Equal(t, FileUploaded, batch.journal.Files["testdata/yarn/package.json"].State)
+}
+
+func TestUploadSkipsFingerprintsForNonEnterprise(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.SetEnterpriseCustomer(false)
+	batch := newTestUploadBatch(t, clientMock)
+	fingerprints := filepath.Join(t.TempDir(), "debricked.fingerprints.txt")
+	assert.NoError(t, os.WriteFile(fingerprints, []byte("file=fingerprint"), 0600))
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	uploaded := batch.uploadJournaledFile(fingerprints)
+	log.SetOutput(os.Stderr)
+
+	assert.True(t, uploaded)
+	assert.Contains(t, buf.String(), "Skipped upload")
+	assert.Equal(t, FileSkipped, batch.journal.Files[fingerprints].State)
+}
+
+func TestNewMultipartFileBody(t *testing.T) {
+	filePath := filepath.Join("testdata", "yarn", "yarn.lock")
+	contentType, body := newMultipartFileBody(filePath, [][2]string{{"commitName", "commit-name"}})
+	_, params, err := mime.ParseMediaType(contentType)
+	assert.NoError(t, err)
+
+	// Each invocation must produce a complete body, since the body is recreated on retries
+	for i := 0; i < 2; i++ {
+		reader, err := body()
+		assert.NoError(t, err)
+		form, err := multipart.NewReader(reader, params["boundary"]).ReadForm(1 << 20)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"commit-name"}, form.Value["commitName"])
+		assert.Len(t, form.File["fileData"], 1)
+		assert.Equal(t, "yarn.lock", form.File["fileData"][0].Filename)
+		expected, _ := os.ReadFile(filePath)
+		assert.Equal(t, int64(len(expected)), form.File["fileData"][0].Size)
+	}
+}
+
+func TestNewMultipartFileBodyMissingFile(t *testing.T) {
+	_, body := newMultipartFileBody(filepath.Join("testdata", "no-such-file"), nil)
+	reader, err := body()
+
+	assert.Nil(t, reader)
+	assert.Error(t, err)
+}
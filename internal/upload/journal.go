@@ -0,0 +1,191 @@
+package upload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/debricked/cli/internal/git"
+)
+
+// journalDir is the directory journals are kept in, within the user cache directory
+const journalDir = "debricked"
+
+type FileState string
+
+const (
+	FilePending  FileState = "pending"
+	FileUploaded FileState = "uploaded"
+	FileFailed   FileState = "failed"
+	FileSkipped  FileState = "skipped"
+)
+
+type JournalEntry struct {
+	Checksum string    `json:"checksum"`
+	State    FileState `json:"state"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Journal keeps track of an ongoing upload, allowing an interrupted upload to be resumed
+type Journal struct {
+	CiUploadId     int                      `json:"ciUploadId"`
+	RepositoryName string                   `json:"repositoryName"`
+	CommitName     string                   `json:"commitName"`
+	BranchName     string                   `json:"branchName"`
+	Files          map[string]*JournalEntry `json:"files"`
+	path           string
+	lock           sync.Mutex
+}
+
+// newMemoryJournal returns a journal which is never persisted
+func newMemoryJournal() *Journal {
+	return &Journal{Files: map[string]*JournalEntry{}}
+}
+
+// JournalPath returns the path of the journal of uploads from dir. Journals are kept in the user cache directory, so
+// that they are not committed or matched by later scans of the repository
+func JournalPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(absDir))
+
+	return filepath.Join(cacheDir, journalDir, "upload-journal-"+hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// LoadJournal loads the journal at path if it belongs to the same commit as gitMetaObject. Otherwise, a new journal is returned
+func LoadJournal(path string, gitMetaObject git.MetaObject) *Journal {
+	journal := &Journal{
+		RepositoryName: gitMetaObject.RepositoryName,
+		CommitName:     gitMetaObject.CommitName,
+		BranchName:     gitMetaObject.BranchName,
+		Files:          map[string]*JournalEntry{},
+		path:           path,
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return journal
+	}
+	var persisted Journal
+	if json.Unmarshal(data, &persisted) != nil || !journal.sameCommit(&persisted) || persisted.Files == nil {
+		return journal
+	}
+	journal.CiUploadId = persisted.CiUploadId
+	journal.Files = persisted.Files
+
+	return journal
+}
+
+func (journal *Journal) sameCommit(other *Journal) bool {
+	return journal.RepositoryName == other.RepositoryName &&
+		journal.CommitName == other.CommitName &&
+		journal.BranchName == other.BranchName
+}
+
+// Resumed returns true if the journal belongs to an upload that was previously initialized
+func (journal *Journal) Resumed() bool {
+	journal.lock.Lock()
+	defer journal.lock.Unlock()
+
+	return journal.CiUploadId > 0
+}
+
+// Uploaded returns true if the file was uploaded and has not changed since
+func (journal *Journal) Uploaded(filePath string, checksum string) bool {
+	journal.lock.Lock()
+	defer journal.lock.Unlock()
+	entry, ok := journal.Files[filePath]
+
+	return ok && entry.State == FileUploaded && entry.Checksum == checksum
+}
+
+// SetCiUploadId sets the id of the initialized upload and persists the journal
+func (journal *Journal) SetCiUploadId(ciUploadId int) error {
+	journal.lock.Lock()
+	journal.CiUploadId = ciUploadId
+	journal.lock.Unlock()
+
+	return journal.Save()
+}
+
+// Update sets the state of a file and persists the journal
+func (journal *Journal) Update(filePath string, checksum string, state FileState, err error) error {
+	journal.lock.Lock()
+	entry, ok := journal.Files[filePath]
+	if !ok || entry.Checksum != checksum {
+		entry = &JournalEntry{Checksum: checksum}
+		journal.Files[filePath] = entry
+	}
+	entry.State = state
+	entry.Error = ""
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if state != FilePending && state != FileSkipped {
+		entry.Attempts++
+	}
+	journal.lock.Unlock()
+
+	return journal.Save()
+}
+
+// Save persists the journal. The file is replaced atomically to not corrupt it if the CLI is interrupted
+func (journal *Journal) Save() error {
+	journal.lock.Lock()
+	defer journal.lock.Unlock()
+	if journal.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(journal.path), 0700); err != nil {
+		return err
+	}
+	tmpPath := journal.path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, journal.path)
+}
+
+// Remove deletes the persisted journal once the upload is complete
+func (journal *Journal) Remove() error {
+	if journal.path == "" {
+		return nil
+	}
+	err := os.Remove(journal.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// checksum returns the hex encoded SHA-256 checksum of the file content
+func checksum(filePath string) (string, error) {
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
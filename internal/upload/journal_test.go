@@ -0,0 +1,141 @@
+package upload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMetaObject() git.MetaObject {
+	return git.MetaObject{RepositoryName: "repository-name", CommitName: "commit-name", BranchName: "main"}
+}
+
+func TestJournalPath(t *testing.T) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Skip("no user cache directory:", err)
+	}
+	cwd, _ := os.Getwd()
+	dir := t.TempDir()
+
+	path, err := JournalPath(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, journalDir), filepath.Dir(path))
+	relativePath, err := JournalPath(".")
+	assert.NoError(t, err)
+	cwdPath, err := JournalPath(cwd)
+	assert.NoError(t, err)
+	assert.Equal(t, cwdPath, relativePath)
+	assert.NotEqual(t, path, relativePath)
+}
+
+func TestLoadJournalNoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload-journal.json")
+	journal := LoadJournal(path, newTestMetaObject())
+
+	assert.False(t, journal.Resumed())
+	assert.Empty(t, journal.Files)
+	assert.Equal(t, "repository-name", journal.RepositoryName)
+	assert.Equal(t, "commit-name", journal.CommitName)
+	assert.Equal(t, "main", journal.BranchName)
+}
+
+func TestLoadJournalResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload-journal.json")
+	journal := LoadJournal(path, newTestMetaObject())
+	assert.NoError(t, journal.Update("yarn.lock", "abc", FileUploaded, nil))
+	assert.NoError(t, journal.Update("package.json", "def", FileFailed, errors.New("timeout")))
+	assert.NoError(t, journal.SetCiUploadId(10))
+
+	resumed := LoadJournal(path, newTestMetaObject())
+
+	assert.True(t, resumed.Resumed())
+	assert.Equal(t, 10, resumed.CiUploadId)
+	assert.True(t, resumed.Uploaded("yarn.lock", "abc"))
+	assert.False(t, resumed.Uploaded("yarn.lock", "changed"))
+	assert.False(t, resumed.Uploaded("package.json", "def"))
+	assert.Equal(t, "timeout", resumed.Files["package.json"].Error)
+	assert.Equal(t, 1, resumed.Files["package.json"].Attempts)
+}
+
+func TestLoadJournalOtherCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload-journal.json")
+	journal := LoadJournal(path, newTestMetaObject())
+	assert.NoError(t, journal.Update("yarn.lock", "abc", FileUploaded, nil))
+	assert.NoError(t, journal.SetCiUploadId(10))
+
+	metaObject := newTestMetaObject()
+	metaObject.CommitName = "other-commit"
+	other := LoadJournal(path, metaObject)
+
+	assert.False(t, other.Resumed())
+	assert.Empty(t, other.Files)
+}
+
+func TestJournalSaveCreatesDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), journalDir, "upload-journal.json")
+	journal := LoadJournal(path, newTestMetaObject())
+
+	assert.NoError(t, journal.SetCiUploadId(10))
+	assert.FileExists(t, path)
+}
+
+func TestLoadJournalCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload-journal.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	journal := LoadJournal(path, newTestMetaObject())
+
+	assert.False(t, journal.Resumed())
+	assert.Empty(t, journal.Files)
+}
+
+func TestJournalUpdate(t *testing.T) {
+	journal := newMemoryJournal()
+
+	assert.NoError(t, journal.Update("yarn.lock", "abc", FilePending, nil))
+	assert.Equal(t, 0, journal.Files["yarn.lock"].Attempts)
+
+	assert.NoError(t, journal.Update("yarn.lock", "abc", FileFailed, errors.New("error")))
+	assert.NoError(t, journal.Update("yarn.lock", "abc", FileUploaded, nil))
+	entry := journal.Files["yarn.lock"]
+	assert.Equal(t, FileUploaded, entry.State)
+	assert.Equal(t, 2, entry.Attempts)
+	assert.Empty(t, entry.Error)
+
+	assert.NoError(t, journal.Update("yarn.lock", "changed", FileFailed, nil))
+	assert.Equal(t, 1, journal.Files["yarn.lock"].Attempts)
+}
+
+func TestJournalRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload-journal.json")
+	journal := LoadJournal(path, newTestMetaObject())
+	assert.NoError(t, journal.Save())
+	assert.FileExists(t, path)
+
+	assert.NoError(t, journal.Remove())
+	assert.NoFileExists(t, path)
+	assert.NoError(t, journal.Remove())
+}
+
+func TestMemoryJournalIsNotPersisted(t *testing.T) {
+	journal := newMemoryJournal()
+
+	assert.NoError(t, journal.SetCiUploadId(1))
+	assert.NoError(t, journal.Remove())
+	assert.NoFileExists(t, "upload-journal.json")
+}
+
+func TestChecksum(t *testing.T) {
+	sum, err := checksum(filepath.Join("testdata", "yarn", "package.json"))
+	assert.NoError(t, err)
+	assert.Len(t, sum, 64)
+
+	_, err = checksum(filepath.Join("testdata", "no-such-file"))
+	assert.Error(t, err)
+}
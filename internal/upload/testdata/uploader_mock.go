@@ -0,0 +1,36 @@
+package testdata
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/upload"
+)
+
+type UploaderMock struct {
+	Options upload.DebrickedOptions
+	Files   map[string][]byte
+	Result  *upload.UploadResult
+	Error   error
+}
+
+func NewUploaderMock() *UploaderMock {
+	return &UploaderMock{
+		Result: &upload.UploadResult{},
+		Files:  map[string][]byte{},
+	}
+}
+
+// Upload records the options and reads all files in the file groups, as they are only readable during the upload
+func (mock *UploaderMock) Upload(o upload.IOptions) (*upload.UploadResult, error) {
+	mock.Options = o.(upload.DebrickedOptions)
+	for _, f := range mock.Options.FileGroups.GetFiles() {
+		content, err := os.ReadFile(filepath.Clean(f))
+		if err != nil {
+			return nil, err
+		}
+		mock.Files[f] = content
+	}
+
+	return mock.Result, mock.Error
+}
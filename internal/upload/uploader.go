@@ -19,6 +19,10 @@ type DebrickedOptions struct {
 	DebrickedConfig        *DebrickedConfig
 	TagCommitAsRelease     bool
 	Experimental           bool
+	UploadWorkers          int
+	// JournalPath is the file the progress of the upload is kept in, so that it can be resumed. The upload is not
+	// resumable if it is empty
+	JournalPath string
 }
 
 type IUploader interface {
@@ -50,6 +54,12 @@ func (uploader *Uploader) Upload(o IOptions) (*UploadResult, error) {
 		dOptions.TagCommitAsRelease,
 		dOptions.Experimental,
 	)
+	if dOptions.UploadWorkers > 0 {
+		batch.workers = dOptions.UploadWorkers
+	}
+	if len(dOptions.JournalPath) > 0 {
+		batch.journal = LoadJournal(dOptions.JournalPath, dOptions.GitMetaObject)
+	}
 
 	err := batch.upload()
 	if err != nil {
@@ -50,12 +50,14 @@ func TestUpload(t *testing.T) {
 
 func TestUploadPollingError(t *testing.T) {
 	debClientMock := testdata.NewDebClientMock()
-	// Create mocked file upload response
-	uploadMockRes := testdata.MockResponse{
-		StatusCode:   http.StatusOK,
-		ResponseBody: io.NopCloser(strings.NewReader("{\"ciUploadId\": 1}")),
+	// Create mocked file upload responses
+	for i := 0; i < 2; i++ {
+		uploadMockRes := testdata.MockResponse{
+			StatusCode:   http.StatusOK,
+			ResponseBody: io.NopCloser(strings.NewReader("{\"ciUploadId\": 1}")),
+		}
+		debClientMock.AddMockUriResponse("/api/1.0/open/uploads/dependencies/files", uploadMockRes)
 	}
-	debClientMock.AddMockUriResponse("/api/1.0/open/uploads/dependencies/files", uploadMockRes)
 
 	// Create a mocked finish response
 	finishMockRes := testdata.MockResponse{
@@ -126,6 +128,10 @@ func (mock *debClientMock) Post(uri string, _ string, _ *bytes.Buffer, _ int) (*
 	return res, nil
 }
 
+func (mock *debClientMock) PostStream(uri string, contentType string, _ func() (io.Reader, error), timeout int) (*http.Response, error) {
+	return mock.Post(uri, contentType, nil, timeout)
+}
+
 var progress = 50
 
 func (mock *debClientMock) Get(_ string, _ string) (*http.Response, error) {
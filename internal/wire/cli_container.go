@@ -4,13 +4,17 @@ import (
 	"fmt"
 
 	"github.com/debricked/cli/internal/auth"
+	"github.com/debricked/cli/internal/bundle"
 	"github.com/debricked/cli/internal/callgraph"
+	"github.com/debricked/cli/internal/callgraph/query"
 	callgraphStrategy "github.com/debricked/cli/internal/callgraph/strategy"
 	"github.com/debricked/cli/internal/ci"
 	"github.com/debricked/cli/internal/client"
+	"github.com/debricked/cli/internal/deps"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/io"
+	"github.com/debricked/cli/internal/policy"
 	licenseReport "github.com/debricked/cli/internal/report/license"
 	sbomReport "github.com/debricked/cli/internal/report/sbom"
 	vulnerabilityReport "github.com/debricked/cli/internal/report/vulnerability"
@@ -56,6 +60,7 @@ func (cc *CliContainer) wire() error {
 	fingerprinter := fingerprint.NewFingerprinter()
 
 	cc.fingerprinter = fingerprinter
+	cc.fingerprintMatcher = fingerprint.NewMatcher(fingerprinter)
 
 	uploader, err := upload.NewUploader(cc.debClient)
 	if err != nil {
@@ -63,6 +68,12 @@ func (cc *CliContainer) wire() error {
 	}
 	cc.uploader = uploader
 
+	replayer, err := bundle.NewReplayer(cc.uploader)
+	if err != nil {
+		return wireErr(err)
+	}
+	cc.bundleReplayer = replayer
+
 	cc.ciService = ci.NewService(nil)
 
 	cc.batchFactory = resolutionFile.NewBatchFactory()
@@ -95,6 +106,9 @@ func (cc *CliContainer) wire() error {
 	cc.vulnerabilityReporter = vulnerabilityReport.Reporter{DebClient: cc.debClient}
 	cc.sbomReporter = sbomReport.Reporter{DebClient: cc.debClient, FileWriter: io.FileWriter{}}
 	cc.authenticator = cc.debClient.Authenticator()
+	cc.policyChecker = policy.NewChecker()
+	cc.depsLoader = deps.NewLoader(cc.finder)
+	cc.cgQueryLoader = query.NewLoader()
 
 	return nil
 }
@@ -104,7 +118,9 @@ type CliContainer struct {
 	debClient             client.IDebClient
 	finder                file.IFinder
 	fingerprinter         fingerprint.IFingerprint
+	fingerprintMatcher    fingerprint.IMatcher
 	uploader              upload.IUploader
+	bundleReplayer        bundle.IReplayer
 	ciService             ci.IService
 	scanner               scan.IScanner
 	resolver              resolution.IResolver
@@ -117,7 +133,10 @@ type CliContainer struct {
 	callgraph             callgraph.IGenerator
 	cgScheduler           callgraph.IScheduler
 	cgStrategyFactory     callgraphStrategy.IFactory
+	cgQueryLoader         query.ILoader
 	authenticator         auth.IAuthenticator
+	policyChecker         policy.IChecker
+	depsLoader            deps.ILoader
 }
 
 func (cc *CliContainer) DebClient() client.IDebClient {
@@ -132,6 +151,10 @@ func (cc *CliContainer) Scanner() scan.IScanner {
 	return cc.scanner
 }
 
+func (cc *CliContainer) BundleReplayer() bundle.IReplayer {
+	return cc.bundleReplayer
+}
+
 func (cc *CliContainer) Resolver() resolution.IResolver {
 	return cc.resolver
 }
@@ -140,6 +163,10 @@ func (cc *CliContainer) CallgraphGenerator() callgraph.IGenerator {
 	return cc.callgraph
 }
 
+func (cc *CliContainer) CallgraphQueryLoader() query.ILoader {
+	return cc.cgQueryLoader
+}
+
 func (cc *CliContainer) LicenseReporter() licenseReport.Reporter {
 	return cc.licenseReporter
 }
@@ -160,6 +187,18 @@ func (cc *CliContainer) Authenticator() auth.IAuthenticator {
 	return cc.authenticator
 }
 
+func (cc *CliContainer) PolicyChecker() policy.IChecker {
+	return cc.policyChecker
+}
+
+func (cc *CliContainer) FingerprintMatcher() fingerprint.IMatcher {
+	return cc.fingerprintMatcher
+}
+
+func (cc *CliContainer) DepsLoader() deps.ILoader {
+	return cc.depsLoader
+}
+
 func wireErr(err error) error {
 	return fmt.Errorf("failed to wire with cli-container. Error %s", err)
 }
@@ -35,11 +35,16 @@ func assertCliContainer(t *testing.T, cc *CliContainer) {
 	assert.NotNil(t, cc.DebClient())
 	assert.NotNil(t, cc.Finder())
 	assert.NotNil(t, cc.Scanner())
+	assert.NotNil(t, cc.BundleReplayer())
 	assert.NotNil(t, cc.Resolver())
 	assert.NotNil(t, cc.CallgraphGenerator())
+	assert.NotNil(t, cc.CallgraphQueryLoader())
 	assert.NotNil(t, cc.LicenseReporter())
 	assert.NotNil(t, cc.VulnerabilityReporter())
 	assert.NotNil(t, cc.Fingerprinter())
 	assert.NotNil(t, cc.Authenticator())
+	assert.NotNil(t, cc.PolicyChecker())
+	assert.NotNil(t, cc.DepsLoader())
+	assert.NotNil(t, cc.FingerprintMatcher())
 	assert.NotNil(t, cc.SBOMReporter())
 }